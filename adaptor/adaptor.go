@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package adaptor implements Schnorr adaptor signatures, i.e. one-time verifiably encrypted signatures, as used in
+// atomic swaps and payment channels. A pre-signature for an adaptor point T = t * G is verifiable by anyone, adapts
+// to a valid signature with the adaptor secret t, and reveals t to whoever holds both. Over secp256k1, adapted
+// signatures are BIP-340 signatures, verifying with the bip340 package, and over the other groups, e.g.
+// Ristretto255, they are those of the schnorr package.
+package adaptor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/bip340"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+var (
+	errInvalidPreSignature = errors.New("invalid pre-signature")
+	errInvalidSignature    = errors.New("signature does not match the pre-signature")
+)
+
+// PreSignature is the adaptor pre-signature (R, s'), where R = k * G + T is the nonce of the adapted signature, and
+// s' = k + c * secret.
+type PreSignature struct {
+	R *ecc.Element
+	S *ecc.Scalar
+}
+
+// challenge returns the signature scheme's challenge of the nonce, the public key, and the message.
+func challenge(r, public *ecc.Element, message []byte) *ecc.Scalar {
+	if r.Group() == ecc.Secp256k1Sha256 {
+		return bip340.Challenge(r.EncodeXOnly(), public.EncodeXOnly(), message)
+	}
+
+	return schnorr.Challenge(r.Group(), r.Encode(), public.Encode(), message)
+}
+
+func checkAdaptor(g ecc.Group, adaptor *ecc.Element) error {
+	if adaptor == nil || adaptor.IsIdentity() {
+		return internal.ErrParamNilPoint
+	}
+
+	if adaptor.Group() != g {
+		return internal.ErrCastElement
+	}
+
+	return nil
+}
+
+// PreSign returns the pre-signature of the message with the private scalar, for the adaptor point.
+func PreSign(secret *ecc.Scalar, message []byte, adaptor *ecc.Element) (*PreSignature, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("adaptor: %w", internal.ErrParamNilScalar)
+	}
+
+	g := secret.Group()
+	if err := checkAdaptor(g, adaptor); err != nil {
+		return nil, fmt.Errorf("adaptor: %w", err)
+	}
+
+	d := secret.Copy()
+	public := g.Base().Multiply(d)
+
+	// BIP-340 public keys and nonces have an even y-coordinate. The signer can negate its key, but not the nonce, as
+	// it does not know t, so it draws nonces until R is even.
+	if g == ecc.Secp256k1Sha256 && !public.HasEvenY() {
+		d = g.NewScalar().Subtract(d)
+	}
+
+	for {
+		k := g.NewScalar().Random()
+
+		r := g.Base().Multiply(k).Add(adaptor)
+		if r.IsIdentity() || (g == ecc.Secp256k1Sha256 && !r.HasEvenY()) {
+			continue
+		}
+
+		return &PreSignature{R: r, S: k.Add(challenge(r, public, message).Multiply(d))}, nil
+	}
+}
+
+// PreVerify returns nil if the pre-signature of the message is valid for the public key and the adaptor point, i.e.
+// s' * G = R - T + c * P, in which case adapting it with the adaptor secret yields a valid signature.
+func PreVerify(public *ecc.Element, message []byte, adaptor *ecc.Element, pre *PreSignature) error {
+	if public == nil || public.IsIdentity() {
+		return fmt.Errorf("adaptor: %w", internal.ErrParamNilPoint)
+	}
+
+	g := public.Group()
+	if err := checkAdaptor(g, adaptor); err != nil {
+		return fmt.Errorf("adaptor: %w", err)
+	}
+
+	if err := checkPreSignature(g, pre); err != nil {
+		return fmt.Errorf("adaptor: %w", err)
+	}
+
+	p := public
+	if g == ecc.Secp256k1Sha256 {
+		// The BIP-340 public key is the even-y element of the x-coordinate.
+		var err error
+		if p, err = g.DecodeXOnly(public.EncodeXOnly()); err != nil {
+			return fmt.Errorf("adaptor: %w", err)
+		}
+	}
+
+	// s' * G - c * P + T == R, with the negation on the scalar.
+	c := challenge(pre.R, p, message)
+	lhs := g.Base().Multiply(pre.S).Add(p.Copy().Multiply(g.NewScalar().Subtract(c))).Add(adaptor)
+
+	if !lhs.Equal(pre.R) {
+		return fmt.Errorf("adaptor: %w", errInvalidPreSignature)
+	}
+
+	return nil
+}
+
+func checkPreSignature(g ecc.Group, pre *PreSignature) error {
+	if pre == nil || pre.R == nil || pre.S == nil || pre.R.Group() != g || pre.S.Group() != g ||
+		pre.R.IsIdentity() || (g == ecc.Secp256k1Sha256 && !pre.R.HasEvenY()) {
+		return errInvalidPreSignature
+	}
+
+	return nil
+}
+
+// encodeNonce returns the encoding of the nonce in signatures.
+func encodeNonce(r *ecc.Element) []byte {
+	if r.Group() == ecc.Secp256k1Sha256 {
+		return r.EncodeXOnly()
+	}
+
+	return r.Encode()
+}
+
+// Adapt returns the signature of the pre-signature completed with the adaptor secret t, i.e. (R, s' + t).
+func Adapt(pre *PreSignature, t *ecc.Scalar) ([]byte, error) {
+	if t == nil || t.IsZero() {
+		return nil, fmt.Errorf("adaptor: %w", internal.ErrParamNilScalar)
+	}
+
+	if err := checkPreSignature(t.Group(), pre); err != nil {
+		return nil, fmt.Errorf("adaptor: %w", err)
+	}
+
+	return append(encodeNonce(pre.R), pre.S.Copy().Add(t).Encode()...), nil
+}
+
+// Extract returns the adaptor secret t = s - s' of the pre-signature and its adapted signature.
+func Extract(pre *PreSignature, signature []byte) (*ecc.Scalar, error) {
+	if pre == nil || pre.R == nil {
+		return nil, fmt.Errorf("adaptor: %w", errInvalidPreSignature)
+	}
+
+	g := pre.R.Group()
+	if err := checkPreSignature(g, pre); err != nil {
+		return nil, fmt.Errorf("adaptor: %w", err)
+	}
+
+	nonce := encodeNonce(pre.R)
+	if len(signature) != len(nonce)+g.ScalarLength() || string(signature[:len(nonce)]) != string(nonce) {
+		return nil, fmt.Errorf("adaptor: %w", errInvalidSignature)
+	}
+
+	s := g.NewScalar()
+	if err := s.Decode(signature[len(nonce):]); err != nil {
+		return nil, fmt.Errorf("adaptor: %w", errInvalidSignature)
+	}
+
+	return s.Subtract(pre.S), nil
+}
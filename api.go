@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+// The concrete types implement the interfaces.
+var (
+	_ GroupAPI[*Element, *Scalar]   = Group(0)
+	_ ElementAPI[*Element, *Scalar] = (*Element)(nil)
+	_ ScalarAPI[*Scalar]            = (*Scalar)(nil)
+)
+
+// GroupAPI is the interface of the operations of Group, which implements GroupAPI[*Element, *Scalar]. Protocol logic
+// written against GroupAPI, ElementAPI, and ScalarAPI runs both with this package and with fakes or mocks doing no
+// curve arithmetic, e.g. in unit tests:
+//
+//	func Prove[E ecc.ElementAPI[E, S], S ecc.ScalarAPI[S]](g ecc.GroupAPI[E, S], secret S) (E, S) { ... }
+//
+//	Prove(ecc.Ristretto255Sha512, secret) // with E = *ecc.Element and S = *ecc.Scalar
+//	Prove(fakeGroup{}, fakeSecret)        // with the types of the fake
+//
+// The types of the elements and scalars are parameters because Go has no covariant return types: *Element.Add returns
+// an *Element, and could not implement an interface method returning an interface.
+type GroupAPI[E ElementAPI[E, S], S ScalarAPI[S]] interface {
+	// NewScalar returns a new scalar set to 0.
+	NewScalar() S
+
+	// NewElement returns a new element set to the identity point.
+	NewElement() E
+
+	// Base returns a new element set to the group's base point.
+	Base() E
+
+	// HashToScalar returns a safe mapping of the arbitrary input to a scalar.
+	HashToScalar(input, dst []byte) S
+
+	// HashToGroup returns a safe mapping of the arbitrary input to an element.
+	HashToGroup(input, dst []byte) E
+
+	// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an element.
+	EncodeToGroup(input, dst []byte) E
+
+	// ScalarLength returns the byte size of an encoded scalar.
+	ScalarLength() int
+
+	// ElementLength returns the byte size of an encoded element.
+	ElementLength() int
+
+	// Order returns the order of the canonical group of scalars.
+	Order() []byte
+
+	// String returns the hash-to-curve string identifier of the ciphersuite.
+	String() string
+}
+
+// ElementAPI is the interface of the operations of *Element, which implements ElementAPI[*Element, *Scalar], for code
+// that also runs with fakes. As with *Element, the operations modify and return the receiver.
+type ElementAPI[E any, S any] interface {
+	// Group returns the group's identifier.
+	Group() Group
+
+	// Base sets the element to the group's base point.
+	Base() E
+
+	// Identity sets the element to the point at infinity.
+	Identity() E
+
+	// Add sets the receiver to the sum of the receiver and the argument.
+	Add(element E) E
+
+	// Double sets the receiver to its double.
+	Double() E
+
+	// Negate sets the receiver to its negation.
+	Negate() E
+
+	// Subtract subtracts the argument from the receiver.
+	Subtract(element E) E
+
+	// Multiply sets the receiver to the scalar multiplication of the receiver with the scalar.
+	Multiply(scalar S) E
+
+	// Equal returns whether the elements are equivalent, in constant time in the backends listed by Element.Equal.
+	Equal(element E) bool
+
+	// IsIdentity returns whether the element is the point at infinity.
+	IsIdentity() bool
+
+	// Set sets the receiver to the argument.
+	Set(element E) E
+
+	// Copy returns a copy of the receiver.
+	Copy() E
+
+	// Encode returns the compressed byte encoding of the element.
+	Encode() []byte
+
+	// Decode sets the receiver to the decoding of the data, and returns an error on failure.
+	Decode(data []byte) error
+
+	// Hex returns the fixed-sized hexadecimal encoding of the element.
+	Hex() string
+
+	// DecodeHex sets the receiver to the decoding of the hex encoded element.
+	DecodeHex(h string) error
+}
+
+// ScalarAPI is the interface of the operations of *Scalar, which implements ScalarAPI[*Scalar], for code that also
+// runs with fakes. As with *Scalar, the operations modify and return the receiver.
+type ScalarAPI[S any] interface {
+	// Group returns the group's identifier.
+	Group() Group
+
+	// Zero sets the scalar to 0.
+	Zero() S
+
+	// One sets the scalar to 1.
+	One() S
+
+	// MinusOne sets the scalar to order-1.
+	MinusOne() S
+
+	// Random sets the scalar to a new random scalar.
+	Random() S
+
+	// Add sets the receiver to the sum of the receiver and the argument.
+	Add(scalar S) S
+
+	// Subtract subtracts the argument from the receiver.
+	Subtract(scalar S) S
+
+	// Multiply multiplies the receiver with the argument.
+	Multiply(scalar S) S
+
+	// Pow sets the receiver to the power of the receiver to the argument.
+	Pow(scalar S) S
+
+	// Invert sets the receiver to its modular inverse.
+	Invert() S
+
+	// Equal returns whether the scalars are equal, in constant time.
+	Equal(scalar S) bool
+
+	// LessOrEqual returns whether the receiver is lower than or equal to the argument.
+	LessOrEqual(scalar S) bool
+
+	// IsZero returns whether the scalar is 0.
+	IsZero() bool
+
+	// Set sets the receiver to the argument.
+	Set(scalar S) S
+
+	// SetUInt64 sets the receiver to the integer.
+	SetUInt64(i uint64) S
+
+	// UInt64 returns the scalar as an integer, or an error if it doesn't fit.
+	UInt64() (uint64, error)
+
+	// Copy returns a copy of the receiver.
+	Copy() S
+
+	// Encode returns the byte encoding of the scalar.
+	Encode() []byte
+
+	// Decode sets the receiver to the decoding of the data, and returns an error on failure.
+	Decode(data []byte) error
+
+	// Hex returns the fixed-sized hexadecimal encoding of the scalar.
+	Hex() string
+
+	// DecodeHex sets the receiver to the decoding of the hex encoded scalar.
+	DecodeHex(h string) error
+}
@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package arkg implements Asynchronous Remote Key Generation, as in
+// https://datatracker.ietf.org/doc/draft-bradleylundberg-cfrg-arkg, for all groups: a delegating party derives public
+// keys from a seed public key, along with key handles from which only the holder of the seed private key derives the
+// matching private keys. It instantiates the ECDH KEM with an HMAC tag over the key handle, and the additive
+// blinding of elliptic curve keys, with domain separation strings specific to this package.
+package arkg
+
+import (
+	"crypto/hmac"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ecdh"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	tagLength = 16
+	macKeyLen = 32
+	tauLength = 64
+)
+
+var errInvalidKeyHandle = errors.New("invalid key handle")
+
+// SeedPrivateKey is the private seed, from which the holder derives the private keys of key handles.
+type SeedPrivateKey struct {
+	KEM      *ecc.Scalar
+	Blinding *ecc.Scalar
+}
+
+// SeedPublicKey is the public seed, from which anyone derives public keys and their key handles.
+type SeedPublicKey struct {
+	KEM      *ecc.Element
+	Blinding *ecc.Element
+}
+
+// GenerateSeed returns a new random seed key pair.
+func GenerateSeed(g ecc.Group) (*SeedPrivateKey, *SeedPublicKey) {
+	sk := &SeedPrivateKey{
+		KEM:      g.NewScalar().Random(),
+		Blinding: g.NewScalar().Random(),
+	}
+
+	return sk, sk.Public()
+}
+
+// Public returns the public seed of the private seed.
+func (sk *SeedPrivateKey) Public() *SeedPublicKey {
+	g := sk.KEM.Group()
+
+	return &SeedPublicKey{
+		KEM:      g.Base().Multiply(sk.KEM),
+		Blinding: g.Base().Multiply(sk.Blinding),
+	}
+}
+
+// DerivePublicKey returns a new public key derived from the seed public key and the info, and its key handle, which
+// the seed private key holder needs to derive the private key.
+func DerivePublicKey(seed *SeedPublicKey, info []byte) (*ecc.Element, []byte, error) {
+	if seed == nil || seed.KEM == nil || seed.Blinding == nil {
+		return nil, nil, fmt.Errorf("arkg: %w", internal.ErrParamNilPoint)
+	}
+
+	g := seed.KEM.Group()
+	if seed.Blinding.Group() != g {
+		return nil, nil, fmt.Errorf("arkg: %w", internal.ErrCastElement)
+	}
+
+	if seed.Blinding.IsIdentity() {
+		return nil, nil, fmt.Errorf("arkg: %w", internal.ErrIdentity)
+	}
+
+	ephemeral, encapsulation := ecdh.GenerateKeyPair(g)
+
+	shared, err := ecdh.DH(ephemeral, seed.KEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("arkg: %w", err)
+	}
+
+	c := encapsulation.Encode()
+
+	tau, tag, err := kdf(g, shared, c, info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	public := g.Base().Multiply(blindingFactor(g, tau, info)).Add(seed.Blinding)
+	if public.IsIdentity() {
+		return nil, nil, fmt.Errorf("arkg: %w", internal.ErrIdentity)
+	}
+
+	return public, append(tag, c...), nil
+}
+
+// DerivePrivateKey returns the private key of the public key derived with the key handle and the info, and an error
+// if the key handle was not issued for the seed and the info.
+func DerivePrivateKey(seed *SeedPrivateKey, keyHandle, info []byte) (*ecc.Scalar, error) {
+	if seed == nil || seed.KEM == nil || seed.Blinding == nil {
+		return nil, fmt.Errorf("arkg: %w", internal.ErrParamNilScalar)
+	}
+
+	g := seed.KEM.Group()
+	if seed.Blinding.Group() != g {
+		return nil, fmt.Errorf("arkg: %w", internal.ErrCastScalar)
+	}
+
+	if len(keyHandle) != tagLength+g.ElementLength() {
+		return nil, fmt.Errorf("arkg: %w", errInvalidKeyHandle)
+	}
+
+	c := keyHandle[tagLength:]
+
+	encapsulation := g.NewElement()
+	if err := encapsulation.Decode(c); err != nil {
+		return nil, fmt.Errorf("arkg: %w", errInvalidKeyHandle)
+	}
+
+	shared, err := ecdh.DH(seed.KEM, encapsulation)
+	if err != nil {
+		return nil, fmt.Errorf("arkg: %w", errInvalidKeyHandle)
+	}
+
+	tau, tag, err := kdf(g, shared, c, info)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hmac.Equal(tag, keyHandle[:tagLength]) {
+		return nil, fmt.Errorf("arkg: %w", errInvalidKeyHandle)
+	}
+
+	secret := blindingFactor(g, tau, info).Add(seed.Blinding)
+	if secret.IsZero() {
+		return nil, fmt.Errorf("arkg: %w", internal.ErrParamNilScalar)
+	}
+
+	return secret, nil
+}
+
+// dstExt returns the domain separation tag of the instance.
+func dstExt(g ecc.Group) string {
+	return "ARKG-ecc-" + g.String()
+}
+
+// kdf returns the shared blinding secret tau and the tag of the encapsulation c.
+func kdf(g ecc.Group, shared, c, info []byte) ([]byte, []byte, error) {
+	ext := dstExt(g)
+	h := g.HashFunc().New
+
+	macKey := make([]byte, macKeyLen)
+	if _, err := io.ReadFull(hkdf.New(h, shared, nil, label("ARKG-KEM-HMAC-mac.", ext, info)), macKey); err != nil {
+		return nil, nil, fmt.Errorf("arkg: %w", err)
+	}
+
+	mac := hmac.New(h, macKey)
+	_, _ = mac.Write(c)
+
+	tau := make([]byte, tauLength)
+	if _, err := io.ReadFull(hkdf.New(h, shared, nil, label("ARKG-KEM-HMAC-shared.", ext, info)), tau); err != nil {
+		return nil, nil, fmt.Errorf("arkg: %w", err)
+	}
+
+	return tau, mac.Sum(nil)[:tagLength], nil
+}
+
+// blindingFactor returns the scalar of tau, by which the blinding seed keys are shifted.
+func blindingFactor(g ecc.Group, tau, info []byte) *ecc.Scalar {
+	return g.HashToScalar(tau, label("ARKG-BL-EC.", dstExt(g), info))
+}
+
+func label(prefix, ext string, info []byte) []byte {
+	l := make([]byte, 0, len(prefix)+len(ext)+len(info))
+	l = append(l, prefix...)
+	l = append(l, ext...)
+
+	return append(l, info...)
+}
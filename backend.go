@@ -0,0 +1,22 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !circl
+
+package ecc
+
+import (
+	"github.com/bytemare/ecc/internal/nist"
+	"github.com/bytemare/ecc/internal/ristretto"
+)
+
+// The default backends of the groups that can be swapped with build tags.
+var (
+	newRistretto255 = ristretto.New
+	newP384         = nist.P384
+)
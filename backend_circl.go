@@ -0,0 +1,19 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build circl
+
+package ecc
+
+import "github.com/bytemare/ecc/internal/circl"
+
+// With the circl build tag, Ristretto255 and P-384 use github.com/cloudflare/circl as backend.
+var (
+	newRistretto255 = circl.Ristretto255
+	newP384         = circl.P384
+)
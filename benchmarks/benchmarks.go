@@ -0,0 +1,273 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package benchmarks defines a standard set of benchmarks of the operations of the ecc groups, with the same inputs
+// in all groups, so that users can pick a group empirically on their platform, and maintainers can compare results
+// across changes. Run them in a benchmark function with Run, e.g. with benchstat to catch regressions, or from any
+// program with Measure and WriteTable.
+package benchmarks
+
+import (
+	"fmt"
+	"io"
+	"testing"
+	"text/tabwriter"
+
+	"github.com/bytemare/ecc"
+)
+
+// MSMSizes are the numbers of terms of the multi-scalar multiplication benchmarks.
+var MSMSizes = []int{2, 16, 128, 1024}
+
+var (
+	input = []byte("benchmark input")
+	dst   = []byte("ecc-benchmarks-dst")
+)
+
+// Benchmark is a named benchmark of an operation in a group.
+type Benchmark struct {
+	Name string
+	Run  func(b *testing.B, g ecc.Group)
+}
+
+// Result is the measurement of a benchmark in a group.
+type Result struct {
+	Group       ecc.Group
+	Name        string
+	NsPerOp     int64
+	AllocsPerOp int64
+	BytesPerOp  int64
+}
+
+// Groups returns the groups available to the benchmarks.
+func Groups() []ecc.Group {
+	groups := make([]ecc.Group, 0, 6)
+
+	for _, g := range []ecc.Group{
+		ecc.Ristretto255Sha512,
+		ecc.P256Sha256,
+		ecc.P384Sha384,
+		ecc.P521Sha512,
+		ecc.Edwards25519Sha512,
+		ecc.Secp256k1Sha256,
+	} {
+		if g.Available() {
+			groups = append(groups, g)
+		}
+	}
+
+	return groups
+}
+
+// All returns the standard benchmarks: key generation, element and scalar encoding and decoding, hashing to scalars
+// and to the group, scalar multiplication of the base and of another element, and multi-scalar multiplications of
+// MSMSizes terms.
+func All() []Benchmark {
+	benchmarks := []Benchmark{
+		{Name: "KeyGen", Run: benchKeyGen},
+		{Name: "ElementEncode", Run: benchElementEncode},
+		{Name: "ElementDecode", Run: benchElementDecode},
+		{Name: "ScalarEncode", Run: benchScalarEncode},
+		{Name: "ScalarDecode", Run: benchScalarDecode},
+		{Name: "HashToScalar", Run: benchHashToScalar},
+		{Name: "HashToGroup", Run: benchHashToGroup},
+		{Name: "ScalarBaseMult", Run: benchScalarBaseMult},
+		{Name: "ScalarMult", Run: benchScalarMult},
+	}
+
+	for _, n := range MSMSizes {
+		benchmarks = append(benchmarks, Benchmark{
+			Name: fmt.Sprintf("MultiScalarMult/%d", n),
+			Run:  func(b *testing.B, g ecc.Group) { benchMSM(b, g, n) },
+		})
+	}
+
+	return benchmarks
+}
+
+// Run runs the benchmarks in the groups as sub-benchmarks of b, named Benchmark/Group, and all the standard
+// benchmarks in all available groups if none are given.
+func Run(b *testing.B, benchmarks []Benchmark, groups ...ecc.Group) {
+	if len(benchmarks) == 0 {
+		benchmarks = All()
+	}
+
+	if len(groups) == 0 {
+		groups = Groups()
+	}
+
+	for _, bench := range benchmarks {
+		for _, g := range groups {
+			b.Run(bench.Name+"/"+g.String(), func(b *testing.B) {
+				b.ReportAllocs()
+				bench.Run(b, g)
+			})
+		}
+	}
+}
+
+// Measure runs the benchmarks in the groups with testing.Benchmark, outside of go test, and returns their results in
+// the same order, with the same defaults as Run.
+func Measure(benchmarks []Benchmark, groups ...ecc.Group) []Result {
+	if len(benchmarks) == 0 {
+		benchmarks = All()
+	}
+
+	if len(groups) == 0 {
+		groups = Groups()
+	}
+
+	results := make([]Result, 0, len(benchmarks)*len(groups))
+
+	for _, bench := range benchmarks {
+		for _, g := range groups {
+			r := testing.Benchmark(func(b *testing.B) {
+				b.ReportAllocs()
+				bench.Run(b, g)
+			})
+
+			results = append(results, Result{
+				Group:       g,
+				Name:        bench.Name,
+				NsPerOp:     r.NsPerOp(),
+				AllocsPerOp: r.AllocsPerOp(),
+				BytesPerOp:  r.AllocedBytesPerOp(),
+			})
+		}
+	}
+
+	return results
+}
+
+// WriteTable writes the results to w as an aligned table with one row per benchmark and group, so that the groups
+// can be compared side by side.
+func WriteTable(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	if _, err := fmt.Fprintln(tw, "benchmark\tgroup\tns/op\tB/op\tallocs/op\t"); err != nil {
+		return fmt.Errorf("benchmarks: %w", err)
+	}
+
+	for _, r := range results {
+		if _, err := fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t\n",
+			r.Name, r.Group, r.NsPerOp, r.BytesPerOp, r.AllocsPerOp); err != nil {
+			return fmt.Errorf("benchmarks: %w", err)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("benchmarks: %w", err)
+	}
+
+	return nil
+}
+
+func benchKeyGen(b *testing.B, g ecc.Group) {
+	for range b.N {
+		g.Base().Multiply(g.NewScalar().Random())
+	}
+}
+
+func benchElementEncode(b *testing.B, g ecc.Group) {
+	e := g.Base().Multiply(g.NewScalar().Random())
+
+	b.ResetTimer()
+
+	for range b.N {
+		e.Encode()
+	}
+}
+
+func benchElementDecode(b *testing.B, g ecc.Group) {
+	enc := g.Base().Multiply(g.NewScalar().Random()).Encode()
+	e := g.NewElement()
+
+	b.ResetTimer()
+
+	for range b.N {
+		if err := e.Decode(enc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchScalarEncode(b *testing.B, g ecc.Group) {
+	s := g.NewScalar().Random()
+
+	b.ResetTimer()
+
+	for range b.N {
+		s.Encode()
+	}
+}
+
+func benchScalarDecode(b *testing.B, g ecc.Group) {
+	enc := g.NewScalar().Random().Encode()
+	s := g.NewScalar()
+
+	b.ResetTimer()
+
+	for range b.N {
+		if err := s.Decode(enc); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchHashToScalar(b *testing.B, g ecc.Group) {
+	for range b.N {
+		g.HashToScalar(input, dst)
+	}
+}
+
+func benchHashToGroup(b *testing.B, g ecc.Group) {
+	for range b.N {
+		g.HashToGroup(input, dst)
+	}
+}
+
+func benchScalarBaseMult(b *testing.B, g ecc.Group) {
+	s := g.NewScalar().Random()
+	e := g.NewElement()
+
+	b.ResetTimer()
+
+	for range b.N {
+		e.Base().Multiply(s)
+	}
+}
+
+func benchScalarMult(b *testing.B, g ecc.Group) {
+	s := g.NewScalar().Random()
+	p := g.Base().Multiply(g.NewScalar().Random())
+	e := g.NewElement()
+
+	b.ResetTimer()
+
+	for range b.N {
+		e.Set(p).Multiply(s)
+	}
+}
+
+func benchMSM(b *testing.B, g ecc.Group, n int) {
+	scalars := make([]*ecc.Scalar, n)
+	elements := make([]*ecc.Element, n)
+
+	for i := range n {
+		scalars[i] = g.NewScalar().Random()
+		elements[i] = g.Base().Multiply(g.NewScalar().Random())
+	}
+
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := g.MultiScalarMult(scalars, elements); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package bip340 implements the Schnorr signatures of Bitcoin's BIP-340 over the Secp256k1Sha256 group, with its
+// tagged hashes. Public keys and nonces use the 32-byte x-only encoding of ecc.Element.EncodeXOnly and
+// ecc.Group.DecodeXOnly.
+package bip340
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	// PublicKeySize is the size of x-only public keys, in bytes.
+	PublicKeySize = 32
+
+	// SignatureSize is the size of the signatures, in bytes.
+	SignatureSize = 64
+
+	// AuxSize is the size of the auxiliary random data of Sign, in bytes.
+	AuxSize = 32
+
+	tagAux       = "BIP0340/aux"
+	tagNonce     = "BIP0340/nonce"
+	tagChallenge = "BIP0340/challenge"
+)
+
+var (
+	errInvalidSignature = errors.New("invalid signature")
+	errInvalidAux       = errors.New("invalid auxiliary data length")
+)
+
+// TaggedHash returns the BIP-340 tagged hash SHA256(SHA256(tag) || SHA256(tag) || data...).
+func TaggedHash(tag string, data ...[]byte) []byte {
+	t := sha256.Sum256([]byte(tag))
+
+	h := sha256.New()
+	_, _ = h.Write(t[:])
+	_, _ = h.Write(t[:])
+
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+
+	return h.Sum(nil)
+}
+
+// PublicKey returns the x-only public key of the secret scalar.
+func PublicKey(secret *ecc.Scalar) ([]byte, error) {
+	if err := checkSecret(secret); err != nil {
+		return nil, err
+	}
+
+	return ecc.Secp256k1Sha256.Base().Multiply(secret).EncodeXOnly(), nil
+}
+
+func checkSecret(secret *ecc.Scalar) error {
+	if secret == nil || secret.IsZero() {
+		return fmt.Errorf("bip340: %w", internal.ErrParamNilScalar)
+	}
+
+	if secret.Group() != ecc.Secp256k1Sha256 {
+		return fmt.Errorf("bip340: %w", internal.ErrInvalidGroup)
+	}
+
+	return nil
+}
+
+// negate returns -s.
+func negate(s *ecc.Scalar) *ecc.Scalar {
+	return s.Group().NewScalar().Subtract(s)
+}
+
+// TaggedHashToScalar returns the tagged hash of the data reduced modulo the group order.
+func TaggedHashToScalar(tag string, data ...[]byte) *ecc.Scalar {
+	n := new(big.Int).SetBytes(ecc.Secp256k1Sha256.Order())
+	i := new(big.Int).SetBytes(TaggedHash(tag, data...))
+
+	s := ecc.Secp256k1Sha256.NewScalar()
+	if err := s.Decode(i.Mod(i, n).FillBytes(make([]byte, ecc.Secp256k1Sha256.ScalarLength()))); err != nil {
+		// A reduced integer is always a valid scalar.
+		panic(err)
+	}
+
+	return s
+}
+
+// Challenge returns the BIP-340 challenge scalar of the x-only encodings of the nonce and the public key, and the
+// message.
+func Challenge(nonce, public, message []byte) *ecc.Scalar {
+	return TaggedHashToScalar(tagChallenge, nonce, public, message)
+}
+
+// Sign returns the BIP-340 signature of the message with the secret scalar and the 32 bytes of auxiliary random data.
+// If aux is nil, fresh random bytes are used, as recommended by BIP-340.
+func Sign(secret *ecc.Scalar, message, aux []byte) ([]byte, error) {
+	if err := checkSecret(secret); err != nil {
+		return nil, err
+	}
+
+	if aux == nil {
+		aux = internal.RandomBytes(AuxSize)
+	}
+
+	if len(aux) != AuxSize {
+		return nil, fmt.Errorf("bip340: %w", errInvalidAux)
+	}
+
+	g := ecc.Secp256k1Sha256
+	d := secret.Copy()
+
+	p := g.Base().Multiply(d)
+	if !p.HasEvenY() {
+		d = negate(d)
+	}
+
+	pk := p.EncodeXOnly()
+
+	t := d.Encode()
+	for i, b := range TaggedHash(tagAux, aux) {
+		t[i] ^= b
+	}
+
+	k := TaggedHashToScalar(tagNonce, t, pk, message)
+	if k.IsZero() {
+		return nil, fmt.Errorf("bip340: %w", errInvalidSignature)
+	}
+
+	r := g.Base().Multiply(k)
+	if !r.HasEvenY() {
+		k = negate(k)
+	}
+
+	rx := r.EncodeXOnly()
+	e := Challenge(rx, pk, message)
+
+	return append(rx, k.Add(e.Multiply(d)).Encode()...), nil
+}
+
+// Verify returns nil if the BIP-340 signature of the message is valid for the x-only public key.
+func Verify(public, message, signature []byte) error {
+	p, err := ecc.Secp256k1Sha256.DecodeXOnly(public)
+	if err != nil {
+		return fmt.Errorf("bip340: %w", err)
+	}
+
+	if len(signature) != SignatureSize {
+		return fmt.Errorf("bip340: %w", errInvalidSignature)
+	}
+
+	g := ecc.Secp256k1Sha256
+
+	s := g.NewScalar()
+	if err = s.Decode(signature[32:]); err != nil {
+		return fmt.Errorf("bip340: %w", errInvalidSignature)
+	}
+
+	e := Challenge(signature[:32], public, message)
+
+	// R = s * G - e * P, where the negation is on the scalar. Comparing the bytes of x(R) and r also rejects r >= p.
+	r := g.Base().Multiply(s).Add(p.Multiply(negate(e)))
+	if r.IsIdentity() || !r.HasEvenY() || string(r.EncodeXOnly()) != string(signature[:32]) {
+		return fmt.Errorf("bip340: %w", errInvalidSignature)
+	}
+
+	return nil
+}
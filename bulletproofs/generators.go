@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package bulletproofs implements the inner-product argument and the range proofs of Bulletproofs (Bunz et al., 2018)
+// over any ecc group, made non-interactive with Fiat-Shamir challenges bound to the group's ciphersuite. Proofs are
+// logarithmic in the vector length, and inner-product verification is a single multi-scalar multiplication.
+package bulletproofs
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	dstApp     = "ecc-bulletproofs"
+	dstVersion = 1
+)
+
+var (
+	errInvalidLength = errors.New("the vector length must be a non-zero power of 2")
+	errInvalidProof  = errors.New("invalid proof")
+	errInvalidInput  = errors.New("invalid input")
+)
+
+// Generators are the independent generators of the vector commitments, derived by hash-to-group from a public label
+// so that nobody knows discrete logarithms between them.
+type Generators struct {
+	U     *ecc.Element
+	G     []*ecc.Element
+	H     []*ecc.Element
+	label []byte
+	group ecc.Group
+}
+
+// NewGenerators returns the generators for vectors of length n, which must be a power of 2, derived from the label.
+// The generators of a shorter length are a prefix of those of a longer one.
+func NewGenerators(g ecc.Group, n int, label []byte) (*Generators, error) {
+	if n <= 0 || n&(n-1) != 0 {
+		return nil, fmt.Errorf("bulletproofs: %w", errInvalidLength)
+	}
+
+	dst := g.DST(dstApp, dstVersion)
+	gens := &Generators{
+		U:     g.HashToGroup(generatorInput(label, 'U', 0), dst),
+		G:     make([]*ecc.Element, n),
+		H:     make([]*ecc.Element, n),
+		label: append([]byte(nil), label...),
+		group: g,
+	}
+
+	for i := range n {
+		gens.G[i] = g.HashToGroup(generatorInput(label, 'G', uint32(i)), dst)
+		gens.H[i] = g.HashToGroup(generatorInput(label, 'H', uint32(i)), dst)
+	}
+
+	return gens, nil
+}
+
+func generatorInput(label []byte, name byte, index uint32) []byte {
+	in := binary.BigEndian.AppendUint32(nil, uint32(len(label)))
+	in = append(in, label...)
+	in = append(in, name)
+
+	return binary.BigEndian.AppendUint32(in, index)
+}
+
+// Group returns the group of the generators.
+func (g *Generators) Group() ecc.Group {
+	return g.group
+}
+
+// Commit returns the vector commitment <a, G> + <b, H> to the vectors, which must have the length of the generators.
+func (g *Generators) Commit(a, b []*ecc.Scalar) (*ecc.Element, error) {
+	if err := g.checkVectors(a, b); err != nil {
+		return nil, fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	p := g.group.NewElement()
+	for i := range a {
+		p.Add(g.G[i].Copy().Multiply(a[i]))
+		p.Add(g.H[i].Copy().Multiply(b[i]))
+	}
+
+	return p, nil
+}
+
+func (g *Generators) checkVectors(a, b []*ecc.Scalar) error {
+	if len(a) != len(g.G) || len(b) != len(g.H) {
+		return errInvalidInput
+	}
+
+	for i := range a {
+		if a[i] == nil || b[i] == nil || a[i].Group() != g.group || b[i].Group() != g.group {
+			return errInvalidInput
+		}
+	}
+
+	return nil
+}
+
+// InnerProduct returns <a, b>.
+func InnerProduct(a, b []*ecc.Scalar) *ecc.Scalar {
+	c := a[0].Group().NewScalar()
+	for i := range a {
+		c.Add(a[i].Copy().Multiply(b[i]))
+	}
+
+	return c
+}
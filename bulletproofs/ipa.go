@@ -0,0 +1,291 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package bulletproofs
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/transcript"
+)
+
+// InnerProductProof proves knowledge of vectors a and b such that P = <a, G> + <b, H> and c = <a, b>, with one pair
+// of elements L and R per halving round, and the final scalars A and B.
+type InnerProductProof struct {
+	A, B *ecc.Scalar
+	L, R []*ecc.Element
+}
+
+// Encode returns the serialization L_0 || R_0 || ... || L_k || R_k || A || B of the proof.
+func (p *InnerProductProof) Encode() []byte {
+	var out []byte
+	for i := range p.L {
+		out = append(out, p.L[i].Encode()...)
+		out = append(out, p.R[i].Encode()...)
+	}
+
+	out = append(out, p.A.Encode()...)
+
+	return append(out, p.B.Encode()...)
+}
+
+// DecodeInnerProductProof returns the proof over the group deserialized from its encoding.
+func DecodeInnerProductProof(g ecc.Group, data []byte) (*InnerProductProof, error) {
+	el, sl := g.ElementLength(), g.ScalarLength()
+	if len(data) < 2*sl || (len(data)-2*sl)%(2*el) != 0 {
+		return nil, fmt.Errorf("bulletproofs: %w", errInvalidProof)
+	}
+
+	rounds := (len(data) - 2*sl) / (2 * el)
+	p := &InnerProductProof{
+		A: g.NewScalar(),
+		B: g.NewScalar(),
+		L: make([]*ecc.Element, rounds),
+		R: make([]*ecc.Element, rounds),
+	}
+
+	for i := range rounds {
+		p.L[i], p.R[i] = g.NewElement(), g.NewElement()
+
+		if err := p.L[i].Decode(data[2*i*el : (2*i+1)*el]); err != nil {
+			return nil, fmt.Errorf("bulletproofs: %w", err)
+		}
+
+		if err := p.R[i].Decode(data[(2*i+1)*el : (2*i+2)*el]); err != nil {
+			return nil, fmt.Errorf("bulletproofs: %w", err)
+		}
+	}
+
+	data = data[2*rounds*el:]
+
+	if err := p.A.Decode(data[:sl]); err != nil {
+		return nil, fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	if err := p.B.Decode(data[sl:]); err != nil {
+		return nil, fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	return p, nil
+}
+
+// transcript returns the initial Fiat-Shamir transcript of the statement.
+func (g *Generators) transcript(context []byte, p *ecc.Element, c *ecc.Scalar) *transcript.Transcript {
+	t := g.newTranscript(context)
+	t.AppendElement("P", p)
+	t.AppendScalar("c", c)
+
+	return t
+}
+
+// newTranscript returns a transcript bound to the context and the generators.
+func (g *Generators) newTranscript(context []byte) *transcript.Transcript {
+	t := transcript.New(g.group, dstApp)
+	t.AppendMessage("context", context)
+	t.AppendMessage("label", g.label)
+	t.AppendUint64("n", uint64(len(g.G)))
+
+	return t
+}
+
+// innerProductCommit returns <a, G> + <b, H> + <a, b> * u.
+func innerProductCommit(a, b []*ecc.Scalar, gs, hs []*ecc.Element, u *ecc.Element) *ecc.Element {
+	p := u.Copy().Multiply(InnerProduct(a, b))
+	for i := range a {
+		p.Add(gs[i].Copy().Multiply(a[i]))
+		p.Add(hs[i].Copy().Multiply(b[i]))
+	}
+
+	return p
+}
+
+// ProveInnerProduct returns the proof of knowledge of a and b such that P = <a, G> + <b, H> and c = <a, b>, where P is
+// the commitment of the vectors with the generators and c their inner product. The context, e.g. a session
+// identifier, is bound to the proof and must be the same for verification.
+func ProveInnerProduct(context []byte, gens *Generators, a, b []*ecc.Scalar) (*InnerProductProof, error) {
+	if err := gens.checkVectors(a, b); err != nil {
+		return nil, fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	p, _ := gens.Commit(a, b)
+
+	return proveIPA(gens, gens.transcript(context, p, InnerProduct(a, b)), gens.G, gens.H, a, b), nil
+}
+
+// proveIPA returns the inner-product proof of a and b for the generators gs and hs, continuing the transcript.
+func proveIPA(
+	gens *Generators,
+	t *transcript.Transcript,
+	gs, hs []*ecc.Element,
+	a, b []*ecc.Scalar,
+) *InnerProductProof {
+	u := gens.U.Copy().Multiply(t.Challenge("u"))
+
+	a, b = copyScalars(a), copyScalars(b)
+	gs, hs = copyElements(gs), copyElements(hs)
+	proof := &InnerProductProof{}
+
+	for n := len(a) / 2; n > 0; n /= 2 {
+		l := innerProductCommit(a[:n], b[n:], gs[n:], hs[:n], u)
+		r := innerProductCommit(a[n:], b[:n], gs[:n], hs[n:], u)
+		proof.L = append(proof.L, l)
+		proof.R = append(proof.R, r)
+
+		t.AppendElement("L", l)
+		t.AppendElement("R", r)
+		x := t.Challenge("x")
+		xInv := x.Copy().Invert()
+
+		for i := range n {
+			a[i] = a[i].Multiply(x).Add(a[n+i].Multiply(xInv))
+			b[i] = b[i].Multiply(xInv).Add(b[n+i].Multiply(x))
+			gs[i] = gs[i].Multiply(xInv).Add(gs[n+i].Multiply(x))
+			hs[i] = hs[i].Multiply(x).Add(hs[n+i].Multiply(xInv))
+		}
+
+		a, b, gs, hs = a[:n], b[:n], gs[:n], hs[:n]
+	}
+
+	proof.A, proof.B = a[0], b[0]
+
+	return proof
+}
+
+// VerifyInnerProduct returns nil if the proof shows knowledge of vectors a and b such that P = <a, G> + <b, H> and
+// c = <a, b>, for the context.
+func VerifyInnerProduct(
+	context []byte,
+	gens *Generators,
+	p *ecc.Element,
+	c *ecc.Scalar,
+	proof *InnerProductProof,
+) error {
+	g := gens.group
+	if p == nil || c == nil || p.Group() != g || c.Group() != g {
+		return fmt.Errorf("bulletproofs: %w", errInvalidInput)
+	}
+
+	return verifyIPA(gens, gens.transcript(context, p, c), nil, p, c, proof)
+}
+
+// verifyIPA verifies the inner-product proof for the generators G and H_i scaled by hScale_i, or H if hScale is nil,
+// continuing the transcript.
+func verifyIPA(
+	gens *Generators,
+	t *transcript.Transcript,
+	hScale []*ecc.Scalar,
+	p *ecc.Element,
+	c *ecc.Scalar,
+	proof *InnerProductProof,
+) error {
+	g := gens.group
+
+	rounds := bits.Len(uint(len(gens.G))) - 1
+	if err := checkProof(g, rounds, proof); err != nil {
+		return fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	x0 := t.Challenge("u")
+
+	challenges := make([]*ecc.Scalar, rounds)
+	inverses := make([]*ecc.Scalar, rounds)
+
+	for j := range rounds {
+		t.AppendElement("L", proof.L[j])
+		t.AppendElement("R", proof.R[j])
+		challenges[j] = t.Challenge("x")
+		inverses[j] = challenges[j].Copy().Invert()
+	}
+
+	n := len(gens.G)
+	scalars := make([]*ecc.Scalar, 0, 2*n+2*rounds+2)
+	elements := make([]*ecc.Element, 0, cap(scalars))
+
+	// a * s_i * G_i + b * s_i^-1 * H_i, where s_i is the product of the challenges folding G_i.
+	for i := range n {
+		s, sInv := g.NewScalar().One(), g.NewScalar().One()
+
+		for j := range rounds {
+			if (i>>(rounds-1-j))&1 == 1 {
+				s.Multiply(challenges[j])
+				sInv.Multiply(inverses[j])
+			} else {
+				s.Multiply(inverses[j])
+				sInv.Multiply(challenges[j])
+			}
+		}
+
+		if hScale != nil {
+			sInv.Multiply(hScale[i])
+		}
+
+		scalars = append(scalars, s.Multiply(proof.A), sInv.Multiply(proof.B))
+		elements = append(elements, gens.G[i], gens.H[i])
+	}
+
+	// + (a * b - c) * x0 * U - sum(x_j^2 * L_j + x_j^-2 * R_j) - P == identity.
+	scalars = append(scalars, proof.A.Copy().Multiply(proof.B).Subtract(c).Multiply(x0))
+	elements = append(elements, gens.U)
+
+	for j := range rounds {
+		scalars = append(scalars,
+			g.NewScalar().Subtract(challenges[j].Copy().Multiply(challenges[j])),
+			g.NewScalar().Subtract(inverses[j].Copy().Multiply(inverses[j])),
+		)
+		elements = append(elements, proof.L[j], proof.R[j])
+	}
+
+	scalars = append(scalars, g.NewScalar().MinusOne())
+	elements = append(elements, p)
+
+	r, err := g.MultiScalarMult(scalars, elements)
+	if err != nil {
+		return fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	if !r.IsIdentity() {
+		return fmt.Errorf("bulletproofs: %w", errInvalidProof)
+	}
+
+	return nil
+}
+
+func checkProof(g ecc.Group, rounds int, proof *InnerProductProof) error {
+	if proof == nil || proof.A == nil || proof.B == nil || proof.A.Group() != g || proof.B.Group() != g ||
+		len(proof.L) != rounds || len(proof.R) != rounds {
+		return errInvalidProof
+	}
+
+	for j := range rounds {
+		if proof.L[j] == nil || proof.R[j] == nil || proof.L[j].Group() != g || proof.R[j].Group() != g {
+			return errInvalidProof
+		}
+	}
+
+	return nil
+}
+
+func copyScalars(s []*ecc.Scalar) []*ecc.Scalar {
+	out := make([]*ecc.Scalar, len(s))
+	for i := range s {
+		out[i] = s[i].Copy()
+	}
+
+	return out
+}
+
+func copyElements(e []*ecc.Element) []*ecc.Element {
+	out := make([]*ecc.Element, len(e))
+	for i := range e {
+		out[i] = e[i].Copy()
+	}
+
+	return out
+}
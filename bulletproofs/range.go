@@ -0,0 +1,328 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package bulletproofs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/commitment"
+	"github.com/bytemare/ecc/transcript"
+)
+
+// maxRangeBits is the maximum bit length of ranges, as values are uint64.
+const maxRangeBits = 64
+
+var errOutOfRange = errors.New("value out of range")
+
+// RangeProof proves that a Pedersen commitment V = v * G + gamma * H commits to a value v in [0, 2^n), where n is the
+// length of the generators, as in section 4.2 of the Bulletproofs paper. Its size is logarithmic in n.
+type RangeProof struct {
+	A, S, T1, T2   *ecc.Element
+	TauX, Mu, THat *ecc.Scalar
+	IPA            *InnerProductProof
+}
+
+// Encode returns the serialization A || S || T1 || T2 || TauX || Mu || THat || IPA of the proof.
+func (p *RangeProof) Encode() []byte {
+	var out []byte
+	for _, e := range []*ecc.Element{p.A, p.S, p.T1, p.T2} {
+		out = append(out, e.Encode()...)
+	}
+
+	for _, s := range []*ecc.Scalar{p.TauX, p.Mu, p.THat} {
+		out = append(out, s.Encode()...)
+	}
+
+	return append(out, p.IPA.Encode()...)
+}
+
+// DecodeRangeProof returns the range proof over the group deserialized from its encoding.
+func DecodeRangeProof(g ecc.Group, data []byte) (*RangeProof, error) {
+	el, sl := g.ElementLength(), g.ScalarLength()
+	if len(data) < 4*el+3*sl {
+		return nil, fmt.Errorf("bulletproofs: %w", errInvalidProof)
+	}
+
+	p := &RangeProof{}
+	elements := []**ecc.Element{&p.A, &p.S, &p.T1, &p.T2}
+	scalars := []**ecc.Scalar{&p.TauX, &p.Mu, &p.THat}
+
+	for _, e := range elements {
+		*e = g.NewElement()
+		if err := (*e).Decode(data[:el]); err != nil {
+			return nil, fmt.Errorf("bulletproofs: %w", err)
+		}
+
+		data = data[el:]
+	}
+
+	for _, s := range scalars {
+		*s = g.NewScalar()
+		if err := (*s).Decode(data[:sl]); err != nil {
+			return nil, fmt.Errorf("bulletproofs: %w", err)
+		}
+
+		data = data[sl:]
+	}
+
+	ipa, err := DecodeInnerProductProof(g, data)
+	if err != nil {
+		return nil, err
+	}
+
+	p.IPA = ipa
+
+	return p, nil
+}
+
+// rangeTranscript returns the initial Fiat-Shamir transcript of the range proof.
+func (g *Generators) rangeTranscript(
+	context []byte,
+	params *commitment.Params,
+	v, a, s *ecc.Element,
+) *transcript.Transcript {
+	t := g.newTranscript(context)
+	t.AppendElement("H", params.H)
+	t.AppendElement("V", v)
+	t.AppendElement("A", a)
+	t.AppendElement("S", s)
+
+	return t
+}
+
+// powers returns (1, x, x^2, ..., x^(n-1)).
+func powers(x *ecc.Scalar, n int) []*ecc.Scalar {
+	p := make([]*ecc.Scalar, n)
+	p[0] = x.Group().NewScalar().One()
+
+	for i := 1; i < n; i++ {
+		p[i] = p[i-1].Copy().Multiply(x)
+	}
+
+	return p
+}
+
+func (g *Generators) checkRange(params *commitment.Params) error {
+	if params == nil || params.Group() != g.group {
+		return errInvalidInput
+	}
+
+	if len(g.G) > maxRangeBits {
+		return errInvalidLength
+	}
+
+	return nil
+}
+
+// ProveRange returns the commitment V = value * G + blinding * H with the Pedersen parameters, and the proof that the
+// value is in [0, 2^n), where n is the length of the generators, at most 64. The context, e.g. a session identifier,
+// is bound to the proof and must be the same for verification.
+func ProveRange(
+	context []byte,
+	gens *Generators,
+	params *commitment.Params,
+	value uint64,
+	blinding *ecc.Scalar,
+) (*RangeProof, *ecc.Element, error) {
+	if err := gens.checkRange(params); err != nil {
+		return nil, nil, fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	n := len(gens.G)
+	if n < maxRangeBits && value>>n != 0 {
+		return nil, nil, fmt.Errorf("bulletproofs: %w", errOutOfRange)
+	}
+
+	g := gens.group
+
+	v, err := params.Commit(g.NewScalar().SetUInt64(value), blinding)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	// a_L are the bits of the value, and a_R = a_L - 1^n. s_L and s_R blind them.
+	one := g.NewScalar().One()
+	aL, aR := make([]*ecc.Scalar, n), make([]*ecc.Scalar, n)
+	sL, sR := make([]*ecc.Scalar, n), make([]*ecc.Scalar, n)
+	alpha, rho := g.NewScalar().Random(), g.NewScalar().Random()
+	a, s := params.H.Copy().Multiply(alpha), params.H.Copy().Multiply(rho)
+
+	for i := range n {
+		aL[i] = g.NewScalar().SetUInt64((value >> i) & 1)
+		aR[i] = aL[i].Copy().Subtract(one)
+		sL[i], sR[i] = g.NewScalar().Random(), g.NewScalar().Random()
+
+		a.Add(gens.G[i].Copy().Multiply(aL[i])).Add(gens.H[i].Copy().Multiply(aR[i]))
+		s.Add(gens.G[i].Copy().Multiply(sL[i])).Add(gens.H[i].Copy().Multiply(sR[i]))
+	}
+
+	t := gens.rangeTranscript(context, params, v, a, s)
+	y := t.Challenge("y")
+	z := t.Challenge("z")
+	z2 := z.Copy().Multiply(z)
+
+	// l(X) = l0 + l1 * X and r(X) = r0 + r1 * X, with l0 = a_L - z * 1^n, l1 = s_L,
+	// r0 = y^n o (a_R + z * 1^n) + z^2 * 2^n, and r1 = y^n o s_R.
+	yn, twoN := powers(y, n), powers(g.NewScalar().SetUInt64(2), n)
+	l0, r0, r1 := make([]*ecc.Scalar, n), make([]*ecc.Scalar, n), make([]*ecc.Scalar, n)
+
+	for i := range n {
+		l0[i] = aL[i].Copy().Subtract(z)
+		r0[i] = aR[i].Copy().Add(z).Multiply(yn[i]).Add(z2.Copy().Multiply(twoN[i]))
+		r1[i] = sR[i].Copy().Multiply(yn[i])
+	}
+
+	// t(X) = <l(X), r(X)> = t0 + t1 * X + t2 * X^2.
+	t1 := InnerProduct(l0, r1).Add(InnerProduct(sL, r0))
+	t2 := InnerProduct(sL, r1)
+	tau1, tau2 := g.NewScalar().Random(), g.NewScalar().Random()
+
+	proof := &RangeProof{A: a, S: s}
+	if proof.T1, err = params.Commit(t1, tau1); err != nil {
+		return nil, nil, fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	if proof.T2, err = params.Commit(t2, tau2); err != nil {
+		return nil, nil, fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	t.AppendElement("T1", proof.T1)
+	t.AppendElement("T2", proof.T2)
+	x := t.Challenge("x")
+
+	l, r := make([]*ecc.Scalar, n), make([]*ecc.Scalar, n)
+	for i := range n {
+		l[i] = sL[i].Copy().Multiply(x).Add(l0[i])
+		r[i] = r1[i].Copy().Multiply(x).Add(r0[i])
+	}
+
+	proof.THat = InnerProduct(l, r)
+	proof.TauX = tau2.Multiply(x).Add(tau1).Multiply(x).Add(z2.Copy().Multiply(blinding))
+	proof.Mu = rho.Multiply(x).Add(alpha)
+
+	// The inner-product argument proves l and r for the generators G and H'_i = y^-i * H_i.
+	yInv := y.Copy().Invert()
+	hPrime := make([]*ecc.Element, n)
+
+	for i, yi := range powers(yInv, n) {
+		hPrime[i] = gens.H[i].Copy().Multiply(yi)
+	}
+
+	proof.appendScalars(t)
+	proof.IPA = proveIPA(gens, t, gens.G, hPrime, l, r)
+
+	return proof, v, nil
+}
+
+func (p *RangeProof) appendScalars(t *transcript.Transcript) {
+	t.AppendScalar("TauX", p.TauX)
+	t.AppendScalar("Mu", p.Mu)
+	t.AppendScalar("THat", p.THat)
+}
+
+func (p *RangeProof) check(g ecc.Group) error {
+	for _, e := range []*ecc.Element{p.A, p.S, p.T1, p.T2} {
+		if e == nil || e.Group() != g {
+			return errInvalidProof
+		}
+	}
+
+	for _, s := range []*ecc.Scalar{p.TauX, p.Mu, p.THat} {
+		if s == nil || s.Group() != g {
+			return errInvalidProof
+		}
+	}
+
+	return nil
+}
+
+// VerifyRange returns nil if the proof shows that the Pedersen commitment commits to a value in [0, 2^n), where n is
+// the length of the generators, for the context.
+func VerifyRange(
+	context []byte,
+	gens *Generators,
+	params *commitment.Params,
+	v *ecc.Element,
+	proof *RangeProof,
+) error {
+	if err := gens.checkRange(params); err != nil {
+		return fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	g := gens.group
+	if v == nil || v.Group() != g || proof == nil {
+		return fmt.Errorf("bulletproofs: %w", errInvalidInput)
+	}
+
+	if err := proof.check(g); err != nil {
+		return fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	n := len(gens.G)
+	t := gens.rangeTranscript(context, params, v, proof.A, proof.S)
+	y := t.Challenge("y")
+	z := t.Challenge("z")
+	t.AppendElement("T1", proof.T1)
+	t.AppendElement("T2", proof.T2)
+	x := t.Challenge("x")
+
+	z2 := z.Copy().Multiply(z)
+	x2 := x.Copy().Multiply(x)
+	yn, twoN := powers(y, n), powers(g.NewScalar().SetUInt64(2), n)
+
+	// delta(y, z) = (z - z^2) * <1^n, y^n> - z^3 * <1^n, 2^n>.
+	sumY, sum2 := g.NewScalar(), g.NewScalar()
+	for i := range n {
+		sumY.Add(yn[i])
+		sum2.Add(twoN[i])
+	}
+
+	delta := z.Copy().Subtract(z2).Multiply(sumY).Subtract(z2.Copy().Multiply(z).Multiply(sum2))
+
+	// THat * G + TauX * H - z^2 * V - delta * G - x * T1 - x^2 * T2 == identity.
+	check, err := g.MultiScalarMult(
+		[]*ecc.Scalar{
+			proof.THat.Copy().Subtract(delta),
+			proof.TauX,
+			g.NewScalar().Subtract(z2),
+			g.NewScalar().Subtract(x),
+			g.NewScalar().Subtract(x2),
+		},
+		[]*ecc.Element{params.G, params.H, v, proof.T1, proof.T2},
+	)
+	if err != nil {
+		return fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	if !check.IsIdentity() {
+		return fmt.Errorf("bulletproofs: %w", errInvalidProof)
+	}
+
+	// P = A + x * S - z * <1^n, G> + <z * 1^n + z^2 * y^-n o 2^n, H> - Mu * H.
+	yInv := powers(y.Copy().Invert(), n)
+	scalars := []*ecc.Scalar{g.NewScalar().One(), x, g.NewScalar().Subtract(proof.Mu)}
+	elements := []*ecc.Element{proof.A, proof.S, params.H}
+	minusZ := g.NewScalar().Subtract(z)
+
+	for i := range n {
+		scalars = append(scalars, minusZ, z2.Copy().Multiply(twoN[i]).Multiply(yInv[i]).Add(z))
+		elements = append(elements, gens.G[i], gens.H[i])
+	}
+
+	p, err := g.MultiScalarMult(scalars, elements)
+	if err != nil {
+		return fmt.Errorf("bulletproofs: %w", err)
+	}
+
+	proof.appendScalars(t)
+
+	return verifyIPA(gens, t, yInv, p, proof.THat, proof.IPA)
+}
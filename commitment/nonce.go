@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package commitment
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// NonceOpeningLength is the byte length of the openings of nonce commitments.
+const NonceOpeningLength = 32
+
+const nonceDSTApp = "ecc-nonce-commitment"
+
+var (
+	errNoNonces       = errors.New("no nonces to commit to")
+	errInvalidOpening = errors.New("invalid opening length")
+)
+
+// CommitNonces returns a hash commitment to the nonce elements, bound to the context, e.g. a session identifier and
+// the signers' indices, and the random opening to later reveal with the nonces. In multi-round signing protocols,
+// every signer first publishes the commitment to its nonces, and only reveals them once it received all other
+// commitments, so that no signer can choose its nonces as a function of the others', which prevents ROS-style
+// attacks on concurrent sessions. The commitment is hiding, thanks to the opening, and binding.
+func CommitNonces(context []byte, nonces ...*ecc.Element) (commitment, opening []byte, err error) {
+	opening = internal.RandomBytes(NonceOpeningLength)
+
+	commitment, err = CommitNoncesWithOpening(context, opening, nonces...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return commitment, opening, nil
+}
+
+// CommitNoncesWithOpening returns the hash commitment to the nonce elements with the opening, deterministically. The
+// opening must be uniformly random and secret until the nonces are revealed for the commitment to hide them.
+func CommitNoncesWithOpening(context, opening []byte, nonces ...*ecc.Element) ([]byte, error) {
+	if len(opening) != NonceOpeningLength {
+		return nil, fmt.Errorf("commitment: %w", errInvalidOpening)
+	}
+
+	if err := checkNonces(nonces); err != nil {
+		return nil, fmt.Errorf("commitment: %w", err)
+	}
+
+	return nonceCommitment(context, opening, nonces), nil
+}
+
+// VerifyNonces returns whether the commitment opens to the nonce elements with the opening, for the context. The
+// comparison is in constant time.
+func VerifyNonces(context, commitment, opening []byte, nonces ...*ecc.Element) bool {
+	if len(opening) != NonceOpeningLength || checkNonces(nonces) != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(commitment, nonceCommitment(context, opening, nonces)) == 1
+}
+
+func checkNonces(nonces []*ecc.Element) error {
+	if len(nonces) == 0 {
+		return errNoNonces
+	}
+
+	for _, n := range nonces {
+		if n == nil {
+			return internal.ErrParamNilPoint
+		}
+
+		if n.Group() != nonces[0].Group() {
+			return internal.ErrCastElement
+		}
+
+		if n.IsIdentity() {
+			return internal.ErrIdentity
+		}
+	}
+
+	return nil
+}
+
+// nonceCommitment returns Hash(len(DST) || DST || len(context) || context || opening || count || nonces), with the
+// hash function of the nonces' group, and the DST bound to its ciphersuite.
+func nonceCommitment(context, opening []byte, nonces []*ecc.Element) []byte {
+	g := nonces[0].Group()
+	dst := g.DST(nonceDSTApp, dstVersion)
+
+	h := g.HashFunc().New()
+	_, _ = h.Write(binary.BigEndian.AppendUint16(nil, uint16(len(dst))))
+	_, _ = h.Write(dst)
+	_, _ = h.Write(binary.BigEndian.AppendUint32(nil, uint32(len(context))))
+	_, _ = h.Write(context)
+	_, _ = h.Write(opening)
+	_, _ = h.Write(binary.BigEndian.AppendUint16(nil, uint16(len(nonces))))
+
+	for _, n := range nonces {
+		_, _ = h.Write(n.Encode())
+	}
+
+	return h.Sum(nil)
+}
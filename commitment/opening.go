@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package commitment
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/zkp"
+)
+
+// openingLabel domain separates opening proofs from other proofs of the same statement.
+const openingLabel = "ecc-pedersen-opening"
+
+// openingStatement returns the statement of knowledge of (value, blinding) such that C = value * G + blinding * H.
+func (p *Params) openingStatement(commitment *ecc.Element) (*zkp.Statement, error) {
+	if commitment == nil {
+		return nil, internal.ErrParamNilPoint
+	}
+
+	if commitment.Group() != p.group {
+		return nil, internal.ErrCastElement
+	}
+
+	return zkp.Representation(commitment, p.G, p.H), nil
+}
+
+func openingContext(context []byte) []byte {
+	return append([]byte(openingLabel), context...)
+}
+
+// ProveOpening returns a proof of knowledge of the value and the blinding of the commitment, which reveals neither.
+// It is a Schnorr proof of representation, whose Fiat-Shamir challenge is bound to the group's ciphersuite, the
+// parameters, the commitment, and the context, e.g. a session identifier.
+func (p *Params) ProveOpening(
+	context []byte,
+	commitment *ecc.Element,
+	value, blinding *ecc.Scalar,
+) (*zkp.Proof, error) {
+	st, err := p.openingStatement(commitment)
+	if err != nil {
+		return nil, fmt.Errorf("commitment: %w", err)
+	}
+
+	if err = p.checkScalar(value); err != nil {
+		return nil, fmt.Errorf("commitment: %w", err)
+	}
+
+	if err = p.checkScalar(blinding); err != nil {
+		return nil, fmt.Errorf("commitment: %w", err)
+	}
+
+	proof, err := zkp.Prove(openingContext(context), st, []*ecc.Scalar{value, blinding})
+	if err != nil {
+		return nil, fmt.Errorf("commitment: %w", err)
+	}
+
+	return proof, nil
+}
+
+// VerifyOpening returns nil if the proof of knowledge of the commitment's opening is valid for the context.
+func (p *Params) VerifyOpening(context []byte, commitment *ecc.Element, proof *zkp.Proof) error {
+	st, err := p.openingStatement(commitment)
+	if err != nil {
+		return fmt.Errorf("commitment: %w", err)
+	}
+
+	if err = zkp.Verify(openingContext(context), st, proof); err != nil {
+		return fmt.Errorf("commitment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package commitment implements Pedersen commitments C = value * G + blinding * H over any ecc group, where H is
+// derived with hash-to-group, so that nobody knows its discrete logarithm to G. Commitments are perfectly hiding,
+// computationally binding, and additively homomorphic.
+//
+// The package also implements hash commitments to nonce elements, for the commit-then-reveal rounds of multi-party
+// signing protocols.
+package commitment
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	setupDSTApp = "ecc-pedersen"
+	dstVersion  = 1
+)
+
+// Params are the public parameters of Pedersen commitments: the group's base element G and an independent
+// generator H.
+type Params struct {
+	G, H  *ecc.Element
+	group ecc.Group
+}
+
+// Setup returns the parameters of the group, with H derived from the label with hash-to-group. Different labels
+// yield independent parameters, and anyone can verify their derivation.
+func Setup(g ecc.Group, label []byte) *Params {
+	return &Params{
+		G:     g.Base(),
+		H:     g.HashToGroup(label, g.DST(setupDSTApp, dstVersion)),
+		group: g,
+	}
+}
+
+// Group returns the group of the parameters.
+func (p *Params) Group() ecc.Group {
+	return p.group
+}
+
+func (p *Params) checkScalar(s *ecc.Scalar) error {
+	if s == nil {
+		return internal.ErrParamNilScalar
+	}
+
+	if s.Group() != p.group {
+		return internal.ErrCastScalar
+	}
+
+	return nil
+}
+
+// Commit returns the commitment value * G + blinding * H. The blinding must be uniformly random and secret for the
+// commitment to hide the value.
+func (p *Params) Commit(value, blinding *ecc.Scalar) (*ecc.Element, error) {
+	if err := p.checkScalar(value); err != nil {
+		return nil, fmt.Errorf("commitment: %w", err)
+	}
+
+	if err := p.checkScalar(blinding); err != nil {
+		return nil, fmt.Errorf("commitment: %w", err)
+	}
+
+	return p.G.Copy().Multiply(value).Add(p.H.Copy().Multiply(blinding)), nil
+}
+
+// Verify returns whether the commitment opens to the value and the blinding.
+func (p *Params) Verify(commitment *ecc.Element, value, blinding *ecc.Scalar) bool {
+	if commitment == nil || commitment.Group() != p.group {
+		return false
+	}
+
+	c, err := p.Commit(value, blinding)
+	if err != nil {
+		return false
+	}
+
+	return c.Equal(commitment)
+}
+
+// Add returns the sum of the commitments, which is a commitment to the sum of their values with the sum of their
+// blindings. The inputs are not modified.
+func Add(commitments ...*ecc.Element) (*ecc.Element, error) {
+	if len(commitments) == 0 || commitments[0] == nil {
+		return nil, fmt.Errorf("commitment: %w", internal.ErrParamNilPoint)
+	}
+
+	sum := commitments[0].Copy()
+
+	for _, c := range commitments[1:] {
+		if c == nil {
+			return nil, fmt.Errorf("commitment: %w", internal.ErrParamNilPoint)
+		}
+
+		if c.Group() != sum.Group() {
+			return nil, fmt.Errorf("commitment: %w", internal.ErrCastElement)
+		}
+
+		sum.Add(c)
+	}
+
+	return sum, nil
+}
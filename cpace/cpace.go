@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package cpace implements the CPace balanced password-authenticated key exchange of draft-irtf-cfrg-cpace over the
+// Ristretto255 and NIST groups, in both the initiator-responder and the symmetric settings. Both parties derive the
+// same intermediate session key (ISK) if and only if they used the same password-related string.
+package cpace
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+// Role selects the ordering of the transcript from which the session key is derived.
+type Role byte
+
+const (
+	// Initiator is the party sending the first message in the initiator-responder setting.
+	Initiator Role = iota
+
+	// Responder is the party answering the initiator in the initiator-responder setting.
+	Responder
+
+	// Symmetric is either party in the symmetric setting, in which there are no roles and messages can cross.
+	Symmetric
+)
+
+const (
+	labelISK     = "_ISK"
+	labelOrdered = "oc"
+)
+
+var (
+	errInvalidRole  = errors.New("invalid role")
+	errInvalidShare = errors.New("invalid share")
+)
+
+// Suite is a CPace ciphersuite.
+type Suite struct {
+	dsi      string
+	group    ecc.Group
+	sInBytes int
+}
+
+// New returns the CPace ciphersuite of the group.
+func New(g ecc.Group) (*Suite, error) {
+	switch g {
+	case ecc.Ristretto255Sha512:
+		return &Suite{dsi: "CPaceRistretto255", group: g, sInBytes: 128}, nil
+	case ecc.P256Sha256:
+		return &Suite{dsi: "CPaceP256_XMD:SHA-256_SSWU_NU_", group: g, sInBytes: 64}, nil
+	case ecc.P384Sha384:
+		return &Suite{dsi: "CPaceP384_XMD:SHA-384_SSWU_NU_", group: g, sInBytes: 128}, nil
+	case ecc.P521Sha512:
+		return &Suite{dsi: "CPaceP521_XMD:SHA-512_SSWU_NU_", group: g, sInBytes: 128}, nil
+	default:
+		return nil, fmt.Errorf("cpace: %w", internal.ErrInvalidGroup)
+	}
+}
+
+// Group returns the group of the ciphersuite.
+func (s *Suite) Group() ecc.Group {
+	return s.group
+}
+
+// prependLen returns the input prefixed with its LEB128-encoded length.
+func prependLen(data []byte) []byte {
+	var out []byte
+
+	l := len(data)
+	for {
+		b := byte(l & 0x7f)
+		if l >>= 7; l == 0 {
+			out = append(out, b)
+			break
+		}
+
+		out = append(out, b|0x80)
+	}
+
+	return append(out, data...)
+}
+
+// lvCat returns the concatenation of the length-prefixed inputs.
+func lvCat(inputs ...[]byte) []byte {
+	var out []byte
+	for _, in := range inputs {
+		out = append(out, prependLen(in)...)
+	}
+
+	return out
+}
+
+// generatorString returns the input of the generator derivation, whose zero padding aligns the password-related
+// string with the hash function's input block.
+func (s *Suite) generatorString(prs, ci, sid []byte) []byte {
+	zpad := max(0, s.sInBytes-len(prependLen(prs))-len(prependLen([]byte(s.dsi)))-1)
+	return lvCat([]byte(s.dsi), prs, make([]byte, zpad), ci, sid)
+}
+
+// Generator returns the password-dependent generator of the password-related string, the channel identifier, and the
+// session identifier.
+func (s *Suite) Generator(prs, ci, sid []byte) *ecc.Element {
+	input := s.generatorString(prs, ci, sid)
+
+	if s.group == ecc.Ristretto255Sha512 {
+		h := s.group.HashFunc().New()
+		_, _ = h.Write(input)
+
+		e, err := s.group.ElementFromUniformBytes(h.Sum(nil))
+		if err != nil {
+			// SHA-512 always returns 64 bytes.
+			panic(err)
+		}
+
+		return e
+	}
+
+	return s.group.EncodeToGroup(input, []byte(s.dsi+"_DST"))
+}
+
+func (s *Suite) encode(e *ecc.Element) []byte {
+	if s.group == ecc.Ristretto255Sha512 {
+		return e.Encode()
+	}
+
+	b, err := encoding.MarshalUncompressed(e)
+	if err != nil {
+		// The NIST groups always have an uncompressed encoding.
+		panic(err)
+	}
+
+	return b
+}
+
+func (s *Suite) decode(data []byte) (*ecc.Element, error) {
+	if s.group == ecc.Ristretto255Sha512 {
+		e := s.group.NewElement()
+		if err := e.Decode(data); err != nil {
+			return nil, err
+		}
+
+		return e, nil
+	}
+
+	if len(data) == 0 || data[0] != 0x04 {
+		return nil, internal.ErrParamInvalidPointEncoding
+	}
+
+	return encoding.ParseSEC1(s.group, data)
+}
+
+// State is a party's ephemeral state between sending its share and receiving its peer's.
+type State struct {
+	suite *Suite
+	y     *ecc.Scalar
+	share []byte
+	ad    []byte
+	sid   []byte
+	role  Role
+}
+
+// Start returns the state and the share to send to the peer, with the associated data, for the password-related
+// string, the channel identifier, and the session identifier.
+func (s *Suite) Start(role Role, prs, ci, sid, ad []byte) (*State, []byte, error) {
+	if role > Symmetric {
+		return nil, nil, fmt.Errorf("cpace: %w", errInvalidRole)
+	}
+
+	y := s.group.NewScalar().Random()
+	share := s.encode(s.Generator(prs, ci, sid).Multiply(y))
+
+	return &State{
+		suite: s,
+		y:     y,
+		share: share,
+		ad:    append([]byte(nil), ad...),
+		sid:   append([]byte(nil), sid...),
+		role:  role,
+	}, share, nil
+}
+
+// transcript returns the transcript of the shares and associated data, in the order of the role.
+func (st *State) transcript(peerShare, peerAD []byte) []byte {
+	own, peer := lvCat(st.share, st.ad), lvCat(peerShare, peerAD)
+
+	switch st.role {
+	case Initiator:
+		return append(own, peer...)
+	case Responder:
+		return append(peer, own...)
+	default:
+		if bytes.Compare(own, peer) < 0 {
+			own, peer = peer, own
+		}
+
+		return append(append([]byte(labelOrdered), own...), peer...)
+	}
+}
+
+// Finish returns the intermediate session key from the peer's share and associated data. It returns an error if the
+// share is invalid, in which case the session must be aborted.
+func (st *State) Finish(peerShare, peerAD []byte) ([]byte, error) {
+	s := st.suite
+
+	e, err := s.decode(peerShare)
+	if err != nil {
+		return nil, fmt.Errorf("cpace: %w", errInvalidShare)
+	}
+
+	k := e.Multiply(st.y)
+	if k.IsIdentity() {
+		return nil, fmt.Errorf("cpace: %w", errInvalidShare)
+	}
+
+	// The shared secret is the encoding of the element for Ristretto255, and its x-coordinate for the NIST groups.
+	var secret []byte
+	if s.group == ecc.Ristretto255Sha512 {
+		secret = k.Encode()
+	} else {
+		secret = k.XCoordinate()
+	}
+
+	h := s.group.HashFunc().New()
+	_, _ = h.Write(lvCat([]byte(s.dsi+labelISK), st.sid, secret))
+	_, _ = h.Write(st.transcript(peerShare, peerAD))
+
+	return h.Sum(nil), nil
+}
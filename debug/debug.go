@@ -10,6 +10,8 @@
 package debug
 
 import (
+	"bytes"
+
 	"github.com/bytemare/ecc"
 )
 
@@ -119,3 +121,58 @@ var badElements = map[ecc.Group][]byte{
 func BadElementEncoding(g ecc.Group) []byte {
 	return badElements[g]
 }
+
+// NonCanonicalScalar returns a non-canonical encoding of a valid scalar, i.e. the encoding of the group's order, which
+// reduces to 0. Its decoding must return an error.
+func NonCanonicalScalar(g ecc.Group) []byte {
+	return g.Order()
+}
+
+// OverflowedScalar returns the encoding of the largest integer that fits in the length of a scalar, i.e. with all bits
+// set, which overflows the group's order and, for most groups, its bit length. Its decoding must return an error.
+func OverflowedScalar(g ecc.Group) []byte {
+	return bytes.Repeat([]byte{0xff}, int(g.ScalarLength()))
+}
+
+// nonCanonicalElements are encodings with an unreduced field element, i.e. x + p or y + p, of valid points: for the
+// Weierstrass groups, the compressed encodings of the points with the smallest valid x coordinate, for Edwards25519
+// the point with y = 3, and for Ristretto255 the encoding with s = 4.
+var nonCanonicalElements = map[ecc.Group][]byte{
+	ecc.Ristretto255Sha512: {
+		241, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 127,
+	},
+	ecc.P256Sha256: {
+		2, 255, 255, 255, 255, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		4,
+	},
+	ecc.P384Sha384: {
+		2, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+		254, 255, 255, 255, 255, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0,
+		1,
+	},
+	ecc.P521Sha512: {
+		2, 2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0,
+	},
+	ecc.Edwards25519Sha512: {
+		240, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 127,
+	},
+	ecc.Secp256k1Sha256: {
+		2, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+		255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 254, 255, 255, 252,
+		48,
+	},
+}
+
+// NonCanonicalElement returns a non-canonical encoding of a valid element, whose field element is not reduced modulo
+// the field order, but that would decode to an element if it was. Its decoding must return an error.
+func NonCanonicalElement(g ecc.Group) []byte {
+	return nonCanonicalElements[g]
+}
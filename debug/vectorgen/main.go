@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Command vectorgen writes the test vectors of debug.GenerateVectors in JSON to the standard output, for a group or
+// all of them, e.g.
+//
+//	go run github.com/bytemare/ecc/debug/vectorgen -group P-256 -seed 00010203 > P256.json
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+)
+
+var groups = []ecc.Group{
+	ecc.Ristretto255Sha512,
+	ecc.P256Sha256,
+	ecc.P384Sha384,
+	ecc.P521Sha512,
+	ecc.Edwards25519Sha512,
+	ecc.Secp256k1Sha256,
+}
+
+func main() {
+	name := flag.String("group", "", "name of the group, e.g. ristretto255 or P-256, or all groups if empty")
+	seedHex := flag.String("seed", "", "hexadecimal seed of the vectors")
+	flag.Parse()
+
+	if err := run(*name, *seedHex); err != nil {
+		fmt.Fprintln(os.Stderr, "vectorgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(name, seedHex string) error {
+	seed, err := hex.DecodeString(seedHex)
+	if err != nil {
+		return fmt.Errorf("invalid seed: %w", err)
+	}
+
+	selected := groups
+
+	if name != "" {
+		g, err := ecc.GroupFromString(name)
+		if err != nil {
+			return fmt.Errorf("%q: %w", name, err)
+		}
+
+		selected = []ecc.Group{g}
+	}
+
+	vectors := make([]*debug.Vectors, len(selected))
+	for i, g := range selected {
+		if vectors[i], err = debug.GenerateVectors(g, seed); err != nil {
+			return fmt.Errorf("%w", err)
+		}
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	if len(vectors) == 1 {
+		return enc.Encode(vectors[0])
+	}
+
+	return enc.Encode(vectors)
+}
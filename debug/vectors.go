@@ -0,0 +1,274 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package debug
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+
+	"github.com/bytemare/ecc"
+)
+
+// The number of vectors of each kind generated by GenerateVectors.
+const (
+	vectorEncodings  = 8
+	vectorArithmetic = 8
+)
+
+// vectorDST is the domain separation tag of the derivation of the values of the vectors from the seed.
+var vectorDST = []byte("ecc-test-vectors-V01")
+
+// Vectors are test vectors of a group, for other implementations to test against this one, and vice versa. All
+// encodings are hexadecimal.
+type Vectors struct {
+	// HashToCurve are the vectors of HashToGroup in the schema of RFC 9380 appendix J, and nil for Ristretto255 and
+	// the backends that don't trace their hash-to-curve.
+	HashToCurve *HashToCurveVectors `json:"hashToCurve,omitempty"`
+
+	Ciphersuite string `json:"ciphersuite"`
+	Seed        string `json:"seed"`
+
+	// Encodings are scalars and their multiple of the base element.
+	Encodings []EncodingVector `json:"encodings"`
+
+	// Arithmetic are the results of the operations on pairs of scalars and elements.
+	Arithmetic []ArithmeticVector `json:"arithmetic"`
+
+	// HashToScalar and HashToGroup are the outputs of HashToScalar and HashToGroup on the messages of RFC 9380 with
+	// the DST of HashToCurve, also in Ristretto255.
+	HashToScalar []HashVector `json:"hashToScalar"`
+	HashToGroup  []HashVector `json:"hashToGroup"`
+
+	// InvalidScalars and InvalidElements are encodings whose decoding must fail.
+	InvalidScalars  []string `json:"invalidScalars"`
+	InvalidElements []string `json:"invalidElements"`
+
+	Group uint8 `json:"group"`
+}
+
+// EncodingVector is a scalar and its multiple of the base element.
+type EncodingVector struct {
+	Scalar  string `json:"scalar"`
+	Element string `json:"element"`
+}
+
+// ArithmeticVector holds the results of the operations on two scalars a and b, and two elements p and q.
+type ArithmeticVector struct {
+	A          string `json:"a"`
+	B          string `json:"b"`
+	AddAB      string `json:"a+b"`
+	SubtractAB string `json:"a-b"`
+	MultiplyAB string `json:"a*b"`
+	InvertA    string `json:"1/a"`
+	P          string `json:"p"`
+	Q          string `json:"q"`
+	AddPQ      string `json:"p+q"`
+	SubtractPQ string `json:"p-q"`
+	DoubleP    string `json:"2p"`
+	MultiplyAP string `json:"a*p"`
+}
+
+// HashVector is the output of a hash function on a message.
+type HashVector struct {
+	Msg    string `json:"msg"`
+	Output string `json:"output"`
+}
+
+// HashToCurveVectors are hash-to-curve test vectors in the schema of RFC 9380 appendix J.
+type HashToCurveVectors struct {
+	Field        HashToCurveField    `json:"field"`
+	Map          HashToCurveMap      `json:"map"`
+	L            string              `json:"L"`
+	Z            string              `json:"Z"`
+	Ciphersuite  string              `json:"ciphersuite"`
+	Curve        string              `json:"curve"`
+	Dst          string              `json:"dst"`
+	Expand       string              `json:"expand"`
+	Hash         string              `json:"hash"`
+	K            string              `json:"k"`
+	Vectors      []HashToCurveVector `json:"vectors"`
+	RandomOracle bool                `json:"randomOracle"`
+}
+
+// HashToCurveField is the field of a curve in RFC 9380 test vectors.
+type HashToCurveField struct {
+	M string `json:"m"`
+	P string `json:"p"`
+}
+
+// HashToCurveMap is the mapping of a suite in RFC 9380 test vectors.
+type HashToCurveMap struct {
+	Name string `json:"name"`
+}
+
+// HashToCurvePoint holds the affine coordinates of a point in RFC 9380 test vectors.
+type HashToCurvePoint struct {
+	X string `json:"x"`
+	Y string `json:"y"`
+}
+
+// HashToCurveVector is a hash-to-curve test vector in the schema of RFC 9380 appendix J.
+type HashToCurveVector struct {
+	P   HashToCurvePoint `json:"P"`
+	Q0  HashToCurvePoint `json:"Q0"`
+	Q1  HashToCurvePoint `json:"Q1"`
+	Msg string           `json:"msg"`
+	U   []string         `json:"u"`
+}
+
+// hashToCurveParams are the parameters of the hash-to-curve suites of RFC 9380 that are not exposed by the groups,
+// with Z as a small integer.
+var hashToCurveParams = map[ecc.Group]struct {
+	curve, mapping, hash string
+	z                    int64
+}{
+	ecc.P256Sha256:         {"NIST P-256", "SSWU", "sha256", -10},
+	ecc.P384Sha384:         {"NIST P-384", "SSWU", "sha384", -12},
+	ecc.P521Sha512:         {"NIST P-521", "SSWU", "sha512", -4},
+	ecc.Edwards25519Sha512: {"edwards25519", "ELL2", "sha512", 2},
+	ecc.Secp256k1Sha256:    {"secp256k1", "SSWU", "sha256", -11},
+}
+
+// hashToCurveMessages are the messages of the RFC 9380 test vectors.
+var hashToCurveMessages = []string{
+	"", "abc", "abcdef0123456789", "q128_" + strings.Repeat("q", 128), "a512_" + strings.Repeat("a", 512),
+}
+
+// GenerateVectors returns test vectors of the group, whose scalars and elements are derived from the seed, so that
+// the same seed always yields the same vectors. The hash-to-curve vectors use the messages and DST of RFC 9380, and
+// are the same as those of its appendix J.
+func GenerateVectors(g ecc.Group, seed []byte) (*Vectors, error) {
+	if !g.Available() {
+		return nil, fmt.Errorf("debug GenerateVectors: %w", ecc.ErrInvalidGroup)
+	}
+
+	v := &Vectors{
+		Group:       byte(g),
+		Ciphersuite: g.String(),
+		Seed:        hex.EncodeToString(seed),
+		Encodings:   make([]EncodingVector, vectorEncodings),
+		Arithmetic:  make([]ArithmeticVector, vectorArithmetic),
+		InvalidScalars: []string{
+			hex.EncodeToString(BadScalarHigh(g)),
+			hex.EncodeToString(NonCanonicalScalar(g)),
+			hex.EncodeToString(OverflowedScalar(g)),
+		},
+		InvalidElements: []string{
+			g.NewElement().Hex(),
+			hex.EncodeToString(BadElementOffCurve(g)),
+			hex.EncodeToString(BadElementEncoding(g)),
+			hex.EncodeToString(NonCanonicalElement(g)),
+		},
+	}
+
+	counter := uint32(0)
+	scalar := func() *ecc.Scalar {
+		counter++
+		return g.HashToScalar(binary.BigEndian.AppendUint32(slices.Clip(seed), counter), vectorDST)
+	}
+
+	for i := range v.Encodings {
+		s := scalar()
+		v.Encodings[i] = EncodingVector{Scalar: s.Hex(), Element: g.Base().Multiply(s).Hex()}
+	}
+
+	for i := range v.Arithmetic {
+		a, b := scalar(), scalar()
+		p, q := g.Base().Multiply(scalar()), g.Base().Multiply(scalar())
+
+		v.Arithmetic[i] = ArithmeticVector{
+			A:          a.Hex(),
+			B:          b.Hex(),
+			AddAB:      a.Copy().Add(b).Hex(),
+			SubtractAB: a.Copy().Subtract(b).Hex(),
+			MultiplyAB: a.Copy().Multiply(b).Hex(),
+			InvertA:    a.Copy().Invert().Hex(),
+			P:          p.Hex(),
+			Q:          q.Hex(),
+			AddPQ:      p.Copy().Add(q).Hex(),
+			SubtractPQ: p.Copy().Subtract(q).Hex(),
+			DoubleP:    p.Copy().Double().Hex(),
+			MultiplyAP: p.Copy().Multiply(a).Hex(),
+		}
+	}
+
+	dst := []byte("QUUX-V01-CS02-with-" + g.String())
+
+	v.HashToScalar = make([]HashVector, len(hashToCurveMessages))
+	v.HashToGroup = make([]HashVector, len(hashToCurveMessages))
+
+	for i, msg := range hashToCurveMessages {
+		v.HashToScalar[i] = HashVector{Msg: msg, Output: g.HashToScalar([]byte(msg), dst).Hex()}
+		v.HashToGroup[i] = HashVector{Msg: msg, Output: g.HashToGroup([]byte(msg), dst).Hex()}
+	}
+
+	v.HashToCurve = hashToCurveVectors(g, dst)
+
+	return v, nil
+}
+
+// hashToCurveVectors returns the hash-to-curve vectors of the group, or nil if its backend doesn't trace its
+// hash-to-curve.
+func hashToCurveVectors(g ecc.Group, dst []byte) *HashToCurveVectors {
+	params := hashToCurveParams[g]
+	order := g.FieldOrder()
+	if g == ecc.Edwards25519Sha512 {
+		slices.Reverse(order)
+	}
+
+	p := new(big.Int).SetBytes(order)
+	bits := p.BitLen()
+	k := g.SecurityLevel()
+
+	h := &HashToCurveVectors{
+		L:            fmt.Sprintf("%#x", (bits+k+7)/8),
+		Z:            fmt.Sprintf("%#x", new(big.Int).Mod(big.NewInt(params.z), p)),
+		Ciphersuite:  g.String(),
+		Curve:        params.curve,
+		Dst:          string(dst),
+		Expand:       "XMD",
+		Field:        HashToCurveField{M: "0x1", P: fmt.Sprintf("%#x", p)},
+		Hash:         params.hash,
+		K:            fmt.Sprintf("%#x", k),
+		Map:          HashToCurveMap{Name: params.mapping},
+		RandomOracle: true,
+		Vectors:      make([]HashToCurveVector, len(hashToCurveMessages)),
+	}
+
+	for i, msg := range hashToCurveMessages {
+		trace, err := g.HashToGroupTrace([]byte(msg), dst)
+		if err != nil {
+			return nil
+		}
+
+		v := HashToCurveVector{
+			P:   hashToCurvePoint(trace.P.AffineCoordinates()),
+			Q0:  hashToCurvePoint(&trace.Q[0]),
+			Q1:  hashToCurvePoint(&trace.Q[1]),
+			Msg: msg,
+			U:   make([]string, len(trace.U)),
+		}
+
+		for j, u := range trace.U {
+			v.U[j] = fmt.Sprintf("%#x", u)
+		}
+
+		h.Vectors[i] = v
+	}
+
+	return h
+}
+
+func hashToCurvePoint(p *ecc.AffinePoint) HashToCurvePoint {
+	return HashToCurvePoint{X: fmt.Sprintf("%#x", p.X), Y: fmt.Sprintf("%#x", p.Y)}
+}
@@ -0,0 +1,302 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package wycheproof parses Project Wycheproof ECDH and XDH test vector files (https://github.com/C2SP/wycheproof)
+// into structured cases for the groups of this module. These vectors exercise point decoding, with invalid,
+// off-curve, and non-canonical public keys, and scalar edge cases through their private keys.
+package wycheproof
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	ed "filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc"
+)
+
+// Result is the expected outcome of a test case.
+type Result string
+
+const (
+	// Valid cases must succeed and produce the expected shared secret.
+	Valid Result = "valid"
+
+	// Invalid cases must fail, either on decoding or with a different shared secret.
+	Invalid Result = "invalid"
+
+	// Acceptable cases may fail, but must produce the expected shared secret if they don't.
+	Acceptable Result = "acceptable"
+)
+
+// Encodings of the public keys in the test groups.
+const (
+	// EncodingECPoint is the SEC 1 compressed or uncompressed point encoding.
+	EncodingECPoint = "ecpoint"
+
+	// EncodingASN is the DER encoded X.509 SubjectPublicKeyInfo.
+	EncodingASN = "asn"
+
+	// EncodingXDH is the RFC 7748 u-coordinate encoding.
+	EncodingXDH = "xdh"
+)
+
+var curves = map[string]ecc.Group{
+	"secp256r1":  ecc.P256Sha256,
+	"secp384r1":  ecc.P384Sha384,
+	"secp521r1":  ecc.P521Sha512,
+	"secp256k1":  ecc.Secp256k1Sha256,
+	"curve25519": ecc.Edwards25519Sha512,
+}
+
+var (
+	errUnsupportedEncoding = errors.New("unsupported public key encoding")
+	errCurveMismatch       = errors.New("public key curve does not match the test group")
+	errTrailingData        = errors.New("trailing data after public key")
+)
+
+// Case is a single ECDH test case.
+type Case struct {
+	// Curve is the Wycheproof name of the test group's curve.
+	Curve string
+
+	// Encoding is the encoding of Public, as one of EncodingECPoint, EncodingASN, and EncodingXDH.
+	Encoding string
+
+	// Comment describes the case.
+	Comment string
+
+	// Result is the expected outcome.
+	Result Result
+
+	// Flags are the Wycheproof flags attached to the case, whose descriptions are in the file's notes.
+	Flags []string
+
+	// Public is the peer's public key.
+	Public []byte
+
+	// Private is the private key, as a big-endian integer for Weierstrass curves and as a little-endian X25519
+	// private key for curve25519.
+	Private []byte
+
+	// Shared is the expected shared secret, i.e. the x-coordinate (or u-coordinate) of the product.
+	Shared []byte
+
+	// ID is the tcId of the case, unique within a file.
+	ID int
+
+	// Group is the ecc group of the curve.
+	Group ecc.Group
+}
+
+// Name returns a name for the case, suitable for testing.T.Run.
+func (c *Case) Name() string {
+	return fmt.Sprintf("%s/%d", c.Curve, c.ID)
+}
+
+// HasFlag returns whether the case has the flag.
+func (c *Case) HasFlag(flag string) bool {
+	return slices.Contains(c.Flags, flag)
+}
+
+type hexBytes []byte
+
+func (h *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	*h = b
+
+	return nil
+}
+
+type file struct {
+	Algorithm  string      `json:"algorithm"`
+	TestGroups []testGroup `json:"testGroups"`
+}
+
+type testGroup struct {
+	Type     string     `json:"type"`
+	Curve    string     `json:"curve"`
+	Encoding string     `json:"encoding"`
+	Tests    []testCase `json:"tests"`
+}
+
+type testCase struct {
+	Comment string   `json:"comment"`
+	Result  Result   `json:"result"`
+	Flags   []string `json:"flags"`
+	Public  hexBytes `json:"public"`
+	Private hexBytes `json:"private"`
+	Shared  hexBytes `json:"shared"`
+	ID      int      `json:"tcId"`
+}
+
+// Parse reads a Wycheproof ECDH or XDH test vector file and returns its cases. Test groups over curves that are not
+// supported by this module are skipped.
+func Parse(r io.Reader) ([]*Case, error) {
+	var f file
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("wycheproof: %w", err)
+	}
+
+	if f.Algorithm != "ECDH" && f.Algorithm != "XDH" {
+		return nil, fmt.Errorf("wycheproof: unsupported algorithm %q", f.Algorithm)
+	}
+
+	var cases []*Case
+
+	for _, tg := range f.TestGroups {
+		g, ok := curves[tg.Curve]
+		if !ok {
+			continue
+		}
+
+		encoding := tg.Encoding
+		if f.Algorithm == "XDH" {
+			encoding = EncodingXDH
+		}
+
+		for _, tc := range tg.Tests {
+			cases = append(cases, &Case{
+				Curve:    tg.Curve,
+				Encoding: encoding,
+				Comment:  tc.Comment,
+				Result:   tc.Result,
+				Flags:    tc.Flags,
+				Public:   tc.Public,
+				Private:  tc.Private,
+				Shared:   tc.Shared,
+				ID:       tc.ID,
+				Group:    g,
+			})
+		}
+	}
+
+	return cases, nil
+}
+
+// PrivateScalar returns the decoded private key. X25519 private keys are clamped, and reduced modulo the group order.
+func (c *Case) PrivateScalar() (*ecc.Scalar, error) {
+	s := c.Group.NewScalar()
+
+	if c.Encoding == EncodingXDH {
+		clamped, err := ed.NewScalar().SetBytesWithClamping(c.Private)
+		if err != nil {
+			return nil, fmt.Errorf("wycheproof: %w", err)
+		}
+
+		if err = s.Decode(clamped.Bytes()); err != nil {
+			return nil, fmt.Errorf("wycheproof: %w", err)
+		}
+
+		return s, nil
+	}
+
+	// Private keys are ASN.1 integers, and can have a leading zero byte or be shorter than the scalar length.
+	private := bytes.TrimLeft(c.Private, "\x00")
+	if length := c.Group.ScalarLength(); len(private) < length {
+		private = append(make([]byte, length-len(private)), private...)
+	}
+
+	if err := s.Decode(private); err != nil {
+		return nil, fmt.Errorf("wycheproof: %w", err)
+	}
+
+	return s, nil
+}
+
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// PublicElement returns the decoded public key.
+func (c *Case) PublicElement() (*ecc.Element, error) {
+	var encoded []byte
+
+	switch c.Encoding {
+	case EncodingECPoint:
+		encoded = c.Public
+	case EncodingASN:
+		var err error
+		if encoded, err = c.parseSubjectPublicKeyInfo(); err != nil {
+			return nil, fmt.Errorf("wycheproof: %w", err)
+		}
+	case EncodingXDH:
+		e, err := c.Group.ElementFromMontgomeryU(c.Public, false)
+		if err != nil {
+			return nil, fmt.Errorf("wycheproof: %w", err)
+		}
+
+		return e, nil
+	default:
+		return nil, fmt.Errorf("wycheproof: %w %q", errUnsupportedEncoding, c.Encoding)
+	}
+
+	e := c.Group.NewElement()
+	if err := e.Decode(encoded); err != nil {
+		return nil, fmt.Errorf("wycheproof: %w", err)
+	}
+
+	return e, nil
+}
+
+func (c *Case) parseSubjectPublicKeyInfo() ([]byte, error) {
+	var spki subjectPublicKeyInfo
+
+	rest, err := asn1.Unmarshal(c.Public, &spki)
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	if len(rest) != 0 {
+		return nil, errTrailingData
+	}
+
+	var namedCurve asn1.ObjectIdentifier
+	if _, err = asn1.Unmarshal(spki.Algorithm.Parameters.FullBytes, &namedCurve); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	if !namedCurve.Equal(c.Group.OID()) {
+		return nil, errCurveMismatch
+	}
+
+	return spki.PublicKey.RightAlign(), nil
+}
+
+// Compute returns the shared secret of the case computed with this module, or an error if one of the keys was
+// rejected.
+func (c *Case) Compute() ([]byte, error) {
+	s, err := c.PrivateScalar()
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := c.PublicElement()
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Multiply(s).XCoordinate(), nil
+}
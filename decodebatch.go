@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// DecodeOptions configures DecodeElements. A nil *DecodeOptions decodes the inputs sequentially.
+type DecodeOptions struct {
+	// Workers is the number of goroutines decoding the inputs, each over a contiguous range of them. It is
+	// runtime.GOMAXPROCS(0) if 0, and is at most the number of inputs.
+	Workers int
+}
+
+func (o *DecodeOptions) workers(n int) int {
+	if o == nil {
+		return 1
+	}
+
+	w := o.Workers
+	if w <= 0 {
+		w = runtime.GOMAXPROCS(0)
+	}
+
+	return max(1, min(w, n))
+}
+
+// DecodeElements returns the elements decoded from the encodings, in the same order, e.g. to load and validate large
+// sets of public keys. With options, the encodings are decoded across several goroutines, as the validation of each
+// point is independent of the others. On failure, it returns the error of the first invalid encoding in the input
+// order, whatever the number of workers, and no elements.
+func (g Group) DecodeElements(encodings [][]byte, opts *DecodeOptions) ([]*Element, error) {
+	res := make([]*Element, len(encodings))
+	p := g.get()
+
+	// failed is the lowest index of the invalid encodings found so far, beyond which the workers stop.
+	var failed atomic.Int64
+	failed.Store(int64(len(encodings)))
+
+	decode := func(start, end int) (int, error) {
+		for i := start; i < end && int64(i) < failed.Load(); i++ {
+			e := p.NewElement()
+			if err := e.Decode(encodings[i]); err != nil {
+				for f := failed.Load(); int64(i) < f; f = failed.Load() {
+					if failed.CompareAndSwap(f, int64(i)) {
+						break
+					}
+				}
+
+				return i, err
+			}
+
+			res[i] = newPoint(e)
+		}
+
+		return -1, nil
+	}
+
+	workers := opts.workers(len(encodings))
+	indices, errs := make([]int, workers), make([]error, workers)
+
+	var wg sync.WaitGroup
+
+	for w := range workers {
+		start, end := w*len(encodings)/workers, (w+1)*len(encodings)/workers
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			indices[w], errs[w] = decode(start, end)
+		}()
+	}
+
+	wg.Wait()
+
+	// The workers cover contiguous ranges in order, so the first error is that of the first worker that failed.
+	for w, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("group DecodeElements: encoding %d: %w", indices[w], err)
+		}
+	}
+
+	return res, nil
+}
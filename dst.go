@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// dstContextFmt is the suffix of the domain separation tags with a context.
+const dstContextFmt = "-CTX-%s"
+
+var (
+	errDSTProtocol = internal.WithKinds(
+		errors.New("invalid DST protocol: must be non-empty printable ASCII"), ErrInvalidDST)
+	errDSTSuite = internal.WithKinds(errors.New("missing DST suite"), ErrInvalidDST)
+)
+
+// DSTBuilder builds domain separation tags for the hash-to-scalar and hash-to-group functions, with a documented and
+// deterministic layout:
+//
+//	<protocol>-V<version>-CS<group>-<hash-to-curve suite ID>[-CTX-<context>]
+//
+// where the version and the group identifier are two decimal digits, and the context suffix is only present if the
+// context is not empty. Without a context, the tag is that of Group.MakeDST. Tags longer than 255 bytes are replaced
+// by H("H2C-OVERSIZE-DST-" || DST) with the suite's hash function, as in RFC 9380 section 5.3.3, so that all tags
+// fit the one-byte length of expand_message_xmd, and hash the same as their long form.
+type DSTBuilder struct {
+	protocol string
+	context  []byte
+	version  uint8
+	group    Group
+}
+
+// NewDST returns a builder of domain separation tags for the protocol, e.g. "my-protocol", with version 0 and without
+// a suite or a context.
+func NewDST(protocol string) *DSTBuilder {
+	return &DSTBuilder{protocol: protocol}
+}
+
+// Version sets the version of the protocol, and returns the builder.
+func (d *DSTBuilder) Version(version uint8) *DSTBuilder {
+	d.version = version
+	return d
+}
+
+// Suite sets the group whose hash-to-curve suite the tag binds to, and returns the builder.
+func (d *DSTBuilder) Suite(g Group) *DSTBuilder {
+	d.group = g
+	return d
+}
+
+// Context sets an optional context, e.g. an application or a session identifier, and returns the builder.
+func (d *DSTBuilder) Context(context []byte) *DSTBuilder {
+	d.context = context
+	return d
+}
+
+// Build returns the domain separation tag, and an error if the protocol is empty or not printable ASCII, or if the
+// suite is missing or unavailable.
+func (d *DSTBuilder) Build() ([]byte, error) {
+	if !printableASCII(d.protocol) {
+		return nil, fmt.Errorf("DST Build: %w", errDSTProtocol)
+	}
+
+	if d.group == 0 {
+		return nil, fmt.Errorf("DST Build: %w", errDSTSuite)
+	}
+
+	if !d.group.Available() {
+		return nil, fmt.Errorf("DST Build: %w", internal.ErrInvalidGroup)
+	}
+
+	dst := d.group.MakeDST(d.protocol, d.version)
+	if len(d.context) != 0 {
+		dst = fmt.Appendf(dst, dstContextFmt, d.context)
+	}
+
+	return internal.ReduceDST(d.group.HashFunc(), dst), nil
+}
+
+func printableASCII(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := range len(s) {
+		if s[i] < ' ' || s[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
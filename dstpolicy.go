@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+var errShortDST = internal.WithKinds(errors.New("DST shorter than the minimum length of the policy"), ErrInvalidDST)
+
+// DSTViolation is what a DSTPolicy does with the DSTs shorter than its minimum length.
+type DSTViolation uint8
+
+const (
+	// DSTAccept accepts the DSTs shorter than the minimum length, as HashToScalar and HashToGroup do with those shorter
+	// than the recommended 16 bytes.
+	DSTAccept DSTViolation = iota
+
+	// DSTReject rejects the DSTs shorter than the minimum length with an error.
+	DSTReject
+)
+
+// DSTPolicy sets the domain separation tags accepted by the Try variants of the hashing functions, e.g. so that
+// libraries taking DSTs from their users enforce the 16 bytes recommended by RFC 9380 without risking panics. Empty
+// DSTs are always rejected, as RFC 9380 forbids them. A nil policy only rejects them, as HashToScalar and HashToGroup.
+type DSTPolicy struct {
+	// MinLength is the minimum length of the DSTs, under which OnViolation applies.
+	MinLength int
+
+	// OnViolation is what the policy does with the DSTs shorter than MinLength.
+	OnViolation DSTViolation
+}
+
+// WithDSTPolicy returns the policy applying onViolation to the DSTs shorter than minLength, e.g.
+// WithDSTPolicy(16, DSTReject) to enforce the recommended length.
+func WithDSTPolicy(minLength int, onViolation DSTViolation) *DSTPolicy {
+	return &DSTPolicy{MinLength: minLength, OnViolation: onViolation}
+}
+
+// Check returns an error matching ErrInvalidDST if the DST is empty, or if it is shorter than the minimum length and
+// the policy rejects it.
+func (p *DSTPolicy) Check(dst []byte) error {
+	if len(dst) == minLength {
+		return errZeroLenDST
+	}
+
+	if p != nil && p.OnViolation == DSTReject && len(dst) < p.MinLength {
+		return errShortDST
+	}
+
+	return nil
+}
+
+// TryHashToScalar is HashToScalar, with the DST checked against the policy, returning an error instead of panicking.
+func (g Group) TryHashToScalar(input, dst []byte, policy *DSTPolicy) (*Scalar, error) {
+	if err := g.tryHash(dst, policy); err != nil {
+		return nil, fmt.Errorf("group TryHashToScalar: %w", err)
+	}
+
+	return g.HashToScalar(input, dst), nil
+}
+
+// TryHashToGroup is HashToGroup, with the DST checked against the policy, returning an error instead of panicking.
+func (g Group) TryHashToGroup(input, dst []byte, policy *DSTPolicy) (*Element, error) {
+	if err := g.tryHash(dst, policy); err != nil {
+		return nil, fmt.Errorf("group TryHashToGroup: %w", err)
+	}
+
+	return g.HashToGroup(input, dst), nil
+}
+
+// TryEncodeToGroup is EncodeToGroup, with the DST checked against the policy, returning an error instead of
+// panicking.
+func (g Group) TryEncodeToGroup(input, dst []byte, policy *DSTPolicy) (*Element, error) {
+	if err := g.tryHash(dst, policy); err != nil {
+		return nil, fmt.Errorf("group TryEncodeToGroup: %w", err)
+	}
+
+	return g.EncodeToGroup(input, dst), nil
+}
+
+// tryHash returns an error if the group is unavailable, or if the DST violates the policy.
+func (g Group) tryHash(dst []byte, policy *DSTPolicy) error {
+	if !g.Available() {
+		return internal.ErrInvalidGroup
+	}
+
+	return policy.Check(dst)
+}
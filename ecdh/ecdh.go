@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ecdh implements Diffie-Hellman key agreement over the ecc groups, with the checks that are easy to get
+// wrong: the private scalar must not be zero, the peer's element must be of the same group and not of small order,
+// and the shared element must not be the identity. DeriveKey additionally binds both public keys into the derived
+// key.
+package ecdh
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// edwards25519Cofactor is the cofactor of Edwards25519, which clears the small order component of its points.
+const edwards25519Cofactor = 8
+
+// deriveKeyLabel domain separates the keys derived by DeriveKey.
+var deriveKeyLabel = []byte("ecc-ecdh-v1")
+
+// GenerateKeyPair returns a new random private scalar and its public element, e.g. for an ephemeral key pair.
+func GenerateKeyPair(g ecc.Group) (*ecc.Scalar, *ecc.Element) {
+	private := g.NewScalar().Random()
+	return private, g.Base().Multiply(private)
+}
+
+// DH returns the Diffie-Hellman shared secret of the private scalar and the peer's public element. This is the
+// x-coordinate of their product for the Weierstrass groups, as with crypto/ecdh, its u-coordinate for Edwards25519,
+// and its encoding for Ristretto255. It returns an error if the peer's element is the identity or of small order,
+// or if the product is the identity.
+func DH(private *ecc.Scalar, peer *ecc.Element) ([]byte, error) {
+	if private == nil || private.IsZero() {
+		return nil, fmt.Errorf("ecdh: %w", internal.ErrParamNilScalar)
+	}
+
+	return dh(private.Group(), peer, func(e *ecc.Element) (*ecc.Element, error) {
+		return e.Multiply(private), nil
+	})
+}
+
+// DHRemote is DH with a private scalar held by the remote, e.g. a hardware device or KMS, which computes the product
+// of the peer's element without the scalar entering the process.
+func DHRemote(private ecc.RemoteScalar, peer *ecc.Element) ([]byte, error) {
+	if private == nil {
+		return nil, fmt.Errorf("ecdh: %w", internal.ErrParamNilScalar)
+	}
+
+	return dh(private.Group(), peer, private.MultiplyElement)
+}
+
+// dh checks the peer's element, multiplies a copy of it by the private scalar with multiply, and returns the shared
+// secret of the product.
+func dh(g ecc.Group, peer *ecc.Element, multiply func(*ecc.Element) (*ecc.Element, error)) ([]byte, error) {
+	if peer == nil {
+		return nil, fmt.Errorf("ecdh: %w", internal.ErrParamNilPoint)
+	}
+
+	if g != peer.Group() {
+		return nil, fmt.Errorf("ecdh: %w", internal.ErrCastScalar)
+	}
+
+	if isSmallOrder(peer) {
+		return nil, fmt.Errorf("ecdh: %w", internal.ErrIdentity)
+	}
+
+	shared, err := multiply(peer.Copy())
+	if err != nil {
+		return nil, fmt.Errorf("ecdh: %w", err)
+	}
+
+	if shared == nil || shared.Group() != g || shared.IsIdentity() {
+		return nil, fmt.Errorf("ecdh: %w", internal.ErrIdentity)
+	}
+
+	switch g {
+	case ecc.Ristretto255Sha512:
+		return shared.Encode(), nil
+	case ecc.Edwards25519Sha512:
+		return shared.ToMontgomeryU(), nil
+	default:
+		return shared.XCoordinate(), nil
+	}
+}
+
+// isSmallOrder returns whether the element is the identity or, for Edwards25519, one of the low order points. The
+// other groups have prime order.
+func isSmallOrder(e *ecc.Element) bool {
+	if e.Group() == ecc.Edwards25519Sha512 {
+		cofactor := e.Group().NewScalar().SetUInt64(edwards25519Cofactor)
+		return e.Copy().Multiply(cofactor).IsIdentity()
+	}
+
+	return e.IsIdentity()
+}
+
+// DeriveKey returns a key of the given length derived with HKDF and the group's hash function from the DH shared
+// secret, both public keys, and the optional info. The public keys are sorted so that both parties derive the same
+// key without agreeing on roles, and binding them prevents a peer from forcing the same key in different sessions.
+func DeriveKey(private *ecc.Scalar, peer *ecc.Element, info []byte, length int) ([]byte, error) {
+	secret, err := DH(private, peer)
+	if err != nil {
+		return nil, err
+	}
+
+	return deriveKey(private.Group(), secret, private.Group().Base().Multiply(private), peer, info, length)
+}
+
+// DeriveKeyRemote is DeriveKey with a private scalar held by the remote, as in DHRemote.
+func DeriveKeyRemote(private ecc.RemoteScalar, peer *ecc.Element, info []byte, length int) ([]byte, error) {
+	secret, err := DHRemote(private, peer)
+	if err != nil {
+		return nil, err
+	}
+
+	public := private.PublicElement()
+	if public == nil || public.Group() != private.Group() {
+		return nil, fmt.Errorf("ecdh: %w", internal.ErrParamNilPoint)
+	}
+
+	return deriveKey(private.Group(), secret, public, peer, info, length)
+}
+
+func deriveKey(g ecc.Group, secret []byte, public, peer *ecc.Element, info []byte, length int) ([]byte, error) {
+	publicKeys := [2][]byte{public.Encode(), peer.Encode()}
+
+	if bytes.Compare(publicKeys[0], publicKeys[1]) > 0 {
+		publicKeys[0], publicKeys[1] = publicKeys[1], publicKeys[0]
+	}
+
+	context := make([]byte, 0, len(deriveKeyLabel)+len(publicKeys[0])+len(publicKeys[1])+len(info)+8)
+	context = append(context, deriveKeyLabel...)
+	context = appendLengthPrefixed(context, publicKeys[0])
+	context = appendLengthPrefixed(context, publicKeys[1])
+	context = appendLengthPrefixed(context, info)
+
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(g.HashFunc().New, secret, nil, context), out); err != nil {
+		return nil, fmt.Errorf("ecdh: %w", err)
+	}
+
+	return out, nil
+}
+
+func appendLengthPrefixed(b, value []byte) []byte {
+	return append(binary.BigEndian.AppendUint16(b, uint16(len(value))), value...)
+}
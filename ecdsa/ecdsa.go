@@ -0,0 +1,280 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ecdsa implements ECDSA over the NIST groups and secp256k1, with the deterministic nonces of RFC 6979,
+// low-S normalization as required by Bitcoin, and the ASN.1 DER and compact (r || s) signature encodings. Signatures
+// are interoperable with crypto/ecdsa and decred's (btcec's) secp256k1 ECDSA.
+package ecdsa
+
+import (
+	"crypto"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+var (
+	errInvalidSignature = errors.New("invalid signature")
+	errInvalidDigest    = errors.New("digest length does not match the hash function")
+)
+
+// Signature is an ECDSA signature (r, s).
+type Signature struct {
+	R, S *ecc.Scalar
+}
+
+func checkGroup(g ecc.Group) error {
+	switch g {
+	case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256:
+		return nil
+	default:
+		return internal.ErrInvalidGroup
+	}
+}
+
+func order(g ecc.Group) *big.Int {
+	return new(big.Int).SetBytes(g.Order())
+}
+
+// toScalar returns the scalar of i reduced modulo the order.
+func toScalar(g ecc.Group, i, n *big.Int) *ecc.Scalar {
+	s := g.NewScalar()
+	if err := s.Decode(new(big.Int).Mod(i, n).FillBytes(make([]byte, g.ScalarLength()))); err != nil {
+		// A reduced integer is always a valid scalar.
+		panic(err)
+	}
+
+	return s
+}
+
+// DigestToScalar returns the scalar e of the digest in signatures over the group, i.e. its leftmost bits up to the
+// bit length of the group order, reduced modulo the order. Together with CommitmentToR, this allows computing
+// signatures without the private scalar, e.g. from shares of it in threshold ECDSA.
+func DigestToScalar(g ecc.Group, digest []byte) (*ecc.Scalar, error) {
+	if err := checkGroup(g); err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	n := order(g)
+
+	return toScalar(g, bits2int(digest, n), n), nil
+}
+
+// CommitmentToR returns the r component of signatures with the nonce commitment R = k * G, i.e. its x-coordinate
+// reduced modulo the group order, and an error if it is zero.
+func CommitmentToR(commitment *ecc.Element) (*ecc.Scalar, error) {
+	if commitment == nil || commitment.IsIdentity() {
+		return nil, fmt.Errorf("ecdsa: %w", internal.ErrParamNilPoint)
+	}
+
+	g := commitment.Group()
+	if err := checkGroup(g); err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	r := toScalar(g, new(big.Int).SetBytes(commitment.XCoordinate()), order(g))
+	if r.IsZero() {
+		return nil, fmt.Errorf("ecdsa: %w", errInvalidSignature)
+	}
+
+	return r, nil
+}
+
+// Sign returns the signature of the digest, which must be the hash of the message with the hash function, with the
+// private scalar and the deterministic nonce of RFC 6979 using the same hash function. The signature is that of the
+// RFC 6979 test vectors, and can be normalized to low-S with Normalize.
+func Sign(secret *ecc.Scalar, hash crypto.Hash, digest []byte) (*Signature, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("ecdsa: %w", internal.ErrParamNilScalar)
+	}
+
+	g := secret.Group()
+	if err := checkGroup(g); err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	if !hash.Available() || len(digest) != hash.Size() {
+		return nil, fmt.Errorf("ecdsa: %w", errInvalidDigest)
+	}
+
+	n := order(g)
+	e := toScalar(g, bits2int(digest, n), n)
+	nonces := newRFC6979(hash, n, secret.Encode(), digest)
+
+	for {
+		k := toScalar(g, nonces.next(), n)
+
+		r := toScalar(g, new(big.Int).SetBytes(g.Base().Multiply(k).XCoordinate()), n)
+		if r.IsZero() {
+			continue
+		}
+
+		s := r.Copy().Multiply(secret).Add(e).Multiply(k.Invert())
+		if s.IsZero() {
+			continue
+		}
+
+		return &Signature{R: r, S: s}, nil
+	}
+}
+
+// SignRemote returns the signature of the digest, which must be the hash of the message with the hash function, with
+// a private scalar held by the remote, e.g. a hardware device or KMS, whose Sign returns ASN.1 DER encoded signatures
+// as signer.Signer. The nonce is chosen by the remote, so the signature is not that of RFC 6979 in general. It is
+// verified against the remote's public key before being returned.
+func SignRemote(remote ecc.RemoteScalar, hash crypto.Hash, digest []byte) (*Signature, error) {
+	if remote == nil {
+		return nil, fmt.Errorf("ecdsa: %w", internal.ErrParamNilScalar)
+	}
+
+	g := remote.Group()
+	if err := checkGroup(g); err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	if !hash.Available() || len(digest) != hash.Size() {
+		return nil, fmt.Errorf("ecdsa: %w", errInvalidDigest)
+	}
+
+	der, err := remote.Sign(nil, digest, hash)
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	signature, err := ParseDER(g, der)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = Verify(remote.PublicElement(), digest, signature); err != nil {
+		return nil, err
+	}
+
+	return signature, nil
+}
+
+// Verify returns nil if the signature of the digest is valid for the public key. Both high and low-S signatures are
+// accepted, use IsLowS to additionally require the latter.
+func Verify(public *ecc.Element, digest []byte, signature *Signature) error {
+	if public == nil || public.IsIdentity() {
+		return fmt.Errorf("ecdsa: %w", internal.ErrParamNilPoint)
+	}
+
+	g := public.Group()
+	if err := checkGroup(g); err != nil {
+		return fmt.Errorf("ecdsa: %w", err)
+	}
+
+	if signature == nil || signature.R == nil || signature.S == nil ||
+		signature.R.Group() != g || signature.S.Group() != g ||
+		signature.R.IsZero() || signature.S.IsZero() {
+		return fmt.Errorf("ecdsa: %w", errInvalidSignature)
+	}
+
+	n := order(g)
+	e := toScalar(g, bits2int(digest, n), n)
+	w := signature.S.Copy().Invert()
+
+	p := g.Base().Multiply(e.Multiply(w))
+	p.Add(public.Copy().Multiply(w.Multiply(signature.R)))
+
+	if p.IsIdentity() || !toScalar(g, new(big.Int).SetBytes(p.XCoordinate()), n).Equal(signature.R) {
+		return fmt.Errorf("ecdsa: %w", errInvalidSignature)
+	}
+
+	return nil
+}
+
+// IsLowS returns whether s is at most half the group order, as required by Bitcoin's BIP-62 and BIP-146.
+func (s *Signature) IsLowS() bool {
+	n := order(s.S.Group())
+	return new(big.Int).SetBytes(s.S.Encode()).Cmp(n.Rsh(n, 1)) <= 0
+}
+
+// Normalize sets s to its low-S form, i.e. to -s if it is higher than half the group order, and returns the
+// receiver. Both forms are valid signatures.
+func (s *Signature) Normalize() *Signature {
+	if !s.IsLowS() {
+		s.S.Set(s.S.Group().NewScalar().Subtract(s.S))
+	}
+
+	return s
+}
+
+type derSignature struct {
+	R, S *big.Int
+}
+
+// DER returns the ASN.1 DER encoding of the signature, as in crypto/ecdsa and Bitcoin.
+func (s *Signature) DER() ([]byte, error) {
+	der, err := asn1.Marshal(derSignature{
+		R: new(big.Int).SetBytes(s.R.Encode()),
+		S: new(big.Int).SetBytes(s.S.Encode()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	return der, nil
+}
+
+// Compact returns the fixed-length encoding r || s of the signature, e.g. as in JWS and for secp256k1.
+func (s *Signature) Compact() []byte {
+	return append(s.R.Encode(), s.S.Encode()...)
+}
+
+func newSignature(g ecc.Group, r, s *big.Int) (*Signature, error) {
+	n := order(g)
+	if r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(n) >= 0 || s.Cmp(n) >= 0 {
+		return nil, errInvalidSignature
+	}
+
+	return &Signature{R: toScalar(g, r, n), S: toScalar(g, s, n)}, nil
+}
+
+// ParseDER returns the signature over the group decoded from its ASN.1 DER encoding. r and s must be in [1, n-1].
+func ParseDER(g ecc.Group, der []byte) (*Signature, error) {
+	if err := checkGroup(g); err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	var sig derSignature
+	if rest, err := asn1.Unmarshal(der, &sig); err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("ecdsa: %w", errInvalidSignature)
+	}
+
+	s, err := newSignature(g, sig.R, sig.S)
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	return s, nil
+}
+
+// ParseCompact returns the signature over the group decoded from its r || s encoding. r and s must be in [1, n-1].
+func ParseCompact(g ecc.Group, compact []byte) (*Signature, error) {
+	if err := checkGroup(g); err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	l := g.ScalarLength()
+	if len(compact) != 2*l {
+		return nil, fmt.Errorf("ecdsa: %w", errInvalidSignature)
+	}
+
+	s, err := newSignature(g, new(big.Int).SetBytes(compact[:l]), new(big.Int).SetBytes(compact[l:]))
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	return s, nil
+}
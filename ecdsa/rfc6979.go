@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecdsa
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"math/big"
+)
+
+// rfc6979 generates the deterministic nonces of RFC 6979 section 3.2 for a private key and a message digest.
+type rfc6979 struct {
+	hash  crypto.Hash
+	order *big.Int
+	k, v  []byte
+	rlen  int
+}
+
+// bits2int converts the bit string to an integer as in RFC 6979 section 2.3.2, keeping its leftmost bits up to the
+// bit length of the order.
+func bits2int(b []byte, order *big.Int) *big.Int {
+	i := new(big.Int).SetBytes(b)
+	if excess := len(b)*8 - order.BitLen(); excess > 0 {
+		i.Rsh(i, uint(excess))
+	}
+
+	return i
+}
+
+// int2octets returns the rlen bytes big-endian encoding of i.
+func int2octets(i *big.Int, rlen int) []byte {
+	return i.FillBytes(make([]byte, rlen))
+}
+
+func newRFC6979(hash crypto.Hash, order *big.Int, secret, digest []byte) *rfc6979 {
+	rlen := (order.BitLen() + 7) / 8
+	size := hash.Size()
+
+	g := &rfc6979{
+		hash:  hash,
+		order: order,
+		k:     make([]byte, size),
+		v:     make([]byte, size),
+		rlen:  rlen,
+	}
+
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+
+	// bits2octets(h1) = int2octets(bits2int(h1) mod q).
+	h := bits2int(digest, order)
+	if h.Cmp(order) >= 0 {
+		h.Sub(h, order)
+	}
+
+	x := int2octets(new(big.Int).SetBytes(secret), rlen)
+	h1 := int2octets(h, rlen)
+
+	g.k = g.mac(g.v, []byte{0x00}, x, h1)
+	g.v = g.mac(g.v)
+	g.k = g.mac(g.v, []byte{0x01}, x, h1)
+	g.v = g.mac(g.v)
+
+	return g
+}
+
+func (g *rfc6979) mac(data ...[]byte) []byte {
+	m := hmac.New(g.hash.New, g.k)
+	for _, d := range data {
+		_, _ = m.Write(d)
+	}
+
+	return m.Sum(nil)
+}
+
+// next returns the next candidate nonce in [1, q-1].
+func (g *rfc6979) next() *big.Int {
+	for {
+		t := make([]byte, 0, g.rlen+len(g.v))
+		for len(t) < g.rlen {
+			g.v = g.mac(g.v)
+			t = append(t, g.v...)
+		}
+
+		k := bits2int(t[:g.rlen], g.order)
+
+		// The state is updated in any case, so that a later call returns a different nonce.
+		g.k = g.mac(g.v, []byte{0x00})
+		g.v = g.mac(g.v)
+
+		if k.Sign() > 0 && k.Cmp(g.order) < 0 {
+			return k
+		}
+	}
+}
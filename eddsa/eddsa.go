@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package eddsa implements the Ed25519, Ed25519ctx, and Ed25519ph signatures of RFC 8032 over the Edwards25519
+// group. Private keys are either derived from a standard 32-byte seed, as in crypto/ed25519, or set from an ecc
+// Scalar, e.g. a blinded or threshold-derived key, and the signatures verify with any RFC 8032 implementation.
+package eddsa
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	ed "filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	// SeedSize is the size of the seeds of private keys, in bytes.
+	SeedSize = 32
+
+	// SignatureSize is the size of the signatures, in bytes.
+	SignatureSize = 64
+
+	// prefixSize is the size of the secret prefix used to derive nonces, in bytes.
+	prefixSize = 32
+
+	// dom2Prefix is the prefix of dom2 in RFC 8032 section 5.1.
+	dom2Prefix = "SigEd25519 no Ed25519 collisions"
+
+	// scalarPrefixDST domain separates the nonce prefix derived for keys set from a scalar.
+	scalarPrefixDST = "ecc-eddsa-scalar-prefix"
+
+	maxContextLength = 255
+)
+
+var (
+	errInvalidSignature = errors.New("invalid signature")
+	errInvalidOptions   = errors.New("invalid options")
+	errInvalidSeed      = errors.New("invalid seed length")
+)
+
+// Options selects the RFC 8032 variant, as with crypto/ed25519. Hash must be zero for Ed25519 and Ed25519ctx, and
+// crypto.SHA512 for Ed25519ph, in which case the message is its SHA-512 digest. Context must be empty for Ed25519,
+// non-empty for Ed25519ctx, and is optional for Ed25519ph. It is at most 255 bytes long.
+type Options struct {
+	Context string
+	Hash    crypto.Hash
+}
+
+// dom2 returns the dom2 prefix of the options, which is empty for Ed25519.
+func (o *Options) dom2() ([]byte, error) {
+	if o == nil {
+		return nil, nil
+	}
+
+	if len(o.Context) > maxContextLength {
+		return nil, errInvalidOptions
+	}
+
+	var flag byte
+
+	switch o.Hash {
+	case crypto.SHA512:
+		flag = 1
+	case 0:
+		if o.Context == "" {
+			return nil, nil
+		}
+	default:
+		return nil, errInvalidOptions
+	}
+
+	dom := make([]byte, 0, len(dom2Prefix)+2+len(o.Context))
+	dom = append(dom, dom2Prefix...)
+	dom = append(dom, flag, byte(len(o.Context)))
+
+	return append(dom, o.Context...), nil
+}
+
+func (o *Options) checkMessage(message []byte) error {
+	if o != nil && o.Hash == crypto.SHA512 && len(message) != sha512.Size {
+		return errInvalidOptions
+	}
+
+	return nil
+}
+
+// PrivateKey is an Ed25519 private key, made of a secret scalar and a secret prefix to derive the nonces.
+type PrivateKey struct {
+	scalar *ecc.Scalar
+	public *ecc.Element
+	prefix []byte
+}
+
+// NewKeyFromSeed returns the private key derived from the seed as in RFC 8032 section 5.1.5, which is that of
+// crypto/ed25519.NewKeyFromSeed.
+func NewKeyFromSeed(seed []byte) (*PrivateKey, error) {
+	if len(seed) != SeedSize {
+		return nil, fmt.Errorf("eddsa: %w", errInvalidSeed)
+	}
+
+	h := sha512.Sum512(seed)
+
+	s, err := ed.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		return nil, fmt.Errorf("eddsa: %w", err)
+	}
+
+	scalar := ecc.Edwards25519Sha512.NewScalar()
+	if err = scalar.SetEdwards25519Scalar(s); err != nil {
+		return nil, fmt.Errorf("eddsa: %w", err)
+	}
+
+	return &PrivateKey{
+		scalar: scalar,
+		public: ecc.Edwards25519Sha512.Base().Multiply(scalar),
+		prefix: h[32:],
+	}, nil
+}
+
+// NewKeyFromScalar returns the private key of the Edwards25519 scalar, which must not be zero. As there is no seed,
+// the nonce prefix is derived from the scalar, so that signatures remain deterministic.
+func NewKeyFromScalar(scalar *ecc.Scalar) (*PrivateKey, error) {
+	if scalar == nil || scalar.IsZero() {
+		return nil, fmt.Errorf("eddsa: %w", internal.ErrParamNilScalar)
+	}
+
+	if scalar.Group() != ecc.Edwards25519Sha512 {
+		return nil, fmt.Errorf("eddsa: %w", internal.ErrInvalidGroup)
+	}
+
+	h := sha512.New()
+	_, _ = h.Write([]byte(scalarPrefixDST))
+	_, _ = h.Write(scalar.Encode())
+
+	return &PrivateKey{
+		scalar: scalar.Copy(),
+		public: ecc.Edwards25519Sha512.Base().Multiply(scalar),
+		prefix: h.Sum(nil)[:prefixSize],
+	}, nil
+}
+
+// Public returns the public key, which encodes to the 32-byte RFC 8032 public key.
+func (k *PrivateKey) Public() *ecc.Element {
+	return k.public.Copy()
+}
+
+// Scalar returns a copy of the secret scalar.
+func (k *PrivateKey) Scalar() *ecc.Scalar {
+	return k.scalar.Copy()
+}
+
+// Sign returns the Ed25519 signature of the message.
+func (k *PrivateKey) Sign(message []byte) []byte {
+	sig, err := k.SignWithOptions(message, nil)
+	if err != nil {
+		// Only happens with invalid options.
+		panic(err)
+	}
+
+	return sig
+}
+
+// SignWithOptions returns the Ed25519, Ed25519ctx, or Ed25519ph signature of the message, as selected by the
+// options. Nil options select Ed25519.
+func (k *PrivateKey) SignWithOptions(message []byte, opts *Options) ([]byte, error) {
+	dom, err := opts.dom2()
+	if err != nil {
+		return nil, fmt.Errorf("eddsa: %w", err)
+	}
+
+	if err = opts.checkMessage(message); err != nil {
+		return nil, fmt.Errorf("eddsa: %w", err)
+	}
+
+	r := hashToScalar(dom, k.prefix, message)
+	commitment := ecc.Edwards25519Sha512.Base().Multiply(r).Encode()
+	c := hashToScalar(dom, commitment, k.public.Encode(), message)
+
+	return append(commitment, r.Add(c.Multiply(k.scalar)).Encode()...), nil
+}
+
+// SignRemote returns the Ed25519 signature of the message with a private scalar held by the remote, e.g. a hardware
+// device or KMS, whose Sign returns Ed25519 signatures as signer.Signer. Ed25519ctx and Ed25519ph are not supported.
+// The signature is verified against the remote's public key before being returned.
+func SignRemote(remote ecc.RemoteScalar, message []byte) ([]byte, error) {
+	if remote == nil {
+		return nil, fmt.Errorf("eddsa: %w", internal.ErrParamNilScalar)
+	}
+
+	if remote.Group() != ecc.Edwards25519Sha512 {
+		return nil, fmt.Errorf("eddsa: %w", internal.ErrInvalidGroup)
+	}
+
+	sig, err := remote.Sign(nil, message, crypto.Hash(0))
+	if err != nil {
+		return nil, fmt.Errorf("eddsa: %w", err)
+	}
+
+	if err = Verify(remote.PublicElement(), message, sig); err != nil {
+		return nil, err
+	}
+
+	return sig, nil
+}
+
+// hashToScalar returns the SHA-512 hash of the inputs reduced modulo the group order.
+func hashToScalar(inputs ...[]byte) *ecc.Scalar {
+	h := sha512.New()
+	for _, in := range inputs {
+		_, _ = h.Write(in)
+	}
+
+	s, err := ed.NewScalar().SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		// Only happens with an invalid length.
+		panic(err)
+	}
+
+	scalar := ecc.Edwards25519Sha512.NewScalar()
+	if err = scalar.SetEdwards25519Scalar(s); err != nil {
+		panic(err)
+	}
+
+	return scalar
+}
+
+// Verify returns nil if the Ed25519 signature of the message is valid for the public key.
+func Verify(public *ecc.Element, message, signature []byte) error {
+	return VerifyWithOptions(public, message, signature, nil)
+}
+
+// VerifyWithOptions returns nil if the Ed25519, Ed25519ctx, or Ed25519ph signature of the message, as selected by
+// the options, is valid for the public key. As in crypto/ed25519, the verification equation is cofactorless and the
+// signature's scalar must be canonical.
+func VerifyWithOptions(public *ecc.Element, message, signature []byte, opts *Options) error {
+	if public == nil || public.IsIdentity() {
+		return fmt.Errorf("eddsa: %w", internal.ErrParamNilPoint)
+	}
+
+	if public.Group() != ecc.Edwards25519Sha512 {
+		return fmt.Errorf("eddsa: %w", internal.ErrInvalidGroup)
+	}
+
+	dom, err := opts.dom2()
+	if err != nil {
+		return fmt.Errorf("eddsa: %w", err)
+	}
+
+	if err = opts.checkMessage(message); err != nil {
+		return fmt.Errorf("eddsa: %w", err)
+	}
+
+	if len(signature) != SignatureSize {
+		return fmt.Errorf("eddsa: %w", errInvalidSignature)
+	}
+
+	r := ecc.Edwards25519Sha512.NewElement()
+	if err = r.Decode(signature[:32]); err != nil {
+		return fmt.Errorf("eddsa: %w", errInvalidSignature)
+	}
+
+	s := ecc.Edwards25519Sha512.NewScalar()
+	if err = s.Decode(signature[32:]); err != nil {
+		return fmt.Errorf("eddsa: %w", errInvalidSignature)
+	}
+
+	c := hashToScalar(dom, signature[:32], public.Encode(), message)
+
+	if !ecc.Edwards25519Sha512.Base().Multiply(s).Equal(r.Add(public.Copy().Multiply(c))) {
+		return fmt.Errorf("eddsa: %w", errInvalidSignature)
+	}
+
+	return nil
+}
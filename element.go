@@ -9,9 +9,17 @@
 package ecc
 
 import (
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"math/big"
+	"slices"
 	"strings"
 
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
 	"github.com/bytemare/ecc/internal"
 )
 
@@ -19,12 +27,34 @@ import (
 type Element struct {
 	_ disallowEqual
 	internal.Element
+
+	// encoding is the cached encoding of the element, if cached is set.
+	encoding []byte
+	cached   bool
 }
 
 func newPoint(p internal.Element) *Element {
 	return &Element{Element: p}
 }
 
+// CacheEncoding makes the element keep its encoding, computed now and after each mutation, so that repeated calls to
+// Encode and the marshalling functions return a copy of it instead of compressing the element every time, e.g. for a
+// long-lived public key serialized into many transcripts. Mutations through the embedded backend element bypass the
+// cache. Copies of the element don't cache their encoding.
+func (e *Element) CacheEncoding() *Element {
+	e.cached = true
+	return e.refresh()
+}
+
+// refresh updates the cached encoding of the element, if any, after a mutation, and returns the element.
+func (e *Element) refresh() *Element {
+	if e.cached {
+		e.encoding = e.Element.Encode()
+	}
+
+	return e
+}
+
 // Group returns the group's Identifier.
 func (e *Element) Group() Group {
 	return Group(e.Element.Group())
@@ -32,12 +62,14 @@ func (e *Element) Group() Group {
 
 // Base sets the element to the group's base point a.k.a. canonical generator.
 func (e *Element) Base() *Element {
-	return &Element{Element: e.Element.Base()}
+	e.Element.Base()
+	return e.refresh()
 }
 
 // Identity sets the element to the point at infinity of the Group's underlying curve.
 func (e *Element) Identity() *Element {
-	return &Element{Element: e.Element.Identity()}
+	e.Element.Identity()
+	return e.refresh()
 }
 
 // Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
@@ -48,19 +80,19 @@ func (e *Element) Add(element *Element) *Element {
 
 	e.Element.Add(element.Element)
 
-	return e
+	return e.refresh()
 }
 
 // Double sets the receiver to its double, and returns it.
 func (e *Element) Double() *Element {
 	e.Element.Double()
-	return e
+	return e.refresh()
 }
 
 // Negate sets the receiver to its negation, and returns it.
 func (e *Element) Negate() *Element {
 	e.Element.Negate()
-	return e
+	return e.refresh()
 }
 
 // Subtract subtracts the input from the receiver, and returns the receiver.
@@ -71,22 +103,23 @@ func (e *Element) Subtract(element *Element) *Element {
 
 	e.Element.Subtract(element.Element)
 
-	return e
+	return e.refresh()
 }
 
 // Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
 func (e *Element) Multiply(scalar *Scalar) *Element {
 	if scalar == nil {
-		e.Element.Identity()
-		return e
+		return e.Identity()
 	}
 
 	e.Element.Multiply(scalar.Scalar)
 
-	return e
+	return e.refresh()
 }
 
-// Equal returns true if the elements are equivalent, and false otherwise.
+// Equal returns true if the elements are equivalent, and false otherwise. It runs in constant time in all backends
+// but secp256k1 and circl's P-384, which compute with the math/big coordinates of their libraries in variable time,
+// and EqualVarTime is faster with circl's P-384 when the elements are public.
 func (e *Element) Equal(element *Element) bool {
 	if element == nil {
 		return false
@@ -105,12 +138,12 @@ func (e *Element) Set(element *Element) *Element {
 	if element == nil {
 		e.Element.Set(nil)
 
-		return e
+		return e.refresh()
 	}
 
 	e.Element.Set(element.Element)
 
-	return e
+	return e.refresh()
 }
 
 // Copy returns a copy of the receiver.
@@ -120,6 +153,10 @@ func (e *Element) Copy() *Element {
 
 // Encode returns the compressed byte encoding of the element.
 func (e *Element) Encode() []byte {
+	if e.cached {
+		return append([]byte(nil), e.encoding...)
+	}
+
 	return e.Element.Encode()
 }
 
@@ -128,10 +165,177 @@ func (e *Element) XCoordinate() []byte {
 	return e.Element.XCoordinate()
 }
 
+// EncodeXOnly returns the 32-byte BIP-340 x-only encoding of the secp256k1 element, i.e. its x-coordinate. The
+// element and its negation share the same encoding, and DecodeXOnly returns the one with an even y-coordinate. It
+// returns nil for the other groups and the identity element.
+func (e *Element) EncodeXOnly() []byte {
+	if e.Group() != Secp256k1Sha256 || e.IsIdentity() {
+		return nil
+	}
+
+	return e.Encode()[1:]
+}
+
+// HasEvenY returns whether the secp256k1 element has an even y-coordinate, as required by BIP-340 for public keys and
+// nonces. It returns false for the other groups and the identity element.
+func (e *Element) HasEvenY() bool {
+	if e.Group() != Secp256k1Sha256 || e.IsIdentity() {
+		return false
+	}
+
+	return e.Encode()[0] == 0x02
+}
+
+// ToMontgomeryU returns the Curve25519 u-coordinate of the Edwards25519 element, mapped by the birational map of
+// RFC 7748 section 4.1, u = (1 + y) / (1 - y). This is the element's X25519 encoding, and the identity element maps
+// to 0. It returns nil for the other groups.
+func (e *Element) ToMontgomeryU() []byte {
+	if e.Group() != Edwards25519Sha512 {
+		return nil
+	}
+
+	return e.Element.XCoordinate()
+}
+
+// ToEllipticPoint returns the crypto/elliptic curve and affine coordinates of the element, for interoperability with
+// APIs using big.Int pairs. It only applies to the NIST groups, and returns nil values for the other groups and the
+// identity element, which has no affine representation.
+func (e *Element) ToEllipticPoint() (elliptic.Curve, *big.Int, *big.Int) {
+	curve := e.Group().EllipticCurve()
+	if curve == nil || e.IsIdentity() {
+		return nil, nil, nil
+	}
+
+	x, y := elliptic.UnmarshalCompressed(curve, e.Encode())
+
+	return curve, x, y
+}
+
+// AffineCoordinates returns the big-endian, fixed-length, encodings of the affine coordinates of the element, in
+// the Weierstrass groups and Edwards25519, e.g. for test vectors, and nil for Ristretto255 and the identity element.
+// It runs in variable time, and must only be used on public values.
+func (e *Element) AffineCoordinates() *AffinePoint {
+	if e.IsIdentity() {
+		return nil
+	}
+
+	g := e.Group()
+	size := len(g.FieldOrder())
+
+	switch g {
+	case P256Sha256, P384Sha384, P521Sha512:
+		_, x, y := e.ToEllipticPoint()
+		return &AffinePoint{X: x.FillBytes(make([]byte, size)), Y: y.FillBytes(make([]byte, size))}
+	case Secp256k1Sha256:
+		// y^2 = x^3 + 7, and since p = 3 mod 4, y = (x^3 + 7)^((p+1)/4), of the parity of the encoding's prefix.
+		encoding := e.Encode()
+		p := new(big.Int).SetBytes(g.FieldOrder())
+		x := new(big.Int).SetBytes(encoding[1:])
+
+		y := new(big.Int).Exp(x, big.NewInt(3), p)
+		y.Add(y, big.NewInt(7))
+		exp := new(big.Int).Add(p, big.NewInt(1))
+		y.Exp(y, exp.Rsh(exp, 2), p)
+
+		if y.Bit(0) != uint(encoding[0]&1) {
+			y.Sub(p, y)
+		}
+
+		return &AffinePoint{X: x.FillBytes(make([]byte, size)), Y: y.FillBytes(make([]byte, size))}
+	case Edwards25519Sha512:
+		x, y, z, _ := e.Edwards25519Point().ExtendedCoordinates()
+		zInv := new(field.Element).Invert(z)
+		ax := new(field.Element).Multiply(x, zInv).Bytes()
+		ay := new(field.Element).Multiply(y, zInv).Bytes()
+		slices.Reverse(ax)
+		slices.Reverse(ay)
+
+		return &AffinePoint{X: ax, Y: ay}
+	default:
+		return nil
+	}
+}
+
+// ElementFromEllipticPoint returns the element of the NIST group with the crypto/elliptic curve and the affine
+// coordinates, which must be on the curve.
+func ElementFromEllipticPoint(curve elliptic.Curve, x, y *big.Int) (*Element, error) {
+	g, err := groupFromEllipticCurve(curve)
+	if err != nil {
+		return nil, fmt.Errorf("element FromEllipticPoint: %w", err)
+	}
+
+	if x == nil || y == nil {
+		return nil, fmt.Errorf("element FromEllipticPoint: %w", internal.ErrParamNilPoint)
+	}
+
+	length := (curve.Params().BitSize + 7) / 8
+	if x.Sign() < 0 || y.Sign() < 0 || x.BitLen() > 8*length || y.BitLen() > 8*length {
+		return nil, fmt.Errorf("element FromEllipticPoint: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	// The NIST backends accept the uncompressed encoding, and verify the point is on the curve.
+	uncompressed := make([]byte, 1+2*length)
+	uncompressed[0] = 4
+	x.FillBytes(uncompressed[1 : 1+length])
+	y.FillBytes(uncompressed[1+length:])
+
+	e := g.NewElement()
+	if err = e.Element.Decode(uncompressed); err != nil {
+		return nil, fmt.Errorf("element FromEllipticPoint: %w", err)
+	}
+
+	return e, nil
+}
+
+func groupFromEllipticCurve(curve elliptic.Curve) (Group, error) {
+	for _, g := range []Group{P256Sha256, P384Sha384, P521Sha512} {
+		if curve != nil && curve == g.EllipticCurve() {
+			return g, nil
+		}
+	}
+
+	return 0, internal.ErrInvalidGroup
+}
+
+type edwards25519Element interface {
+	EdwardsPoint() *edwards25519.Point
+	SetEdwardsPoint(p *edwards25519.Point) error
+}
+
+// Edwards25519Point returns a copy of the element as a filippo.io/edwards25519 point, to use with backend-specific
+// functions like VarTimeMultiScalarMult. For Ristretto255, this is one of the Edwards25519 points representing the
+// element. It returns nil for the other groups.
+func (e *Element) Edwards25519Point() *edwards25519.Point {
+	if ee, ok := e.Element.(edwards25519Element); ok {
+		return ee.EdwardsPoint()
+	}
+
+	return nil
+}
+
+// SetEdwards25519Point sets e to the filippo.io/edwards25519 point. For Ristretto255, the point must represent a
+// Ristretto255 element, as those returned by Edwards25519Point and the results of operations on them do.
+func (e *Element) SetEdwards25519Point(p *edwards25519.Point) error {
+	ee, ok := e.Element.(edwards25519Element)
+	if !ok {
+		return fmt.Errorf("element SetEdwards25519Point: %w", internal.ErrInvalidGroup)
+	}
+
+	defer e.refresh()
+
+	if err := ee.SetEdwardsPoint(p); err != nil {
+		return fmt.Errorf("element SetEdwards25519Point: %w", err)
+	}
+
+	return nil
+}
+
 // Decode sets the receiver to a decoding of the input data, and returns an error on failure.
 func (e *Element) Decode(data []byte) error {
+	defer e.refresh()
+
 	if err := e.Element.Decode(data); err != nil {
-		return fmt.Errorf("element Decode: %w", err)
+		return elementDecodingError("Decode", err)
 	}
 
 	return nil
@@ -139,13 +343,36 @@ func (e *Element) Decode(data []byte) error {
 
 // Hex returns the fixed-sized hexadecimal encoding of e.
 func (e *Element) Hex() string {
-	return e.Element.Hex()
+	return hex.EncodeToString(e.Encode())
 }
 
 // DecodeHex sets e to the decoding of the hex encoded element.
 func (e *Element) DecodeHex(h string) error {
+	defer e.refresh()
+
 	if err := e.Element.DecodeHex(h); err != nil {
-		return fmt.Errorf("element DecodeHex: %w", err)
+		return elementDecodingError("DecodeHex", err)
+	}
+
+	return nil
+}
+
+// EncodeBase64 returns the unpadded base64url encoding (RFC 4648 section 5) of e.
+func (e *Element) EncodeBase64() string {
+	return base64.RawURLEncoding.EncodeToString(e.Encode())
+}
+
+// DecodeBase64 sets e to the decoding of the unpadded base64url encoded element.
+func (e *Element) DecodeBase64(b64 string) error {
+	b, err := base64.RawURLEncoding.DecodeString(b64)
+	if err != nil {
+		return elementDecodingError("DecodeBase64", err)
+	}
+
+	defer e.refresh()
+
+	if err = e.Element.Decode(b); err != nil {
+		return elementDecodingError("DecodeBase64", err)
 	}
 
 	return nil
@@ -162,15 +389,83 @@ func (e *Element) UnmarshalJSON(data []byte) error {
 	return e.DecodeHex(j)
 }
 
+// MarshalText implements the encoding.TextMarshaler interface, returning the hexadecimal encoding of the element. This
+// is what makes the element usable as a plain string in YAML, TOML, or XML documents, and as a JSON map key.
+func (e *Element) MarshalText() ([]byte, error) {
+	return []byte(e.Hex()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, decoding the hexadecimal encoding of the element.
+func (e *Element) UnmarshalText(text []byte) error {
+	defer e.refresh()
+
+	if err := e.Element.DecodeHex(string(text)); err != nil {
+		return elementDecodingError("UnmarshalText", err)
+	}
+
+	return nil
+}
+
 // MarshalBinary returns the compressed byte encoding of the element.
 func (e *Element) MarshalBinary() ([]byte, error) {
-	return e.Element.Encode(), nil
+	return e.Encode(), nil
 }
 
 // UnmarshalBinary sets e to the decoding of the byte encoded element.
 func (e *Element) UnmarshalBinary(data []byte) error {
+	defer e.refresh()
+
 	if err := e.Element.Decode(data); err != nil {
-		return fmt.Errorf("element UnmarshalBinary: %w", err)
+		return elementDecodingError("UnmarshalBinary", err)
+	}
+
+	return nil
+}
+
+// EncodeTagged returns the self-describing encoding of the element, i.e. the group identifier followed by the element's
+// binary encoding.
+func (e *Element) EncodeTagged() []byte {
+	return append([]byte{byte(e.Group())}, e.Encode()...)
+}
+
+func (e *Element) decodeTagged(data []byte) error {
+	if len(data) == 0 {
+		return internal.ErrParamNilPoint
+	}
+
+	g := Group(data[0])
+	if !g.Available() {
+		return internal.ErrInvalidGroup
+	}
+
+	if e.Element == nil || e.Group() != g {
+		e.Element = g.get().NewElement()
+	}
+
+	defer e.refresh()
+
+	return e.Element.Decode(data[1:])
+}
+
+// DecodeTaggedElement returns the element decoded from its self-describing encoding produced by EncodeTagged.
+func DecodeTaggedElement(data []byte) (*Element, error) {
+	e := new(Element)
+	if err := e.decodeTagged(data); err != nil {
+		return nil, elementDecodingError("DecodeTagged", err)
+	}
+
+	return e, nil
+}
+
+// GobEncode implements the gob.GobEncoder interface, using the self-describing encoding of EncodeTagged.
+func (e *Element) GobEncode() ([]byte, error) {
+	return e.EncodeTagged(), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface, and sets e to the decoding of the self-describing encoding.
+func (e *Element) GobDecode(data []byte) error {
+	if err := e.decodeTagged(data); err != nil {
+		return elementDecodingError("GobDecode", err)
 	}
 
 	return nil
@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package elgamal implements ElGamal encryption over any ecc group, for messages that are elements, and exponential
+// ElGamal for small integer messages encrypted as m * G, which is additively homomorphic, e.g. to tally votes.
+// Ciphertexts can be re-randomized, e.g. in mixnets, and decrypted with shares of a threshold key.
+package elgamal
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+var (
+	errMessageNotFound = errors.New("message not found in range")
+	errDuplicateShare  = errors.New("duplicate or zero share identifier")
+)
+
+// Ciphertext is an ElGamal ciphertext (C1, C2) = (r * G, M + r * PK).
+type Ciphertext struct {
+	C1, C2 *ecc.Element
+}
+
+// KeyGen returns a new random private key and its public key.
+func KeyGen(g ecc.Group) (*ecc.Scalar, *ecc.Element) {
+	sk := g.NewScalar().Random()
+	return sk, g.Base().Multiply(sk)
+}
+
+func checkPublicKey(public *ecc.Element) error {
+	if public == nil || public.IsIdentity() {
+		return internal.ErrParamNilPoint
+	}
+
+	return nil
+}
+
+func (c *Ciphertext) check(g ecc.Group) error {
+	if c == nil || c.C1 == nil || c.C2 == nil {
+		return internal.ErrParamNilPoint
+	}
+
+	if c.C1.Group() != g || c.C2.Group() != g {
+		return internal.ErrCastElement
+	}
+
+	return nil
+}
+
+// Encrypt returns the encryption of the message element to the public key.
+func Encrypt(public, message *ecc.Element) (*Ciphertext, error) {
+	if err := checkPublicKey(public); err != nil {
+		return nil, fmt.Errorf("elgamal: %w", err)
+	}
+
+	if message == nil {
+		return nil, fmt.Errorf("elgamal: %w", internal.ErrParamNilPoint)
+	}
+
+	if message.Group() != public.Group() {
+		return nil, fmt.Errorf("elgamal: %w", internal.ErrCastElement)
+	}
+
+	r := public.Group().NewScalar().Random()
+
+	return &Ciphertext{
+		C1: public.Group().Base().Multiply(r),
+		C2: public.Copy().Multiply(r).Add(message),
+	}, nil
+}
+
+// EncryptExponential returns the encryption of the message * G to the public key.
+func EncryptExponential(public *ecc.Element, message uint64) (*Ciphertext, error) {
+	if err := checkPublicKey(public); err != nil {
+		return nil, fmt.Errorf("elgamal: %w", err)
+	}
+
+	g := public.Group()
+
+	return Encrypt(public, g.Base().Multiply(g.NewScalar().SetUInt64(message)))
+}
+
+// Decrypt returns the message element of the ciphertext, M = C2 - sk * C1.
+func Decrypt(secret *ecc.Scalar, ciphertext *Ciphertext) (*ecc.Element, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("elgamal: %w", internal.ErrParamNilScalar)
+	}
+
+	if err := ciphertext.check(secret.Group()); err != nil {
+		return nil, fmt.Errorf("elgamal: %w", err)
+	}
+
+	return ciphertext.C2.Copy().Subtract(ciphertext.C1.Copy().Multiply(secret)), nil
+}
+
+// DecryptExponential returns the integer message m of the exponential ciphertext, which must be at most max. It
+// solves the discrete logarithm of M = m * G with the baby-step giant-step algorithm, in O(sqrt(max)) time and
+// memory.
+func DecryptExponential(secret *ecc.Scalar, ciphertext *Ciphertext, maxMessage uint64) (uint64, error) {
+	m, err := Decrypt(secret, ciphertext)
+	if err != nil {
+		return 0, err
+	}
+
+	return discreteLog(m, maxMessage)
+}
+
+// discreteLog returns x in [0, maxMessage] such that x * G = m, with the baby-step giant-step algorithm.
+func discreteLog(m *ecc.Element, maxMessage uint64) (uint64, error) {
+	g := m.Group()
+	steps := uint64(math.Sqrt(float64(maxMessage))) + 1
+
+	// Baby steps: j * G for j in [0, steps).
+	table := make(map[string]uint64, steps)
+	baby := g.NewElement()
+
+	for j := range steps {
+		table[string(baby.Encode())] = j
+		baby.Add(g.Base())
+	}
+
+	// Giant steps: m - i * steps * G for i in [0, steps].
+	giant := g.Base().Multiply(g.NewScalar().SetUInt64(steps))
+	target := m.Copy()
+
+	for i := uint64(0); i <= steps; i++ {
+		if j, ok := table[string(target.Encode())]; ok {
+			if x := i*steps + j; x <= maxMessage {
+				return x, nil
+			}
+		}
+
+		target.Subtract(giant)
+	}
+
+	return 0, fmt.Errorf("elgamal: %w", errMessageNotFound)
+}
+
+// ReRandomize returns a new encryption of the same message to the public key, which is unlinkable to the input.
+func ReRandomize(public *ecc.Element, ciphertext *Ciphertext) (*Ciphertext, error) {
+	if err := checkPublicKey(public); err != nil {
+		return nil, fmt.Errorf("elgamal: %w", err)
+	}
+
+	if err := ciphertext.check(public.Group()); err != nil {
+		return nil, fmt.Errorf("elgamal: %w", err)
+	}
+
+	r := public.Group().NewScalar().Random()
+
+	return &Ciphertext{
+		C1: public.Group().Base().Multiply(r).Add(ciphertext.C1),
+		C2: public.Copy().Multiply(r).Add(ciphertext.C2),
+	}, nil
+}
+
+// Add returns the encryption of the sum of the messages of the ciphertexts, encrypted to the same public key. For
+// exponential ciphertexts, this is the sum of the integer messages.
+func Add(a, b *Ciphertext) (*Ciphertext, error) {
+	if a == nil || a.C1 == nil {
+		return nil, fmt.Errorf("elgamal: %w", internal.ErrParamNilPoint)
+	}
+
+	g := a.C1.Group()
+	if err := a.check(g); err != nil {
+		return nil, fmt.Errorf("elgamal: %w", err)
+	}
+
+	if err := b.check(g); err != nil {
+		return nil, fmt.Errorf("elgamal: %w", err)
+	}
+
+	return &Ciphertext{
+		C1: a.C1.Copy().Add(b.C1),
+		C2: a.C2.Copy().Add(b.C2),
+	}, nil
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package elgamal
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// DecryptionShare is the partial decryption sk_i * C1 of a ciphertext by the holder of the Shamir share sk_i of the
+// private key, at the non-zero identifier ID.
+type DecryptionShare struct {
+	Element *ecc.Element
+	ID      uint64
+}
+
+// PartialDecrypt returns the decryption share of the ciphertext by the holder of the private key share at the
+// identifier.
+func PartialDecrypt(id uint64, secretShare *ecc.Scalar, ciphertext *Ciphertext) (*DecryptionShare, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("elgamal: %w", errDuplicateShare)
+	}
+
+	if secretShare == nil || secretShare.IsZero() {
+		return nil, fmt.Errorf("elgamal: %w", internal.ErrParamNilScalar)
+	}
+
+	if err := ciphertext.check(secretShare.Group()); err != nil {
+		return nil, fmt.Errorf("elgamal: %w", err)
+	}
+
+	return &DecryptionShare{ID: id, Element: ciphertext.C1.Copy().Multiply(secretShare)}, nil
+}
+
+// lagrangeCoefficient returns the Lagrange coefficient at 0 of the identifier among the identifiers.
+func lagrangeCoefficient(g ecc.Group, id uint64, ids []uint64) *ecc.Scalar {
+	xi := g.NewScalar().SetUInt64(id)
+	num := g.NewScalar().One()
+	den := g.NewScalar().One()
+
+	for _, other := range ids {
+		if other == id {
+			continue
+		}
+
+		xj := g.NewScalar().SetUInt64(other)
+		num.Multiply(xj)
+		den.Multiply(xj.Copy().Subtract(xi))
+	}
+
+	return num.Multiply(den.Invert())
+}
+
+// Combine returns the message element of the ciphertext from at least threshold decryption shares, with
+// M = C2 - sum(lambda_i * D_i). The shares must have distinct identifiers, and are not verified.
+func Combine(ciphertext *Ciphertext, shares []*DecryptionShare) (*ecc.Element, error) {
+	if len(shares) == 0 || shares[0] == nil || shares[0].Element == nil {
+		return nil, fmt.Errorf("elgamal: %w", internal.ErrParamNilPoint)
+	}
+
+	g := shares[0].Element.Group()
+	if err := ciphertext.check(g); err != nil {
+		return nil, fmt.Errorf("elgamal: %w", err)
+	}
+
+	ids := make([]uint64, len(shares))
+	seen := make(map[uint64]struct{}, len(shares))
+
+	for i, s := range shares {
+		if s == nil || s.Element == nil {
+			return nil, fmt.Errorf("elgamal: %w", internal.ErrParamNilPoint)
+		}
+
+		if s.Element.Group() != g {
+			return nil, fmt.Errorf("elgamal: %w", internal.ErrCastElement)
+		}
+
+		if _, ok := seen[s.ID]; ok || s.ID == 0 {
+			return nil, fmt.Errorf("elgamal: %w", errDuplicateShare)
+		}
+
+		seen[s.ID] = struct{}{}
+		ids[i] = s.ID
+	}
+
+	d := g.NewElement()
+	for _, s := range shares {
+		d.Add(s.Element.Copy().Multiply(lagrangeCoefficient(g, s.ID, ids)))
+	}
+
+	return ciphertext.C2.Copy().Subtract(d), nil
+}
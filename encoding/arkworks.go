@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+
+	"filippo.io/edwards25519/field"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// Interoperability with other libraries:
+//
+//   - curve25519-dalek and libsodium use the same encodings as this package for Edwards25519 and Ristretto255: RFC 8032
+//     compressed points, RFC 9496 Ristretto255 elements, and 32-byte little-endian scalars. libsodium's
+//     crypto_core_ristretto255_from_hash is Group.ElementFromUniformBytes. No conversion is needed.
+//   - arkworks (ark-serialize) uses little-endian scalars and coordinates, and stores the sign of the compressed
+//     point in flags in the most significant bits of the last byte, which the functions below convert from and to.
+
+const (
+	// arkworksNegative is the arkworks flag of a negative coordinate, i.e. greater than (p-1)/2, for both short
+	// Weierstrass (SWFlags, on y) and twisted Edwards (TEFlags, on x) curves.
+	arkworksNegative = byte(1 << 7)
+
+	// arkworksInfinity is the arkworks SWFlags flag of the point at infinity.
+	arkworksInfinity = byte(1 << 6)
+
+	// arkworksSWFlagBits is the number of bits of the arkworks SWFlags.
+	arkworksSWFlagBits = 2
+)
+
+// fieldPrime returns the base field prime of the Weierstrass group.
+func fieldPrime(g ecc.Group) *big.Int {
+	if g == ecc.Secp256k1Sha256 {
+		return secp256k1Prime
+	}
+
+	return g.EllipticCurve().Params().P
+}
+
+// isArkworksNegative returns whether the coordinate, in big-endian, is negative as per arkworks, i.e. -c < c.
+func isArkworksNegative(c []byte, p *big.Int) bool {
+	i := new(big.Int).SetBytes(c)
+	return i.Cmp(new(big.Int).Sub(p, i)) > 0
+}
+
+// ScalarToArkworks returns the arkworks serialization of the scalar, i.e. its little-endian encoding.
+func ScalarToArkworks(scalar *ecc.Scalar) ([]byte, error) {
+	if scalar == nil {
+		return nil, fmt.Errorf("arkworks: %w", internal.ErrParamNilScalar)
+	}
+
+	switch scalar.Group() {
+	case ecc.Ristretto255Sha512, ecc.Edwards25519Sha512:
+		return scalar.Encode(), nil
+	default:
+		b := scalar.Encode()
+		slices.Reverse(b)
+
+		return b, nil
+	}
+}
+
+// ScalarFromArkworks returns the scalar of the group g deserialized from its arkworks serialization.
+func ScalarFromArkworks(g ecc.Group, data []byte) (*ecc.Scalar, error) {
+	b := slices.Clone(data)
+	if g != ecc.Ristretto255Sha512 && g != ecc.Edwards25519Sha512 {
+		slices.Reverse(b)
+	}
+
+	s := g.NewScalar()
+	if err := s.Decode(b); err != nil {
+		return nil, fmt.Errorf("arkworks: %w", err)
+	}
+
+	return s, nil
+}
+
+// ElementToArkworks returns the arkworks compressed serialization of the element. For the Weierstrass groups, this is
+// the little-endian x-coordinate, with the sign of y flagged in the last byte, which is added if the field has not two
+// spare bits. For Edwards25519, this is the little-endian y-coordinate with the sign of x flagged in its most
+// significant bit, which differs from RFC 8032 that uses the parity of x. Ristretto255 is not supported, and the
+// identity element is rejected.
+func ElementToArkworks(element *ecc.Element) ([]byte, error) {
+	if element == nil {
+		return nil, fmt.Errorf("arkworks: %w", internal.ErrParamNilPoint)
+	}
+
+	if element.IsIdentity() {
+		return nil, fmt.Errorf("arkworks: %w", internal.ErrIdentity)
+	}
+
+	if element.Group() == ecc.Edwards25519Sha512 {
+		return edwards25519ToArkworks(element), nil
+	}
+
+	uncompressed, err := encodeUncompressed(element)
+	if err != nil {
+		return nil, fmt.Errorf("arkworks: %w", err)
+	}
+
+	p := fieldPrime(element.Group())
+	n := element.Group().ElementLength() - 1
+	out := make([]byte, arkworksSWLength(p))
+	copy(out, uncompressed[1:1+n])
+	slices.Reverse(out[:n])
+
+	if isArkworksNegative(uncompressed[1+n:], p) {
+		out[len(out)-1] |= arkworksNegative
+	}
+
+	return out, nil
+}
+
+// arkworksSWLength returns the length of a compressed arkworks short Weierstrass point, i.e. the byte length of the
+// field's bits and the flags' bits.
+func arkworksSWLength(p *big.Int) int {
+	return (p.BitLen() + arkworksSWFlagBits + 7) / 8
+}
+
+func edwards25519X(element *ecc.Element) []byte {
+	x, _, z, _ := element.Edwards25519Point().ExtendedCoordinates()
+	x.Multiply(x, new(field.Element).Invert(z))
+
+	b := x.Bytes()
+	slices.Reverse(b)
+
+	return b
+}
+
+func edwards25519ToArkworks(element *ecc.Element) []byte {
+	out := element.Encode()
+
+	// The RFC 8032 sign bit is replaced with the arkworks flag.
+	out[31] &= 0x7f
+
+	if isArkworksNegative(edwards25519X(element), edwards25519Prime) {
+		out[31] |= arkworksNegative
+	}
+
+	return out
+}
+
+// edwards25519Prime is the field prime of Edwards25519, 2^255 - 19.
+var edwards25519Prime = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+
+// ElementFromArkworks returns the element of the group g deserialized from its arkworks compressed serialization, as
+// described in ElementToArkworks.
+func ElementFromArkworks(g ecc.Group, data []byte) (*ecc.Element, error) {
+	var (
+		e   *ecc.Element
+		err error
+	)
+
+	switch g {
+	case ecc.Edwards25519Sha512:
+		e, err = edwards25519FromArkworks(data)
+	case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256:
+		e, err = weierstrassFromArkworks(g, data)
+	default:
+		err = internal.ErrInvalidGroup
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("arkworks: %w", err)
+	}
+
+	return e, nil
+}
+
+func weierstrassFromArkworks(g ecc.Group, data []byte) (*ecc.Element, error) {
+	p := fieldPrime(g)
+	n := g.ElementLength() - 1
+
+	if len(data) != arkworksSWLength(p) {
+		return nil, internal.ErrParamInvalidPointEncoding
+	}
+
+	flags := data[len(data)-1] & (arkworksNegative | arkworksInfinity)
+	if flags&arkworksInfinity != 0 {
+		return nil, internal.ErrIdentity
+	}
+
+	// The x-coordinate must not overlap with the flags, and the remaining bytes must be zero.
+	rest := slices.Clone(data)
+	rest[len(rest)-1] &^= arkworksNegative | arkworksInfinity
+
+	for _, b := range rest[n:] {
+		if b != 0 {
+			return nil, internal.ErrParamInvalidPointEncoding
+		}
+	}
+
+	x := slices.Clone(rest[:n])
+	slices.Reverse(x)
+
+	e, err := ParseSEC1(g, append([]byte{0x02}, x...))
+	if err != nil {
+		return nil, err
+	}
+
+	uncompressed, err := encodeUncompressed(e)
+	if err != nil {
+		return nil, err
+	}
+
+	// The other root is selected with the odd SEC 1 prefix rather than with a negation, which is not reduced by the
+	// secp256k1 backend for decoded points.
+	if isArkworksNegative(uncompressed[1+n:], p) != (flags == arkworksNegative) {
+		return ParseSEC1(g, append([]byte{0x03}, x...))
+	}
+
+	return e, nil
+}
+
+func edwards25519FromArkworks(data []byte) (*ecc.Element, error) {
+	if len(data) != ecc.Edwards25519Sha512.ElementLength() {
+		return nil, internal.ErrParamInvalidPointEncoding
+	}
+
+	negative := data[31]&arkworksNegative != 0
+
+	encoded := slices.Clone(data)
+	encoded[31] &^= arkworksNegative
+
+	e := ecc.Edwards25519Sha512.NewElement()
+	if err := e.Decode(encoded); err != nil {
+		return nil, err
+	}
+
+	// Reject non-canonical encodings of y.
+	if check := e.Encode(); check[31]&0x7f != encoded[31] || string(check[:31]) != string(encoded[:31]) {
+		return nil, internal.ErrParamInvalidPointEncoding
+	}
+
+	if isArkworksNegative(edwards25519X(e), edwards25519Prime) != negative {
+		e.Negate()
+	}
+
+	return e, nil
+}
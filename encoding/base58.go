@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	base58Alphabet       = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base58ChecksumLength = 4
+
+	// wifCompressedSuffix follows the private key in WIF encodings whose public key is compressed.
+	wifCompressedSuffix = 0x01
+)
+
+var (
+	bigRadix58  = big.NewInt(58)
+	bigZero     = big.NewInt(0)
+	base58Index = func() (index [256]int8) {
+		for i := range index {
+			index[i] = -1
+		}
+
+		for i, c := range base58Alphabet {
+			index[c] = int8(i)
+		}
+
+		return index
+	}()
+)
+
+func base58Encode(input []byte) string {
+	x := new(big.Int).SetBytes(input)
+	mod := new(big.Int)
+	out := make([]byte, 0, len(input)*138/100+1)
+
+	for x.Cmp(bigZero) > 0 {
+		x.DivMod(x, bigRadix58, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	// Leading zero bytes are encoded as the first character of the alphabet.
+	for _, b := range input {
+		if b != 0 {
+			break
+		}
+
+		out = append(out, base58Alphabet[0])
+	}
+
+	return string(reverse(out))
+}
+
+func base58Decode(input string) ([]byte, error) {
+	if len(input) == 0 {
+		return nil, internal.ErrDecodingInvalidBase58
+	}
+
+	x := new(big.Int)
+
+	for i := 0; i < len(input); i++ {
+		v := base58Index[input[i]]
+		if v < 0 {
+			return nil, internal.ErrDecodingInvalidBase58
+		}
+
+		x.Mul(x, bigRadix58)
+		x.Add(x, big.NewInt(int64(v)))
+	}
+
+	leadingZeros := len(input) - len(strings.TrimLeft(input, base58Alphabet[:1]))
+	decoded := x.Bytes()
+	out := make([]byte, leadingZeros, leadingZeros+len(decoded))
+
+	return append(out, decoded...), nil
+}
+
+func reverse(b []byte) []byte {
+	l := len(b) - 1
+	for i := 0; i < len(b)/2; i++ {
+		b[i], b[l-i] = b[l-i], b[i]
+	}
+
+	return b
+}
+
+func base58Checksum(input []byte) []byte {
+	h := sha256.Sum256(input)
+	h = sha256.Sum256(h[:])
+
+	return h[:base58ChecksumLength]
+}
+
+// Base58CheckEncode returns the Base58Check encoding of the version byte followed by the payload, as used in Bitcoin
+// addresses and WIF private keys.
+func Base58CheckEncode(version byte, payload []byte) string {
+	b := make([]byte, 0, 1+len(payload)+base58ChecksumLength)
+	b = append(b, version)
+	b = append(b, payload...)
+	b = append(b, base58Checksum(b)...)
+
+	return base58Encode(b)
+}
+
+// Base58CheckDecode decodes the Base58Check encoded input, verifies its checksum, and returns the version byte and
+// the payload.
+func Base58CheckDecode(input string) (byte, []byte, error) {
+	decoded, err := base58Decode(input)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if len(decoded) < 1+base58ChecksumLength {
+		return 0, nil, internal.ErrDecodingInvalidLength
+	}
+
+	data, checksum := decoded[:len(decoded)-base58ChecksumLength], decoded[len(decoded)-base58ChecksumLength:]
+	if subtle.ConstantTimeCompare(checksum, base58Checksum(data)) != 1 {
+		return 0, nil, internal.ErrDecodingInvalidChecksum
+	}
+
+	return data[0], data[1:], nil
+}
+
+// ElementToBase58Check returns the Base58Check encoding of the compressed element, prefixed with the version byte.
+func ElementToBase58Check(element *ecc.Element, version byte) string {
+	return Base58CheckEncode(version, element.Encode())
+}
+
+// ElementFromBase58Check decodes the Base58Check encoded element in the group g, and returns it with its version byte.
+func ElementFromBase58Check(g ecc.Group, input string) (*ecc.Element, byte, error) {
+	version, payload, err := Base58CheckDecode(input)
+	if err != nil {
+		return nil, 0, fmt.Errorf("element Base58Check: %w", err)
+	}
+
+	e := g.NewElement()
+	if err = e.Decode(payload); err != nil {
+		return nil, 0, fmt.Errorf("element Base58Check: %w", err)
+	}
+
+	return e, version, nil
+}
+
+// ScalarToBase58Check returns the Base58Check encoding of the scalar, prefixed with the version byte. Using version
+// 0x80 on a Secp256k1Sha256 scalar yields an uncompressed WIF private key, and ScalarToWIF also encodes compressed
+// ones.
+func ScalarToBase58Check(scalar *ecc.Scalar, version byte) string {
+	return Base58CheckEncode(version, scalar.Encode())
+}
+
+// ScalarFromBase58Check decodes the Base58Check encoded scalar in the group g, and returns it with its version byte.
+// The payload must be the scalar only, so compressed WIF private keys must be decoded with ScalarFromWIF.
+func ScalarFromBase58Check(g ecc.Group, input string) (*ecc.Scalar, byte, error) {
+	version, payload, err := Base58CheckDecode(input)
+	if err != nil {
+		return nil, 0, fmt.Errorf("scalar Base58Check: %w", err)
+	}
+
+	s := g.NewScalar()
+	if err = s.Decode(payload); err != nil {
+		return nil, 0, fmt.Errorf("scalar Base58Check: %w", err)
+	}
+
+	return s, version, nil
+}
+
+// ScalarToWIF returns the Wallet Import Format encoding of the Secp256k1Sha256 scalar with the version byte, e.g. 0x80
+// for Bitcoin's mainnet and 0xef for its testnet. If compressed is set, the scalar is followed by the 0x01 suffix
+// marking that its public key is compressed, as in the keys of most wallets.
+func ScalarToWIF(scalar *ecc.Scalar, version byte, compressed bool) (string, error) {
+	if scalar == nil {
+		return "", fmt.Errorf("WIF: %w", internal.ErrParamNilScalar)
+	}
+
+	if scalar.Group() != ecc.Secp256k1Sha256 {
+		return "", fmt.Errorf("WIF: %w", internal.ErrInvalidGroup)
+	}
+
+	payload := scalar.Encode()
+	if compressed {
+		payload = append(payload, wifCompressedSuffix)
+	}
+
+	return Base58CheckEncode(version, payload), nil
+}
+
+// ScalarFromWIF decodes the Wallet Import Format encoded Secp256k1Sha256 scalar, and returns it with its version byte
+// and whether it is marked as having a compressed public key.
+func ScalarFromWIF(input string) (*ecc.Scalar, byte, bool, error) {
+	version, payload, err := Base58CheckDecode(input)
+	if err != nil {
+		return nil, 0, false, fmt.Errorf("WIF: %w", err)
+	}
+
+	g := ecc.Secp256k1Sha256
+	compressed := len(payload) == g.ScalarLength()+1
+
+	if compressed {
+		if payload[g.ScalarLength()] != wifCompressedSuffix {
+			return nil, 0, false, fmt.Errorf("WIF: %w", internal.ErrDecodingInvalidLength)
+		}
+
+		payload = payload[:g.ScalarLength()]
+	}
+
+	s := g.NewScalar()
+	if err = s.Decode(payload); err != nil {
+		return nil, 0, false, fmt.Errorf("WIF: %w", err)
+	}
+
+	return s, version, compressed, nil
+}
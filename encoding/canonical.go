@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// CanonicalJSON returns a byte-stable JSON encoding of v, suitable for signing or hashing into a transcript: object
+// keys are sorted, there is no insignificant whitespace, HTML characters are not escaped, and numbers are kept as
+// they were encoded. Scalars and Elements are encoded as lowercase fixed-length hexadecimal strings.
+func CanonicalJSON(v any) ([]byte, error) {
+	enc, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("canonical JSON: %w", err)
+	}
+
+	// Decoding into generic values and re-encoding them sorts the object keys, since maps are always encoded with
+	// sorted keys.
+	var generic any
+
+	d := json.NewDecoder(bytes.NewReader(enc))
+	d.UseNumber()
+
+	if err = d.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("canonical JSON: %w", err)
+	}
+
+	var buf bytes.Buffer
+
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+
+	if err = e.Encode(generic); err != nil {
+		return nil, fmt.Errorf("canonical JSON: %w", err)
+	}
+
+	// Encode terminates the output with a newline.
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// ContainerVersion is the latest version of the container format produced by Marshal.
+const ContainerVersion byte = 1
+
+const containerHeaderLength = 3
+
+// Type tags of the values in a container.
+const (
+	tagGroup byte = 1 + iota
+	tagScalar
+	tagElement
+)
+
+// Marshal returns the self-describing container encoding of v, which must be an ecc.Group, *ecc.Scalar, or
+// *ecc.Element. The container is in the form of version || group || type tag || payload, where the payload is the
+// value's canonical encoding, and can be decoded with Unmarshal.
+func Marshal(v any) ([]byte, error) {
+	var (
+		g       ecc.Group
+		tag     byte
+		payload []byte
+	)
+
+	switch t := v.(type) {
+	case ecc.Group:
+		if !t.Available() {
+			return nil, fmt.Errorf("container: %w", internal.ErrInvalidGroup)
+		}
+
+		g, tag = t, tagGroup
+	case *ecc.Scalar:
+		if t == nil {
+			return nil, fmt.Errorf("container: %w", internal.ErrParamNilScalar)
+		}
+
+		g, tag, payload = t.Group(), tagScalar, t.Encode()
+	case *ecc.Element:
+		if t == nil {
+			return nil, fmt.Errorf("container: %w", internal.ErrParamNilPoint)
+		}
+
+		g, tag, payload = t.Group(), tagElement, t.Encode()
+	default:
+		return nil, fmt.Errorf("container: %w: %T", internal.ErrUnsupportedType, v)
+	}
+
+	out := make([]byte, containerHeaderLength, containerHeaderLength+len(payload))
+	out[0], out[1], out[2] = ContainerVersion, byte(g), tag
+
+	return append(out, payload...), nil
+}
+
+// Unmarshal decodes the container into v, which must be a non-nil *ecc.Group, *ecc.Scalar, or *ecc.Element matching
+// the container's type tag. Scalars and elements are set to the group of the container.
+func Unmarshal(data []byte, v any) error {
+	if len(data) < containerHeaderLength {
+		return fmt.Errorf("container: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	version, g, tag, payload := data[0], ecc.Group(data[1]), data[2], data[containerHeaderLength:]
+
+	if version == 0 || version > ContainerVersion {
+		return fmt.Errorf("container: %w: got version %d, supported up to %d",
+			internal.ErrDecodingInvalidVersion, version, ContainerVersion)
+	}
+
+	if !g.Available() {
+		return fmt.Errorf("container: %w", internal.ErrInvalidGroup)
+	}
+
+	var err error
+
+	switch t := v.(type) {
+	case *ecc.Group:
+		err = unmarshalGroup(t, tag, payload, g)
+	case *ecc.Scalar:
+		err = unmarshalScalar(t, tag, payload, g)
+	case *ecc.Element:
+		err = unmarshalElement(t, tag, payload, g)
+	default:
+		err = fmt.Errorf("%w: %T", internal.ErrUnsupportedType, v)
+	}
+
+	if err != nil {
+		return fmt.Errorf("container: %w", err)
+	}
+
+	return nil
+}
+
+func checkTag(tag, expected byte, valid bool) error {
+	if !valid {
+		return fmt.Errorf("%w: nil receiver", internal.ErrUnsupportedType)
+	}
+
+	if tag != expected {
+		return fmt.Errorf("%w: unexpected type tag %d", internal.ErrUnsupportedType, tag)
+	}
+
+	return nil
+}
+
+func unmarshalGroup(v *ecc.Group, tag byte, payload []byte, g ecc.Group) error {
+	if err := checkTag(tag, tagGroup, v != nil); err != nil {
+		return err
+	}
+
+	if len(payload) != 0 {
+		return internal.ErrDecodingInvalidLength
+	}
+
+	*v = g
+
+	return nil
+}
+
+func unmarshalScalar(v *ecc.Scalar, tag byte, payload []byte, g ecc.Group) error {
+	if err := checkTag(tag, tagScalar, v != nil); err != nil {
+		return err
+	}
+
+	s := g.NewScalar()
+	if err := s.Decode(payload); err != nil {
+		return err
+	}
+
+	*v = *s
+
+	return nil
+}
+
+func unmarshalElement(v *ecc.Element, tag byte, payload []byte, g ecc.Group) error {
+	if err := checkTag(tag, tagElement, v != nil); err != nil {
+		return err
+	}
+
+	e := g.NewElement()
+	if err := e.Decode(payload); err != nil {
+		return err
+	}
+
+	*v = *e
+
+	return nil
+}
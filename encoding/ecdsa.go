@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"fmt"
+	"math/big"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// ecdsaCurve returns the elliptic.Curve of the group. The standard library has no secp256k1 implementation, so the
+// caller must provide one, e.g. from a third-party library, in which case it must match the group's parameters.
+func ecdsaCurve(g ecc.Group, curve elliptic.Curve) (elliptic.Curve, error) {
+	switch g {
+	case ecc.P256Sha256:
+		return elliptic.P256(), nil
+	case ecc.P384Sha384:
+		return elliptic.P384(), nil
+	case ecc.P521Sha512:
+		return elliptic.P521(), nil
+	case ecc.Secp256k1Sha256:
+		if curve == nil {
+			return nil, internal.ErrInvalidGroup
+		}
+
+		if c, err := groupFromCurve(curve); err != nil || c != g {
+			return nil, internal.ErrInvalidGroup
+		}
+
+		return curve, nil
+	default:
+		return nil, internal.ErrInvalidGroup
+	}
+}
+
+// groupFromCurve returns the group of the elliptic.Curve. Implementations other than those of crypto/elliptic are
+// identified by their parameters.
+func groupFromCurve(curve elliptic.Curve) (ecc.Group, error) {
+	switch curve {
+	case elliptic.P256():
+		return ecc.P256Sha256, nil
+	case elliptic.P384():
+		return ecc.P384Sha384, nil
+	case elliptic.P521():
+		return ecc.P521Sha512, nil
+	}
+
+	if curve == nil {
+		return 0, internal.ErrInvalidGroup
+	}
+
+	params := curve.Params()
+	if params == nil || params.N == nil || params.P == nil {
+		return 0, internal.ErrInvalidGroup
+	}
+
+	if params.P.Cmp(secp256k1Prime) == 0 && params.N.Cmp(new(big.Int).SetBytes(ecc.Secp256k1Sha256.Order())) == 0 {
+		return ecc.Secp256k1Sha256, nil
+	}
+
+	return 0, internal.ErrInvalidGroup
+}
+
+// ToECDSAPublicKey returns the crypto/ecdsa public key of the element over one of the NIST groups or secp256k1. The
+// curve argument is only used for secp256k1, for which the standard library has no elliptic.Curve, and must then be
+// an implementation of it (e.g. from a third-party library). It is ignored for the NIST groups and can be nil.
+func ToECDSAPublicKey(element *ecc.Element, curve elliptic.Curve) (*ecdsa.PublicKey, error) {
+	if element == nil {
+		return nil, fmt.Errorf("ecdsa: %w", internal.ErrParamNilPoint)
+	}
+
+	c, err := ecdsaCurve(element.Group(), curve)
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	q, err := encodeUncompressed(element)
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	l := (len(q) - 1) / 2
+
+	return &ecdsa.PublicKey{
+		Curve: c,
+		X:     new(big.Int).SetBytes(q[1 : 1+l]),
+		Y:     new(big.Int).SetBytes(q[1+l:]),
+	}, nil
+}
+
+// FromECDSAPublicKey returns the element of the crypto/ecdsa public key, which is validated.
+func FromECDSAPublicKey(key *ecdsa.PublicKey) (*ecc.Element, error) {
+	if key == nil || key.X == nil || key.Y == nil {
+		return nil, fmt.Errorf("ecdsa: %w", internal.ErrParamNilPoint)
+	}
+
+	g, err := groupFromCurve(key.Curve)
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	e, err := decodeAffine(g, key.X, key.Y)
+	if err != nil {
+		return nil, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	return e, nil
+}
+
+// decodeAffine returns the element with the affine coordinates, after verifying they are on the curve.
+func decodeAffine(g ecc.Group, x, y *big.Int) (*ecc.Element, error) {
+	fieldLength := g.ElementLength() - 1
+	if x.Sign() < 0 || y.Sign() < 0 || x.BitLen() > 8*fieldLength || y.BitLen() > 8*fieldLength {
+		return nil, internal.ErrParamInvalidPointEncoding
+	}
+
+	uncompressed := make([]byte, 1+2*fieldLength)
+	uncompressed[0] = 0x04
+	x.FillBytes(uncompressed[1 : 1+fieldLength])
+	y.FillBytes(uncompressed[1+fieldLength:])
+
+	compressed := make([]byte, 1+fieldLength)
+	compressed[0] = 0x02 | byte(y.Bit(0))
+	copy(compressed[1:], uncompressed[1:1+fieldLength])
+
+	e := g.NewElement()
+	if err := e.Decode(compressed); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	// The compressed encoding only carries the parity of y, so the full coordinate must be checked against the curve.
+	if check, err := encodeUncompressed(e); err != nil || string(check) != string(uncompressed) {
+		return nil, internal.ErrParamInvalidPointEncoding
+	}
+
+	return e, nil
+}
+
+// ToECDSAPrivateKey returns the crypto/ecdsa private key of the scalar over one of the NIST groups or secp256k1. See
+// ToECDSAPublicKey for the use of curve.
+func ToECDSAPrivateKey(scalar *ecc.Scalar, curve elliptic.Curve) (*ecdsa.PrivateKey, error) {
+	if scalar == nil || scalar.IsZero() {
+		return nil, fmt.Errorf("ecdsa: %w", internal.ErrParamNilScalar)
+	}
+
+	pub, err := ToECDSAPublicKey(scalar.Group().Base().Multiply(scalar), curve)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: *pub,
+		D:         new(big.Int).SetBytes(scalar.Encode()),
+	}, nil
+}
+
+// FromECDSAPrivateKey returns the scalar and group of the crypto/ecdsa private key. If the key has a public key, it
+// must match the private key.
+func FromECDSAPrivateKey(key *ecdsa.PrivateKey) (*ecc.Scalar, ecc.Group, error) {
+	if key == nil || key.D == nil {
+		return nil, 0, fmt.Errorf("ecdsa: %w", internal.ErrParamNilScalar)
+	}
+
+	g, err := groupFromCurve(key.Curve)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	if key.D.Sign() <= 0 || key.D.BitLen() > 8*g.ScalarLength() {
+		return nil, 0, fmt.Errorf("ecdsa: %w", internal.ErrParamScalarInvalidEncoding)
+	}
+
+	s := g.NewScalar()
+	if err = s.Decode(key.D.FillBytes(make([]byte, g.ScalarLength()))); err != nil {
+		return nil, 0, fmt.Errorf("ecdsa: %w", err)
+	}
+
+	if key.X != nil || key.Y != nil {
+		pub, err := FromECDSAPublicKey(&key.PublicKey)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if !pub.Equal(g.Base().Multiply(s)) {
+			return nil, 0, fmt.Errorf("ecdsa: %w", internal.ErrParamInvalidPointEncoding)
+		}
+	}
+
+	return s, g, nil
+}
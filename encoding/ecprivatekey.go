@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	pemECPrivateKey = "EC PRIVATE KEY"
+	pemECParameters = "EC PARAMETERS"
+)
+
+// MarshalECPrivateKey returns the DER encoded SEC 1 (RFC 5915) ECPrivateKey of the scalar, i.e. the "EC PRIVATE KEY"
+// of OpenSSL, with the named curve and the uncompressed public key. It supports P256Sha256, P384Sha384, P521Sha512,
+// and Secp256k1Sha256 scalars.
+func MarshalECPrivateKey(scalar *ecc.Scalar) ([]byte, error) {
+	if scalar == nil || scalar.IsZero() {
+		return nil, fmt.Errorf("sec1: %w", internal.ErrParamNilScalar)
+	}
+
+	g := scalar.Group()
+
+	oid, err := namedCurveOID(g)
+	if err != nil {
+		return nil, fmt.Errorf("sec1: %w", err)
+	}
+
+	publicKey, err := encodeUncompressed(g.Base().Multiply(scalar))
+	if err != nil {
+		return nil, fmt.Errorf("sec1: %w", err)
+	}
+
+	der, err := asn1.Marshal(ecPrivateKey{
+		Version:       ecPrivateKeyVersion,
+		PrivateKey:    scalar.Encode(),
+		NamedCurveOID: oid,
+		PublicKey:     asn1.BitString{Bytes: publicKey, BitLength: 8 * len(publicKey)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("sec1: %w", err)
+	}
+
+	return der, nil
+}
+
+// ParseECPrivateKey parses a DER encoded SEC 1 (RFC 5915) ECPrivateKey, and returns the scalar and its group. The
+// named curve is required, and the embedded public key, if any, must be the scalar's.
+func ParseECPrivateKey(der []byte) (*ecc.Scalar, ecc.Group, error) {
+	var key ecPrivateKey
+	if rest, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, 0, fmt.Errorf("sec1: %w", err)
+	} else if len(rest) != 0 {
+		return nil, 0, fmt.Errorf("sec1: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	g, err := groupFromNamedCurveOID(key.NamedCurveOID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("sec1: %w", err)
+	}
+
+	encoded, err := decodeECPrivateKey(g, &key)
+	if err != nil {
+		return nil, 0, fmt.Errorf("sec1: %w", err)
+	}
+
+	s := g.NewScalar()
+	if err = s.Decode(encoded); err != nil {
+		return nil, 0, fmt.Errorf("sec1: %w", err)
+	}
+
+	if s.IsZero() {
+		return nil, 0, fmt.Errorf("sec1: %w", internal.ErrParamNilScalar)
+	}
+
+	return s, g, nil
+}
+
+// ParseECPrivateKeyPEM parses the first "EC PRIVATE KEY" PEM block of the input, as output by
+// "openssl ecparam -genkey", and returns the scalar and its group. Preceding "EC PARAMETERS" blocks are skipped.
+func ParseECPrivateKeyPEM(data []byte) (*ecc.Scalar, ecc.Group, error) {
+	for {
+		var block *pem.Block
+		if block, data = pem.Decode(data); block == nil {
+			return nil, 0, fmt.Errorf("sec1: %w", internal.ErrParamNilScalar)
+		}
+
+		switch block.Type {
+		case pemECParameters:
+			continue
+		case pemECPrivateKey:
+			return ParseECPrivateKey(block.Bytes)
+		default:
+			return nil, 0, fmt.Errorf("sec1: %w", internal.ErrUnsupportedType)
+		}
+	}
+}
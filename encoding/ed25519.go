@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+
+	ed "filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// ToEd25519PublicKey returns the Ed25519 public key of the Edwards25519 element. Both share the same RFC 8032
+// encoding.
+func ToEd25519PublicKey(element *ecc.Element) (ed25519.PublicKey, error) {
+	if element == nil {
+		return nil, fmt.Errorf("ed25519: %w", internal.ErrParamNilPoint)
+	}
+
+	if element.Group() != ecc.Edwards25519Sha512 {
+		return nil, fmt.Errorf("ed25519: %w", internal.ErrInvalidGroup)
+	}
+
+	if element.IsIdentity() {
+		return nil, fmt.Errorf("ed25519: %w", internal.ErrIdentity)
+	}
+
+	return element.Encode(), nil
+}
+
+// FromEd25519PublicKey returns the Edwards25519 element of the Ed25519 public key.
+func FromEd25519PublicKey(key ed25519.PublicKey) (*ecc.Element, error) {
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("ed25519: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	e := ecc.Edwards25519Sha512.NewElement()
+	if err := e.Decode(key); err != nil {
+		return nil, fmt.Errorf("ed25519: %w", err)
+	}
+
+	return e, nil
+}
+
+// ScalarFromEd25519PrivateKey returns the Edwards25519 secret scalar of the Ed25519 private key, i.e. the clamped
+// lower half of the SHA-512 hash of the seed, reduced modulo the group order (RFC 8032 section 5.1.5). Multiplying the
+// base element by this scalar yields the key's public key, so the same identity can be used in other protocols.
+//
+// The upper half of the hash, the signing prefix, is discarded, so this scalar can't be used to produce Ed25519
+// signatures compatible with the original key.
+func ScalarFromEd25519PrivateKey(key ed25519.PrivateKey) (*ecc.Scalar, error) {
+	if len(key) != ed25519.PrivateKeySize {
+		return nil, fmt.Errorf("ed25519: %w", internal.ErrParamScalarLength)
+	}
+
+	h := sha512.Sum512(key.Seed())
+
+	clamped, err := ed.NewScalar().SetBytesWithClamping(h[:32])
+	if err != nil {
+		return nil, fmt.Errorf("ed25519: %w", err)
+	}
+
+	s := ecc.Edwards25519Sha512.NewScalar()
+	if err = s.Decode(clamped.Bytes()); err != nil {
+		return nil, fmt.Errorf("ed25519: %w", err)
+	}
+
+	// The private key embeds its public key, which must match the seed.
+	pub := ecc.Edwards25519Sha512.Base().Multiply(s).Encode()
+	if subtle.ConstantTimeCompare(pub, key[ed25519.SeedSize:]) != 1 {
+		return nil, fmt.Errorf("ed25519: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	return s, nil
+}
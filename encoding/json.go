@@ -12,12 +12,34 @@ package encoding
 import (
 	"fmt"
 	"regexp"
+	"slices"
 	"strconv"
 
 	"github.com/bytemare/ecc"
 	"github.com/bytemare/ecc/internal"
 )
 
+const (
+	jsonCatchGroup = `(\w+)`
+	jsonCatchHex   = `"([^"]*)"`
+)
+
+// FieldError reports a failure to extract a given field from a JSON encoding.
+type FieldError struct {
+	Err error
+	Key string
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("JSON field %q: %s", e.Key, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
 func jsonReGetField(key, s, catch string) (string, error) {
 	r := fmt.Sprintf(`%q:%s`, regexp.QuoteMeta(key), catch)
 	re := regexp.MustCompile(r)
@@ -30,9 +52,8 @@ func jsonReGetField(key, s, catch string) (string, error) {
 	return matches[1], nil
 }
 
-// JSONReGetGroup attempts to find the group JSON encoding in s.
-func JSONReGetGroup(s string) (ecc.Group, error) {
-	f, err := jsonReGetField("group", s, `(\w+)`)
+func jsonReGetGroup(key, s string) (ecc.Group, error) {
+	f, err := jsonReGetField(key, s, jsonCatchGroup)
 	if err != nil {
 		return 0, err
 	}
@@ -53,3 +74,107 @@ func JSONReGetGroup(s string) (ecc.Group, error) {
 
 	return c, nil
 }
+
+// JSONReGetGroup attempts to find the group JSON encoding in s.
+func JSONReGetGroup(s string) (ecc.Group, error) {
+	return jsonReGetGroup("group", s)
+}
+
+// JSONUnmarshalInto extracts the fields of the JSON encoding s designated by the keys, and decodes each into the
+// associated value, which must be a non-nil *ecc.Group, *ecc.Scalar, or *ecc.Element. Groups are read as integers,
+// scalars and elements as hexadecimal strings.
+//
+// A scalar or element that is not yet set to a group is set to the group found in the other fields, which must then
+// all agree. Any failure is reported as a *FieldError for the first offending key in lexicographic order.
+func JSONUnmarshalInto(s string, keys map[string]any) error {
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+
+	slices.Sort(sorted)
+
+	// Groups are decoded first, since scalars and elements might depend on them.
+	var found ecc.Group
+
+	ambiguous := false
+
+	for _, key := range sorted {
+		v, ok := keys[key].(*ecc.Group)
+		if !ok {
+			continue
+		}
+
+		if v == nil {
+			return &FieldError{Key: key, Err: internal.ErrUnsupportedType}
+		}
+
+		g, err := jsonReGetGroup(key, s)
+		if err != nil {
+			return &FieldError{Key: key, Err: err}
+		}
+
+		*v = g
+		ambiguous = ambiguous || (found != 0 && found != g)
+		found = g
+	}
+
+	if ambiguous {
+		found = 0
+	}
+
+	for _, key := range sorted {
+		if err := jsonUnmarshalField(s, key, keys[key], found); err != nil {
+			return &FieldError{Key: key, Err: err}
+		}
+	}
+
+	return nil
+}
+
+func jsonUnmarshalField(s, key string, v any, g ecc.Group) error {
+	switch t := v.(type) {
+	case *ecc.Group:
+		return nil
+	case *ecc.Scalar:
+		if t == nil {
+			return internal.ErrUnsupportedType
+		}
+
+		if t.Scalar == nil {
+			if g == 0 {
+				return internal.ErrInvalidGroup
+			}
+
+			*t = *g.NewScalar()
+		}
+
+		h, err := jsonReGetField(key, s, jsonCatchHex)
+		if err != nil {
+			return err
+		}
+
+		return t.DecodeHex(h)
+	case *ecc.Element:
+		if t == nil {
+			return internal.ErrUnsupportedType
+		}
+
+		if t.Element == nil {
+			if g == 0 {
+				return internal.ErrInvalidGroup
+			}
+
+			*t = *g.NewElement()
+		}
+
+		h, err := jsonReGetField(key, s, jsonCatchHex)
+		if err != nil {
+			return err
+		}
+
+		return t.DecodeHex(h)
+	default:
+		return internal.ErrUnsupportedType
+	}
+}
@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"encoding/asn1"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+var (
+	oidPublicKeyECDSA   = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidPublicKeyEd25519 = ecc.Edwards25519Sha512.OID()
+
+	weierstrassGroups = []ecc.Group{ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256}
+)
+
+// namedCurveOID returns the SEC 2 named curve object identifier of the Weierstrass group g.
+func namedCurveOID(g ecc.Group) (asn1.ObjectIdentifier, error) {
+	for _, w := range weierstrassGroups {
+		if g == w {
+			return g.OID(), nil
+		}
+	}
+
+	return nil, internal.ErrInvalidGroup
+}
+
+// groupFromNamedCurveOID returns the Weierstrass group identified by the SEC 2 named curve object identifier.
+func groupFromNamedCurveOID(oid asn1.ObjectIdentifier) (ecc.Group, error) {
+	for _, g := range weierstrassGroups {
+		if oid.Equal(g.OID()) {
+			return g, nil
+		}
+	}
+
+	return 0, internal.ErrInvalidGroup
+}
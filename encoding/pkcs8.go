@@ -0,0 +1,232 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"crypto/ed25519"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const ecPrivateKeyVersion = 1
+
+// pkcs8 reflects an ASN.1, PKCS #8 PrivateKey (RFC 5208).
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// ecPrivateKey reflects an ASN.1 Elliptic Curve Private Key Structure (RFC 5915).
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// MarshalPKCS8 returns the DER encoded PKCS #8 PrivateKeyInfo of the scalar in the group g.
+//
+// P256Sha256, P384Sha384, P521Sha512, and Secp256k1Sha256 scalars are wrapped in an RFC 5915 ECPrivateKey together
+// with the compressed public key. Edwards25519Sha512 scalars are not supported, as the RFC 8410 Ed25519 private key is
+// a seed that standard tooling hashes and clamps into the scalar: use MarshalEd25519PKCS8 with the seed instead.
+// Ristretto255Sha512 has no registered algorithm identifier and is not supported.
+func MarshalPKCS8(scalar *ecc.Scalar, g ecc.Group) ([]byte, error) {
+	if scalar == nil {
+		return nil, fmt.Errorf("pkcs8: %w", internal.ErrParamNilScalar)
+	}
+
+	if scalar.Group() != g {
+		return nil, fmt.Errorf("pkcs8: %w", internal.ErrCastScalar)
+	}
+
+	algo, privateKey, err := marshalECPrivateKey(scalar, g)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs8: %w", err)
+	}
+
+	return marshalPKCS8(algo, privateKey)
+}
+
+// MarshalEd25519PKCS8 returns the DER encoded RFC 8410 PKCS #8 PrivateKeyInfo of the Ed25519 private key of the
+// 32-byte seed, as crypto/x509.MarshalPKCS8PrivateKey does for ed25519.NewKeyFromSeed(seed).
+func MarshalEd25519PKCS8(seed []byte) ([]byte, error) {
+	if len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("pkcs8: %w", internal.ErrParamScalarLength)
+	}
+
+	privateKey, err := asn1.Marshal(seed)
+	if err != nil {
+		return nil, fmt.Errorf("pkcs8: %w", err)
+	}
+
+	return marshalPKCS8(pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyEd25519}, privateKey)
+}
+
+func marshalPKCS8(algo pkix.AlgorithmIdentifier, privateKey []byte) ([]byte, error) {
+	der, err := asn1.Marshal(pkcs8{Algo: algo, PrivateKey: privateKey})
+	if err != nil {
+		return nil, fmt.Errorf("pkcs8: %w", err)
+	}
+
+	return der, nil
+}
+
+func marshalECPrivateKey(scalar *ecc.Scalar, g ecc.Group) (pkix.AlgorithmIdentifier, []byte, error) {
+	oid, err := namedCurveOID(g)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, err
+	}
+
+	params, err := asn1.Marshal(oid)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, fmt.Errorf("%w", err)
+	}
+
+	publicKey := g.Base().Multiply(scalar).Encode()
+
+	// The curve is given by the algorithm identifier, and is therefore omitted here as per RFC 5915.
+	der, err := asn1.Marshal(ecPrivateKey{
+		Version:    ecPrivateKeyVersion,
+		PrivateKey: scalar.Encode(),
+		PublicKey:  asn1.BitString{Bytes: publicKey, BitLength: 8 * len(publicKey)},
+	})
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, nil, fmt.Errorf("%w", err)
+	}
+
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  oidPublicKeyECDSA,
+		Parameters: asn1.RawValue{FullBytes: params},
+	}, der, nil
+}
+
+// ParsePKCS8 parses a DER encoded PKCS #8 PrivateKeyInfo, and returns the scalar and its group. For RFC 8410 Ed25519
+// keys, the scalar is the secret scalar derived from the seed, as with ScalarFromEd25519PrivateKey, whose public key
+// is that of standard tooling. The seed itself is returned by crypto/x509.ParsePKCS8PrivateKey.
+func ParsePKCS8(der []byte) (*ecc.Scalar, ecc.Group, error) {
+	var key pkcs8
+	if rest, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, 0, fmt.Errorf("pkcs8: %w", err)
+	} else if len(rest) != 0 {
+		return nil, 0, fmt.Errorf("pkcs8: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	var (
+		g       ecc.Group
+		encoded []byte
+		err     error
+	)
+
+	switch {
+	case key.Algo.Algorithm.Equal(oidPublicKeyEd25519):
+		return parseEd25519PrivateKey(key.PrivateKey)
+	case key.Algo.Algorithm.Equal(oidPublicKeyECDSA):
+		g, encoded, err = parseECPrivateKey(key.Algo.Parameters.FullBytes, key.PrivateKey)
+	default:
+		err = internal.ErrInvalidGroup
+	}
+
+	if err != nil {
+		return nil, 0, fmt.Errorf("pkcs8: %w", err)
+	}
+
+	s := g.NewScalar()
+	if err = s.Decode(encoded); err != nil {
+		return nil, 0, fmt.Errorf("pkcs8: %w", err)
+	}
+
+	return s, g, nil
+}
+
+// parseEd25519PrivateKey returns the secret scalar of the RFC 8410 CurvePrivateKey holding an Ed25519 seed.
+func parseEd25519PrivateKey(der []byte) (*ecc.Scalar, ecc.Group, error) {
+	var seed []byte
+	if rest, err := asn1.Unmarshal(der, &seed); err != nil || len(rest) != 0 || len(seed) != ed25519.SeedSize {
+		return nil, 0, fmt.Errorf("pkcs8: %w", internal.ErrParamScalarLength)
+	}
+
+	s, err := ScalarFromEd25519PrivateKey(ed25519.NewKeyFromSeed(seed))
+	if err != nil {
+		return nil, 0, fmt.Errorf("pkcs8: %w", err)
+	}
+
+	return s, ecc.Edwards25519Sha512, nil
+}
+
+func parseECPrivateKey(params, der []byte) (ecc.Group, []byte, error) {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(params, &oid); err != nil {
+		return 0, nil, fmt.Errorf("%w", err)
+	}
+
+	g, err := groupFromNamedCurveOID(oid)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var key ecPrivateKey
+	if _, err = asn1.Unmarshal(der, &key); err != nil {
+		return 0, nil, fmt.Errorf("%w", err)
+	}
+
+	// The inner curve is optional, but must be the same if present.
+	if len(key.NamedCurveOID) != 0 && !key.NamedCurveOID.Equal(oid) {
+		return 0, nil, internal.ErrInvalidGroup
+	}
+
+	encoded, err := decodeECPrivateKey(g, &key)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return g, encoded, nil
+}
+
+// decodeECPrivateKey returns the fixed-length scalar encoding of the RFC 5915 private key in the group g, after
+// checking that the embedded public key, if any, is the private key's.
+func decodeECPrivateKey(g ecc.Group, key *ecPrivateKey) ([]byte, error) {
+	if key.Version != ecPrivateKeyVersion {
+		return nil, internal.ErrDecodingInvalidVersion
+	}
+
+	if len(key.PrivateKey) > g.ScalarLength() {
+		return nil, internal.ErrParamScalarLength
+	}
+
+	// Some encoders strip leading zeros from the private key, so we restore them.
+	encoded := key.PrivateKey
+	if l := g.ScalarLength() - len(encoded); l > 0 {
+		encoded = append(make([]byte, l, g.ScalarLength()), encoded...)
+	}
+
+	if len(key.PublicKey.Bytes) == 0 {
+		return encoded, nil
+	}
+
+	s := g.NewScalar()
+	if err := s.Decode(encoded); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	public, err := ParseSEC1(g, key.PublicKey.RightAlign())
+	if err != nil {
+		return nil, err
+	}
+
+	if !public.Equal(g.Base().Multiply(s)) {
+		return nil, internal.ErrPublicKeyMismatch
+	}
+
+	return encoded, nil
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"fmt"
+	"math/big"
+
+	"filippo.io/nistec"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// secp256k1Prime is the field prime of secp256k1, 2^256 - 2^32 - 977.
+var secp256k1Prime, _ = new(big.Int).SetString(
+	"fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+
+// encodeUncompressed returns the SEC 1 uncompressed encoding (0x04 || x || y) of the element over a Weierstrass curve.
+func encodeUncompressed(e *ecc.Element) ([]byte, error) {
+	if e.IsIdentity() {
+		return nil, internal.ErrIdentity
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+
+	switch e.Group() {
+	case ecc.P256Sha256:
+		var p *nistec.P256Point
+		if p, err = nistec.NewP256Point().SetBytes(e.Encode()); err == nil {
+			b = p.Bytes()
+		}
+	case ecc.P384Sha384:
+		var p *nistec.P384Point
+		if p, err = nistec.NewP384Point().SetBytes(e.Encode()); err == nil {
+			b = p.Bytes()
+		}
+	case ecc.P521Sha512:
+		var p *nistec.P521Point
+		if p, err = nistec.NewP521Point().SetBytes(e.Encode()); err == nil {
+			b = p.Bytes()
+		}
+	case ecc.Secp256k1Sha256:
+		b = decompressSecp256k1(e.Encode())
+	default:
+		return nil, internal.ErrInvalidGroup
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	return b, nil
+}
+
+// decompressSecp256k1 returns the uncompressed encoding of the valid compressed secp256k1 point. Since p = 3 mod 4,
+// the square root of y^2 = x^3 + 7 is (x^3 + 7)^((p+1)/4). This runs in variable time, and must only be used on
+// public values.
+func decompressSecp256k1(compressed []byte) []byte {
+	x := new(big.Int).SetBytes(compressed[1:])
+
+	y := new(big.Int).Exp(x, big.NewInt(3), secp256k1Prime)
+	y.Add(y, big.NewInt(7))
+
+	exp := new(big.Int).Add(secp256k1Prime, big.NewInt(1))
+	y.Exp(y, exp.Rsh(exp, 2), secp256k1Prime)
+
+	if y.Bit(0) != uint(compressed[0]&1) {
+		y.Sub(secp256k1Prime, y)
+	}
+
+	out := make([]byte, 1+2*len(compressed[1:]))
+	out[0] = 0x04
+	x.FillBytes(out[1 : 1+len(compressed[1:])])
+	y.FillBytes(out[1+len(compressed[1:]):])
+
+	return out
+}
@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// The btcec/v2 PublicKey and PrivateKey types are aliases of those of github.com/decred/dcrd/dcrec/secp256k1/v4,
+// which are used here to avoid depending on btcd.
+
+// MarshalUncompressed returns the SEC 1 uncompressed encoding (0x04 || x || y) of the element over a Weierstrass
+// group. For secp256k1, this is the 65-byte public key format used by Ethereum.
+func MarshalUncompressed(element *ecc.Element) ([]byte, error) {
+	if element == nil {
+		return nil, fmt.Errorf("sec1: %w", internal.ErrParamNilPoint)
+	}
+
+	b, err := encodeUncompressed(element)
+	if err != nil {
+		return nil, fmt.Errorf("sec1: %w", err)
+	}
+
+	return b, nil
+}
+
+// ParseSEC1 returns the element of the Weierstrass group g decoded from its SEC 1 compressed or uncompressed
+// encoding, e.g. the 33 and 65-byte secp256k1 public key formats used by Bitcoin and Ethereum.
+func ParseSEC1(g ecc.Group, data []byte) (*ecc.Element, error) {
+	if _, err := namedCurveOID(g); err != nil {
+		return nil, fmt.Errorf("sec1: %w", err)
+	}
+
+	fieldLength := g.ElementLength() - 1
+
+	switch {
+	case len(data) == g.ElementLength():
+		e := g.NewElement()
+		if err := e.Decode(data); err != nil {
+			return nil, fmt.Errorf("sec1: %w", err)
+		}
+
+		return e, nil
+	case len(data) == 1+2*fieldLength && data[0] == 0x04:
+		x := new(big.Int).SetBytes(data[1 : 1+fieldLength])
+		y := new(big.Int).SetBytes(data[1+fieldLength:])
+
+		e, err := decodeAffine(g, x, y)
+		if err != nil {
+			return nil, fmt.Errorf("sec1: %w", err)
+		}
+
+		return e, nil
+	default:
+		return nil, fmt.Errorf("sec1: %w", internal.ErrParamInvalidPointEncoding)
+	}
+}
+
+// ToBtcecPublicKey returns the btcec/v2 (and dcrd) public key of the secp256k1 element.
+func ToBtcecPublicKey(element *ecc.Element) (*secp256k1.PublicKey, error) {
+	if element == nil {
+		return nil, fmt.Errorf("btcec: %w", internal.ErrParamNilPoint)
+	}
+
+	if element.Group() != ecc.Secp256k1Sha256 {
+		return nil, fmt.Errorf("btcec: %w", internal.ErrInvalidGroup)
+	}
+
+	if element.IsIdentity() {
+		return nil, fmt.Errorf("btcec: %w", internal.ErrIdentity)
+	}
+
+	key, err := secp256k1.ParsePubKey(element.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("btcec: %w", err)
+	}
+
+	return key, nil
+}
+
+// FromBtcecPublicKey returns the secp256k1 element of the btcec/v2 (and dcrd) public key.
+func FromBtcecPublicKey(key *secp256k1.PublicKey) (*ecc.Element, error) {
+	if key == nil {
+		return nil, fmt.Errorf("btcec: %w", internal.ErrParamNilPoint)
+	}
+
+	e := ecc.Secp256k1Sha256.NewElement()
+	if err := e.Decode(key.SerializeCompressed()); err != nil {
+		return nil, fmt.Errorf("btcec: %w", err)
+	}
+
+	return e, nil
+}
+
+// ToBtcecPrivateKey returns the btcec/v2 (and dcrd) private key of the secp256k1 scalar.
+func ToBtcecPrivateKey(scalar *ecc.Scalar) (*secp256k1.PrivateKey, error) {
+	if scalar == nil || scalar.IsZero() {
+		return nil, fmt.Errorf("btcec: %w", internal.ErrParamNilScalar)
+	}
+
+	if scalar.Group() != ecc.Secp256k1Sha256 {
+		return nil, fmt.Errorf("btcec: %w", internal.ErrInvalidGroup)
+	}
+
+	return secp256k1.PrivKeyFromBytes(scalar.Encode()), nil
+}
+
+// FromBtcecPrivateKey returns the secp256k1 scalar of the btcec/v2 (and dcrd) private key.
+func FromBtcecPrivateKey(key *secp256k1.PrivateKey) (*ecc.Scalar, error) {
+	if key == nil || key.Key.IsZero() {
+		return nil, fmt.Errorf("btcec: %w", internal.ErrParamNilScalar)
+	}
+
+	s := ecc.Secp256k1Sha256.NewScalar()
+	if err := s.Decode(key.Serialize()); err != nil {
+		return nil, fmt.Errorf("btcec: %w", err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	sshKeyTypeEd25519   = "ssh-ed25519"
+	sshKeyTypeNistP256  = "ecdsa-sha2-nistp256"
+	sshKeyTypeNistP384  = "ecdsa-sha2-nistp384"
+	sshKeyTypeNistP521  = "ecdsa-sha2-nistp521"
+	sshCurveNistP256    = "nistp256"
+	sshCurveNistP384    = "nistp384"
+	sshCurveNistP521    = "nistp521"
+	sshStringLengthSize = 4
+)
+
+func sshKeyType(g ecc.Group) (keyType, curve string, err error) {
+	switch g {
+	case ecc.P256Sha256:
+		return sshKeyTypeNistP256, sshCurveNistP256, nil
+	case ecc.P384Sha384:
+		return sshKeyTypeNistP384, sshCurveNistP384, nil
+	case ecc.P521Sha512:
+		return sshKeyTypeNistP521, sshCurveNistP521, nil
+	case ecc.Edwards25519Sha512:
+		return sshKeyTypeEd25519, "", nil
+	default:
+		return "", "", internal.ErrInvalidGroup
+	}
+}
+
+func sshGroup(keyType string) (g ecc.Group, curve string, err error) {
+	switch keyType {
+	case sshKeyTypeNistP256:
+		return ecc.P256Sha256, sshCurveNistP256, nil
+	case sshKeyTypeNistP384:
+		return ecc.P384Sha384, sshCurveNistP384, nil
+	case sshKeyTypeNistP521:
+		return ecc.P521Sha512, sshCurveNistP521, nil
+	case sshKeyTypeEd25519:
+		return ecc.Edwards25519Sha512, "", nil
+	default:
+		return 0, "", internal.ErrInvalidGroup
+	}
+}
+
+func sshAppendString(b, s []byte) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+	return append(b, s...)
+}
+
+func sshReadString(b []byte) (s, rest []byte, err error) {
+	if len(b) < sshStringLengthSize {
+		return nil, nil, internal.ErrDecodingInvalidLength
+	}
+
+	l := binary.BigEndian.Uint32(b)
+	if uint64(len(b)-sshStringLengthSize) < uint64(l) {
+		return nil, nil, internal.ErrDecodingInvalidLength
+	}
+
+	b = b[sshStringLengthSize:]
+
+	return b[:l], b[l:], nil
+}
+
+// MarshalSSHPublicKey returns the SSH wire format encoding (RFC 4253, RFC 5656, and RFC 8709) of the element as a
+// public key. Only P256Sha256, P384Sha384, P521Sha512, and Edwards25519Sha512 elements have an SSH key type.
+func MarshalSSHPublicKey(element *ecc.Element) ([]byte, error) {
+	if element == nil {
+		return nil, fmt.Errorf("ssh: %w", internal.ErrParamNilPoint)
+	}
+
+	keyType, curve, err := sshKeyType(element.Group())
+	if err != nil {
+		return nil, fmt.Errorf("ssh: %w", err)
+	}
+
+	out := sshAppendString(nil, []byte(keyType))
+
+	if element.Group() == ecc.Edwards25519Sha512 {
+		return sshAppendString(out, element.Encode()), nil
+	}
+
+	q, err := encodeUncompressed(element)
+	if err != nil {
+		return nil, fmt.Errorf("ssh: %w", err)
+	}
+
+	out = sshAppendString(out, []byte(curve))
+
+	return sshAppendString(out, q), nil
+}
+
+// ParseSSHPublicKey parses an SSH wire format encoded public key, and returns it as an element.
+func ParseSSHPublicKey(wire []byte) (*ecc.Element, error) {
+	keyType, rest, err := sshReadString(wire)
+	if err != nil {
+		return nil, fmt.Errorf("ssh: %w", err)
+	}
+
+	g, curve, err := sshGroup(string(keyType))
+	if err != nil {
+		return nil, fmt.Errorf("ssh: %w", err)
+	}
+
+	if g != ecc.Edwards25519Sha512 {
+		var c []byte
+		if c, rest, err = sshReadString(rest); err != nil {
+			return nil, fmt.Errorf("ssh: %w", err)
+		}
+
+		if string(c) != curve {
+			return nil, fmt.Errorf("ssh: %w", internal.ErrInvalidGroup)
+		}
+	}
+
+	key, rest, err := sshReadString(rest)
+	if err != nil {
+		return nil, fmt.Errorf("ssh: %w", err)
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("ssh: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	e := g.NewElement()
+	if err = e.Decode(key); err != nil {
+		return nil, fmt.Errorf("ssh: %w", err)
+	}
+
+	return e, nil
+}
+
+// MarshalAuthorizedKey returns the element as a public key in the OpenSSH authorized_keys format, with an optional
+// trailing comment.
+func MarshalAuthorizedKey(element *ecc.Element, comment string) ([]byte, error) {
+	wire, err := MarshalSSHPublicKey(element)
+	if err != nil {
+		return nil, err
+	}
+
+	keyType, _, _ := sshKeyType(element.Group())
+
+	out := make([]byte, 0, len(keyType)+base64.StdEncoding.EncodedLen(len(wire))+len(comment)+3)
+	out = append(out, keyType...)
+	out = append(out, ' ')
+	out = base64.StdEncoding.AppendEncode(out, wire)
+
+	if comment != "" {
+		out = append(out, ' ')
+		out = append(out, comment...)
+	}
+
+	return append(out, '\n'), nil
+}
+
+// ParseAuthorizedKey parses a single public key in the OpenSSH authorized_keys format, and returns the element and
+// the trailing comment, if any. Key options preceding the key type are not supported.
+func ParseAuthorizedKey(line []byte) (*ecc.Element, string, error) {
+	fields := bytes.Fields(line)
+	if len(fields) < 2 {
+		return nil, "", fmt.Errorf("ssh: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	wire, err := base64.StdEncoding.DecodeString(string(fields[1]))
+	if err != nil {
+		return nil, "", fmt.Errorf("ssh: %w", err)
+	}
+
+	e, err := ParseSSHPublicKey(wire)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// The key type in the clear must match the one in the wire encoding.
+	if keyType, _, _ := sshKeyType(e.Group()); keyType != string(fields[0]) {
+		return nil, "", fmt.Errorf("ssh: %w", internal.ErrInvalidGroup)
+	}
+
+	return e, string(bytes.Join(fields[2:], []byte{' '})), nil
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const streamLengthPrefixSize = 2
+
+// A Writer streams scalars and elements of a group to an underlying io.Writer, each value being prefixed with its
+// 2-byte big-endian encoding length. Wrap the io.Writer in a bufio.Writer when writing many small values.
+type Writer struct {
+	w     io.Writer
+	buf   []byte
+	group ecc.Group
+}
+
+// NewWriter returns a new Writer for values of the group g.
+func NewWriter(w io.Writer, g ecc.Group) *Writer {
+	return &Writer{
+		w:     w,
+		buf:   make([]byte, 0, streamLengthPrefixSize+max(g.ScalarLength(), g.ElementLength())),
+		group: g,
+	}
+}
+
+// Group returns the group of the values written by w.
+func (w *Writer) Group() ecc.Group {
+	return w.group
+}
+
+func (w *Writer) write(encoded []byte) error {
+	w.buf = binary.BigEndian.AppendUint16(w.buf[:0], uint16(len(encoded)))
+	w.buf = append(w.buf, encoded...)
+
+	if _, err := w.w.Write(w.buf); err != nil {
+		return fmt.Errorf("stream: %w", err)
+	}
+
+	return nil
+}
+
+// WriteScalar writes the length-prefixed encoding of the scalar.
+func (w *Writer) WriteScalar(scalar *ecc.Scalar) error {
+	if scalar == nil {
+		return fmt.Errorf("stream: %w", internal.ErrParamNilScalar)
+	}
+
+	if scalar.Group() != w.group {
+		return fmt.Errorf("stream: %w", internal.ErrCastScalar)
+	}
+
+	return w.write(scalar.Encode())
+}
+
+// WriteElement writes the length-prefixed encoding of the element.
+func (w *Writer) WriteElement(element *ecc.Element) error {
+	if element == nil {
+		return fmt.Errorf("stream: %w", internal.ErrParamNilPoint)
+	}
+
+	if element.Group() != w.group {
+		return fmt.Errorf("stream: %w", internal.ErrCastElement)
+	}
+
+	return w.write(element.Encode())
+}
+
+// A Reader reads the scalars and elements written by a Writer from an underlying io.Reader.
+type Reader struct {
+	r     io.Reader
+	buf   []byte
+	group ecc.Group
+}
+
+// NewReader returns a new Reader for values of the group g.
+func NewReader(r io.Reader, g ecc.Group) *Reader {
+	return &Reader{
+		r:     r,
+		buf:   make([]byte, streamLengthPrefixSize+max(g.ScalarLength(), g.ElementLength())),
+		group: g,
+	}
+}
+
+// Group returns the group of the values read by r.
+func (r *Reader) Group() ecc.Group {
+	return r.group
+}
+
+// read returns the next value encoding of the expected length. It returns io.EOF if the stream ends cleanly before
+// the value, and io.ErrUnexpectedEOF if it ends in the middle of it.
+func (r *Reader) read(length int) ([]byte, error) {
+	prefix := r.buf[:streamLengthPrefixSize]
+	if _, err := io.ReadFull(r.r, prefix); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		return nil, fmt.Errorf("stream: %w", err)
+	}
+
+	if int(binary.BigEndian.Uint16(prefix)) != length {
+		return nil, fmt.Errorf("stream: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	encoded := r.buf[streamLengthPrefixSize : streamLengthPrefixSize+length]
+	if _, err := io.ReadFull(r.r, encoded); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+
+		return nil, fmt.Errorf("stream: %w", err)
+	}
+
+	return encoded, nil
+}
+
+// ReadScalar reads and decodes the next scalar in the stream. It returns io.EOF when there are no more values.
+func (r *Reader) ReadScalar() (*ecc.Scalar, error) {
+	encoded, err := r.read(r.group.ScalarLength())
+	if err != nil {
+		return nil, err
+	}
+
+	s := r.group.NewScalar()
+	if err = s.Decode(encoded); err != nil {
+		return nil, fmt.Errorf("stream: %w", err)
+	}
+
+	return s, nil
+}
+
+// ReadElement reads and decodes the next element in the stream. It returns io.EOF when there are no more values.
+func (r *Reader) ReadElement() (*ecc.Element, error) {
+	encoded, err := r.read(r.group.ElementLength())
+	if err != nil {
+		return nil, err
+	}
+
+	e := r.group.NewElement()
+	if err = e.Decode(encoded); err != nil {
+		return nil, fmt.Errorf("stream: %w", err)
+	}
+
+	return e, nil
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package encoding
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// IANA TLS Supported Groups registry codepoints.
+const (
+	NamedGroupSecp256r1 uint16 = 0x0017
+	NamedGroupSecp384r1 uint16 = 0x0018
+	NamedGroupSecp521r1 uint16 = 0x0019
+	NamedGroupX25519    uint16 = 0x001d
+)
+
+// GroupFromNamedGroup returns the group for the IANA TLS NamedGroup codepoint.
+func GroupFromNamedGroup(namedGroup uint16) (ecc.Group, error) {
+	for g := ecc.Ristretto255Sha512; g <= ecc.Secp256k1Sha256; g++ {
+		if namedGroup != 0 && g.IANANamedGroup() == namedGroup {
+			return g, nil
+		}
+	}
+
+	return 0, internal.ErrInvalidGroup
+}
+
+// ToKeyShare returns the TLS 1.3 KeyShareEntry key_exchange encoding of the element and its NamedGroup codepoint
+// (RFC 8446 section 4.2.8.2). NIST curve elements use the uncompressed point format, and Edwards25519 elements are
+// encoded as their X25519 Montgomery u-coordinate. It returns nil and 0 if the element has no TLS representation.
+func ToKeyShare(element *ecc.Element) ([]byte, uint16) {
+	if element == nil || element.IsIdentity() {
+		return nil, 0
+	}
+
+	namedGroup := element.Group().IANANamedGroup()
+
+	switch namedGroup {
+	case 0:
+		return nil, 0
+	case NamedGroupX25519:
+		return element.ToMontgomeryU(), namedGroup
+	default:
+		b, err := encodeUncompressed(element)
+		if err != nil {
+			return nil, 0
+		}
+
+		return b, namedGroup
+	}
+}
+
+// FromKeyShare decodes the TLS 1.3 KeyShareEntry key_exchange for the NamedGroup codepoint into an element.
+//
+// An X25519 u-coordinate corresponds to two Edwards25519 points of opposite sign, and the one with a positive x
+// coordinate is returned. Both yield the same X25519 shared secret.
+func FromKeyShare(keyExchange []byte, namedGroup uint16) (*ecc.Element, error) {
+	g, err := GroupFromNamedGroup(namedGroup)
+	if err != nil {
+		return nil, fmt.Errorf("key share: %w", err)
+	}
+
+	e := g.NewElement()
+
+	switch g {
+	case ecc.Edwards25519Sha512:
+		e, err = g.ElementFromMontgomeryU(keyExchange, false)
+	default:
+		// The SEC 1 uncompressed format is the only one allowed in TLS 1.3.
+		if len(keyExchange) != 2*g.ScalarLength()+1 || keyExchange[0] != 0x04 {
+			return nil, fmt.Errorf("key share: %w", internal.ErrParamInvalidPointEncoding)
+		}
+
+		err = e.Decode(keyExchange)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("key share: %w", err)
+	}
+
+	return e, nil
+}
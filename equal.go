@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+// EqualSlices returns whether the slices have the same length and their elements are pairwise equal, e.g. to compare
+// vectors of commitments. The comparisons are aggregated into a single result, so that the time taken doesn't depend
+// on the position of the first difference, but only on the length of the slices. Nil elements are equal to none. It
+// panics if the elements are of different groups, as Element.Equal.
+func EqualSlices(a, b []*Element) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	eq := 1
+
+	for i, e := range a {
+		if e == nil || b[i] == nil {
+			eq = 0
+			continue
+		}
+
+		eq &= e.Element.Equal(b[i].Element)
+	}
+
+	return eq == 1
+}
+
+// EqualScalarSlices returns whether the slices have the same length and their scalars are pairwise equal, with the
+// comparisons aggregated into a single result as in EqualSlices. Nil scalars are equal to none. It panics if the
+// scalars are of different groups, as Scalar.Equal.
+func EqualScalarSlices(a, b []*Scalar) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	eq := 1
+
+	for i, s := range a {
+		if s == nil || b[i] == nil {
+			eq = 0
+			continue
+		}
+
+		eq &= s.Scalar.Equal(b[i].Scalar)
+	}
+
+	return eq == 1
+}
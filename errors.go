@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// The errors returned by the package match these kinds with errors.Is, so that callers can branch on them instead of
+// on the messages, which stay free to change. All the errors of decoding elements and scalars, from any encoding,
+// match ErrInvalidEncoding, and some of them a more specific kind too.
+var (
+	// ErrInvalidEncoding is matched by the errors of decoding malformed or invalid encodings.
+	ErrInvalidEncoding = internal.ErrInvalidEncoding
+
+	// ErrNonCanonical is matched by the errors of decoding a scalar that is not reduced modulo the order of the group,
+	// or an Edwards25519 element that is not canonically encoded, and not by those of other invalid encodings, e.g. of
+	// the wrong length.
+	ErrNonCanonical = internal.ErrNonCanonical
+
+	// ErrIdentity is matched by the errors of decoding the encodings of the identity element, which all groups reject:
+	// the encoding returned by Encode for the identity, and the single zero byte of SEC 1 in the NIST groups.
+	ErrIdentity = internal.ErrIdentity
+
+	// ErrWrongGroup is matched by the errors of mixing elements or scalars of different groups.
+	ErrWrongGroup = internal.ErrWrongGroup
+
+	// ErrInvalidGroup is matched by the errors of using or decoding an unavailable group identifier.
+	ErrInvalidGroup = internal.ErrInvalidGroup
+
+	// ErrInvalidDST is matched by the errors and panics of the domain separation tags that are empty, malformed, or
+	// rejected by a DSTPolicy.
+	ErrInvalidDST = errors.New("invalid DST")
+)
+
+// elementDecodingError returns the error of the element decoding method with the given name, that failed with err,
+// and that matches ErrInvalidEncoding, and ErrIdentity if err does, as the backends reject the encodings of the
+// identity with it.
+func elementDecodingError(method string, err error) error {
+	return internal.WithKinds(fmt.Errorf("element %s: %w", method, err), ErrInvalidEncoding)
+}
+
+// scalarDecodingError returns the error of the scalar decoding method with the given name, that failed with err, and
+// that matches ErrInvalidEncoding, and ErrNonCanonical if err does, as the backends reject the values that are not
+// reduced modulo the order with it.
+func scalarDecodingError(method string, err error) error {
+	return internal.WithKinds(fmt.Errorf("scalar %s: %w", method, err), ErrInvalidEncoding)
+}
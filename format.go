@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// formatPrefixLength is the number of leading bytes of the encoding printed by the short %v format.
+const formatPrefixLength = 8
+
+// groupNames are the short names of the groups in the formats of elements and scalars.
+var groupNames = map[Group]string{
+	Ristretto255Sha512: "ristretto255",
+	P256Sha256:         "P256",
+	P384Sha384:         "P384",
+	P521Sha512:         "P521",
+	Edwards25519Sha512: "edwards25519",
+	Secp256k1Sha256:    "secp256k1",
+}
+
+// Format implements the fmt.Formatter interface, for debug printing: %v and %s print the group and the first bytes
+// of the encoding in hex, %+v the group and the whole encoding, followed by the affine coordinates of the elements of
+// the Weierstrass groups, and %x and %X the encoding in hex, as Hex.
+func (e *Element) Format(f fmt.State, verb rune) {
+	if e == nil || e.Element == nil {
+		formatValue(f, verb, "*ecc.Element", 0, nil)
+		return
+	}
+
+	encoding := e.Encode()
+	if verb == 'v' && f.Flag('+') && e.Group() != Ristretto255Sha512 && e.Group() != Edwards25519Sha512 {
+		if p := e.AffineCoordinates(); p != nil {
+			_, _ = fmt.Fprintf(f, "%s:%x{x: %x, y: %x}", groupNames[e.Group()], encoding, p.X, p.Y)
+			return
+		}
+	}
+
+	formatValue(f, verb, "*ecc.Element", e.Group(), encoding)
+}
+
+// Format implements the fmt.Formatter interface, for debug printing: %v and %s print the group and the first bytes
+// of the encoding in hex, %+v the group and the whole encoding, and %x and %X the encoding in hex, as Hex.
+func (s *Scalar) Format(f fmt.State, verb rune) {
+	if s == nil || s.Scalar == nil {
+		formatValue(f, verb, "*ecc.Scalar", 0, nil)
+		return
+	}
+
+	formatValue(f, verb, "*ecc.Scalar", s.Group(), s.Encode())
+}
+
+// formatValue writes the encoding of a value of the group with the given type name in the format of the verb.
+func formatValue(f fmt.State, verb rune, typeName string, g Group, encoding []byte) {
+	if encoding == nil && verb != 'T' {
+		_, _ = f.Write([]byte("<nil>"))
+		return
+	}
+
+	switch verb {
+	case 'x':
+		_, _ = f.Write([]byte(hex.EncodeToString(encoding)))
+	case 'X':
+		_, _ = fmt.Fprintf(f, "%X", encoding)
+	case 's', 'v':
+		if verb == 'v' && f.Flag('+') || len(encoding) <= formatPrefixLength {
+			_, _ = fmt.Fprintf(f, "%s:%x", groupNames[g], encoding)
+		} else {
+			_, _ = fmt.Fprintf(f, "%s:%x…", groupNames[g], encoding[:formatPrefixLength])
+		}
+	case 'T':
+		_, _ = f.Write([]byte(typeName))
+	default:
+		_, _ = fmt.Fprintf(f, "%%!%c(%s=%s:%x)", verb, typeName, groupNames[g], encoding)
+	}
+}
@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package fuzz provides fuzz targets for the decoding of the scalars and elements of all groups, with seed corpora of
+// valid and invalid encodings and edge values, and checks of the invariants that hold for any input: the decoding
+// methods agree with each other, accepted encodings are canonical and round-trip, and the arithmetic of the decoded
+// values is consistent. Downstream projects and OSS-Fuzz run them from their own fuzz functions:
+//
+//	func FuzzScalar(f *testing.F) { fuzz.Scalar(f) }
+//	func FuzzElement(f *testing.F) { fuzz.Element(f) }
+package fuzz
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+)
+
+// Groups are the groups of the targets and their corpora.
+var Groups = []ecc.Group{
+	ecc.Ristretto255Sha512,
+	ecc.P256Sha256,
+	ecc.P384Sha384,
+	ecc.P521Sha512,
+	ecc.Edwards25519Sha512,
+	ecc.Secp256k1Sha256,
+}
+
+var corpusDST = []byte("ecc-fuzz-corpus-V01")
+
+// edwards25519Torsion is the encoding of an Edwards25519 point of order 8, which Decode accepts.
+const edwards25519Torsion = "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a"
+
+// Scalar fuzzes the decoding of scalars with CheckScalar, on inputs made of a group identifier and an encoding,
+// seeded with ScalarCorpus for all groups. Inputs of unavailable groups are skipped.
+func Scalar(f *testing.F) {
+	for _, g := range Groups {
+		for _, data := range ScalarCorpus(g) {
+			f.Add(byte(g), data)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, group byte, data []byte) {
+		if g := ecc.Group(group); g.Available() {
+			if err := CheckScalar(g, data); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
+
+// Element fuzzes the decoding of elements with CheckElement, on inputs made of a group identifier and an encoding,
+// seeded with ElementCorpus for all groups. Inputs of unavailable groups are skipped.
+func Element(f *testing.F) {
+	for _, g := range Groups {
+		for _, data := range ElementCorpus(g) {
+			f.Add(byte(g), data)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, group byte, data []byte) {
+		if g := ecc.Group(group); g.Available() {
+			if err := CheckElement(g, data); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
+
+// ScalarCorpus returns encodings of the group's edge scalars, i.e. 0, 1, 2, -2, -1, and a hashed scalar, followed by
+// invalid encodings: the order, values above it, and encodings of the wrong length.
+func ScalarCorpus(g ecc.Group) [][]byte {
+	two := g.NewScalar().SetUInt64(2)
+	hashed := g.HashToScalar([]byte("scalar"), corpusDST)
+
+	return [][]byte{
+		g.NewScalar().Encode(),
+		g.NewScalar().One().Encode(),
+		two.Encode(),
+		g.NewScalar().Subtract(two).Encode(),
+		g.NewScalar().MinusOne().Encode(),
+		hashed.Encode(),
+		debug.NonCanonicalScalar(g),
+		debug.BadScalarHigh(g),
+		debug.OverflowedScalar(g),
+		nil,
+		hashed.Encode()[1:],
+		append(hashed.Encode(), 0),
+	}
+}
+
+// ElementCorpus returns encodings of the group's base point, its double and negation, and a hashed element, followed
+// by invalid encodings: the identity, off-curve, malformed, non-canonical, and truncated encodings, and encodings of
+// the wrong length. In Edwards25519, it adds a point of order 8, which Decode accepts but not ValidateElementEncoding.
+func ElementCorpus(g ecc.Group) [][]byte {
+	hashed := g.HashToGroup([]byte("element"), corpusDST)
+
+	flipped := g.Base().Encode()
+	flipped[len(flipped)-1] ^= 1
+
+	corpus := [][]byte{
+		g.Base().Encode(),
+		g.Base().Double().Encode(),
+		g.Base().Negate().Encode(),
+		hashed.Encode(),
+		g.NewElement().Encode(),
+		debug.BadElementOffCurve(g),
+		debug.BadElementEncoding(g),
+		debug.NonCanonicalElement(g),
+		flipped,
+		nil,
+		hashed.Encode()[1:],
+		append(hashed.Encode(), 0),
+	}
+
+	if g == ecc.Edwards25519Sha512 {
+		torsion, _ := hex.DecodeString(edwards25519Torsion)
+		corpus = append(corpus, torsion)
+	}
+
+	return corpus
+}
+
+// CheckScalar decodes the data as a scalar of the group with all the decoding methods, and returns an error if they
+// disagree or if ValidateScalarEncoding disagrees with them, if an error doesn't match ecc.ErrInvalidEncoding, if an
+// accepted encoding doesn't round-trip, or if the arithmetic of the decoded scalar is inconsistent. The text decoders
+// are also run on the raw data, which must not panic.
+func CheckScalar(g ecc.Group, data []byte) error {
+	h := hex.EncodeToString(data)
+	s, t := g.NewScalar(), g.NewScalar()
+	err := s.Decode(data)
+
+	decoders := map[string]func() error{
+		"UnmarshalBinary": func() error { return t.UnmarshalBinary(data) },
+		"DecodeHex":       func() error { return t.DecodeHex(h) },
+		"UnmarshalText":   func() error { return t.UnmarshalText([]byte(h)) },
+		"DecodeBase64":    func() error { return t.DecodeBase64(base64.RawURLEncoding.EncodeToString(data)) },
+	}
+
+	for name, decode := range decoders {
+		if derr := differential("scalar", name, data, err, decode(), func() bool { return s.Equal(t) }); derr != nil {
+			return derr
+		}
+	}
+
+	verr := validation("scalar", "ValidateScalarEncoding", data, err == nil, g.ValidateScalarEncoding(data))
+	if verr != nil {
+		return verr
+	}
+
+	_ = t.DecodeHex(string(data))
+	_ = t.UnmarshalJSON(data)
+	_ = t.DecodeBase64(string(data))
+
+	if err != nil {
+		return nil
+	}
+
+	if !bytes.Equal(s.Encode(), data) {
+		return fmt.Errorf("fuzz: scalar %x decoded and re-encoded to %x", data, s.Encode())
+	}
+
+	return scalarInvariants(g, s)
+}
+
+func scalarInvariants(g ecc.Group, s *ecc.Scalar) error {
+	switch {
+	case !s.Copy().Subtract(s).IsZero():
+		return fmt.Errorf("fuzz: scalar %x: s - s != 0", s.Encode())
+	case !s.Copy().Multiply(g.NewScalar().One()).Equal(s):
+		return fmt.Errorf("fuzz: scalar %x: s * 1 != s", s.Encode())
+	case !s.Copy().Add(s).Equal(s.Copy().Multiply(g.NewScalar().SetUInt64(2))):
+		return fmt.Errorf("fuzz: scalar %x: s + s != 2s", s.Encode())
+	case !s.LessOrEqual(g.NewScalar().MinusOne()):
+		return fmt.Errorf("fuzz: scalar %x is above the order", s.Encode())
+	case !s.IsZero() && !s.Copy().Multiply(s.Copy().Invert()).Equal(g.NewScalar().One()):
+		return fmt.Errorf("fuzz: scalar %x: s * 1/s != 1", s.Encode())
+	}
+
+	return nil
+}
+
+// CheckElement decodes the data as an element of the group with all the decoding methods, and returns an error if
+// they disagree or if ValidateElementEncoding doesn't accept exactly the decoded elements of the prime-order subgroup,
+// if an error doesn't match ecc.ErrInvalidEncoding, if an accepted encoding doesn't round-trip, or if the arithmetic of
+// the decoded element is inconsistent. The text decoders are also run on the raw data, which must not panic.
+func CheckElement(g ecc.Group, data []byte) error {
+	h := hex.EncodeToString(data)
+	e, t := g.NewElement(), g.NewElement()
+	err := e.Decode(data)
+
+	decoders := map[string]func() error{
+		"UnmarshalBinary": func() error { return t.UnmarshalBinary(data) },
+		"DecodeHex":       func() error { return t.DecodeHex(h) },
+		"UnmarshalText":   func() error { return t.UnmarshalText([]byte(h)) },
+		"DecodeBase64":    func() error { return t.DecodeBase64(base64.RawURLEncoding.EncodeToString(data)) },
+	}
+
+	for name, decode := range decoders {
+		if derr := differential("element", name, data, err, decode(), func() bool { return e.Equal(t) }); derr != nil {
+			return derr
+		}
+	}
+
+	// ValidateElementEncoding also rejects the points outside the prime-order subgroup, which Edwards25519 decodes.
+	valid := err == nil && e.Copy().Multiply(g.NewScalar().MinusOne()).Add(e).IsIdentity()
+
+	verr := validation("element", "ValidateElementEncoding", data, valid, g.ValidateElementEncoding(data))
+	if verr != nil {
+		return verr
+	}
+
+	_ = t.DecodeHex(string(data))
+	_ = t.UnmarshalJSON(data)
+	_ = t.DecodeBase64(string(data))
+
+	if err != nil {
+		return nil
+	}
+
+	if !bytes.Equal(e.Encode(), data) {
+		return fmt.Errorf("fuzz: element %x decoded and re-encoded to %x", data, e.Encode())
+	}
+
+	return elementInvariants(g, e, valid)
+}
+
+// elementInvariants checks the arithmetic of e, which is in the prime-order subgroup if primeOrder is set, as the
+// scalars are reduced modulo the order, e.g. (-1) * e = -e only holds in that subgroup.
+func elementInvariants(g ecc.Group, e *ecc.Element, primeOrder bool) error {
+	switch {
+	case !e.Copy().Subtract(e).IsIdentity():
+		return fmt.Errorf("fuzz: element %x: e - e != identity", e.Encode())
+	case !e.Copy().Double().Equal(e.Copy().Add(e)):
+		return fmt.Errorf("fuzz: element %x: 2e != e + e", e.Encode())
+	case primeOrder && !e.Copy().Multiply(g.NewScalar().MinusOne()).Equal(e.Copy().Negate()):
+		return fmt.Errorf("fuzz: element %x: (-1) * e != -e", e.Encode())
+	case !e.Copy().Multiply(g.NewScalar().SetUInt64(3)).Equal(e.Copy().Double().Add(e)):
+		return fmt.Errorf("fuzz: element %x: 3 * e != 2e + e", e.Encode())
+	}
+
+	return nil
+}
+
+// differential returns an error if a decoding method disagrees with Decode, whose error is want, or if the error
+// doesn't match ecc.ErrInvalidEncoding.
+func differential(kind, method string, data []byte, want, got error, equal func() bool) error {
+	switch {
+	case (want == nil) != (got == nil):
+		return fmt.Errorf("fuzz: %s Decode and %s disagree on %x: %v, %v", kind, method, data, want, got)
+	case want != nil && !errors.Is(want, ecc.ErrInvalidEncoding):
+		return fmt.Errorf("fuzz: %s Decode error doesn't match ErrInvalidEncoding: %w", kind, want)
+	case got != nil && !errors.Is(got, ecc.ErrInvalidEncoding):
+		return fmt.Errorf("fuzz: %s %s error doesn't match ErrInvalidEncoding: %w", kind, method, got)
+	case want == nil && !equal():
+		return fmt.Errorf("fuzz: %s Decode and %s decoded %x to different values", kind, method, data)
+	}
+
+	return nil
+}
+
+// validation returns an error if the validation method, which returned err, doesn't accept the data exactly when it
+// is valid, or if the error doesn't match ecc.ErrInvalidEncoding.
+func validation(kind, method string, data []byte, valid bool, err error) error {
+	switch {
+	case valid != (err == nil):
+		return fmt.Errorf("fuzz: %s %s on %x: expected valid %t, got %v", kind, method, data, valid, err)
+	case err != nil && !errors.Is(err, ecc.ErrInvalidEncoding):
+		return fmt.Errorf("fuzz: %s %s error doesn't match ErrInvalidEncoding: %w", kind, method, err)
+	}
+
+	return nil
+}
@@ -7,11 +7,15 @@ require (
 	filippo.io/nistec v0.0.3
 	github.com/bytemare/hash2curve v0.3.0
 	github.com/bytemare/secp256k1 v0.1.6
+	github.com/cloudflare/circl v1.6.1
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.3.0
 	github.com/gtank/ristretto255 v0.1.2
+	golang.org/x/crypto v0.27.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
+	github.com/bwesterb/go-ristretto v1.2.3 // indirect
 	github.com/bytemare/hash v0.3.0 // indirect
-	golang.org/x/crypto v0.27.0 // indirect
 	golang.org/x/sys v0.25.0 // indirect
 )
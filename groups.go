@@ -14,14 +14,19 @@ package ecc
 
 import (
 	"crypto"
+	"crypto/elliptic"
+	"encoding/asn1"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
 
+	"filippo.io/edwards25519/field"
+
 	"github.com/bytemare/ecc/internal"
 	"github.com/bytemare/ecc/internal/edwards25519"
 	"github.com/bytemare/ecc/internal/nist"
-	"github.com/bytemare/ecc/internal/ristretto"
 	"github.com/bytemare/ecc/internal/secp256k1"
 )
 
@@ -52,15 +57,29 @@ const (
 
 	maxID
 
+	// IANA TLS Supported Groups registry codepoints.
+	ianaSecp256r1 uint16 = 0x0017
+	ianaSecp384r1 uint16 = 0x0018
+	ianaSecp521r1 uint16 = 0x0019
+	ianaX25519    uint16 = 0x001d
+
 	dstfmt               = "%s-V%02d-CS%02d-%s"
+	maxCachedDSTs        = 64
 	minLength            = 0
 	recommendedMinLength = 16
 )
 
 var (
-	once          [maxID - 1]sync.Once
 	groups        [maxID - 1]internal.Group
-	errZeroLenDST = errors.New("zero-length DST")
+	orders        [maxID - 1][]byte
+	dsts          = dstCache{tags: make(map[dstKey][]byte)}
+	errZeroLenDST = internal.WithKinds(errors.New("zero-length DST"), ErrInvalidDST)
+
+	oidEd25519        = asn1.ObjectIdentifier{1, 3, 101, 112}
+	oidCurveP256      = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidCurveP384      = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+	oidCurveP521      = asn1.ObjectIdentifier{1, 3, 132, 0, 35}
+	oidCurveSecp256k1 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
 )
 
 // Available reports whether the given Group is linked into the binary.
@@ -68,21 +87,78 @@ func (g Group) Available() bool {
 	return 0 < g && g < maxID && g != decaf448Shake256
 }
 
+// The backends are all instantiated at package initialization, which takes a few tens of microseconds, so that get()
+// on the hot path of all operations is a bounds check and an array load, without synchronization.
+func init() {
+	for g := Ristretto255Sha512; g < maxID; g++ {
+		if g.Available() {
+			g.init()
+			orders[g-1] = g.get().Order()
+		}
+	}
+}
+
 func (g Group) get() internal.Group {
 	if !g.Available() {
 		panic(internal.ErrInvalidGroup)
 	}
 
-	once[g-1].Do(g.init)
-
 	return groups[g-1]
 }
 
+// dstKey identifies a domain separation tag in the cache of Group.DST.
+type dstKey struct {
+	app     string
+	version uint8
+	group   Group
+}
+
+// dstCache holds the domain separation tags returned by Group.DST. Protocols use a handful of constant apps and
+// versions, but apps built from untrusted input must not grow it without bound, so it is emptied when it holds
+// maxCachedDSTs tags.
+type dstCache struct {
+	tags map[dstKey][]byte
+	mu   sync.RWMutex
+}
+
 // MakeDST builds a domain separation tag in the form of <app>-V<version>-CS<id>-<hash-to-curve-ID>,
-// and returns no error.
+// and returns no error. The returned slice is a copy of that of DST, which the caller may modify.
 func (g Group) MakeDST(app string, version uint8) []byte {
-	p := g.get()
-	return []byte(fmt.Sprintf(dstfmt, app, version, g, p.Ciphersuite()))
+	return slices.Clone(g.DST(app, version))
+}
+
+// DST returns the domain separation tag of MakeDST, which is only built on the first call for a given group, app,
+// and version, and then returned from a cache, e.g. for protocols calling it in each request handler. The cache holds
+// up to 64 tags and is emptied when full, so an application using more tags gets them built again. The returned
+// slice may be shared by other callers, and must not be modified: use MakeDST for a copy.
+func (g Group) DST(app string, version uint8) []byte {
+	key := dstKey{app: app, version: version, group: g}
+
+	dsts.mu.RLock()
+	dst, ok := dsts.tags[key]
+	dsts.mu.RUnlock()
+
+	if ok {
+		return dst
+	}
+
+	dst = []byte(fmt.Sprintf(dstfmt, app, version, g, g.get().Ciphersuite()))
+	dst = dst[:len(dst):len(dst)]
+
+	dsts.mu.Lock()
+	defer dsts.mu.Unlock()
+
+	if cached, ok := dsts.tags[key]; ok {
+		return cached
+	}
+
+	if len(dsts.tags) >= maxCachedDSTs {
+		clear(dsts.tags)
+	}
+
+	dsts.tags[key] = dst
+
+	return dst
 }
 
 // String returns the hash-to-curve string identifier of the ciphersuite.
@@ -90,6 +166,124 @@ func (g Group) String() string {
 	return g.get().Ciphersuite()
 }
 
+// IANANamedGroup returns the IANA TLS Supported Groups codepoint of the group, and 0 if there is none. Edwards25519
+// maps to x25519, as they share the same underlying curve. The deprecated secp256k1 codepoint is not returned, as it
+// is not allowed in TLS 1.3.
+func (g Group) IANANamedGroup() uint16 {
+	switch g {
+	case P256Sha256:
+		return ianaSecp256r1
+	case P384Sha384:
+		return ianaSecp384r1
+	case P521Sha512:
+		return ianaSecp521r1
+	case Edwards25519Sha512:
+		return ianaX25519
+	default:
+		return 0
+	}
+}
+
+// OID returns the ASN.1 object identifier of the group, and nil if there is none. Weierstrass groups return the SEC 2
+// named curve identifier, Edwards25519 returns the RFC 8410 id-Ed25519 algorithm identifier.
+func (g Group) OID() asn1.ObjectIdentifier {
+	var oid asn1.ObjectIdentifier
+
+	switch g {
+	case P256Sha256:
+		oid = oidCurveP256
+	case P384Sha384:
+		oid = oidCurveP384
+	case P521Sha512:
+		oid = oidCurveP521
+	case Edwards25519Sha512:
+		oid = oidEd25519
+	case Secp256k1Sha256:
+		oid = oidCurveSecp256k1
+	default:
+		return nil
+	}
+
+	return append(asn1.ObjectIdentifier(nil), oid...)
+}
+
+// EllipticCurve returns the crypto/elliptic curve of the NIST groups, and nil for the other groups.
+func (g Group) EllipticCurve() elliptic.Curve {
+	switch g {
+	case P256Sha256:
+		return elliptic.P256()
+	case P384Sha384:
+		return elliptic.P384()
+	case P521Sha512:
+		return elliptic.P521()
+	default:
+		return nil
+	}
+}
+
+// GroupFromSuiteID returns the group identified by the RFC 9380 hash-to-curve suite ID, either for hash_to_curve
+// (e.g. "P256_XMD:SHA-256_SSWU_RO_") or encode_to_curve (e.g. "P256_XMD:SHA-256_SSWU_NU_").
+func GroupFromSuiteID(suite string) (Group, error) {
+	for g := Ristretto255Sha512; g < maxID; g++ {
+		if !g.Available() {
+			continue
+		}
+
+		h2c := g.String()
+		if suite == h2c {
+			return g, nil
+		}
+
+		// Ristretto255 doesn't define a non-uniform encoding.
+		if g != Ristretto255Sha512 && suite == strings.TrimSuffix(h2c, "RO_")+"NU_" {
+			return g, nil
+		}
+	}
+
+	return 0, internal.ErrInvalidGroup
+}
+
+// groupAliases are the common names of the groups accepted by GroupFromString, in lower case.
+var groupAliases = map[string]Group{
+	"ristretto255":       Ristretto255Sha512,
+	"ristretto":          Ristretto255Sha512,
+	"p-256":              P256Sha256,
+	"p256":               P256Sha256,
+	"secp256r1":          P256Sha256,
+	"prime256v1":         P256Sha256,
+	"p-384":              P384Sha384,
+	"p384":               P384Sha384,
+	"secp384r1":          P384Sha384,
+	"p-521":              P521Sha512,
+	"p521":               P521Sha512,
+	"secp521r1":          P521Sha512,
+	"edwards25519":       Edwards25519Sha512,
+	"ed25519":            Edwards25519Sha512,
+	"secp256k1":          Secp256k1Sha256,
+	"ristretto255sha512": Ristretto255Sha512,
+	"p256sha256":         P256Sha256,
+	"p384sha384":         P384Sha384,
+	"p521sha512":         P521Sha512,
+	"edwards25519sha512": Edwards25519Sha512,
+	"secp256k1sha256":    Secp256k1Sha256,
+}
+
+// GroupFromString returns the group named by s, the inverse of Group.String, e.g. for ciphersuites selected in
+// configuration files. Besides the hash-to-curve suite IDs of GroupFromSuiteID, it accepts the common names of the
+// curves, e.g. "ristretto255", "P-256", "secp384r1", "edwards25519", or "secp256k1", and the names of the Group
+// constants, e.g. "P256Sha256", regardless of case.
+func GroupFromString(s string) (Group, error) {
+	if g, err := GroupFromSuiteID(s); err == nil {
+		return g, nil
+	}
+
+	if g, ok := groupAliases[strings.ToLower(s)]; ok && g.Available() {
+		return g, nil
+	}
+
+	return 0, internal.ErrInvalidGroup
+}
+
 // NewScalar returns a new scalar set to 0.
 func (g Group) NewScalar() *Scalar {
 	return newScalar(g.get().NewScalar())
@@ -105,6 +299,87 @@ func (g Group) Base() *Element {
 	return newPoint(g.get().Base())
 }
 
+// ScalarBaseMult returns a new element set to scalar * G, for the group's base point G, and the identity if the scalar
+// is nil. It is the same as g.Base().Multiply(scalar): multiplications of an element equal to the base point use the
+// backends' fixed-base algorithms, with precomputed multiples of the base point, which are several times faster. The
+// secp256k1 backend has none, and uses its ladder.
+func (g Group) ScalarBaseMult(scalar *Scalar) *Element {
+	return g.Base().Multiply(scalar)
+}
+
+// ElementFromMontgomeryU returns the Edwards25519 element mapped from the Curve25519 u-coordinate by the birational
+// map of RFC 7748 section 4.1, y = (u - 1) / (u + 1), with a negative x-coordinate if sign is true. As in X25519, the
+// most significant bit of u is ignored and non-canonical values are accepted. Both signs yield the same X25519 shared
+// secrets. It returns an error for the other groups, and if u has no point on Edwards25519.
+func (g Group) ElementFromMontgomeryU(u []byte, sign bool) (*Element, error) {
+	if g != Edwards25519Sha512 {
+		return nil, fmt.Errorf("element FromMontgomeryU: %w", internal.ErrInvalidGroup)
+	}
+
+	fu, err := new(field.Element).SetBytes(u)
+	if err != nil {
+		return nil, fmt.Errorf("element FromMontgomeryU: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	// u = -1 is the exceptional point that maps to no Edwards point.
+	if fu.Equal(new(field.Element).Negate(new(field.Element).One())) == 1 {
+		return nil, fmt.Errorf("element FromMontgomeryU: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	// The Edwards encoding is the y coordinate with the sign of x in the most significant bit.
+	y := edwards25519.MontgomeryUToEdwardsY(fu).Bytes()
+	if sign {
+		y[31] |= 0x80
+	}
+
+	e := g.NewElement()
+	if err = e.Element.Decode(y); err != nil {
+		return nil, fmt.Errorf("element FromMontgomeryU: %w", err)
+	}
+
+	return e, nil
+}
+
+// DecodeXOnly returns the secp256k1 element with the BIP-340 x-only encoding and an even y-coordinate. It returns an
+// error for the other groups.
+func (g Group) DecodeXOnly(x []byte) (*Element, error) {
+	if g != Secp256k1Sha256 {
+		return nil, fmt.Errorf("element DecodeXOnly: %w", internal.ErrInvalidGroup)
+	}
+
+	if len(x) != g.ElementLength()-1 {
+		return nil, fmt.Errorf("element DecodeXOnly: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	e := g.NewElement()
+	if err := e.Element.Decode(append([]byte{0x02}, x...)); err != nil {
+		return nil, fmt.Errorf("element DecodeXOnly: %w", err)
+	}
+
+	return e, nil
+}
+
+type uniformBytesSetter interface {
+	SetUniformBytes(uniform []byte) error
+}
+
+// ElementFromUniformBytes returns the Ristretto255 element mapped from the 64 uniformly random bytes by the one-way
+// map of RFC 9496 section 4.3.4, e.g. to implement a custom hash-to-group. It returns an error for the other groups.
+func (g Group) ElementFromUniformBytes(uniform []byte) (*Element, error) {
+	e := g.NewElement()
+
+	u, ok := e.Element.(uniformBytesSetter)
+	if !ok {
+		return nil, fmt.Errorf("element FromUniformBytes: %w", internal.ErrInvalidGroup)
+	}
+
+	if err := u.SetUniformBytes(uniform); err != nil {
+		return nil, fmt.Errorf("element FromUniformBytes: %w", err)
+	}
+
+	return e, nil
+}
+
 func checkDST(dst []byte) {
 	if len(dst) < recommendedMinLength {
 		if len(dst) == minLength {
@@ -113,7 +388,9 @@ func checkDST(dst []byte) {
 	}
 }
 
-// HashFunc returns the RFC9380 associated hash function of the group.
+// HashFunc returns the RFC9380 associated hash function of the group, i.e. the hash of expand_message_xmd in its
+// hash-to-curve and hash-to-scalar, to which protocols can bind their transcripts. No group uses an XOF with
+// expand_message_xof, so there is no XOF counterpart.
 func (g Group) HashFunc() crypto.Hash {
 	return g.get().HashFunc()
 }
@@ -161,11 +438,11 @@ func (g Group) initGroup(get func() internal.Group) {
 func (g Group) init() {
 	switch g {
 	case Ristretto255Sha512:
-		g.initGroup(ristretto.New)
+		g.initGroup(newRistretto255)
 	case P256Sha256:
 		g.initGroup(nist.P256)
 	case P384Sha384:
-		g.initGroup(nist.P384)
+		g.initGroup(newP384)
 	case P521Sha512:
 		g.initGroup(nist.P521)
 	case Edwards25519Sha512:
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// uniformMapper is implemented by the backends that map the expand_message_xmd outputs of their hash-to-scalar and
+// hash-to-group, so that the input can be streamed through the hash function.
+type uniformMapper interface {
+	UniformLengths() (scalar, element uint)
+	ScalarFromUniform(uniform []byte) internal.Scalar
+	ElementFromUniform(uniform []byte) internal.Element
+}
+
+// HashToScalarReader returns the same scalar as HashToScalar for the input read from r until EOF, which is streamed
+// through expand_message_xmd without being fully held in memory, e.g. to hash large files.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g Group) HashToScalarReader(r io.Reader, dst []byte) (*Scalar, error) {
+	checkDST(dst)
+
+	m, ok := g.get().(uniformMapper)
+	if !ok {
+		input, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("group HashToScalarReader: %w", err)
+		}
+
+		return g.HashToScalar(input, dst), nil
+	}
+
+	length, _ := m.UniformLengths()
+	uniform := make([]byte, length)
+
+	if err := internal.ExpandXMDReader(g.HashFunc(), uniform, r, dst); err != nil {
+		return nil, fmt.Errorf("group HashToScalarReader: %w", err)
+	}
+
+	return newScalar(m.ScalarFromUniform(uniform)), nil
+}
+
+// HashToGroupReader returns the same element as HashToGroup for the input read from r until EOF, which is streamed
+// through expand_message_xmd without being fully held in memory, e.g. to hash large files.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g Group) HashToGroupReader(r io.Reader, dst []byte) (*Element, error) {
+	checkDST(dst)
+
+	m, ok := g.get().(uniformMapper)
+	if !ok {
+		input, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("group HashToGroupReader: %w", err)
+		}
+
+		return g.HashToGroup(input, dst), nil
+	}
+
+	_, length := m.UniformLengths()
+	uniform := make([]byte, length)
+
+	if err := internal.ExpandXMDReader(g.HashFunc(), uniform, r, dst); err != nil {
+		return nil, fmt.Errorf("group HashToGroupReader: %w", err)
+	}
+
+	return newPoint(m.ElementFromUniform(uniform)), nil
+}
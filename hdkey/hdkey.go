@@ -0,0 +1,251 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package hdkey implements hierarchical deterministic key derivation in the style of BIP-32, with chain codes, and
+// hardened and non-hardened child keys, for all groups. Over secp256k1 and P-256, derivation follows BIP-32 and
+// SLIP-0010, and the keys are those of other wallets. Over the other groups, which SLIP-0010 does not cover with
+// additive derivation, the same construction derives the child key tweaks with the group's HashToScalar, so that they
+// are uniform in groups whose order is not close to 2^256.
+package hdkey
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	// HardenedOffset is the index of the first hardened child key.
+	HardenedOffset uint32 = 0x80000000
+
+	// MinSeedLength and MaxSeedLength are the bounds of the length of master seeds, in bytes.
+	MinSeedLength = 16
+	MaxSeedLength = 64
+
+	dstApp     = "ecc-hdkey"
+	dstVersion = 1
+)
+
+var (
+	errInvalidSeed   = errors.New("invalid seed length")
+	errHardenedChild = errors.New("cannot derive a hardened child from a public key")
+	errInvalidPath   = errors.New("invalid derivation path")
+	errMaxDepth      = errors.New("maximum depth reached")
+)
+
+// ExtendedKey is a private or public key with its chain code.
+type ExtendedKey struct {
+	secret    *ecc.Scalar
+	public    *ecc.Element
+	chainCode []byte
+	index     uint32
+	depth     uint8
+}
+
+// isSLIP10 returns whether the group's derivation follows SLIP-0010.
+func isSLIP10(g ecc.Group) bool {
+	return g == ecc.Secp256k1Sha256 || g == ecc.P256Sha256
+}
+
+// curveKey returns the HMAC key of master key generation.
+func curveKey(g ecc.Group) []byte {
+	switch g {
+	case ecc.Secp256k1Sha256:
+		return []byte("Bitcoin seed")
+	case ecc.P256Sha256:
+		return []byte("Nist256p1 seed")
+	default:
+		return []byte(dstApp + " " + g.String() + " seed")
+	}
+}
+
+func hmacSHA512(key []byte, data ...[]byte) []byte {
+	h := hmac.New(sha512.New, key)
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+
+	return h.Sum(nil)
+}
+
+// tweak returns the scalar of the left half of an HMAC output, and false if it is invalid. Over the SLIP-0010 groups,
+// it is the big-endian integer, which must be lower than the order, and otherwise the group's HashToScalar of it.
+func tweak(g ecc.Group, il []byte) (*ecc.Scalar, bool) {
+	if !isSLIP10(g) {
+		s := g.HashToScalar(il, g.DST(dstApp, dstVersion))
+		return s, !s.IsZero()
+	}
+
+	s := g.NewScalar()
+	if new(big.Int).SetBytes(il).Cmp(new(big.Int).SetBytes(g.Order())) >= 0 {
+		return nil, false
+	}
+
+	if err := s.Decode(il); err != nil {
+		return nil, false
+	}
+
+	return s, true
+}
+
+// NewMaster returns the master private key of the seed.
+func NewMaster(g ecc.Group, seed []byte) (*ExtendedKey, error) {
+	if len(seed) < MinSeedLength || len(seed) > MaxSeedLength {
+		return nil, fmt.Errorf("hdkey: %w", errInvalidSeed)
+	}
+
+	if !g.Available() {
+		return nil, fmt.Errorf("hdkey: %w", internal.ErrInvalidGroup)
+	}
+
+	key := curveKey(g)
+	i := hmacSHA512(key, seed)
+
+	// As in SLIP-0010, invalid keys are retried with the HMAC of the previous output.
+	for {
+		if s, ok := tweak(g, i[:32]); ok && !s.IsZero() {
+			return &ExtendedKey{secret: s, public: g.Base().Multiply(s), chainCode: i[32:]}, nil
+		}
+
+		i = hmacSHA512(key, i)
+	}
+}
+
+// IsPrivate returns whether the key holds a private scalar.
+func (k *ExtendedKey) IsPrivate() bool {
+	return k.secret != nil
+}
+
+// Secret returns a copy of the private scalar, or nil for a public key.
+func (k *ExtendedKey) Secret() *ecc.Scalar {
+	if k.secret == nil {
+		return nil
+	}
+
+	return k.secret.Copy()
+}
+
+// Public returns the public key.
+func (k *ExtendedKey) Public() *ecc.Element {
+	return k.public.Copy()
+}
+
+// ChainCode returns a copy of the chain code.
+func (k *ExtendedKey) ChainCode() []byte {
+	return append([]byte(nil), k.chainCode...)
+}
+
+// Depth returns the number of derivations from the master key.
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+// Index returns the child index of the key, and 0 for the master key.
+func (k *ExtendedKey) Index() uint32 {
+	return k.index
+}
+
+// Neuter returns the public key of the extended key, from which only non-hardened children can be derived.
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	return &ExtendedKey{public: k.public.Copy(), chainCode: k.ChainCode(), index: k.index, depth: k.depth}
+}
+
+// Child returns the child key of the index, which is hardened from HardenedOffset on. The child of a private key is
+// private, and the child of a public key is public, which can only be non-hardened.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	if k.depth == 0xff {
+		return nil, fmt.Errorf("hdkey: %w", errMaxDepth)
+	}
+
+	hardened := index >= HardenedOffset
+	if hardened && k.secret == nil {
+		return nil, fmt.Errorf("hdkey: %w", errHardenedChild)
+	}
+
+	g := k.public.Group()
+	ser32 := binary.BigEndian.AppendUint32(nil, index)
+
+	var i []byte
+	if hardened {
+		i = hmacSHA512(k.chainCode, []byte{0}, k.secret.Encode(), ser32)
+	} else {
+		i = hmacSHA512(k.chainCode, k.public.Encode(), ser32)
+	}
+
+	// As in SLIP-0010, invalid children are retried with I = HMAC(c, 0x01 || IR || ser32(i)).
+	for {
+		if t, ok := tweak(g, i[:32]); ok {
+			child := &ExtendedKey{chainCode: i[32:], index: index, depth: k.depth + 1}
+
+			if k.secret != nil {
+				child.secret = t.Add(k.secret)
+				if !child.secret.IsZero() {
+					child.public = g.Base().Multiply(child.secret)
+					return child, nil
+				}
+			} else {
+				child.public = g.Base().Multiply(t).Add(k.public)
+				if !child.public.IsIdentity() {
+					return child, nil
+				}
+			}
+		}
+
+		i = hmacSHA512(k.chainCode, []byte{1}, i[32:], ser32)
+	}
+}
+
+// Derive returns the descendant key of the path of child indices.
+func (k *ExtendedKey) Derive(path []uint32) (*ExtendedKey, error) {
+	key := k
+	for _, index := range path {
+		var err error
+		if key, err = key.Child(index); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// ParsePath returns the child indices of a derivation path like "m/44'/0'/0'/0/1", where hardened indices are
+// marked with ' or h.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("hdkey: %w", errInvalidPath)
+	}
+
+	indices := make([]uint32, 0, len(parts)-1)
+
+	for _, p := range parts[1:] {
+		offset := uint32(0)
+		if trimmed, ok := strings.CutSuffix(p, "'"); ok {
+			p, offset = trimmed, HardenedOffset
+		} else if trimmed, ok = strings.CutSuffix(p, "h"); ok {
+			p, offset = trimmed, HardenedOffset
+		}
+
+		i, err := strconv.ParseUint(p, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("hdkey: %w", errInvalidPath)
+		}
+
+		indices = append(indices, uint32(i)+offset)
+	}
+
+	return indices, nil
+}
@@ -0,0 +1,282 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build circl
+
+package circl
+
+import (
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	circl "github.com/cloudflare/circl/group"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+var (
+	errRistrettoEncoding = errors.New("invalid Ristretto encoding")
+	errP384PointEncoding = errors.New("invalid P384 point encoding")
+	errP384Encoding      = errors.New("invalid P384Element encoding")
+	errP384Compressed    = errors.New("invalid P384 compressed point encoding")
+	errP384NotOnCurve    = errors.New("P384 point not on curve")
+	errP384Identity      = internal.WithKinds(errP384PointEncoding, internal.ErrIdentity)
+)
+
+// Element implements the Element interface for circl group elements.
+type Element struct {
+	group   *Group
+	element circl.Element
+}
+
+func (e *Element) assert(element internal.Element) *Element {
+	if element == nil {
+		panic(internal.ErrParamNilPoint)
+	}
+
+	ec, ok := element.(*Element)
+	if !ok || ec.group != e.group {
+		panic(internal.ErrCastElement)
+	}
+
+	return ec
+}
+
+// Group returns the group's Identifier.
+func (e *Element) Group() byte {
+	return e.group.id
+}
+
+// Base sets the element to the group's base point a.k.a. canonical generator.
+func (e *Element) Base() internal.Element {
+	e.element.Set(e.group.generator())
+	return e
+}
+
+// Identity sets the element to the point at infinity of the Group's underlying curve.
+func (e *Element) Identity() internal.Element {
+	e.element.Set(e.group.group.Identity())
+	return e
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (e *Element) Add(element internal.Element) internal.Element {
+	e.element.Add(e.element, e.assert(element).element)
+	return e
+}
+
+// Double sets the receiver to its double, and returns it.
+func (e *Element) Double() internal.Element {
+	e.element.Dbl(e.element)
+	return e
+}
+
+// Negate sets the receiver to its negation, and returns it.
+func (e *Element) Negate() internal.Element {
+	e.element.Neg(e.element)
+	return e
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (e *Element) Subtract(element internal.Element) internal.Element {
+	neg := e.group.group.NewElement().Neg(e.assert(element).element)
+	e.element.Add(e.element, neg)
+
+	return e
+}
+
+// Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
+func (e *Element) Multiply(scalar internal.Scalar) internal.Element {
+	if scalar == nil {
+		return e.Identity()
+	}
+
+	sc, ok := scalar.(*Scalar)
+	if !ok || sc.group != e.group {
+		panic(internal.ErrCastScalar)
+	}
+
+	if e.element.IsEqual(e.group.group.Generator()) {
+		e.element.MulGen(sc.scalar)
+	} else {
+		e.element.Mul(e.element, sc.scalar)
+	}
+
+	return e
+}
+
+// Equal returns 1 if the elements are equivalent, and 0 otherwise, in constant time. Ristretto255 elements are
+// compared by the constant-time equality of circl, and P-384 elements, which circl holds in affine coordinates, by the
+// fixed-width encodings of their coordinates.
+func (e *Element) Equal(element internal.Element) int {
+	q := e.assert(element)
+
+	if e.group.id == identifierRistretto255 {
+		if e.element.IsEqual(q.element) {
+			return 1
+		}
+
+		return 0
+	}
+
+	return subtle.ConstantTimeCompare(e.coordinates(), q.coordinates())
+}
+
+// coordinates returns the uncompressed encoding of the affine coordinates of the P-384 element, and as many zeros for
+// the identity.
+func (e *Element) coordinates() []byte {
+	if e.IsIdentity() {
+		return make([]byte, 2*e.group.ElementLength()-1)
+	}
+
+	b, err := e.element.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// EqualVarTime returns 1 if the elements are equivalent, and 0 otherwise, in variable time.
+func (e *Element) EqualVarTime(element internal.Element) int {
+	if e.element.IsEqual(e.assert(element).element) {
+		return 1
+	}
+
+	return 0
+}
+
+// IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
+func (e *Element) IsIdentity() bool {
+	return e.element.IsIdentity()
+}
+
+// Set sets the receiver to the value of the argument, and returns the receiver.
+func (e *Element) Set(element internal.Element) internal.Element {
+	if element == nil {
+		return e.Identity()
+	}
+
+	ec, ok := element.(*Element)
+	if !ok || ec.group != e.group {
+		panic(internal.ErrCastElement)
+	}
+
+	e.element.Set(ec.element)
+
+	return e
+}
+
+// Copy returns a copy of the receiver.
+func (e *Element) Copy() internal.Element {
+	return e.group.newElement(e.element.Copy())
+}
+
+// Encode returns the compressed byte encoding of the element. The identity element is encoded as a sequence of
+// zeros, as with the default backends.
+func (e *Element) Encode() []byte {
+	if e.IsIdentity() {
+		return make([]byte, e.group.ElementLength())
+	}
+
+	b, err := e.element.MarshalBinaryCompress()
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// XCoordinate returns the encoded x coordinate of the element. For Ristretto255, this is the same as Encode().
+func (e *Element) XCoordinate() []byte {
+	if e.group.id == identifierRistretto255 {
+		return e.Encode()
+	}
+
+	return e.Encode()[1:]
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+func (e *Element) Decode(data []byte) error {
+	if len(data) == 0 {
+		return internal.ErrParamInvalidPointEncoding
+	}
+
+	if e.group.id == identifierRistretto255 {
+		return e.decodeRistretto(data)
+	}
+
+	return e.decodeP384(data)
+}
+
+// decodeP384 accepts the same SEC 1 encodings as the default backend, i.e. compressed and uncompressed, and rejects
+// the encodings of the identity as it does.
+func (e *Element) decodeP384(data []byte) error {
+	n := e.group.ElementLength() - 1
+
+	switch {
+	case internal.IsZeroEncoding(data, 1) || internal.IsZeroEncoding(data, 1+n):
+		return errP384Identity
+	case len(data) == 1+2*n && data[0] == 4:
+		if !e.group.isFieldElement(data[1:1+n]) || !e.group.isFieldElement(data[1+n:]) {
+			return errP384Encoding
+		}
+	case len(data) == 1+n && (data[0] == 2 || data[0] == 3):
+		if !e.group.isFieldElement(data[1:]) {
+			return errP384Encoding
+		}
+	default:
+		return errP384PointEncoding
+	}
+
+	d := e.group.group.NewElement()
+	if err := d.UnmarshalBinary(data); err != nil {
+		if data[0] == 4 {
+			return errP384NotOnCurve
+		}
+
+		return errP384Compressed
+	}
+
+	e.element.Set(d)
+
+	return nil
+}
+
+func (e *Element) decodeRistretto(data []byte) error {
+	d := e.group.group.NewElement()
+	if err := d.UnmarshalBinary(data); err != nil {
+		return errRistrettoEncoding
+	}
+
+	// As with the default backend, the identity element is rejected.
+	if d.IsIdentity() {
+		return fmt.Errorf("%w: %w", errRistrettoEncoding, internal.ErrIdentity)
+	}
+
+	e.element.Set(d)
+
+	return nil
+}
+
+// Hex returns the fixed-sized hexadecimal encoding of e.
+func (e *Element) Hex() string {
+	return hex.EncodeToString(e.Encode())
+}
+
+// DecodeHex sets e to the decoding of the hex encoded element.
+func (e *Element) DecodeHex(h string) error {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return e.Decode(b)
+}
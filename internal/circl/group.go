@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build circl
+
+// Package circl implements the Ristretto255 and P-384 groups with github.com/cloudflare/circl as backend. It is only
+// compiled with the circl build tag, which makes these groups use it instead of the default backends.
+//
+// Warning: circl's P-384 scalar arithmetic is not constant time.
+package circl
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"math/big"
+	"slices"
+
+	circl "github.com/cloudflare/circl/group"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	identifierRistretto255 = byte(1)
+	identifierP384         = byte(4)
+
+	h2cRistretto255 = "ristretto255_XMD:SHA-512_R255MAP_RO_"
+	h2cP384         = "P384_XMD:SHA-384_SSWU_RO_"
+)
+
+var (
+	ristretto255 = newGroup(circl.Ristretto255, identifierRistretto255, h2cRistretto255, crypto.SHA512, true)
+	p384         = newGroup(circl.P384, identifierP384, h2cP384, crypto.SHA384, false)
+)
+
+// Group wraps a circl group. It exposes a prime-order group API with hash-to-curve operations.
+type Group struct {
+	group circl.Group
+	order *big.Int
+	field *big.Int
+	h2c   string
+	hash  crypto.Hash
+	id    byte
+
+	// littleEndian is whether scalars are encoded in little-endian, as for Ristretto255.
+	littleEndian bool
+}
+
+func newGroup(g circl.Group, id byte, h2c string, hash crypto.Hash, littleEndian bool) *Group {
+	minusOne, err := g.NewScalar().Neg(g.NewScalar().SetUint64(1)).MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	group := &Group{
+		group:        g,
+		h2c:          h2c,
+		hash:         hash,
+		id:           id,
+		littleEndian: littleEndian,
+	}
+
+	group.order = group.toInt(minusOne)
+	group.order.Add(group.order, big.NewInt(1))
+
+	if id == identifierP384 {
+		group.field = elliptic.P384().Params().P
+	}
+
+	return group
+}
+
+// Ristretto255 returns the Ristretto255 group.
+func Ristretto255() internal.Group {
+	return ristretto255
+}
+
+// P384 returns the P-384 group.
+func P384() internal.Group {
+	return p384
+}
+
+// toInt returns the integer of the scalar encoding.
+func (g *Group) toInt(b []byte) *big.Int {
+	if g.littleEndian {
+		b = slices.Clone(b)
+		slices.Reverse(b)
+	}
+
+	return new(big.Int).SetBytes(b)
+}
+
+// fromInt returns the scalar encoding of the integer, which must be reduced.
+func (g *Group) fromInt(i *big.Int) []byte {
+	b := i.FillBytes(make([]byte, g.ScalarLength()))
+	if g.littleEndian {
+		slices.Reverse(b)
+	}
+
+	return b
+}
+
+// isFieldElement returns whether the big-endian encoding is that of a reduced field element.
+func (g *Group) isFieldElement(b []byte) bool {
+	return new(big.Int).SetBytes(b).Cmp(g.field) < 0
+}
+
+func (g *Group) newScalar(s circl.Scalar) *Scalar {
+	return &Scalar{group: g, scalar: s}
+}
+
+func (g *Group) newElement(e circl.Element) *Element {
+	return &Element{group: g, element: e}
+}
+
+// NewScalar returns a new scalar set to 0.
+func (g *Group) NewScalar() internal.Scalar {
+	return g.newScalar(g.group.NewScalar())
+}
+
+// NewElement returns the identity element (point at infinity).
+func (g *Group) NewElement() internal.Element {
+	return g.newElement(g.group.Identity())
+}
+
+// Base returns the group's base point a.k.a. canonical generator.
+func (g *Group) Base() internal.Element {
+	return g.newElement(g.generator())
+}
+
+// generator returns a new generator, as circl's short Weierstrass generators share their coordinates with the
+// curve parameters, which in-place operations would otherwise modify.
+func (g *Group) generator() circl.Element {
+	return g.group.Generator().Copy()
+}
+
+// HashFunc returns the RFC9380 associated hash function of the group.
+func (g *Group) HashFunc() crypto.Hash {
+	return g.hash
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToScalar(input, dst []byte) internal.Scalar {
+	return g.newScalar(g.group.HashToScalar(input, dst))
+}
+
+// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) HashToGroup(input, dst []byte) internal.Element {
+	return g.newElement(g.group.HashToElement(input, dst))
+}
+
+// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group) EncodeToGroup(input, dst []byte) internal.Element {
+	return g.newElement(g.group.HashToElementNonUniform(input, dst))
+}
+
+// Ciphersuite returns the hash-to-curve ciphersuite identifier.
+func (g *Group) Ciphersuite() string {
+	return g.h2c
+}
+
+// ScalarLength returns the byte size of an encoded scalar.
+func (g *Group) ScalarLength() int {
+	return int(g.group.Params().ScalarLength)
+}
+
+// ElementLength returns the byte size of an encoded element.
+func (g *Group) ElementLength() int {
+	return int(g.group.Params().CompressedElementLength)
+}
+
+// Order returns the order of the canonical group of scalars.
+func (g *Group) Order() []byte {
+	return g.fromInt(g.order)
+}
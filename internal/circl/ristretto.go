@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build circl
+
+package circl
+
+import (
+	ed "filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/internal/ristretto"
+)
+
+// The Ristretto255 Edwards25519 and uniform bytes features are not exposed by circl, and are delegated to the default
+// backend through the canonical encodings, which both share.
+
+func (e *Element) toRistretto() *ristretto.Element {
+	r, _ := ristretto.New().NewElement().(*ristretto.Element)
+	if !e.IsIdentity() {
+		if err := r.Decode(e.Encode()); err != nil {
+			// Elements are always valid.
+			panic(err)
+		}
+	}
+
+	return r
+}
+
+func (e *Element) fromRistretto(r *ristretto.Element) error {
+	if r.IsIdentity() {
+		e.Identity()
+		return nil
+	}
+
+	return e.Decode(r.Encode())
+}
+
+// EdwardsPoint returns an Edwards25519 point representing the Ristretto255 element, and nil for the other groups.
+func (e *Element) EdwardsPoint() *ed.Point {
+	if e.group.id != identifierRistretto255 {
+		return nil
+	}
+
+	return e.toRistretto().EdwardsPoint()
+}
+
+// SetEdwardsPoint sets e to the Ristretto255 element represented by the Edwards25519 point.
+func (e *Element) SetEdwardsPoint(p *ed.Point) error {
+	if e.group.id != identifierRistretto255 {
+		return internal.ErrInvalidGroup
+	}
+
+	r, _ := ristretto.New().NewElement().(*ristretto.Element)
+	if err := r.SetEdwardsPoint(p); err != nil {
+		return err
+	}
+
+	return e.fromRistretto(r)
+}
+
+// SetUniformBytes sets e to the Ristretto255 element mapped from the 64 uniformly random bytes by the one-way map of
+// RFC 9496 section 4.3.4.
+func (e *Element) SetUniformBytes(uniform []byte) error {
+	if e.group.id != identifierRistretto255 {
+		return internal.ErrInvalidGroup
+	}
+
+	r, _ := ristretto.New().NewElement().(*ristretto.Element)
+	if err := r.SetUniformBytes(uniform); err != nil {
+		return err
+	}
+
+	return e.fromRistretto(r)
+}
+
+// EdwardsScalar returns the Ristretto255 scalar as an Edwards25519 scalar, and nil for the other groups.
+func (s *Scalar) EdwardsScalar() *ed.Scalar {
+	if s.group.id != identifierRistretto255 {
+		return nil
+	}
+
+	e, err := ed.NewScalar().SetCanonicalBytes(s.Encode())
+	if err != nil {
+		// Ristretto255 scalars are always canonically encoded.
+		panic(err)
+	}
+
+	return e
+}
+
+// SetEdwardsScalar sets the Ristretto255 scalar s to the Edwards25519 scalar.
+func (s *Scalar) SetEdwardsScalar(scalar *ed.Scalar) error {
+	if s.group.id != identifierRistretto255 {
+		return internal.ErrInvalidGroup
+	}
+
+	if scalar == nil {
+		return internal.ErrParamNilScalar
+	}
+
+	return s.Decode(scalar.Bytes())
+}
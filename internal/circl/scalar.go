@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build circl
+
+package circl
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	circl "github.com/cloudflare/circl/group"
+
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/internal/nist"
+)
+
+// Scalar implements the Scalar interface for circl group scalars.
+type Scalar struct {
+	group  *Group
+	scalar circl.Scalar
+}
+
+func (s *Scalar) assert(scalar internal.Scalar) *Scalar {
+	sc, ok := scalar.(*Scalar)
+	if ok && sc.group == s.group {
+		return sc
+	}
+
+	// As for the default backend, the scalars of the other NIST groups are of another field.
+	if nist.IsNIST(s.group.id) && nist.IsNIST(scalar.Group()) {
+		panic(internal.ErrWrongField)
+	}
+
+	panic(internal.ErrCastScalar)
+}
+
+func (s *Scalar) toInt() *big.Int {
+	return s.group.toInt(s.Encode())
+}
+
+func (s *Scalar) setInt(i *big.Int) {
+	if err := s.scalar.UnmarshalBinary(s.group.fromInt(i)); err != nil {
+		panic(err)
+	}
+}
+
+// Group returns the group's Identifier.
+func (s *Scalar) Group() byte {
+	return s.group.id
+}
+
+// Zero sets the scalar to 0, and returns it.
+func (s *Scalar) Zero() internal.Scalar {
+	s.scalar.SetUint64(0)
+	return s
+}
+
+// One sets the scalar to 1, and returns it.
+func (s *Scalar) One() internal.Scalar {
+	s.scalar.SetUint64(1)
+	return s
+}
+
+// MinusOne sets the scalar to order-1, and returns it.
+func (s *Scalar) MinusOne() internal.Scalar {
+	s.scalar.Neg(s.scalar.SetUint64(1))
+	return s
+}
+
+// Random sets the current scalar to a new random scalar and returns it.
+// The random source is crypto/rand, and this functions is guaranteed to return a non-zero scalar.
+func (s *Scalar) Random() internal.Scalar {
+	s.scalar.Set(s.group.group.RandomNonZeroScalar(rand.Reader))
+	return s
+}
+
+// Add sets the receiver to the sum of the input and the receiver, and returns the receiver.
+func (s *Scalar) Add(scalar internal.Scalar) internal.Scalar {
+	if scalar == nil {
+		return s
+	}
+
+	s.scalar.Add(s.scalar, s.assert(scalar).scalar)
+
+	return s
+}
+
+// Subtract subtracts the input from the receiver, and returns the receiver.
+func (s *Scalar) Subtract(scalar internal.Scalar) internal.Scalar {
+	if scalar == nil {
+		return s
+	}
+
+	s.scalar.Sub(s.scalar, s.assert(scalar).scalar)
+
+	return s
+}
+
+// Multiply multiplies the receiver with the input, and returns the receiver.
+func (s *Scalar) Multiply(scalar internal.Scalar) internal.Scalar {
+	if scalar == nil {
+		return s.Zero()
+	}
+
+	s.scalar.Mul(s.scalar, s.assert(scalar).scalar)
+
+	return s
+}
+
+// Pow sets s to s**scalar modulo the group order, and returns s. If scalar is nil, it returns 1.
+func (s *Scalar) Pow(scalar internal.Scalar) internal.Scalar {
+	if scalar == nil || scalar.IsZero() {
+		return s.One()
+	}
+
+	e := s.assert(scalar).toInt()
+	s.setInt(e.Exp(s.toInt(), e, s.group.order))
+
+	return s
+}
+
+// Invert sets the receiver to the scalar's modular inverse ( 1 / scalar ), and returns it.
+func (s *Scalar) Invert() internal.Scalar {
+	s.scalar.Inv(s.scalar)
+	return s
+}
+
+// Equal returns 1 if the scalars are equal, and 0 otherwise, in constant time: circl compares the fixed-width bytes of
+// P-384 scalars, and the limbs of the difference of Ristretto255 scalars.
+func (s *Scalar) Equal(scalar internal.Scalar) int {
+	if scalar == nil {
+		return 0
+	}
+
+	if s.scalar.IsEqual(s.assert(scalar).scalar) {
+		return 1
+	}
+
+	return 0
+}
+
+// LessOrEqual returns 1 if s <= scalar, and 0 otherwise.
+func (s *Scalar) LessOrEqual(scalar internal.Scalar) int {
+	if s.toInt().Cmp(s.assert(scalar).toInt()) <= 0 {
+		return 1
+	}
+
+	return 0
+}
+
+// IsZero returns whether the scalar is 0.
+func (s *Scalar) IsZero() bool {
+	return s.scalar.IsZero()
+}
+
+// Set sets the receiver to the value of the argument scalar, and returns the receiver.
+func (s *Scalar) Set(scalar internal.Scalar) internal.Scalar {
+	if scalar == nil {
+		return s.Zero()
+	}
+
+	s.scalar.Set(s.assert(scalar).scalar)
+
+	return s
+}
+
+// SetUInt64 sets s to i modulo the field order, and returns an error if one occurs.
+func (s *Scalar) SetUInt64(i uint64) internal.Scalar {
+	s.scalar.SetUint64(i)
+	return s
+}
+
+// UInt64 returns the uint64 representation of the scalar,
+// or an error if its value is higher than the authorized limit for uint64.
+func (s *Scalar) UInt64() (uint64, error) {
+	i := s.toInt()
+	if !i.IsUint64() {
+		return 0, internal.ErrUInt64TooBig
+	}
+
+	return i.Uint64(), nil
+}
+
+// Copy returns a copy of the receiver.
+func (s *Scalar) Copy() internal.Scalar {
+	return s.group.newScalar(s.scalar.Copy())
+}
+
+// Encode returns the compressed byte encoding of the scalar.
+func (s *Scalar) Encode() []byte {
+	b, err := s.scalar.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+func (s *Scalar) Decode(in []byte) error {
+	switch len(in) {
+	case 0:
+		return internal.ErrParamNilScalar
+	case s.group.ScalarLength():
+		break
+	default:
+		return internal.ErrParamScalarLength
+	}
+
+	if s.group.toInt(in).Cmp(s.group.order) >= 0 {
+		return internal.ErrParamScalarNotReduced
+	}
+
+	if err := s.scalar.UnmarshalBinary(in); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
+// Hex returns the fixed-sized hexadecimal encoding of s.
+func (s *Scalar) Hex() string {
+	return hex.EncodeToString(s.Encode())
+}
+
+// DecodeHex sets s to the decoding of the hex encoded scalar.
+func (s *Scalar) DecodeHex(h string) error {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return s.Decode(b)
+}
@@ -9,14 +9,28 @@
 package edwards25519
 
 import (
+	"crypto/subtle"
 	"encoding/hex"
 	"fmt"
 
 	ed "filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
 
 	"github.com/bytemare/ecc/internal"
 )
 
+// generator is the base point, which Multiply compares the receiver to in order to use the precomputed tables of
+// ScalarBaseMult.
+var generator = ed.NewGeneratorPoint()
+
+var (
+	feOne      = new(field.Element).One()
+	feMinusOne = new(field.Element).Negate(feOne)
+)
+
+// lMinusOne is the scalar order - 1, with which inPrimeOrderSubgroup computes [order]P as [order - 1]P + P.
+var lMinusOne *ed.Scalar
+
 // Element implements the Element interface for the Edwards25519 group element.
 type Element struct {
 	element ed.Point
@@ -88,7 +102,11 @@ func (e *Element) Multiply(scalar internal.Scalar) internal.Element {
 	}
 
 	sc := assert(scalar)
-	e.element.ScalarMult(&sc.scalar, &e.element)
+	if e.element.Equal(generator) == 1 {
+		e.element.ScalarBaseMult(&sc.scalar)
+	} else {
+		e.element.ScalarMult(&sc.scalar, &e.element)
+	}
 
 	return e
 }
@@ -139,36 +157,66 @@ func (e *Element) XCoordinate() []byte {
 	return e.element.BytesMontgomery()
 }
 
-func decodeElement(element []byte) (*ed.Point, error) {
+// decodeElement sets p to the decoding of element, which it leaves unchanged on failure.
+func decodeElement(p *ed.Point, element []byte) error {
 	if len(element) == 0 {
-		return nil, internal.ErrParamInvalidPointEncoding
+		return internal.ErrParamInvalidPointEncoding
 	}
 
-	e := ed.NewIdentityPoint()
-	if _, err := e.SetBytes(element); err != nil {
-		return nil, fmt.Errorf("%w", err)
+	var d ed.Point
+	if _, err := d.SetBytes(element); err != nil {
+		return fmt.Errorf("%w", err)
 	}
 
-	return e, nil
-}
-
-// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
-func (e *Element) Decode(data []byte) error {
-	element, err := decodeElement(data)
-	if err != nil {
-		return err
+	// SetBytes accepts non-canonical encodings, which RFC 8032 rejects.
+	if !isCanonical(element) {
+		return fmt.Errorf("invalid edwards25519 encoding: %w", internal.ErrNonCanonical)
 	}
 
 	// superfluous identity check
-	if element.Equal(ed.NewIdentityPoint()) == 1 {
+	if d.Equal(ed.NewIdentityPoint()) == 1 {
 		return fmt.Errorf("invalid edwards25519 encoding: %w", internal.ErrIdentity)
 	}
 
-	e.element = *element
+	p.Set(&d)
 
 	return nil
 }
 
+// isCanonical returns whether the 32-byte encoding of a point is canonical, i.e. its y coordinate is reduced modulo p,
+// and its sign bit is not set if x = 0, i.e. if y = 1 or y = -1.
+func isCanonical(encoding []byte) bool {
+	var y field.Element
+	if _, err := y.SetBytes(encoding); err != nil {
+		return false
+	}
+
+	sign := encoding[31] >> 7
+	canonical := y.Bytes()
+	canonical[31] |= sign << 7
+
+	// Both conditions are evaluated without branching on the encoding, which may be secret.
+	reduced := subtle.ConstantTimeCompare(canonical, encoding)
+	signed := int(sign) & (y.Equal(feOne) | y.Equal(feMinusOne))
+
+	return reduced&^signed == 1
+}
+
+// inPrimeOrderSubgroup returns whether [order]p is the identity, i.e. whether p has no small order component, in
+// constant time.
+func inPrimeOrderSubgroup(p *ed.Point) bool {
+	var q ed.Point
+	q.ScalarMult(lMinusOne, p)
+	q.Add(&q, p)
+
+	return q.Equal(ed.NewIdentityPoint()) == 1
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+func (e *Element) Decode(data []byte) error {
+	return decodeElement(&e.element, data)
+}
+
 // Hex returns the fixed-sized hexadecimal encoding of e.
 func (e *Element) Hex() string {
 	return hex.EncodeToString(e.Encode())
@@ -183,3 +231,19 @@ func (e *Element) DecodeHex(h string) error {
 
 	return e.Decode(b)
 }
+
+// EdwardsPoint returns a copy of the underlying point.
+func (e *Element) EdwardsPoint() *ed.Point {
+	return ed.NewIdentityPoint().Set(&e.element)
+}
+
+// SetEdwardsPoint sets e to the point.
+func (e *Element) SetEdwardsPoint(p *ed.Point) error {
+	if p == nil {
+		return internal.ErrParamNilPoint
+	}
+
+	e.element.Set(p)
+
+	return nil
+}
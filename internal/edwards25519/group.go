@@ -11,6 +11,7 @@ package edwards25519
 
 import (
 	"crypto"
+	"fmt"
 	"slices"
 
 	ed "filippo.io/edwards25519"
@@ -60,12 +61,33 @@ func (g Group) HashToScalar(input, dst []byte) internal.Scalar {
 	return &Scalar{*HashToEdwards25519Field(input, dst)}
 }
 
+// HashToCurveTrace returns the count field elements hashed from input with dst, and the big-endian affine coordinates
+// of their mappings to the curve, before their addition and cofactor clearing.
+func (g Group) HashToCurveTrace(input, dst []byte, count uint) (u [][]byte, q [][2][]byte) {
+	return HashToCurveTrace(input, dst, count)
+}
+
 // HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func (g Group) HashToGroup(input, dst []byte) internal.Element {
 	return &Element{*HashToEdwards25519(input, dst)}
 }
 
+// UniformLengths returns the byte lengths of the expand_message_xmd outputs mapped by HashToScalar and HashToGroup.
+func (g Group) UniformLengths() (scalar, element uint) {
+	return secLength, 2 * secLength
+}
+
+// ScalarFromUniform returns the scalar mapped from the expand_message_xmd output, as in HashToScalar.
+func (g Group) ScalarFromUniform(uniform []byte) internal.Scalar {
+	return &Scalar{*Edwards25519FieldFromUniform(uniform)}
+}
+
+// ElementFromUniform returns the element mapped from the expand_message_xmd output, as in HashToGroup.
+func (g Group) ElementFromUniform(uniform []byte) internal.Element {
+	return &Element{*Edwards25519FromUniform(uniform)}
+}
+
 // EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func (g Group) EncodeToGroup(input, dst []byte) internal.Element {
@@ -87,6 +109,21 @@ func (g Group) ElementLength() int {
 	return canonicalEncodingLength
 }
 
+// ValidateElementEncoding returns the error of Decode on the data, which it decodes on the stack without retaining it,
+// or an error if the point is not in the prime-order subgroup, which Decode accepts.
+func (g Group) ValidateElementEncoding(data []byte) error {
+	var p ed.Point
+	if err := decodeElement(&p, data); err != nil {
+		return err
+	}
+
+	if !inPrimeOrderSubgroup(&p) {
+		return fmt.Errorf("invalid edwards25519 encoding: %w", internal.ErrNotInSubgroup)
+	}
+
+	return nil
+}
+
 // Order returns the order of the canonical group of scalars.
 func (g Group) Order() []byte {
 	return slices.Clone(orderBytes)
@@ -15,6 +15,8 @@ import (
 	"filippo.io/edwards25519"
 	"filippo.io/edwards25519/field"
 	"github.com/bytemare/hash2curve"
+
+	"github.com/bytemare/ecc/internal"
 )
 
 const (
@@ -24,6 +26,12 @@ const (
 	// p25519 is the prime 2^255 - 19 for the field.
 	// = 0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed.
 	p25519 = "57896044618658097711785492504343953926634992332820282019728792003956564819949"
+
+	// secLength is the byte length of the expand_message_xmd output for a field element or a scalar.
+	secLength = 48
+
+	// wideLength is the input length of the wide reductions modulo the field prime and the group order.
+	wideLength = 64
 )
 
 var (
@@ -92,10 +100,17 @@ func reverse(b []byte) []byte {
 
 // HashToEdwards25519Field implements hash-to-scalar mapping modulo the order of Edwards25519 using input with dst.
 func HashToEdwards25519Field(input, dst []byte) *edwards25519.Scalar {
-	sc := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 1, 1, 48, &order)
-	b := adjust(sc[0].Bytes())
+	var uniform [secLength]byte
+	internal.ExpandXMD(crypto.SHA512, uniform[:], input, dst)
 
-	s, err := edwards25519.NewScalar().SetCanonicalBytes(b)
+	return Edwards25519FieldFromUniform(uniform[:])
+}
+
+// Edwards25519FieldFromUniform reduces the 48 uniform bytes of expand_message_xmd modulo the order of Edwards25519.
+func Edwards25519FieldFromUniform(uniform []byte) *edwards25519.Scalar {
+	var wide [wideLength]byte
+
+	s, err := edwards25519.NewScalar().SetUniformBytes(toWideLittleEndian(&wide, uniform))
 	if err != nil {
 		panic(err)
 	}
@@ -105,11 +120,17 @@ func HashToEdwards25519Field(input, dst []byte) *edwards25519.Scalar {
 
 // HashToEdwards25519 implements hash-to-curve mapping to Edwards25519 of input with dst.
 func HashToEdwards25519(input, dst []byte) *edwards25519.Point {
-	u := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 2, 1, 48, fieldPrime)
-	q0 := element(adjust(u[0].Bytes()))
-	q1 := element(adjust(u[1].Bytes()))
-	p0 := Elligator2Edwards(q0)
-	p1 := Elligator2Edwards(q1)
+	var uniform [2 * secLength]byte
+	internal.ExpandXMD(crypto.SHA512, uniform[:], input, dst)
+
+	return Edwards25519FromUniform(uniform[:])
+}
+
+// Edwards25519FromUniform maps the 96 uniform bytes of expand_message_xmd to two field elements, and returns the sum
+// of their mappings to Edwards25519 with the cofactor cleared.
+func Edwards25519FromUniform(uniform []byte) *edwards25519.Point {
+	p0 := Elligator2Edwards(fieldFromUniform(uniform[:secLength]))
+	p1 := Elligator2Edwards(fieldFromUniform(uniform[secLength:]))
 	p0.Add(p0, p1)
 	p0.MultByCofactor(p0)
 
@@ -118,14 +139,48 @@ func HashToEdwards25519(input, dst []byte) *edwards25519.Point {
 
 // EncodeToEdwards25519 implements encode-to-curve mapping to Edwards25519 of input with dst.
 func EncodeToEdwards25519(input, dst []byte) *edwards25519.Point {
-	q := hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, 1, 1, 48, fieldPrime)
-	b := adjust(q[0].Bytes())
-	p0 := Elligator2Edwards(element(b))
+	var uniform [secLength]byte
+	internal.ExpandXMD(crypto.SHA512, uniform[:], input, dst)
+
+	p0 := Elligator2Edwards(fieldFromUniform(uniform[:]))
 	p0.MultByCofactor(p0)
 
 	return p0
 }
 
+// fieldFromUniform reduces the big-endian uniform bytes modulo the field prime.
+func fieldFromUniform(uniform []byte) *field.Element {
+	var wide [wideLength]byte
+
+	e, err := new(field.Element).SetWideBytes(toWideLittleEndian(&wide, uniform))
+	if err != nil {
+		panic(err)
+	}
+
+	return e
+}
+
+// toWideLittleEndian sets wide to the little-endian encoding of the big-endian uniform bytes, and returns it.
+func toWideLittleEndian(wide *[wideLength]byte, uniform []byte) []byte {
+	for i, b := range uniform {
+		wide[len(uniform)-1-i] = b
+	}
+
+	return wide[:]
+}
+
+// HashToCurveTrace returns the count field elements hashed from input with dst, and the big-endian affine Edwards
+// coordinates of their Elligator 2 mappings to the curve, before their addition and cofactor clearing.
+func HashToCurveTrace(input, dst []byte, count uint) (u [][]byte, q [][2][]byte) {
+	for _, fe := range hash2curve.HashToFieldXMD(crypto.SHA512, input, dst, count, 1, secLength, fieldPrime) {
+		u = append(u, fe.FillBytes(make([]byte, canonicalEncodingLength)))
+		x, y := MontgomeryToEdwards(Elligator2Montgomery(element(adjust(fe.Bytes()))))
+		q = append(q, [2][]byte{reverse(x.Bytes()), reverse(y.Bytes())})
+	}
+
+	return u, q
+}
+
 // Elligator2Edwards maps the field element to a point on Edwards25519.
 func Elligator2Edwards(e *field.Element) *edwards25519.Point {
 	u, v := Elligator2Montgomery(e)
@@ -136,10 +191,10 @@ func Elligator2Edwards(e *field.Element) *edwards25519.Point {
 
 // Elligator2Montgomery implements the Elligator2 mapping to Curve25519.
 func Elligator2Montgomery(e *field.Element) (x, y *field.Element) {
-	t1 := fe().Square(e)   // u^2
-	t1.Multiply(t1, two)   // t1 = 2u^2
-	e1 := t1.Equal(minOne) //
-	t1.Swap(zero, e1)      // if 2u^2 == -1, t1 = 0
+	t1 := fe().Square(e)    // u^2
+	t1.Multiply(t1, two)    // t1 = 2u^2
+	e1 := t1.Equal(minOne)  //
+	t1.Select(zero, t1, e1) // if 2u^2 == -1, t1 = 0, without writing to zero
 
 	x1 := fe().Add(t1, one) // t1 + 1
 	x1.Invert(x1)           // 1 / (t1 + 1)
@@ -52,6 +52,11 @@ func init() {
 		panic(err)
 	}
 
+	var err error
+	if lMinusOne, err = ed.NewScalar().SetCanonicalBytes(scMinusOne); err != nil {
+		panic(err)
+	}
+
 	if _, ok := order.SetString(orderPrime, 10); !ok {
 		panic(internal.ErrBigIntConversion)
 	}
@@ -248,26 +253,7 @@ func (s *Scalar) Equal(scalar internal.Scalar) int {
 // LessOrEqual returns 1 if s <= scalar and 0 otherwise.
 func (s *Scalar) LessOrEqual(scalar internal.Scalar) int {
 	sc := assert(scalar)
-
-	ienc := s.Encode()
-	jenc := sc.Encode()
-
-	i := len(ienc)
-	if i != len(jenc) {
-		panic(internal.ErrParamScalarLength)
-	}
-
-	var res bool
-
-	for i--; i >= 0; i-- {
-		res = res || (ienc[i] > jenc[i])
-	}
-
-	if res {
-		return 0
-	}
-
-	return 1
+	return internal.LessOrEqual(s.Encode(), sc.Encode(), true)
 }
 
 // IsZero returns whether the scalar is 0.
@@ -340,8 +326,9 @@ func (s *Scalar) decodeScalar(scalar []byte) error {
 		return internal.ErrParamScalarLength
 	}
 
+	// The decoding of 32 bytes only fails on values that are not reduced modulo the order.
 	if _, err := s.scalar.SetCanonicalBytes(scalar); err != nil {
-		return fmt.Errorf("%w", err)
+		return internal.WithKinds(fmt.Errorf("%w", err), internal.ErrParamScalarNotReduced)
 	}
 
 	return nil
@@ -366,3 +353,19 @@ func (s *Scalar) DecodeHex(h string) error {
 
 	return s.Decode(b)
 }
+
+// EdwardsScalar returns a copy of the underlying scalar.
+func (s *Scalar) EdwardsScalar() *ed.Scalar {
+	return ed.NewScalar().Set(&s.scalar)
+}
+
+// SetEdwardsScalar sets s to the scalar.
+func (s *Scalar) SetEdwardsScalar(scalar *ed.Scalar) error {
+	if scalar == nil {
+		return internal.ErrParamNilScalar
+	}
+
+	s.set(scalar)
+
+	return nil
+}
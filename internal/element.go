@@ -35,7 +35,7 @@ type Element interface {
 	// Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
 	Multiply(Scalar) Element
 
-	// Equal returns 1 if the elements are equivalent, and 0 otherwise.
+	// Equal returns 1 if the elements are equivalent, and 0 otherwise, in constant time where the backend supports it.
 	Equal(Element) int
 
 	// IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
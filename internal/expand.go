@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package internal
+
+import (
+	"crypto"
+	"encoding"
+	"hash"
+	"io"
+	"sync"
+)
+
+const (
+	// MaxUniformLength is the largest expand_message_xmd output of the groups' hash-to-field, for two P-521 field
+	// elements, so that callers can expand into fixed-size arrays.
+	MaxUniformLength = 2 * 98
+
+	maxHashSize   = 64
+	dstMaxLength  = 255
+	dstLongPrefix = "H2C-OVERSIZE-DST-"
+)
+
+// expander holds a hash function and the buffers of expand_message_xmd, to be reused across calls.
+type expander struct {
+	h      hash.Hash
+	zPad   []byte
+	dst    []byte
+	long   [maxHashSize]byte
+	b0, bi [maxHashSize]byte
+	suffix [3]byte
+	dstLen [1]byte
+}
+
+var expanders [crypto.BLAKE2b_512 + 1]sync.Pool
+
+func init() {
+	for i := range expanders {
+		id := crypto.Hash(i)
+		expanders[i].New = func() any {
+			h := id.New()
+			return &expander{h: h, zPad: make([]byte, h.BlockSize())}
+		}
+	}
+}
+
+// ExpandXMD implements expand_message_xmd of RFC 9380 section 5.3.1 into out, for len(out) bytes of output, with
+// pooled hash functions and buffers. It returns the same bytes as hash2curve.ExpandXMD.
+func ExpandXMD(id crypto.Hash, out, input, dst []byte) {
+	x := newExpander(id, dst)
+	_, _ = x.h.Write(input)
+	x.finish(out)
+	x.release(id)
+}
+
+// ExpandXMDReader is ExpandXMD with the message read from r until EOF, which is streamed through the hash function
+// without being fully held in memory.
+func ExpandXMDReader(id crypto.Hash, out []byte, r io.Reader, dst []byte) error {
+	x := newExpander(id, dst)
+	defer x.release(id)
+
+	if _, err := io.Copy(x.h, r); err != nil {
+		return err
+	}
+
+	x.finish(out)
+
+	return nil
+}
+
+// ExpandXMDBatch is ExpandXMD for each input into the output of the same index, with the same DST. The hash state
+// after Z_pad and the hashing of a long DST are computed once for all inputs, instead of once per input.
+func ExpandXMDBatch(id crypto.Hash, out, inputs [][]byte, dst []byte) {
+	x := newExpander(id, dst)
+	defer x.release(id)
+
+	m, ok := x.h.(encoding.BinaryMarshaler)
+	if !ok {
+		for i, input := range inputs {
+			ExpandXMD(id, out[i], input, dst)
+		}
+
+		return
+	}
+
+	state, err := m.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	u, _ := x.h.(encoding.BinaryUnmarshaler)
+
+	for i, input := range inputs {
+		if err = u.UnmarshalBinary(state); err != nil {
+			panic(err)
+		}
+
+		_, _ = x.h.Write(input)
+		x.finish(out[i])
+	}
+}
+
+// ReduceDST returns the DST unchanged if it is at most 255 bytes long, and H("H2C-OVERSIZE-DST-" || DST) otherwise,
+// as in RFC 9380 section 5.3.3, which expand_message_xmd uses in its stead.
+func ReduceDST(id crypto.Hash, dst []byte) []byte {
+	if len(dst) <= dstMaxLength {
+		return dst
+	}
+
+	h := id.New()
+	_, _ = h.Write([]byte(dstLongPrefix))
+	_, _ = h.Write(dst)
+
+	return h.Sum(nil)
+}
+
+// newExpander returns a pooled expander that absorbed Z_pad, ready for the message.
+func newExpander(id crypto.Hash, dst []byte) *expander {
+	x, _ := expanders[id].Get().(*expander)
+	x.h.Reset()
+	x.dst = dst
+
+	// Tags longer than 255 bytes are replaced by H("H2C-OVERSIZE-DST-" || DST), as in RFC 9380 section 5.3.3.
+	if len(dst) > dstMaxLength {
+		_, _ = x.h.Write([]byte(dstLongPrefix))
+		_, _ = x.h.Write(dst)
+		x.dst = x.h.Sum(x.long[:0])
+		x.h.Reset()
+	}
+
+	_, _ = x.h.Write(x.zPad)
+
+	return x
+}
+
+func (x *expander) release(id crypto.Hash) {
+	x.dst = nil
+	expanders[id].Put(x)
+}
+
+// writeDSTPrime absorbs DST_prime = DST || I2OSP(len(DST), 1).
+func (x *expander) writeDSTPrime() {
+	_, _ = x.h.Write(x.dst)
+	x.dstLen[0] = byte(len(x.dst))
+	_, _ = x.h.Write(x.dstLen[:])
+}
+
+// finish completes b_0 = H(Z_pad || msg || I2OSP(len(out), 2) || I2OSP(0, 1) || DST_prime), and fills out with
+// b_i = H(strxor(b_0, b_(i - 1)) || I2OSP(i, 1) || DST_prime), where b_1 = H(b_0 || I2OSP(1, 1) || DST_prime).
+func (x *expander) finish(out []byte) {
+	x.suffix = [3]byte{byte(len(out) >> 8), byte(len(out)), 0}
+	_, _ = x.h.Write(x.suffix[:])
+	x.writeDSTPrime()
+
+	b0 := x.h.Sum(x.b0[:0])
+	bi := x.bi[:len(b0)]
+	clear(bi)
+
+	for i, n := 1, 0; n < len(out); i++ {
+		for j := range bi {
+			bi[j] ^= b0[j]
+		}
+
+		x.h.Reset()
+		_, _ = x.h.Write(bi)
+		x.suffix[0] = byte(i)
+		_, _ = x.h.Write(x.suffix[:1])
+		x.writeDSTPrime()
+		bi = x.h.Sum(bi[:0])
+		n += copy(out[n:], bi)
+	}
+}
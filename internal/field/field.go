@@ -30,6 +30,7 @@ type Field struct {
 	pMinus1div2 *big.Int // used in IsSquare
 	pMinus2     *big.Int // used for Field big.Int inversion
 	exp         *big.Int
+	mont        *Montgomery // nil if not MontgomeryLimbs
 	byteLen     int
 }
 
@@ -49,13 +50,19 @@ func NewField(prime *big.Int) Field {
 	exp.Add(prime, exp)
 	exp.Rsh(exp, 2)
 
-	return Field{
+	f := Field{
 		order:       prime,
 		pMinus1div2: pMinus1div2,
 		pMinus2:     pMinus2,
 		exp:         exp,
 		byteLen:     (prime.BitLen() + 7) / 8,
 	}
+
+	if MontgomeryLimbs {
+		f.mont = NewMontgomery(prime)
+	}
+
+	return f
 }
 
 // Random sets res to a random big.Int in the Field.
@@ -93,9 +100,19 @@ func (f Field) IsZero(e *big.Int) bool {
 
 // Inv sets res to the modular inverse of x mod field order.
 func (f Field) Inv(res, x *big.Int) {
+	if f.mont != nil && x.Sign() >= 0 && x.Cmp(f.order) < 0 {
+		f.mont.Inv(res, x)
+		return
+	}
+
 	f.Exponent(res, x, f.pMinus2)
 }
 
+// Montgomery returns the Montgomery limb arithmetic of the field, and nil if it is not available.
+func (f Field) Montgomery() *Montgomery {
+	return f.mont
+}
+
 // Exponent returns x^n mod field order.
 func (f Field) Exponent(res, x, n *big.Int) *big.Int {
 	return res.Exp(x, n, f.order)
@@ -125,3 +142,34 @@ func (f Field) Sub(res, x, y *big.Int) *big.Int {
 func (f Field) Mul(res, x, y *big.Int) {
 	f.Mod(res.Mul(x, y))
 }
+
+// BatchInv sets each res[i] to the modular inverse of x[i] with Montgomery's trick, for the cost of a single
+// inversion and 3(n-1) multiplications, e.g. for the denominators of the x coordinates of the SSWU mappings of the
+// NIST hash-to-group batches. All x[i] must be non-zero, and res and x must have the same length. res and x may
+// alias.
+func (f Field) BatchInv(res, x []*big.Int) {
+	if len(x) == 0 {
+		return
+	}
+
+	// prefix[i] = x[0] * ... * x[i].
+	prefix := make([]*big.Int, len(x))
+	prefix[0] = new(big.Int).Set(x[0])
+
+	for i := 1; i < len(x); i++ {
+		prefix[i] = new(big.Int)
+		f.Mul(prefix[i], prefix[i-1], x[i])
+	}
+
+	inv := new(big.Int)
+	f.Inv(inv, prefix[len(x)-1])
+
+	// inv = (x[0] * ... * x[i])^-1, so x[i]^-1 = inv * prefix[i-1], and inv * x[i] is the next inverse of the prefix.
+	for i := len(x) - 1; i > 0; i-- {
+		xi := new(big.Int).Set(x[i])
+		f.Mul(res[i], inv, prefix[i-1])
+		f.Mul(inv, inv, xi)
+	}
+
+	res[0].Set(inv)
+}
@@ -0,0 +1,16 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build amd64 || arm64
+
+package field
+
+// MontgomeryLimbs is whether fields use the Montgomery arithmetic on 64-bit limbs, whose 64-bit multiplications and
+// carries compile to single instructions on this platform, and which uses assembly for moduli of four limbs unless
+// built with the purego tag.
+const MontgomeryLimbs = true
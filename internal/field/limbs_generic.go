@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !(amd64 || arm64)
+
+package field
+
+// MontgomeryLimbs is whether fields use the pure Go Montgomery arithmetic on 64-bit limbs, which is not faster than
+// math/big on platforms without 64-bit multiplication instructions, where fields use math/big.
+const MontgomeryLimbs = false
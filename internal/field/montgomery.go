@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package field
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// maxLimbs is the number of 64-bit limbs of the largest supported modulus, e.g. the order of P-521.
+const maxLimbs = 9
+
+// wordsPerLimb is the number of math/big words in a 64-bit limb.
+const wordsPerLimb = 64 / bits.UintSize
+
+// invWindow is the window width in bits of the exponentiation of inversions.
+const invWindow = 4
+
+// limbs is a little-endian integer of 64-bit limbs.
+type limbs = [maxLimbs]uint64
+
+// Montgomery implements arithmetic modulo an odd modulus of at most 576 bits with 64-bit limbs in the Montgomery
+// domain, in constant time for a given modulus. On 64-bit platforms, the limb multiplications and additions compile
+// to single instructions, which makes it much faster than math/big.
+type Montgomery struct {
+	m     limbs
+	r2    limbs // R^2 mod m, where R = 2^(64*n).
+	exp   limbs // m - 2, the exponent of inversion.
+	mInv  uint64
+	n     int
+	nBits int
+}
+
+// NewMontgomery returns the Montgomery arithmetic modulo the odd modulus m, and nil if it is too large or even.
+func NewMontgomery(m *big.Int) *Montgomery {
+	n := (m.BitLen() + 63) / 64
+	if n > maxLimbs || m.Bit(0) == 0 {
+		return nil
+	}
+
+	mont := &Montgomery{n: n, nBits: m.BitLen()}
+	setLimbs(&mont.m, m)
+	setLimbs(&mont.exp, new(big.Int).Sub(m, big.NewInt(2)))
+
+	r2 := new(big.Int).Lsh(big.NewInt(1), uint(128*n))
+	setLimbs(&mont.r2, r2.Mod(r2, m))
+
+	// Newton's iteration doubles the number of correct low bits of m^-1 mod 2^64 at each step.
+	inv := uint64(1)
+	for range 6 {
+		inv *= 2 - mont.m[0]*inv
+	}
+
+	mont.mInv = -inv
+
+	return mont
+}
+
+// setLimbs sets z to the limbs of x, which must be lower than 2^576.
+func setLimbs(z *limbs, x *big.Int) {
+	clear(z[:])
+
+	for i, w := range x.Bits() {
+		z[i/wordsPerLimb] |= uint64(w) << (bits.UintSize * (i % wordsPerLimb))
+	}
+}
+
+// setBytes sets z to the big-endian encoding b, which must be at most 72 bytes long.
+func setBytes(z *limbs, b []byte) {
+	clear(z[:])
+
+	for i, v := range b {
+		pos := len(b) - 1 - i
+		z[pos/8] |= uint64(v) << (8 * (pos % 8))
+	}
+}
+
+// fillBytes sets b to the big-endian encoding of x, truncated to the length of b.
+func fillBytes(b []byte, x *limbs) {
+	for i := range b {
+		pos := len(b) - 1 - i
+		b[i] = byte(x[pos/8] >> (8 * (pos % 8)))
+	}
+}
+
+// toInt sets z to the value of the n limbs of x, reusing the memory of z.
+func toInt(z *big.Int, x *limbs, n int) {
+	words := z.Bits()
+	if cap(words) < n*wordsPerLimb {
+		words = make([]big.Word, n*wordsPerLimb)
+	}
+
+	words = words[:n*wordsPerLimb]
+	for i := range words {
+		words[i] = big.Word(x[i/wordsPerLimb] >> (bits.UintSize * (i % wordsPerLimb)))
+	}
+
+	z.SetBits(words)
+}
+
+// mul sets z = x * y / R mod m, with the assembly of mul4 for moduli of four limbs, e.g. of 256 bits, and mulGeneric
+// otherwise.
+func (f *Montgomery) mul(z, x, y *limbs) {
+	if f.n == 4 {
+		mul4(z, x, y, &f.m, f.mInv)
+		return
+	}
+
+	mulGeneric(z, x, y, f.m[:f.n], f.mInv)
+}
+
+// mulGeneric sets z = x * y / R mod m, where mInv = -m^-1 mod 2^64, with the coarsely integrated operand scanning
+// (CIOS) method.
+func mulGeneric(z, x, y *limbs, m []uint64, mInv uint64) {
+	var buf, u limbs
+
+	xs, ys, t := x[:len(m)], y[:len(m)], buf[:len(m)]
+
+	// t is the n low limbs of the accumulator, and t1 and t2 its two high limbs.
+	var t1, t2 uint64
+
+	for _, yi := range ys {
+		var c, carry uint64
+
+		// t += x * y_i
+		for j, xj := range xs {
+			hi, lo := bits.Mul64(xj, yi)
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			t[j], c = lo, hi+carry
+		}
+
+		t1, carry = bits.Add64(t1, c, 0)
+		t2 = carry
+
+		// t = (t + q * m) / 2^64, where q makes the lowest limb zero.
+		q := t[0] * mInv
+		hi, lo := bits.Mul64(q, m[0])
+		_, carry = bits.Add64(lo, t[0], 0)
+		c = hi + carry
+
+		for j := 1; j < len(t); j++ {
+			hi, lo = bits.Mul64(q, m[j])
+			lo, carry = bits.Add64(lo, t[j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			t[j-1], c = lo, hi+carry
+		}
+
+		t[len(t)-1], carry = bits.Add64(t1, c, 0)
+		t1 = t2 + carry
+	}
+
+	// t < 2m, so a conditional subtraction of m fully reduces it.
+	d := u[:len(m)]
+
+	var borrow uint64
+	for j, mj := range m {
+		d[j], borrow = bits.Sub64(t[j], mj, borrow)
+	}
+
+	_, borrow = bits.Sub64(t1, 0, borrow)
+	mask := borrow - 1 // all ones if t >= m
+
+	for j := range d {
+		z[j] = d[j]&mask | t[j]&^mask
+	}
+}
+
+// Mul sets res to x * y mod m, for x and y lower than m.
+func (f *Montgomery) Mul(res, x, y *big.Int) {
+	var a, b limbs
+
+	setLimbs(&a, x)
+	setLimbs(&b, y)
+	f.mul(&a, &a, &b)    // x * y / R
+	f.mul(&a, &a, &f.r2) // x * y
+	toInt(res, &a, f.n)
+}
+
+// MulBytes sets out to the big-endian encoding of x * y mod m, as in Mul, for the big-endian encodings of x and y,
+// which must all be at most 8n bytes long. out may alias x or y.
+func (f *Montgomery) MulBytes(out, x, y []byte) {
+	var a, b limbs
+
+	setBytes(&a, x)
+	setBytes(&b, y)
+	f.mul(&a, &a, &b)
+	f.mul(&a, &a, &f.r2)
+	fillBytes(out, &a)
+}
+
+// Inv sets res to the inverse of x mod m, for x lower than m, with Fermat's little theorem for a prime m, and to 0 if
+// x is 0. The exponent m - 2 is public, so the fixed-window exponentiation is constant time in x.
+func (f *Montgomery) Inv(res, x *big.Int) {
+	var a limbs
+
+	setLimbs(&a, x)
+	f.inv(&a)
+	toInt(res, &a, f.n)
+}
+
+// InvBytes sets out to the big-endian encoding of the inverse of x mod m, as in Inv, for the big-endian encoding of x,
+// which must both be at most 8n bytes long.
+func (f *Montgomery) InvBytes(out, x []byte) {
+	var a limbs
+
+	setBytes(&a, x)
+	f.inv(&a)
+	fillBytes(out, &a)
+}
+
+// inv sets a = a^(m - 2) mod m.
+func (f *Montgomery) inv(a *limbs) {
+	var table [1 << invWindow]limbs
+
+	// table[i] = a^i * R, where table[0] is never used.
+	f.mul(&table[1], a, &f.r2)
+
+	for i := 2; i < len(table); i++ {
+		f.mul(&table[i], &table[i-1], &table[1])
+	}
+
+	windows := (f.nBits + invWindow - 1) / invWindow
+	r := table[f.window(windows-1)]
+
+	for w := windows - 2; w >= 0; w-- {
+		for range invWindow {
+			f.mul(&r, &r, &r)
+		}
+
+		if d := f.window(w); d != 0 {
+			f.mul(&r, &r, &table[d])
+		}
+	}
+
+	one := limbs{1}
+	f.mul(a, &r, &one) // out of the Montgomery domain
+}
+
+// window returns the w-th window of invWindow bits of the exponent m - 2, from the least significant bits.
+func (f *Montgomery) window(w int) uint64 {
+	bit := w * invWindow
+	return f.exp[bit/64] >> (bit % 64) & (1<<invWindow - 1)
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !purego
+
+#include "textflag.h"
+
+// The accumulator t is held in R8 to R13, from its lowest to its highest limb, with x in SI, y in BX and m in R14.
+// CX holds the current limb of y or the quotient q, and DI the carry limb.
+
+// MULADD adds a * CX + DI to the limb t, and sets DI to the high limb of the result.
+#define MULADD(a, t) \
+	MOVQ a, AX; \
+	MULQ CX; \
+	ADDQ DI, AX; \
+	ADCQ $0, DX; \
+	ADDQ AX, t; \
+	ADCQ $0, DX; \
+	MOVQ DX, DI
+
+// MULSHIFT sets the limb dst to a * CX + DI + src, and DI to the high limb of the result.
+#define MULSHIFT(a, src, dst) \
+	MOVQ a, AX; \
+	MULQ CX; \
+	ADDQ DI, AX; \
+	ADCQ $0, DX; \
+	ADDQ src, AX; \
+	ADCQ $0, DX; \
+	MOVQ AX, dst; \
+	MOVQ DX, DI
+
+// ROUND sets t = (t + x * y_i + q * m) / 2^64 for the limb of y at offset off, where q makes the lowest limb zero.
+#define ROUND(off) \
+	MOVQ off(BX), CX; \
+	XORQ DI, DI; \
+	MULADD(0(SI), R8); \
+	MULADD(8(SI), R9); \
+	MULADD(16(SI), R10); \
+	MULADD(24(SI), R11); \
+	XORQ R13, R13; \
+	ADDQ DI, R12; \
+	ADCQ $0, R13; \
+	MOVQ R8, CX; \
+	IMULQ mInv+32(FP), CX; \
+	MOVQ 0(R14), AX; \
+	MULQ CX; \
+	ADDQ R8, AX; \
+	ADCQ $0, DX; \
+	MOVQ DX, DI; \
+	MULSHIFT(8(R14), R9, R8); \
+	MULSHIFT(16(R14), R10, R9); \
+	MULSHIFT(24(R14), R11, R10); \
+	MOVQ R12, R11; \
+	ADDQ DI, R11; \
+	MOVQ R13, R12; \
+	ADCQ $0, R12
+
+// func mul4(z, x, y, m *limbs, mInv uint64)
+TEXT ·mul4(SB), NOSPLIT, $0-40
+	MOVQ x+8(FP), SI
+	MOVQ y+16(FP), BX
+	MOVQ m+24(FP), R14
+
+	XORQ R8, R8
+	XORQ R9, R9
+	XORQ R10, R10
+	XORQ R11, R11
+	XORQ R12, R12
+
+	ROUND(0)
+	ROUND(8)
+	ROUND(16)
+	ROUND(24)
+
+	// t < 2m, so a conditional subtraction of m fully reduces it.
+	MOVQ R8, AX
+	SUBQ 0(R14), AX
+	MOVQ R9, DX
+	SBBQ 8(R14), DX
+	MOVQ R10, CX
+	SBBQ 16(R14), CX
+	MOVQ R11, SI
+	SBBQ 24(R14), SI
+	SBBQ $0, R12
+
+	// Keep t - m if it did not borrow, that is if t >= m.
+	CMOVQCC AX, R8
+	CMOVQCC DX, R9
+	CMOVQCC CX, R10
+	CMOVQCC SI, R11
+
+	MOVQ z+0(FP), DI
+	MOVQ R8, 0(DI)
+	MOVQ R9, 8(DI)
+	MOVQ R10, 16(DI)
+	MOVQ R11, 24(DI)
+	RET
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !purego
+
+#include "textflag.h"
+
+// The limbs of x are held in R5 to R8, those of m in R9 to R12, and the accumulator t in R13 to R17 and R19, from
+// its lowest to its highest limb. R20 holds the current limb of y, R21 the quotient q, and R24 the carry limb.
+
+// MULADD adds a * R20 + R24 to the limb t, and sets R24 to the high limb of the result.
+#define MULADD(a, t) \
+	MUL   R20, a, R22; \
+	UMULH R20, a, R23; \
+	ADDS  R24, R22, R22; \
+	ADC   ZR, R23, R23; \
+	ADDS  R22, t, t; \
+	ADC   ZR, R23, R24
+
+// MULSHIFT sets the limb dst to a * R21 + R24 + src, and R24 to the high limb of the result.
+#define MULSHIFT(a, src, dst) \
+	MUL   R21, a, R22; \
+	UMULH R21, a, R23; \
+	ADDS  R24, R22, R22; \
+	ADC   ZR, R23, R23; \
+	ADDS  src, R22, dst; \
+	ADC   ZR, R23, R24
+
+// ROUND sets t = (t + x * y_i + q * m) / 2^64 for the limb of y at offset off, where q makes the lowest limb zero.
+#define ROUND(off) \
+	MOVD  off(R2), R20; \
+	MOVD  ZR, R24; \
+	MULADD(R5, R13); \
+	MULADD(R6, R14); \
+	MULADD(R7, R15); \
+	MULADD(R8, R16); \
+	ADDS  R24, R17, R17; \
+	ADC   ZR, ZR, R19; \
+	MUL   R4, R13, R21; \
+	MUL   R21, R9, R22; \
+	UMULH R21, R9, R23; \
+	ADDS  R13, R22, R22; \
+	ADC   ZR, R23, R24; \
+	MULSHIFT(R10, R14, R13); \
+	MULSHIFT(R11, R15, R14); \
+	MULSHIFT(R12, R16, R15); \
+	ADDS  R24, R17, R16; \
+	ADC   ZR, R19, R17
+
+// func mul4(z, x, y, m *limbs, mInv uint64)
+TEXT ·mul4(SB), NOSPLIT, $0-40
+	MOVD z+0(FP), R0
+	MOVD x+8(FP), R1
+	MOVD y+16(FP), R2
+	MOVD m+24(FP), R3
+	MOVD mInv+32(FP), R4
+
+	LDP 0(R1), (R5, R6)
+	LDP 16(R1), (R7, R8)
+	LDP 0(R3), (R9, R10)
+	LDP 16(R3), (R11, R12)
+
+	MOVD ZR, R13
+	MOVD ZR, R14
+	MOVD ZR, R15
+	MOVD ZR, R16
+	MOVD ZR, R17
+
+	ROUND(0)
+	ROUND(8)
+	ROUND(16)
+	ROUND(24)
+
+	// t < 2m, so a conditional subtraction of m fully reduces it.
+	SUBS R9, R13, R22
+	SBCS R10, R14, R23
+	SBCS R11, R15, R24
+	SBCS R12, R16, R25
+	SBCS ZR, R17, R17
+
+	// Keep t - m if it did not borrow, that is if t >= m.
+	CSEL CS, R22, R13, R13
+	CSEL CS, R23, R14, R14
+	CSEL CS, R24, R15, R15
+	CSEL CS, R25, R16, R16
+
+	STP (R13, R14), 0(R0)
+	STP (R15, R16), 16(R0)
+	RET
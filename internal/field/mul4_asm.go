@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build (amd64 || arm64) && !purego
+
+package field
+
+// mul4 sets z = x * y / 2^256 mod m for a modulus m of four limbs, where mInv = -m^-1 mod 2^64. It runs in constant
+// time, and is implemented in mul4_amd64.s and mul4_arm64.s.
+//
+//go:noescape
+func mul4(z, x, y, m *limbs, mInv uint64)
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build (!amd64 && !arm64) || purego
+
+package field
+
+// mul4 sets z = x * y / 2^256 mod m for a modulus m of four limbs, where mInv = -m^-1 mod 2^64. The purego build tag
+// and platforms without assembly use mulGeneric.
+func mul4(z, x, y, m *limbs, mInv uint64) {
+	mulGeneric(z, x, y, m[:4], mInv)
+}
@@ -13,6 +13,19 @@ import (
 	"encoding"
 	"errors"
 	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+var (
+	// ErrInvalidEncoding is the category of all the errors of decoding malformed or invalid encodings.
+	ErrInvalidEncoding = errors.New("invalid encoding")
+
+	// ErrNonCanonical is the category of the errors of decoding valid values from non-canonical encodings.
+	ErrNonCanonical = errors.New("non-canonical encoding")
+
+	// ErrWrongGroup is the category of the errors of mixing elements or scalars of different groups.
+	ErrWrongGroup = errors.New("wrong group")
 )
 
 var (
@@ -23,19 +36,22 @@ var (
 	ErrParamNilScalar = errors.New("nil or empty scalar")
 
 	// ErrParamScalarLength indicates an invalid scalar length.
-	ErrParamScalarLength = errors.New("invalid scalar length")
+	ErrParamScalarLength = newError("invalid scalar length", ErrInvalidEncoding)
+
+	// ErrParamLengthMismatch indicates input lists of different lengths.
+	ErrParamLengthMismatch = errors.New("mismatching input lengths")
 
 	// ErrParamNilPoint indicated a forbidden nil or empty point.
 	ErrParamNilPoint = errors.New("nil or empty point")
 
 	// ErrParamInvalidPointEncoding indicates an invalid point encoding has been provided.
-	ErrParamInvalidPointEncoding = errors.New("invalid point encoding")
+	ErrParamInvalidPointEncoding = newError("invalid point encoding", ErrInvalidEncoding)
 
 	// ErrCastElement indicates a failed attempt to cast to a point.
-	ErrCastElement = errors.New("could not cast to same group element (wrong group ?)")
+	ErrCastElement = newError("could not cast to same group element (wrong group ?)", ErrWrongGroup)
 
 	// ErrCastScalar indicates a failed attempt to cast to a scalar.
-	ErrCastScalar = errors.New("could not cast to same group scalar (wrong group ?)")
+	ErrCastScalar = newError("could not cast to same group scalar (wrong group ?)", ErrWrongGroup)
 
 	// ErrWrongField indicates an incompatible field has been encountered.
 	ErrWrongField = errors.New("incompatible fields")
@@ -43,6 +59,9 @@ var (
 	// ErrIdentity indicates that the identity point (or point at infinity) has been encountered.
 	ErrIdentity = errors.New("infinity/identity point")
 
+	// ErrNotInSubgroup indicates that a point is not in the prime-order subgroup of the curve.
+	ErrNotInSubgroup = errors.New("point not in the prime-order subgroup")
+
 	// ErrBigIntConversion reports an error in converting to a *big.int.
 	ErrBigIntConversion = errors.New("conversion error")
 
@@ -52,19 +71,77 @@ var (
 	// ErrParamScalarTooBig reports an error when the input scalar is too big.
 	ErrParamScalarTooBig = errors.New("scalar too big")
 
-	// ErrParamScalarInvalidEncoding indicates an invalid scalar encoding has been provided, or that it's too big.
-	ErrParamScalarInvalidEncoding = errors.New("invalid scalar encoding")
+	// ErrParamScalarInvalidEncoding indicates an invalid scalar encoding has been provided.
+	ErrParamScalarInvalidEncoding = newError("invalid scalar encoding", ErrInvalidEncoding)
+
+	// ErrParamScalarNotReduced indicates that an encoded scalar is not lower than the order of the group, and has the
+	// message of ErrParamScalarInvalidEncoding, which the backends returned in that case.
+	ErrParamScalarNotReduced = newError("invalid scalar encoding", ErrInvalidEncoding, ErrNonCanonical)
 
 	// ErrUInt64TooBig indicates that the scalar is higher than the allowed values for uint64.
 	ErrUInt64TooBig = errors.New("scalar is too big to be uint64")
 
 	// ErrDecodingInvalidLength indicates an invalid encoding length.
-	ErrDecodingInvalidLength = errors.New("invalid encoding length")
+	ErrDecodingInvalidLength = newError("invalid encoding length", ErrInvalidEncoding)
 
 	// ErrDecodingInvalidJSONEncoding indicates an invalid JSON encoding.
-	ErrDecodingInvalidJSONEncoding = errors.New("invalid JSON encoding")
+	ErrDecodingInvalidJSONEncoding = newError("invalid JSON encoding", ErrInvalidEncoding)
+
+	// ErrDecodingInvalidBase58 indicates an invalid Base58 encoding.
+	ErrDecodingInvalidBase58 = newError("invalid Base58 encoding", ErrInvalidEncoding)
+
+	// ErrDecodingInvalidChecksum indicates a checksum mismatch in the decoded input.
+	ErrDecodingInvalidChecksum = newError("invalid checksum", ErrInvalidEncoding)
+
+	// ErrDecodingInvalidVersion indicates an unsupported version in the decoded input.
+	ErrDecodingInvalidVersion = newError("invalid encoding version", ErrInvalidEncoding)
+
+	// ErrUnsupportedType indicates a value type that can't be encoded or decoded.
+	ErrUnsupportedType = errors.New("unsupported value type")
+
+	// ErrPublicKeyMismatch indicates that an embedded public key does not match the private key.
+	ErrPublicKeyMismatch = errors.New("public key does not match the private key")
+
+	// ErrDeriveKeyPair indicates that no valid key pair could be derived from the input keying material.
+	ErrDeriveKeyPair = errors.New("key pair derivation failed")
 )
 
+// kindError is an error with its own message, that also matches the categories it belongs to with errors.Is.
+type kindError struct {
+	msg   string
+	kinds []error
+}
+
+func newError(msg string, kinds ...error) error {
+	return &kindError{msg: msg, kinds: kinds}
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Unwrap() []error {
+	return e.kinds
+}
+
+// WithKinds returns an error with the message of err, that matches err and the categories with errors.Is, or err
+// itself if it already matches all of them.
+func WithKinds(err error, kinds ...error) error {
+	var missing []error
+
+	for _, kind := range kinds {
+		if !errors.Is(err, kind) {
+			missing = append(missing, kind)
+		}
+	}
+
+	if len(missing) == 0 {
+		return err
+	}
+
+	return &kindError{msg: err.Error(), kinds: append([]error{err}, missing...)}
+}
+
 // An Encoder can encode itself to machine or human-readable forms.
 type Encoder interface {
 	// Encode returns the compressed byte encoding.
@@ -89,13 +166,84 @@ type Decoder interface {
 	encoding.BinaryUnmarshaler
 }
 
-// RandomBytes returns random bytes of length len (wrapper for crypto/rand).
+// randomSource wraps the random source set with SetRandomSource.
+type randomSource struct {
+	io.Reader
+}
+
+var source atomic.Pointer[randomSource]
+
+// SetRandomSource sets the source of randomness of the module, or restores crypto/rand if random is nil.
+func SetRandomSource(random io.Reader) {
+	if random == nil {
+		source.Store(nil)
+		return
+	}
+
+	source.Store(&randomSource{random})
+}
+
+// CustomRandomSource returns the source of randomness set with SetRandomSource, or nil if it's crypto/rand.
+func CustomRandomSource() io.Reader {
+	if r := source.Load(); r != nil {
+		return r.Reader
+	}
+
+	return nil
+}
+
+// RandomSource returns the source of randomness of the module, crypto/rand unless set otherwise.
+func RandomSource() io.Reader {
+	if r := CustomRandomSource(); r != nil {
+		return r
+	}
+
+	return cryptorand.Reader
+}
+
+// RandomBytes returns random bytes of length len, read from RandomSource.
 func RandomBytes(length int) []byte {
 	random := make([]byte, length)
-	if _, err := cryptorand.Read(random); err != nil {
+	if _, err := io.ReadFull(RandomSource(), random); err != nil {
 		// We can as well not panic and try again in a loop
 		panic(fmt.Errorf("unexpected error in generating random bytes : %w", err))
 	}
 
 	return random
 }
+
+// LessOrEqual returns 1 if the big-endian integer a is lower than or equal to b, and 0 otherwise, in constant time.
+// They must have the same length. If littleEndian is set, a and b are read as little-endian integers.
+func LessOrEqual(a, b []byte, littleEndian bool) int {
+	if len(a) != len(b) {
+		panic(ErrParamScalarLength)
+	}
+
+	// gt and lt are set at the most significant differing byte, after which they don't change.
+	var gt, lt uint64
+
+	for i := range a {
+		j := i
+		if littleEndian {
+			j = len(a) - 1 - i
+		}
+
+		x, y := uint64(a[j]), uint64(b[j])
+		undecided := 1 ^ (gt | lt)
+		gt |= undecided & ((y - x) >> 63)
+		lt |= undecided & ((x - y) >> 63)
+	}
+
+	return int(1 ^ gt)
+}
+
+// IsZeroEncoding returns whether data has the given length and only zero bytes, the encoding of the identity by the
+// backends on Weierstrass curves, in constant time with respect to the contents of data.
+func IsZeroEncoding(data []byte, length int) bool {
+	var acc byte
+	for _, b := range data {
+		acc |= b
+	}
+
+	return len(data) == length && acc == 0
+}
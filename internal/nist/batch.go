@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package nist
+
+import (
+	"math/big"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+var (
+	zero = new(big.Int)
+	one  = big.NewInt(1)
+)
+
+// ElementsFromUniform returns the elements mapped from the expand_message_xmd outputs, as in HashToGroup, with a
+// single inversion for all the SSWU mappings.
+func (g *Group[P]) ElementsFromUniform(uniform [][]byte) []internal.Element {
+	points := g.curve.hashUniformBatch(uniform)
+	res := make([]internal.Element, len(points))
+
+	for i, p := range points {
+		res[i] = g.newPoint(p)
+	}
+
+	return res
+}
+
+// hashUniformBatch is hashUniform for each of the uniform outputs, with the inversions of the x coordinates of all
+// the SSWU mappings batched into one.
+func (c *curve[point]) hashUniformBatch(uniform [][]byte) []point {
+	n := 2 * len(uniform)
+	xn, xd, y := make([]*big.Int, n), make([]*big.Int, n), make([]*big.Int, n)
+
+	for i, u := range uniform {
+		xn[2*i], xd[2*i], y[2*i] = c.sswu(c.reduce(u[:c.secLength]))
+		xn[2*i+1], xd[2*i+1], y[2*i+1] = c.sswu(c.reduce(u[c.secLength:]))
+	}
+
+	c.field.BatchInv(xd, xd)
+
+	res := make([]point, len(uniform))
+
+	for i := range res {
+		c.field.Mul(xn[2*i], xn[2*i], xd[2*i])
+		c.field.Mul(xn[2*i+1], xn[2*i+1], xd[2*i+1])
+		q0 := c.affineToPoint(xn[2*i], y[2*i])
+		q1 := c.affineToPoint(xn[2*i+1], y[2*i+1])
+		// We can save cofactor clearing because it is 1.
+		res[i] = q0.Add(q0, q1)
+	}
+
+	return res
+}
+
+// sswu implements the simplified SWU mapping of RFC 9380 section 6.6.2, as hash2curve.MapToCurveSSWU, but returns x
+// as a fraction xn / xd, so that the inversion of xd can be batched. The denominator is never zero.
+func (c *curve[point]) sswu(u *big.Int) (xn, xd, y *big.Int) {
+	var tv1, tv2, tv3, tv5, tv6 big.Int
+
+	xd, xn, y = new(big.Int), new(big.Int), new(big.Int)
+	f := c.field
+
+	f.Mul(&tv1, u, u)       // 1.  tv1 = u^2
+	f.Mul(&tv1, &c.z, &tv1) // 2.  tv1 = Z * tv1
+	f.Mul(&tv2, &tv1, &tv1) // 3.  tv2 = tv1^2
+	f.Add(&tv2, &tv2, &tv1) // 4.  tv2 = tv2 + tv1
+	f.Add(&tv3, &tv2, one)  // 5.  tv3 = tv2 + 1
+	f.Mul(&tv3, &c.b, &tv3) // 6.  tv3 = B * tv3
+	xd.Set(&c.z)            // 7.  tv4 = CMOV(Z, -tv2, tv2 != 0)
+	if tv2.Sign() != 0 {
+		f.Sub(xd, zero, &tv2)
+	}
+
+	f.Mul(xd, &nistWa, xd)     // 8.  tv4 = A * tv4
+	f.Mul(&tv2, &tv3, &tv3)    // 9.  tv2 = tv3^2
+	f.Mul(&tv6, xd, xd)        // 10. tv6 = tv4^2
+	f.Mul(&tv5, &nistWa, &tv6) // 11. tv5 = A * tv6
+	f.Add(&tv2, &tv2, &tv5)    // 12. tv2 = tv2 + tv5
+	f.Mul(&tv2, &tv2, &tv3)    // 13. tv2 = tv2 * tv3
+	f.Mul(&tv6, &tv6, xd)      // 14. tv6 = tv6 * tv4
+	f.Mul(&tv5, &c.b, &tv6)    // 15. tv5 = B * tv6
+	f.Add(&tv2, &tv2, &tv5)    // 16. tv2 = tv2 + tv5
+	f.Mul(xn, &tv1, &tv3)      // 17.   x = tv1 * tv3
+
+	isQR, y1 := c.sqrtRatio(&tv2, &tv6) // 18. (is_gx1_square, y1) = sqrt_ratio(tv2, tv6)
+
+	f.Mul(y, &tv1, u) // 19.   y = tv1 * u
+	f.Mul(y, y, y1)   // 20.   y = y * y1
+
+	if isQR { // 21.   x = CMOV(x, tv3, is_gx1_square), 22.   y = CMOV(y, y1, is_gx1_square)
+		xn.Set(&tv3)
+		y.Set(y1)
+	}
+
+	if u.Bit(0) != y.Bit(0) { // 23. e1 = sgn0(u) == sgn0(y), 24. y = CMOV(-y, y, e1)
+		f.Sub(y, zero, y)
+	}
+
+	return xn, xd, y
+}
+
+// sqrtRatio implements sqrt_ratio of RFC 9380 appendix F.2.1.2 for p = 3 mod 4, and returns whether u / v is a square,
+// and sqrt(u / v) if it is, or sqrt(Z * u / v) otherwise.
+func (c *curve[point]) sqrtRatio(u, v *big.Int) (bool, *big.Int) {
+	var tv1, tv2, tv3, y2 big.Int
+
+	y1 := new(big.Int)
+	f := c.field
+
+	f.Mul(&tv1, v, v)           // 1. tv1 = v^2
+	f.Mul(&tv2, u, v)           // 2. tv2 = u * v
+	f.Mul(&tv1, &tv1, &tv2)     // 3. tv1 = tv1 * tv2
+	f.Exponent(y1, &tv1, &c.c1) // 4. y1 = tv1^c1
+	f.Mul(y1, y1, &tv2)         // 5. y1 = y1 * tv2
+	f.Mul(&y2, y1, &c.c2)       // 6. y2 = y1 * c2
+	f.Mul(&tv3, y1, y1)         // 7. tv3 = y1^2
+	f.Mul(&tv3, &tv3, v)        // 8. tv3 = tv3 * v
+
+	if tv3.Cmp(u) == 0 { // 9. isQR = tv3 == u
+		return true, y1
+	}
+
+	return false, y1.Set(&y2) // 10. y = CMOV(y2, y1, isQR)
+}
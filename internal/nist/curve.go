@@ -14,11 +14,13 @@ import (
 
 	"github.com/bytemare/hash2curve"
 
+	"github.com/bytemare/ecc/internal"
 	"github.com/bytemare/ecc/internal/field"
 )
 
 type mapping struct {
 	z         big.Int
+	c1, c2    big.Int // (p - 3) / 4 and sqrt(-Z), the constants of sqrt_ratio for p = 3 mod 4.
 	hash      crypto.Hash
 	secLength uint
 }
@@ -34,6 +36,14 @@ func (c *curve[point]) setMapping(hash crypto.Hash, z string, secLength uint) {
 	c.mapping.hash = hash
 	c.mapping.secLength = secLength
 	c.mapping.z = field.String2Int(z)
+
+	p := c.field.Order()
+	c.mapping.c1.Sub(p, big.NewInt(3))
+	c.mapping.c1.Rsh(&c.mapping.c1, 2)
+
+	// sqrt(-Z) = (-Z)^((p + 1) / 4)
+	negZ := c.field.Mod(new(big.Int).Neg(&c.mapping.z))
+	c.field.Exponent(&c.mapping.c2, negZ, new(big.Int).Add(&c.mapping.c1, big.NewInt(1)))
 }
 
 func (c *curve[point]) setCurveParams(prime *big.Int, b string, newPoint func() point) {
@@ -43,46 +53,63 @@ func (c *curve[point]) setCurveParams(prime *big.Int, b string, newPoint func()
 }
 
 func (c *curve[point]) encodeXMD(input, dst []byte) point {
-	u := hash2curve.HashToFieldXMD(c.hash, input, dst, 1, 1, c.secLength, c.field.Order())
-	q := c.map2curve(u[0])
+	var uniform [internal.MaxUniformLength]byte
+
+	u := uniform[:c.secLength]
+	internal.ExpandXMD(c.hash, u, input, dst)
+	q := c.map2curve(c.reduce(u))
 	// We can save cofactor clearing because it is 1.
 	return q
 }
 
 func (c *curve[point]) hashXMD(input, dst []byte) point {
-	u := hash2curve.HashToFieldXMD(c.hash, input, dst, 2, 1, c.secLength, c.field.Order())
-	q0 := c.map2curve(u[0])
-	q1 := c.map2curve(u[1])
+	var uniform [internal.MaxUniformLength]byte
+
+	u := uniform[:2*c.secLength]
+	internal.ExpandXMD(c.hash, u, input, dst)
+
+	return c.hashUniform(u)
+}
+
+// hashUniform maps the expand_message_xmd output to two field elements, and returns the sum of their mappings.
+func (c *curve[point]) hashUniform(uniform []byte) point {
+	q0 := c.map2curve(c.reduce(uniform[:c.secLength]))
+	q1 := c.map2curve(c.reduce(uniform[c.secLength:]))
 	// We can save cofactor clearing because it is 1.
 	return q0.Add(q0, q1)
 }
 
+// reduce returns the big-endian uniform bytes reduced modulo the field prime.
+func (c *curve[point]) reduce(uniform []byte) *big.Int {
+	u := new(big.Int).SetBytes(uniform)
+	return u.Mod(u, c.field.Order())
+}
+
+// trace returns the count field elements hashed from input with dst, and the big-endian affine coordinates of their
+// SSWU mappings to the curve.
+func (c *curve[point]) trace(input, dst []byte, count uint) (u [][]byte, q [][2][]byte) {
+	l := c.field.ByteLen()
+
+	for _, fe := range hash2curve.HashToFieldXMD(c.hash, input, dst, count, 1, c.secLength, c.field.Order()) {
+		u = append(u, fe.FillBytes(make([]byte, l)))
+		x, y := hash2curve.MapToCurveSSWU(&nistWa, &c.b, &c.z, fe, c.field.Order())
+		q = append(q, [2][]byte{x.FillBytes(make([]byte, l)), y.FillBytes(make([]byte, l))})
+	}
+
+	return u, q
+}
+
 func (c *curve[point]) map2curve(fe *big.Int) point {
 	x, y := hash2curve.MapToCurveSSWU(&nistWa, &c.b, &c.z, fe, c.field.Order())
 	return c.affineToPoint(x, y)
 }
 
-var (
-	decompressed256 = [65]byte{0x04}
-	decompressed384 = [97]byte{0x04}
-	decompressed521 = [133]byte{0x04}
-)
-
 func (c *curve[point]) affineToPoint(pxc, pyc *big.Int) point {
-	var decompressed []byte
+	// The uncompressed encoding is built on the stack, since the point may be hashed concurrently.
+	var buf [1 + 2*66]byte
 
 	byteLen := c.field.ByteLen()
-	switch byteLen {
-	case 32:
-		decompressed = decompressed256[:]
-	case 48:
-		decompressed = decompressed384[:]
-	case 66:
-		decompressed = decompressed521[:]
-	default:
-		panic("invalid byte length")
-	}
-
+	decompressed := buf[:1+2*byteLen]
 	decompressed[0] = 0x04
 	pxc.FillBytes(decompressed[1 : 1+byteLen])
 	pyc.FillBytes(decompressed[1+byteLen:])
@@ -12,11 +12,11 @@ package nist
 
 import (
 	"crypto"
+	"fmt"
 	"math/big"
 	"sync"
 
 	"filippo.io/nistec"
-	"github.com/bytemare/hash2curve"
 
 	"github.com/bytemare/ecc/internal"
 	"github.com/bytemare/ecc/internal/field"
@@ -51,6 +51,11 @@ const (
 	IdentifierP521 = byte(5)
 )
 
+// IsNIST returns whether the group identifier is that of P256, P384, or P521.
+func IsNIST(id byte) bool {
+	return id == IdentifierP256 || id == IdentifierP384 || id == IdentifierP521
+}
+
 // P256 returns the single instantiation of the P256 Group.
 func P256() internal.Group {
 	initOnceP256.Do(initP256)
@@ -78,12 +83,12 @@ type Group[Point nistECPoint[Point]] struct {
 }
 
 // NewScalar returns a new scalar set to 0.
-func (g Group[P]) NewScalar() internal.Scalar {
+func (g *Group[P]) NewScalar() internal.Scalar {
 	return newScalar(&g.scalarField)
 }
 
 // NewElement returns the identity element (point at infinity).
-func (g Group[P]) NewElement() internal.Element {
+func (g *Group[P]) NewElement() internal.Element {
 	return &Element[P]{
 		p:   g.curve.NewPoint(),
 		new: g.curve.NewPoint,
@@ -91,75 +96,104 @@ func (g Group[P]) NewElement() internal.Element {
 }
 
 // Base returns the group's base point a.k.a. canonical generator.
-func (g Group[P]) Base() internal.Element {
+func (g *Group[P]) Base() internal.Element {
 	b := g.curve.NewPoint()
 	b.SetGenerator()
 
 	return g.newPoint(b)
 }
 
-func (g Group[P]) newPoint(p P) *Element[P] {
+func (g *Group[P]) newPoint(p P) *Element[P] {
 	return &Element[P]{
 		p:   p,
 		new: g.curve.NewPoint,
 	}
 }
 
+// HashToCurveTrace returns the count field elements hashed from input with dst, and the big-endian affine coordinates
+// of their mappings to the curve, before their addition.
+func (g *Group[P]) HashToCurveTrace(input, dst []byte, count uint) (u [][]byte, q [][2][]byte) {
+	return g.curve.trace(input, dst, count)
+}
+
 // HashFunc returns the RFC9380 associated hash function of the group.
-func (g Group[P]) HashFunc() crypto.Hash {
+func (g *Group[P]) HashFunc() crypto.Hash {
 	return g.curve.hash
 }
 
 // HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func (g Group[P]) HashToScalar(input, dst []byte) internal.Scalar {
-	s := hash2curve.HashToFieldXMD(g.curve.hash, input, dst, 1, 1, g.curve.secLength, g.scalarField.Order())[0]
+func (g *Group[P]) HashToScalar(input, dst []byte) internal.Scalar {
+	var uniform [internal.MaxUniformLength]byte
 
-	// If necessary, build a buffer of right size, so it gets correctly interpreted.
-	bytes := s.Bytes()
+	u := uniform[:g.curve.secLength]
+	internal.ExpandXMD(g.curve.hash, u, input, dst)
 
-	length := g.ScalarLength()
-	if l := length - len(bytes); l > 0 {
-		buf := make([]byte, l, length)
-		buf = append(buf, bytes...)
-		bytes = buf
-	}
+	return g.ScalarFromUniform(u)
+}
+
+// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
+// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
+func (g *Group[P]) HashToGroup(input, dst []byte) internal.Element {
+	return g.newPoint(g.curve.hashXMD(input, dst))
+}
 
+// UniformLengths returns the byte lengths of the expand_message_xmd outputs mapped by HashToScalar and HashToGroup.
+func (g *Group[P]) UniformLengths() (scalar, element uint) {
+	return g.curve.secLength, 2 * g.curve.secLength
+}
+
+// ScalarFromUniform returns the scalar mapped from the expand_message_xmd output, as in HashToScalar.
+func (g *Group[P]) ScalarFromUniform(uniform []byte) internal.Scalar {
 	res := newScalar(&g.scalarField)
-	res.scalar.SetBytes(bytes)
+	res.product.SetBytes(uniform)
+	res.quotient.QuoRem(&res.product, g.scalarField.Order(), &res.scalar)
 
 	return res
 }
 
-// HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
-// The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func (g Group[P]) HashToGroup(input, dst []byte) internal.Element {
-	return g.newPoint(g.curve.hashXMD(input, dst))
+// ElementFromUniform returns the element mapped from the expand_message_xmd output, as in HashToGroup.
+func (g *Group[P]) ElementFromUniform(uniform []byte) internal.Element {
+	return g.newPoint(g.curve.hashUniform(uniform))
 }
 
 // EncodeToGroup returns a non-uniform mapping of the arbitrary input to an Element in the Group.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
-func (g Group[P]) EncodeToGroup(input, dst []byte) internal.Element {
+func (g *Group[P]) EncodeToGroup(input, dst []byte) internal.Element {
 	return g.newPoint(g.curve.encodeXMD(input, dst))
 }
 
 // Ciphersuite returns the hash-to-curve ciphersuite identifier.
-func (g Group[P]) Ciphersuite() string {
+func (g *Group[P]) Ciphersuite() string {
 	return g.h2c
 }
 
 // ScalarLength returns the byte size of an encoded element.
-func (g Group[P]) ScalarLength() int {
+func (g *Group[P]) ScalarLength() int {
 	return g.scalarField.ByteLen()
 }
 
 // ElementLength returns the byte size of an encoded element.
-func (g Group[P]) ElementLength() int {
+func (g *Group[P]) ElementLength() int {
 	return 1 + g.scalarField.ByteLen()
 }
 
+// ValidateElementEncoding returns the error of Decode on the data, which it decodes on the stack without retaining it.
+func (g *Group[P]) ValidateElementEncoding(data []byte) error {
+	var p P
+	if err := identityEncodingError(p, data); err != nil {
+		return err
+	}
+
+	if err := validateEncoding(p, data); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	return nil
+}
+
 // Order returns the order of the canonical group of scalars.
-func (g Group[P]) Order() []byte {
+func (g *Group[P]) Order() []byte {
 	out := make([]byte, g.scalarField.ByteLen())
 	return g.scalarField.Order().FillBytes(out)
 }
@@ -8,6 +8,15 @@
 
 package nist
 
+import (
+	"crypto/subtle"
+	"math/bits"
+
+	"filippo.io/nistec"
+
+	"github.com/bytemare/ecc/internal"
+)
+
 type nistECPoint[point any] interface {
 	Add(p1, p2 point) point
 	Negate(p point) point
@@ -22,3 +31,155 @@ type nistECPoint[point any] interface {
 	SetBytes(b []byte) (point, error)
 	SetGenerator() point
 }
+
+// maxScalarLength is the byte length of P-521 scalars, the longest.
+const maxScalarLength = 66
+
+// maxScalarWords is the number of words of the big.Int of P-521 scalars.
+const maxScalarWords = (maxScalarLength + bits.UintSize/8 - 1) / (bits.UintSize / 8)
+
+// The uncompressed encodings of the generators, to detect base point multiplications.
+var (
+	p256Generator = nistec.NewP256Point().SetGenerator().Bytes()
+	p384Generator = nistec.NewP384Point().SetGenerator().Bytes()
+	p521Generator = nistec.NewP521Point().SetGenerator().Bytes()
+)
+
+// The following functions dispatch on the concrete point types rather than through the nistECPoint constraint, as
+// calls on type parameters are indirect: this lets the compiler inline the backend's encoders, which are outlined for
+// that purpose, and keep their buffers and the scalars on the stack, so that the hot paths don't allocate.
+
+// isInfinity returns whether the point is the point at infinity, whose uncompressed encoding is a single byte.
+func isInfinity(p any) bool {
+	switch p := p.(type) {
+	case *nistec.P256Point:
+		return len(p.Bytes()) == 1
+	case *nistec.P384Point:
+		return len(p.Bytes()) == 1
+	case *nistec.P521Point:
+		return len(p.Bytes()) == 1
+	}
+
+	panic(internal.ErrCastElement)
+}
+
+// The points at infinity, which setInfinity copies in place.
+var (
+	p256Infinity = nistec.NewP256Point()
+	p384Infinity = nistec.NewP384Point()
+	p521Infinity = nistec.NewP521Point()
+)
+
+// setInfinity sets the point to the point at infinity, without allocating a new point.
+func setInfinity(p any) {
+	switch p := p.(type) {
+	case *nistec.P256Point:
+		p.Set(p256Infinity)
+	case *nistec.P384Point:
+		p.Set(p384Infinity)
+	case *nistec.P521Point:
+		p.Set(p521Infinity)
+	default:
+		panic(internal.ErrCastElement)
+	}
+}
+
+// identityEncodingError returns an error matching internal.ErrIdentity if the data encodes the identity for points of
+// the type of p, either as the single zero byte of SEC 1, which SetBytes accepts, or as the zero bytes of the length
+// of a compressed point returned by Encode, and nil otherwise.
+func identityEncodingError(p any, data []byte) error {
+	var (
+		length int
+		err    error
+	)
+
+	switch p.(type) {
+	case *nistec.P256Point:
+		length, err = p256CompressedEncodingLength, errP256Identity
+	case *nistec.P384Point:
+		length, err = p384CompressedEncodingLength, errP384Identity
+	case *nistec.P521Point:
+		length, err = p521CompressedEncodingLength, errP521Identity
+	default:
+		panic(internal.ErrCastElement)
+	}
+
+	if internal.IsZeroEncoding(data, 1) || internal.IsZeroEncoding(data, length) {
+		return err
+	}
+
+	return nil
+}
+
+// validateEncoding returns the error of the decoding of the data into a new point of the type of p, which the
+// compiler keeps on the stack.
+func validateEncoding(p any, data []byte) (err error) {
+	switch p.(type) {
+	case *nistec.P256Point:
+		_, err = nistec.NewP256Point().SetBytes(data)
+	case *nistec.P384Point:
+		_, err = nistec.NewP384Point().SetBytes(data)
+	case *nistec.P521Point:
+		_, err = nistec.NewP521Point().SetBytes(data)
+	default:
+		panic(internal.ErrCastElement)
+	}
+
+	return err
+}
+
+// equal returns 1 if the points, of the same type, are equal, and 0 otherwise, in constant time.
+func equal(p, q any) int {
+	switch p := p.(type) {
+	case *nistec.P256Point:
+		return subtle.ConstantTimeCompare(p.Bytes(), q.(*nistec.P256Point).Bytes())
+	case *nistec.P384Point:
+		return subtle.ConstantTimeCompare(p.Bytes(), q.(*nistec.P384Point).Bytes())
+	case *nistec.P521Point:
+		return subtle.ConstantTimeCompare(p.Bytes(), q.(*nistec.P521Point).Bytes())
+	}
+
+	panic(internal.ErrCastElement)
+}
+
+// isGenerator returns whether the point is the base point.
+func isGenerator(p any) bool {
+	switch p := p.(type) {
+	case *nistec.P256Point:
+		return subtle.ConstantTimeCompare(p.Bytes(), p256Generator) == 1
+	case *nistec.P384Point:
+		return subtle.ConstantTimeCompare(p.Bytes(), p384Generator) == 1
+	case *nistec.P521Point:
+		return subtle.ConstantTimeCompare(p.Bytes(), p521Generator) == 1
+	}
+
+	panic(internal.ErrCastElement)
+}
+
+// scalarMult sets p to scalar * p, with the base point's precomputed tables if base is true.
+func scalarMult(p any, scalar []byte, base bool) (err error) {
+	switch p := p.(type) {
+	case *nistec.P256Point:
+		if base {
+			_, err = p.ScalarBaseMult(scalar)
+		} else {
+			_, err = p.ScalarMult(p, scalar)
+		}
+	case *nistec.P384Point:
+		if base {
+			_, err = p.ScalarBaseMult(scalar)
+		} else {
+			_, err = p.ScalarMult(p, scalar)
+		}
+	case *nistec.P521Point:
+		if base {
+			_, err = p.ScalarBaseMult(scalar)
+		} else {
+			_, err = p.ScalarMult(p, scalar)
+		}
+	default:
+		panic(internal.ErrCastElement)
+	}
+
+	return err
+}
@@ -9,7 +9,6 @@
 package nist
 
 import (
-	"crypto/subtle"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -23,6 +22,9 @@ import (
 type Scalar struct {
 	field  *field.Field
 	scalar big.Int
+
+	// product and quotient are scratch space for Multiply, whose memory is reused across calls.
+	product, quotient big.Int
 }
 
 func newScalar(f *field.Field) *Scalar {
@@ -37,6 +39,11 @@ func newScalar(f *field.Field) *Scalar {
 func (s *Scalar) assert(scalar internal.Scalar) *Scalar {
 	_sc, ok := scalar.(*Scalar)
 	if !ok {
+		// The scalars of a NIST group of another backend, e.g. P-384 with the circl build tag, are of another field.
+		if IsNIST(scalar.Group()) {
+			panic(internal.ErrWrongField)
+		}
+
 		panic(internal.ErrCastScalar)
 	}
 
@@ -97,8 +104,11 @@ func (s *Scalar) Add(scalar internal.Scalar) internal.Scalar {
 		return s
 	}
 
+	// Both scalars are reduced, so a conditional subtraction of the order reduces the sum without allocating.
 	sc := s.assert(scalar)
-	s.field.Add(&s.scalar, &s.scalar, &sc.scalar)
+	if s.scalar.Add(&s.scalar, &sc.scalar).Cmp(s.field.Order()) >= 0 {
+		s.scalar.Sub(&s.scalar, s.field.Order())
+	}
 
 	return s
 }
@@ -110,7 +120,9 @@ func (s *Scalar) Subtract(scalar internal.Scalar) internal.Scalar {
 	}
 
 	sc := s.assert(scalar)
-	s.field.Sub(&s.scalar, &s.scalar, &sc.scalar)
+	if s.scalar.Sub(&s.scalar, &sc.scalar).Sign() < 0 {
+		s.scalar.Add(&s.scalar, s.field.Order())
+	}
 
 	return s
 }
@@ -122,7 +134,14 @@ func (s *Scalar) Multiply(scalar internal.Scalar) internal.Scalar {
 	}
 
 	sc := s.assert(scalar)
-	s.field.Mul(&s.scalar, &s.scalar, &sc.scalar)
+
+	if m := s.field.Montgomery(); m != nil {
+		m.Mul(&s.scalar, &s.scalar, &sc.scalar)
+		return s
+	}
+
+	s.product.Mul(&s.scalar, &sc.scalar)
+	s.quotient.QuoRem(&s.product, s.field.Order(), &s.scalar)
 
 	return s
 }
@@ -149,7 +168,7 @@ func (s *Scalar) Invert() internal.Scalar {
 	return s
 }
 
-// Equal returns 1 if the scalars are equal, and 0 otherwise.
+// Equal returns 1 if the scalars are equal, and 0 otherwise, in constant time.
 func (s *Scalar) Equal(scalar internal.Scalar) int {
 	if scalar == nil {
 		return 0
@@ -157,32 +176,25 @@ func (s *Scalar) Equal(scalar internal.Scalar) int {
 
 	sc := s.assert(scalar)
 
-	return subtle.ConstantTimeCompare(s.scalar.Bytes(), sc.scalar.Bytes())
-}
+	// The words are compared rather than the encodings, as big.Int.FillBytes takes time depending on the bit length of
+	// the most significant word, which only has 9 bits in P-521.
+	var a, b [maxScalarWords]big.Word
 
-// LessOrEqual returns 1 if s <= scalar, and 0 otherwise.
-func (s *Scalar) LessOrEqual(scalar internal.Scalar) int {
-	sc := s.assert(scalar)
+	copy(a[:], s.scalar.Bits())
+	copy(b[:], sc.scalar.Bits())
 
-	ienc := s.Encode()
-	jenc := sc.Encode()
-
-	leni := len(ienc)
-	if leni != len(jenc) {
-		panic(internal.ErrParamScalarLength)
-	}
-
-	var res bool
-
-	for i := 0; i < leni; i++ {
-		res = res || (ienc[i] > jenc[i])
+	var diff uint64
+	for i := range a {
+		diff |= uint64(a[i] ^ b[i])
 	}
 
-	if res {
-		return 0
-	}
+	return int((diff|-diff)>>63) ^ 1
+}
 
-	return 1
+// LessOrEqual returns 1 if s <= scalar, and 0 otherwise.
+func (s *Scalar) LessOrEqual(scalar internal.Scalar) int {
+	sc := s.assert(scalar)
+	return internal.LessOrEqual(s.Encode(), sc.Encode(), false)
 }
 
 // IsZero returns whether the scalar is 0.
@@ -234,6 +246,11 @@ func (s *Scalar) Copy() internal.Scalar {
 	return cpy
 }
 
+// fill returns the big-endian encoding of the scalar in the buffer, without allocating.
+func (s *Scalar) fill(buf *[maxScalarLength]byte) []byte {
+	return s.scalar.FillBytes(buf[:s.field.ByteLen()])
+}
+
 // Encode returns the compressed byte encoding of the scalar.
 func (s *Scalar) Encode() []byte {
 	scalar := make([]byte, s.field.ByteLen())
@@ -258,7 +275,7 @@ func (s *Scalar) Decode(in []byte) error {
 	tmp := new(big.Int).SetBytes(in)
 
 	if s.field.Order().Cmp(tmp) <= 0 {
-		return internal.ErrParamScalarInvalidEncoding
+		return internal.ErrParamScalarNotReduced
 	}
 
 	s.scalar.Set(tmp)
@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ristretto
+
+import (
+	ed "filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+var (
+	feOne = new(field.Element).One()
+
+	// d is the Edwards25519 curve constant -121665/121666.
+	d = func() *field.Element {
+		n := new(field.Element).Mult32(feOne, 121665)
+		v := new(field.Element).Mult32(feOne, 121666)
+
+		return n.Negate(n.Multiply(n, v.Invert(v)))
+	}()
+
+	// sqrtM1 is the non-negative square root of -1.
+	sqrtM1, _ = new(field.Element).SqrtRatio(new(field.Element).Negate(feOne), feOne)
+
+	// invSqrtAMinusD is the non-negative 1/sqrt(a-d), with a = -1.
+	invSqrtAMinusD, _ = new(field.Element).SqrtRatio(
+		feOne,
+		new(field.Element).Subtract(new(field.Element).Negate(feOne), d),
+	)
+)
+
+// EdwardsPoint returns an Edwards25519 point representing the element, as decoded in RFC 9496 section 4.3.1. The
+// element is the equivalence class of this point modulo the 4-torsion subgroup.
+func (e *Element) EdwardsPoint() *ed.Point {
+	s, _ := new(field.Element).SetBytes(e.element.Encode(nil))
+
+	ss := new(field.Element).Square(s)
+	u1 := new(field.Element).Subtract(feOne, ss)
+	u2 := new(field.Element).Add(feOne, ss)
+	u2Sqr := new(field.Element).Square(u2)
+
+	// v = -(d * u1^2) - u2^2
+	v := new(field.Element).Square(u1)
+	v.Multiply(v, d)
+	v.Negate(v)
+	v.Subtract(v, u2Sqr)
+
+	invSqrt, _ := new(field.Element).SqrtRatio(feOne, new(field.Element).Multiply(v, u2Sqr))
+
+	denX := new(field.Element).Multiply(invSqrt, u2)
+	denY := new(field.Element).Multiply(invSqrt, denX)
+	denY.Multiply(denY, v)
+
+	x := new(field.Element).Multiply(s, denX)
+	x.Add(x, x)
+	x.Absolute(x)
+
+	y := new(field.Element).Multiply(u1, denY)
+	t := new(field.Element).Multiply(x, y)
+
+	p, err := new(ed.Point).SetExtendedCoordinates(x, y, new(field.Element).One(), t)
+	if err != nil {
+		// Valid Ristretto255 encodings always decode to a point on the curve.
+		panic(err)
+	}
+
+	return p
+}
+
+// SetEdwardsPoint sets e to the element represented by the Edwards25519 point, using the encoding of RFC 9496
+// section 4.3.2. It returns an error if the point doesn't represent a Ristretto255 element.
+func (e *Element) SetEdwardsPoint(p *ed.Point) error {
+	if p == nil {
+		return internal.ErrParamNilPoint
+	}
+
+	var r Element
+	if err := r.element.Decode(encodeEdwards(p)); err != nil {
+		return internal.ErrParamInvalidPointEncoding
+	}
+
+	// Points outside the subgroup of even elements encode to another element's representative.
+	if !equalModTorsion(p, r.EdwardsPoint()) {
+		return internal.ErrParamInvalidPointEncoding
+	}
+
+	e.element = r.element
+
+	return nil
+}
+
+func encodeEdwards(p *ed.Point) []byte {
+	x0, y0, z0, t0 := p.ExtendedCoordinates()
+
+	u1 := new(field.Element).Add(z0, y0)
+	u1.Multiply(u1, new(field.Element).Subtract(z0, y0))
+	u2 := new(field.Element).Multiply(x0, y0)
+
+	w := new(field.Element).Square(u2)
+	invSqrt, _ := new(field.Element).SqrtRatio(feOne, w.Multiply(w, u1))
+
+	den1 := new(field.Element).Multiply(invSqrt, u1)
+	den2 := new(field.Element).Multiply(invSqrt, u2)
+	zInv := new(field.Element).Multiply(den1, den2)
+	zInv.Multiply(zInv, t0)
+
+	ix0 := new(field.Element).Multiply(x0, sqrtM1)
+	iy0 := new(field.Element).Multiply(y0, sqrtM1)
+	enchantedDenominator := new(field.Element).Multiply(den1, invSqrtAMinusD)
+
+	rotate := new(field.Element).Multiply(t0, zInv).IsNegative()
+
+	x := new(field.Element).Select(iy0, x0, rotate)
+	y := new(field.Element).Select(ix0, y0, rotate)
+	denInv := new(field.Element).Select(enchantedDenominator, den2, rotate)
+
+	y.Select(new(field.Element).Negate(y), y, new(field.Element).Multiply(x, zInv).IsNegative())
+
+	s := new(field.Element).Subtract(z0, y)
+	s.Multiply(s, denInv)
+
+	return s.Absolute(s).Bytes()
+}
+
+// equalModTorsion implements the Ristretto255 equality of RFC 9496 section 4.3.3 on Edwards25519 points.
+func equalModTorsion(p, q *ed.Point) bool {
+	x1, y1, _, _ := p.ExtendedCoordinates()
+	x2, y2, _, _ := q.ExtendedCoordinates()
+
+	a := new(field.Element).Multiply(x1, y2)
+	b := new(field.Element).Multiply(y1, x2)
+	c := new(field.Element).Multiply(y1, y2)
+	f := new(field.Element).Multiply(x1, x2)
+
+	return a.Equal(b)|c.Equal(f) == 1
+}
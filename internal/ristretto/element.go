@@ -18,6 +18,10 @@ import (
 	"github.com/bytemare/ecc/internal"
 )
 
+// generator is the base point, which Multiply compares the receiver to in order to use the precomputed tables of
+// ScalarBaseMult.
+var generator = ristretto255.NewElement().Base()
+
 // Element implements the Element interface for the Ristretto255 group element.
 type Element struct {
 	element ristretto255.Element
@@ -89,7 +93,11 @@ func (e *Element) Multiply(scalar internal.Scalar) internal.Element {
 	}
 
 	sc := assert(scalar)
-	e.element.ScalarMult(&sc.scalar, &e.element)
+	if e.element.Equal(generator) == 1 {
+		e.element.ScalarBaseMult(&sc.scalar)
+	} else {
+		e.element.ScalarMult(&sc.scalar, &e.element)
+	}
 
 	return e
 }
@@ -127,12 +135,7 @@ func (e *Element) Set(element internal.Element) internal.Element {
 
 // Copy returns a copy of the receiver.
 func (e *Element) Copy() internal.Element {
-	n := ristretto255.NewElement()
-	if err := n.Decode(e.element.Encode(nil)); err != nil {
-		panic(err)
-	}
-
-	return &Element{element: *n}
+	return &Element{element: e.element}
 }
 
 // Encode returns the compressed byte encoding of the element.
@@ -145,32 +148,40 @@ func (e *Element) XCoordinate() []byte {
 	return e.Encode()
 }
 
-func decodeElement(element []byte) (*ristretto255.Element, error) {
+// decodeElement sets e to the decoding of element, which it leaves unchanged on failure.
+func decodeElement(e *ristretto255.Element, element []byte) error {
 	if len(element) == 0 {
-		return nil, internal.ErrParamInvalidPointEncoding
+		return internal.ErrParamInvalidPointEncoding
 	}
 
-	e := ristretto255.NewElement()
-	if err := e.Decode(element); err != nil {
-		return nil, fmt.Errorf("%w", err)
+	var d ristretto255.Element
+	if err := d.Decode(element); err != nil {
+		return fmt.Errorf("%w", err)
+	}
+
+	// superfluous identity check
+	if d.Equal(ristretto255.NewElement().Zero()) == 1 {
+		return fmt.Errorf("invalid Ristretto encoding: %w", internal.ErrIdentity)
 	}
 
-	return e, nil
+	*e = d
+
+	return nil
 }
 
 // Decode sets the receiver to a decoding of the input data, and returns an error on failure.
 func (e *Element) Decode(data []byte) error {
-	element, err := decodeElement(data)
-	if err != nil {
-		return err
-	}
+	return decodeElement(&e.element, data)
+}
 
-	// superfluous identity check
-	if element.Equal(ristretto255.NewElement().Zero()) == 1 {
-		return fmt.Errorf("invalid Ristretto encoding: %w", internal.ErrIdentity)
+// SetUniformBytes sets e to the element mapped from the 64 uniformly random bytes by the one-way map of RFC 9496
+// section 4.3.4.
+func (e *Element) SetUniformBytes(uniform []byte) error {
+	if len(uniform) != inputLength {
+		return internal.ErrDecodingInvalidLength
 	}
 
-	e.element = *element
+	e.element.FromUniformBytes(uniform)
 
 	return nil
 }
@@ -13,7 +13,6 @@ import (
 	"crypto"
 	"slices"
 
-	"github.com/bytemare/hash2curve"
 	"github.com/gtank/ristretto255"
 
 	"github.com/bytemare/ecc/internal"
@@ -60,15 +59,33 @@ func (g Group) HashFunc() crypto.Hash {
 // HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func (g Group) HashToScalar(input, dst []byte) internal.Scalar {
-	uniform := hash2curve.ExpandXMD(crypto.SHA512, input, dst, inputLength)
-	return &Scalar{*ristretto255.NewScalar().FromUniformBytes(uniform)}
+	var uniform [inputLength]byte
+	internal.ExpandXMD(crypto.SHA512, uniform[:], input, dst)
+
+	return g.ScalarFromUniform(uniform[:])
 }
 
 // HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func (g Group) HashToGroup(input, dst []byte) internal.Element {
-	uniform := hash2curve.ExpandXMD(crypto.SHA512, input, dst, inputLength)
+	var uniform [inputLength]byte
+	internal.ExpandXMD(crypto.SHA512, uniform[:], input, dst)
+
+	return g.ElementFromUniform(uniform[:])
+}
+
+// UniformLengths returns the byte lengths of the expand_message_xmd outputs mapped by HashToScalar and HashToGroup.
+func (g Group) UniformLengths() (scalar, element uint) {
+	return inputLength, inputLength
+}
 
+// ScalarFromUniform returns the scalar mapped from the expand_message_xmd output, as in HashToScalar.
+func (g Group) ScalarFromUniform(uniform []byte) internal.Scalar {
+	return &Scalar{*ristretto255.NewScalar().FromUniformBytes(uniform)}
+}
+
+// ElementFromUniform returns the element mapped from the expand_message_xmd output, as in HashToGroup.
+func (g Group) ElementFromUniform(uniform []byte) internal.Element {
 	return &Element{*ristretto255.NewElement().FromUniformBytes(uniform)}
 }
 
@@ -93,6 +110,12 @@ func (g Group) ElementLength() int {
 	return canonicalEncodingLength
 }
 
+// ValidateElementEncoding returns the error of Decode on the data, which it decodes on the stack without retaining it.
+func (g Group) ValidateElementEncoding(data []byte) error {
+	var e ristretto255.Element
+	return decodeElement(&e, data)
+}
+
 // Order returns the order of the canonical group of scalars.
 func (g Group) Order() []byte {
 	return slices.Clone(orderBytes)
@@ -14,6 +14,7 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	ed "filippo.io/edwards25519"
 	"github.com/gtank/ristretto255"
 
 	"github.com/bytemare/ecc/internal"
@@ -172,11 +173,13 @@ func (s *Scalar) square() {
 
 // Pow sets s to s**scalar modulo the group order, and returns s. If scalar is nil, it returns 1.
 func (s *Scalar) Pow(scalar internal.Scalar) internal.Scalar {
-	s1 := s.copy()
-	s2 := s.copy()
+	var enc [canonicalEncodingLength]byte
+
+	s1 := &Scalar{scalar: s.scalar}
+	s2 := &Scalar{scalar: s.scalar}
 	s2.square()
 
-	bytes := assert(scalar).Encode()
+	bytes := assert(scalar).scalar.Encode(enc[:0])
 	msbyte := getMSByte(bytes)
 	msbit := getMSBit(bytes[msbyte])
 
@@ -239,25 +242,9 @@ func (s *Scalar) Equal(scalar internal.Scalar) int {
 func (s *Scalar) LessOrEqual(scalar internal.Scalar) int {
 	sc := assert(scalar)
 
-	ienc := s.Encode()
-	jenc := sc.Encode()
-
-	i := len(ienc)
-	if i != len(jenc) {
-		panic(internal.ErrParamScalarLength)
-	}
-
-	var res bool
-
-	for i--; i >= 0; i-- {
-		res = res || (ienc[i] > jenc[i])
-	}
+	var ibuf, jbuf [canonicalEncodingLength]byte
 
-	if res {
-		return 0
-	}
-
-	return 1
+	return internal.LessOrEqual(s.scalar.Encode(ibuf[:0]), sc.scalar.Encode(jbuf[:0]), true)
 }
 
 // IsZero returns whether the scalar is 0.
@@ -309,7 +296,7 @@ func (s *Scalar) UInt64() (uint64, error) {
 }
 
 func (s *Scalar) copy() *Scalar {
-	return &Scalar{*ristretto255.NewScalar().Add(ristretto255.NewScalar(), &s.scalar)}
+	return &Scalar{scalar: s.scalar}
 }
 
 // Copy returns a copy of the receiver.
@@ -331,8 +318,9 @@ func (s *Scalar) decodeScalar(scalar []byte) error {
 		return internal.ErrParamScalarLength
 	}
 
+	// The decoding of 32 bytes only fails on values that are not reduced modulo the order.
 	if err := s.scalar.Decode(scalar); err != nil {
-		return fmt.Errorf("%w", err)
+		return internal.WithKinds(fmt.Errorf("%w", err), internal.ErrParamScalarNotReduced)
 	}
 
 	return nil
@@ -357,3 +345,23 @@ func (s *Scalar) DecodeHex(h string) error {
 
 	return s.Decode(b)
 }
+
+// EdwardsScalar returns the scalar as an Edwards25519 scalar, as both share the same scalar field and encoding.
+func (s *Scalar) EdwardsScalar() *ed.Scalar {
+	e, err := ed.NewScalar().SetCanonicalBytes(s.Encode())
+	if err != nil {
+		// Ristretto255 scalars are always canonically encoded.
+		panic(err)
+	}
+
+	return e
+}
+
+// SetEdwardsScalar sets s to the Edwards25519 scalar.
+func (s *Scalar) SetEdwardsScalar(scalar *ed.Scalar) error {
+	if scalar == nil {
+		return internal.ErrParamNilScalar
+	}
+
+	return s.Decode(scalar.Bytes())
+}
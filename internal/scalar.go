@@ -42,7 +42,7 @@ type Scalar interface {
 	// Invert sets the receiver to the scalar's modular inverse ( 1 / scalar ), and returns it.
 	Invert() Scalar
 
-	// Equal returns 1 if the scalars are equal, and 0 otherwise.
+	// Equal returns 1 if the scalars are equal, and 0 otherwise, in constant time.
 	Equal(Scalar) int
 
 	// LessOrEqual returns 1 if s <= scalar, and 0 otherwise.
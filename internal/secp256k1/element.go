@@ -73,19 +73,31 @@ func (e *Element) Double() internal.Element {
 
 // Negate sets the receiver to its negation, and returns it.
 func (e *Element) Negate() internal.Element {
-	e.element.Negate()
+	negate(e.element)
 	return e
 }
 
+// negate sets p to -p, and returns it. The secp256k1 package's negation leaves a negative y coordinate, which affine
+// points encode with the wrong parity, so -p is computed as p + (-2p), whose coordinates the addition reduces.
+func negate(p *secp256k1.Element) *secp256k1.Element {
+	if p.IsIdentity() {
+		return p
+	}
+
+	return p.Add(p.Copy().Double().Negate())
+}
+
 // Subtract subtracts the input from the receiver, and returns the receiver.
 func (e *Element) Subtract(element internal.Element) internal.Element {
 	q := assertElement(element)
-	e.element.Subtract(q.element)
+	e.element.Add(negate(q.element.Copy()))
 
 	return e
 }
 
 // Multiply sets the receiver to the scalar multiplication of the receiver with the given Scalar, and returns it.
+// It uses the Montgomery ladder of the secp256k1 package, which doesn't read tables at indices derived from the
+// scalar, as the scalar may be secret.
 func (e *Element) Multiply(scalar internal.Scalar) internal.Element {
 	s := assert(scalar)
 	e.element.Multiply(s.scalar)
@@ -93,10 +105,31 @@ func (e *Element) Multiply(scalar internal.Scalar) internal.Element {
 	return e
 }
 
-// Equal returns 1 if the elements are equivalent, and 0 otherwise.
+// DoubleMultiply sets the receiver to a * e + b * G, where G is the base point, with a joint ladder over the GLV
+// decompositions of both scalars, and returns it. It is variable time, and only used by VarTime.DoubleMultiply on
+// public scalars.
+func (e *Element) DoubleMultiply(a, b internal.Scalar) internal.Element {
+	sa, sb := assert(a), assert(b)
+	p := e.element
+	e.element.Set(glvMultiply(
+		[]*Scalar{sa, sb},
+		[]*secp256k1.Element{p, secp256k1.Base()},
+		[]*secp256k1.Element{endomorphism(p), basePhi},
+	))
+
+	return e
+}
+
+// Equal returns 1 if the elements are equivalent, and 0 otherwise, in constant time. The complete projective addition
+// of the secp256k1 package computes e - element without branching on the coordinates, which cross-multiplies them, and
+// its Z coordinate is zero if and only if the elements are equal, so that neither is converted to affine coordinates.
 func (e *Element) Equal(element internal.Element) int {
 	q := assertElement(element)
-	return e.element.Equal(q.element)
+	if e.element.Copy().Subtract(q.element).IsIdentity() {
+		return 1
+	}
+
+	return 0
 }
 
 // IsIdentity returns whether the Element is the point at infinity of the Group's underlying curve.
@@ -134,7 +167,14 @@ func (e *Element) XCoordinate() []byte {
 // Decode sets the receiver to a decoding of the input data, and returns an error on failure.
 func (e *Element) Decode(data []byte) error {
 	if err := e.element.Decode(data); err != nil {
-		return fmt.Errorf("invalid secp256k1 encoding: %w", err)
+		err = fmt.Errorf("invalid secp256k1 encoding: %w", err)
+
+		// The zero bytes returned by Encode for the identity are rejected as an invalid point encoding.
+		if internal.IsZeroEncoding(data, secp256k1.ElementLength()) {
+			return internal.WithKinds(err, internal.ErrIdentity)
+		}
+
+		return err
 	}
 
 	return nil
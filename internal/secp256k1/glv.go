@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+
+	"github.com/bytemare/secp256k1"
+
+	"github.com/bytemare/ecc/internal/field"
+)
+
+const (
+	// glvBits is the bit length bound of the halves of the decomposed scalars.
+	glvBits = 128
+
+	glvLambda = "5363ad4cc05c30e0a5261c028812645a122e22ea20816678df02967c1b23bd72"
+)
+
+// u256 is a little-endian integer of 64-bit limbs.
+type u256 [4]uint64
+
+// The parameters of the GLV endomorphism phi(x, y) = (beta * x, y) = lambda * (x, y) and of the scalar decomposition,
+// as in libsecp256k1's secp256k1_scalar_split_lambda.
+var (
+	glvBeta        = hexBytes("7ae96a2b657c07106e64479eac3434e99cf0497512f58995c1396c28719501ee")
+	glvMinusLambda = new(big.Int).Sub(groupOrder, setHex(glvLambda)).FillBytes(make([]byte, scalarLength))
+	glvMinusB1     = hexBytes("00000000000000000000000000000000e4437ed6010e88286f547fa90abfe4c3")
+	glvMinusB2     = hexBytes("fffffffffffffffffffffffffffffffe8a280ac50774346dd765cda83db1562c")
+	glvG1          = u256FromBytes(hexBytes("3086d221a7d46bcde86c90e49284eb153daa8a1471e8ca7fe893209a45dbb031"))
+	glvG2          = u256FromBytes(hexBytes("e4437ed6010e88286f547fa90abfe4c4221208ac9df506c61571b4ae8ac47f71"))
+	glvOrder       = u256FromBytes(groupOrder.FillBytes(make([]byte, scalarLength)))
+	glvHalfOrder   = u256FromBytes(new(big.Int).Rsh(groupOrder, 1).FillBytes(make([]byte, scalarLength)))
+
+	scalarField = field.NewMontgomery(groupOrder)
+	baseField   = field.NewMontgomery(fieldPrime)
+
+	// basePhi is phi(G), for the double multiplications with the base point.
+	basePhi = endomorphism(secp256k1.Base())
+)
+
+func hexBytes(s string) []byte {
+	return setHex(s).FillBytes(make([]byte, scalarLength))
+}
+
+func u256FromBytes(b []byte) (z u256) {
+	for i := range z {
+		z[i] = binary.BigEndian.Uint64(b[len(b)-8*(i+1):])
+	}
+
+	return z
+}
+
+func (z *u256) fillBytes(b []byte) {
+	for i := range z {
+		binary.BigEndian.PutUint64(b[len(b)-8*(i+1):], z[i])
+	}
+}
+
+// mulShift384 returns round(x * y / 2^384).
+func mulShift384(x, y *u256) (z u256) {
+	var p [8]uint64
+
+	for i, xi := range x {
+		var c uint64
+
+		for j, yj := range y {
+			hi, lo := bits.Mul64(xi, yj)
+			lo, carry := bits.Add64(lo, p[i+j], 0)
+			hi += carry
+			lo, carry = bits.Add64(lo, c, 0)
+			p[i+j], c = lo, hi+carry
+		}
+
+		p[i+len(y)] = c
+	}
+
+	var carry uint64
+	z[0], carry = bits.Add64(p[6], p[5]>>63, 0)
+	z[1], _ = bits.Add64(p[7], 0, carry)
+
+	return z
+}
+
+// addMod returns x + y mod n, for x and y lower than n.
+func addMod(x, y *u256) (z u256) {
+	var s, d u256
+
+	var carry, borrow uint64
+	for i := range s {
+		s[i], carry = bits.Add64(x[i], y[i], carry)
+	}
+
+	for i := range d {
+		d[i], borrow = bits.Sub64(s[i], glvOrder[i], borrow)
+	}
+
+	mask := -(carry | (1 - borrow)) // all ones if s >= n
+	for i := range z {
+		z[i] = d[i]&mask | s[i]&^mask
+	}
+
+	return z
+}
+
+// signedAbs returns the absolute value of x as a signed integer in (-n/2, n/2], and 1 if it is negative.
+func signedAbs(x *u256) (z u256, neg uint64) {
+	var d, m u256
+
+	for i := range d {
+		d[i], neg = bits.Sub64(glvHalfOrder[i], x[i], neg)
+	}
+
+	var borrow uint64
+	for i := range m {
+		m[i], borrow = bits.Sub64(glvOrder[i], x[i], borrow)
+	}
+
+	mask := -neg
+	for i := range z {
+		z[i] = m[i]&mask | x[i]&^mask
+	}
+
+	return z, neg
+}
+
+// mulMod returns x * y mod n, for x and y lower than n.
+func mulMod(x *u256, y []byte) u256 {
+	var a [scalarLength]byte
+
+	x.fillBytes(a[:])
+	scalarField.MulBytes(a[:], a[:], y)
+
+	return u256FromBytes(a[:])
+}
+
+// splitScalar returns k1 and k2 of at most 128 bits, and their signs, such that k = (-1)^neg1 * k1 + (-1)^neg2 * k2 *
+// lambda mod n, for the big-endian encoding of k lower than n, in constant time.
+func splitScalar(enc []byte) (k1, k2 u256, neg1, neg2 uint64) {
+	k := u256FromBytes(enc)
+	c1 := mulShift384(&k, &glvG1)
+	c2 := mulShift384(&k, &glvG2)
+	c1 = mulMod(&c1, glvMinusB1)
+	c2 = mulMod(&c2, glvMinusB2)
+	r2 := addMod(&c1, &c2)
+	r1 := mulMod(&r2, glvMinusLambda)
+	r1 = addMod(&r1, &k)
+
+	k1, neg1 = signedAbs(&r1)
+	k2, neg2 = signedAbs(&r2)
+
+	return k1, k2, neg1, neg2
+}
+
+// endomorphism returns phi(p) = (beta * x, y), which is lambda * p.
+func endomorphism(p *secp256k1.Element) *secp256k1.Element {
+	if p.IsIdentity() {
+		return secp256k1.NewElement()
+	}
+
+	enc := p.Encode()
+	baseField.MulBytes(enc[1:], enc[1:], glvBeta)
+
+	q := secp256k1.NewElement()
+	if err := q.Decode(enc); err != nil {
+		panic(err)
+	}
+
+	return q
+}
+
+// glvMultiply returns sum(scalars_i * points_i), where phis_i = phi(points_i), with the GLV decomposition of the
+// scalars into halves of 128 bits, and a joint double-and-add over a table of all the sums of the 2n signed points.
+// This halves the number of doublings and additions of the ladder. Only the decomposition runs in constant time: the
+// multiplication is variable time, as the table and the signed points are read at indices derived from the scalars,
+// so it must only be used with public scalars.
+func glvMultiply(scalars []*Scalar, points, phis []*secp256k1.Element) *secp256k1.Element {
+	halves := make([]u256, 0, 2*len(scalars))
+	signed := make([]*secp256k1.Element, 0, 2*len(scalars))
+
+	for i, s := range scalars {
+		k1, k2, neg1, neg2 := splitScalar(s.scalar.Encode())
+		p := [2]*secp256k1.Element{points[i], negate(points[i].Copy())}
+		q := [2]*secp256k1.Element{phis[i], negate(phis[i].Copy())}
+		halves = append(halves, k1, k2)
+		signed = append(signed, p[neg1], q[neg2])
+	}
+
+	// table[i] is the sum of the signed points at the set bits of i.
+	table := make([]*secp256k1.Element, 1<<len(signed))
+	table[0] = secp256k1.NewElement()
+
+	for i := 1; i < len(table); i++ {
+		table[i] = table[i&(i-1)].Copy().Add(signed[bits.TrailingZeros(uint(i))])
+	}
+
+	r := secp256k1.NewElement()
+
+	for b := glvBits - 1; b >= 0; b-- {
+		r.Double()
+
+		var index uint64
+		for j := range halves {
+			index |= (halves[j][b/64] >> (b % 64) & 1) << j
+		}
+
+		r.Add(table[index])
+	}
+
+	return r
+}
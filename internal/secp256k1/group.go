@@ -61,7 +61,10 @@ func (g Group) HashFunc() crypto.Hash {
 // HashToScalar returns a safe mapping of the arbitrary input to a Scalar.
 // The DST must not be empty or nil, and is recommended to be longer than 16 bytes.
 func (g Group) HashToScalar(input, dst []byte) internal.Scalar {
-	return &Scalar{scalar: secp256k1.HashToScalar(input, dst)}
+	var uniform [secLength]byte
+	internal.ExpandXMD(crypto.SHA256, uniform[:], input, dst)
+
+	return g.ScalarFromUniform(uniform[:])
 }
 
 // HashToGroup returns a safe mapping of the arbitrary input to an Element in the Group.
@@ -15,8 +15,22 @@ import (
 	"github.com/bytemare/secp256k1"
 
 	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/internal/field"
 )
 
+// orderField is the Montgomery limb arithmetic modulo the group order for inversions, and nil if it is not
+// available, in which case the scalars use the math/big arithmetic of the secp256k1 package. Multiplications stay in
+// the secp256k1 package, as the conversions from its scalars cost more than the faster multiplication saves.
+var orderField = newOrderField()
+
+func newOrderField() *field.Montgomery {
+	if !field.MontgomeryLimbs {
+		return nil
+	}
+
+	return field.NewMontgomery(groupOrder)
+}
+
 // Scalar implements the Scalar interface for Edwards25519 group scalars.
 type Scalar struct {
 	scalar *secp256k1.Scalar
@@ -119,25 +133,50 @@ func (s *Scalar) Pow(scalar internal.Scalar) internal.Scalar {
 
 // Invert sets the receiver to its modular inverse ( 1 / s ), and returns it.
 func (s *Scalar) Invert() internal.Scalar {
+	if orderField != nil {
+		var out [scalarLength]byte
+
+		orderField.InvBytes(out[:], s.scalar.Encode())
+
+		return s.setCanonical(out[:])
+	}
+
 	s.scalar.Invert()
+
 	return s
 }
 
-// Equal returns 1 if the scalars are equal, and 0 otherwise.
+// setCanonical sets s to the canonical encoding of a scalar, and returns it.
+func (s *Scalar) setCanonical(enc []byte) *Scalar {
+	if err := s.scalar.Decode(enc); err != nil {
+		panic(err)
+	}
+
+	return s
+}
+
+// Equal returns 1 if the scalars are equal, and 0 otherwise, in constant time. The scalars are written to fixed-width
+// encodings, whose 64-bit limbs are compared without branching.
 func (s *Scalar) Equal(scalar internal.Scalar) int {
 	if scalar == nil {
 		return 0
 	}
 
 	sc := assert(scalar)
+	a, b := u256FromBytes(s.scalar.Encode()), u256FromBytes(sc.scalar.Encode())
 
-	return s.scalar.Equal(sc.scalar)
+	var diff uint64
+	for i := range a {
+		diff |= a[i] ^ b[i]
+	}
+
+	return int(1 ^ (diff|-diff)>>63)
 }
 
 // LessOrEqual returns 1 if s <= scalar and 0 otherwise.
 func (s *Scalar) LessOrEqual(scalar internal.Scalar) int {
 	sc := assert(scalar)
-	return s.scalar.LessOrEqual(sc.scalar)
+	return internal.LessOrEqual(s.scalar.Encode(), sc.scalar.Encode(), false)
 }
 
 // IsZero returns whether the scalar is 0.
@@ -194,7 +233,7 @@ func (s *Scalar) Encode() []byte {
 func (s *Scalar) Decode(in []byte) error {
 	if err := s.scalar.Decode(in); err != nil {
 		if err.Error() == "scalar too big" {
-			return internal.ErrParamScalarInvalidEncoding
+			return internal.ErrParamScalarNotReduced
 		}
 
 		return fmt.Errorf("%w", err)
@@ -211,6 +250,10 @@ func (s *Scalar) Hex() string {
 // DecodeHex sets s to the decoding of the hex encoded scalar.
 func (s *Scalar) DecodeHex(h string) error {
 	if err := s.scalar.DecodeHex(h); err != nil {
+		if err.Error() == "scalar too big" {
+			return internal.WithKinds(fmt.Errorf("%w", err), internal.ErrParamScalarNotReduced)
+		}
+
 		return fmt.Errorf("%w", err)
 	}
 
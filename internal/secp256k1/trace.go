@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package secp256k1
+
+import (
+	"crypto"
+	"math/big"
+
+	"github.com/bytemare/hash2curve"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	fieldLength = 32
+	secLength   = 48
+)
+
+// The group order, the field prime, and the parameters of the SSWU mapping to the 3-isogenous curve of RFC 9380
+// section 8.7.
+var (
+	groupOrder = setHex("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	fieldPrime = setHex("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	isoA       = setHex("3f8731abdd661adca08a5558f0f5d272e953d363cb6f0e5d405447c01a444533")
+	isoB       = big.NewInt(1771)
+	mapZ       = new(big.Int).Sub(fieldPrime, big.NewInt(11))
+)
+
+func setHex(s string) *big.Int {
+	i, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		panic("invalid hex")
+	}
+
+	return i
+}
+
+// HashToCurveTrace returns the count field elements hashed from input with dst, and the big-endian affine coordinates
+// of their mappings to the curve, i.e. after the 3-isogeny map and before their addition. A mapping to the identity
+// has nil coordinates.
+func (g Group) HashToCurveTrace(input, dst []byte, count uint) (u [][]byte, q [][2][]byte) {
+	for _, fe := range hash2curve.HashToFieldXMD(crypto.SHA256, input, dst, count, 1, secLength, fieldPrime) {
+		u = append(u, fe.FillBytes(make([]byte, fieldLength)))
+
+		x, y := hash2curve.MapToCurveSSWU(isoA, isoB, mapZ, fe, fieldPrime)
+
+		px, py, isIdentity := hash2curve.IsogenySecp256k13iso(x, y)
+		if isIdentity {
+			q = append(q, [2][]byte{})
+			continue
+		}
+
+		q = append(q, [2][]byte{px.FillBytes(make([]byte, fieldLength)), py.FillBytes(make([]byte, fieldLength))})
+	}
+
+	return u, q
+}
+
+// UniformLengths returns the byte lengths of the expand_message_xmd outputs mapped by HashToScalar and HashToGroup.
+func (g Group) UniformLengths() (scalar, element uint) {
+	return secLength, 2 * secLength
+}
+
+// ScalarFromUniform returns the scalar mapped from the expand_message_xmd output, as in HashToScalar.
+func (g Group) ScalarFromUniform(uniform []byte) internal.Scalar {
+	var enc [scalarLength]byte
+
+	s := new(big.Int).SetBytes(uniform)
+
+	res := newScalar()
+	if err := res.Decode(s.Mod(s, groupOrder).FillBytes(enc[:])); err != nil {
+		panic(err)
+	}
+
+	return res
+}
+
+// ElementFromUniform returns the element mapped from the expand_message_xmd output, as in HashToGroup. Since the
+// 3-isogeny is a group homomorphism, the two mappings are added on secp256k1 rather than on the isogenous curve.
+func (g Group) ElementFromUniform(uniform []byte) internal.Element {
+	res := newElement()
+	res.Add(mapUniform(uniform[:secLength]))
+	res.Add(mapUniform(uniform[secLength:]))
+
+	return res
+}
+
+// mapUniform returns the SSWU and 3-isogeny mapping to secp256k1 of the field element of the uniform bytes.
+func mapUniform(uniform []byte) *Element {
+	u := new(big.Int).SetBytes(uniform)
+	x, y := hash2curve.MapToCurveSSWU(isoA, isoB, mapZ, u.Mod(u, fieldPrime), fieldPrime)
+	e := newElement()
+
+	px, py, isIdentity := hash2curve.IsogenySecp256k13iso(x, y)
+	if isIdentity {
+		return e
+	}
+
+	enc := make([]byte, 1+fieldLength)
+	enc[0] = 0x02 | byte(py.Bit(0))
+	px.FillBytes(enc[1:])
+
+	if err := e.Decode(enc); err != nil {
+		panic(err)
+	}
+
+	return e
+}
@@ -0,0 +1,229 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package jose implements the ECDH-ES key agreement of JWE (RFC 7518 section 4.6) over the ecc groups, i.e. the "epk"
+// header values and the Concat KDF derived keys, to build JWE encryption on top of ecc scalars and elements.
+package jose
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	ktyEC  = "EC"
+	ktyOKP = "OKP"
+)
+
+// JWK is the JSON Web Key of an ephemeral public key, as set in the "epk" header parameter. It is an "EC" key
+// (RFC 7518 section 6.2.1) for the Weierstrass groups, and an "OKP" X25519 key (RFC 8037) for Edwards25519.
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+}
+
+// Curve returns the JWK "crv" value of the group, and an error for Ristretto255, which has none.
+func Curve(g ecc.Group) (string, error) {
+	switch g {
+	case ecc.P256Sha256:
+		return "P-256", nil
+	case ecc.P384Sha384:
+		return "P-384", nil
+	case ecc.P521Sha512:
+		return "P-521", nil
+	case ecc.Secp256k1Sha256:
+		return "secp256k1", nil
+	case ecc.Edwards25519Sha512:
+		return "X25519", nil
+	default:
+		return "", fmt.Errorf("jose: %w", internal.ErrInvalidGroup)
+	}
+}
+
+func groupFromCurve(crv string) (ecc.Group, error) {
+	for _, g := range []ecc.Group{
+		ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256, ecc.Edwards25519Sha512,
+	} {
+		if c, _ := Curve(g); c == crv {
+			return g, nil
+		}
+	}
+
+	return 0, internal.ErrInvalidGroup
+}
+
+// EphemeralPublicKey returns the JWK of the public element, to set as the "epk" header parameter. For Edwards25519,
+// this is the X25519 key of the element's u-coordinate.
+func EphemeralPublicKey(element *ecc.Element) (*JWK, error) {
+	if element == nil {
+		return nil, fmt.Errorf("jose: %w", internal.ErrParamNilPoint)
+	}
+
+	crv, err := Curve(element.Group())
+	if err != nil {
+		return nil, err
+	}
+
+	if element.IsIdentity() {
+		return nil, fmt.Errorf("jose: %w", internal.ErrIdentity)
+	}
+
+	if element.Group() == ecc.Edwards25519Sha512 {
+		return &JWK{Kty: ktyOKP, Crv: crv, X: base64.RawURLEncoding.EncodeToString(element.ToMontgomeryU())}, nil
+	}
+
+	b, err := encoding.MarshalUncompressed(element)
+	if err != nil {
+		return nil, fmt.Errorf("jose: %w", err)
+	}
+
+	n := (len(b) - 1) / 2
+
+	return &JWK{
+		Kty: ktyEC,
+		Crv: crv,
+		X:   base64.RawURLEncoding.EncodeToString(b[1 : 1+n]),
+		Y:   base64.RawURLEncoding.EncodeToString(b[1+n:]),
+	}, nil
+}
+
+// ParseEphemeralPublicKey returns the element of the JWK, e.g. from the "epk" header parameter. The coordinates must
+// have the full length of the curve's field elements. For X25519 keys, the element is one of the two Edwards25519
+// points mapping to the u-coordinate, which yield the same shared secret.
+func ParseEphemeralPublicKey(jwk *JWK) (*ecc.Element, error) {
+	if jwk == nil {
+		return nil, fmt.Errorf("jose: %w", internal.ErrParamNilPoint)
+	}
+
+	g, err := groupFromCurve(jwk.Crv)
+	if err != nil {
+		return nil, fmt.Errorf("jose: %w", err)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+	if err != nil {
+		return nil, fmt.Errorf("jose: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	if g == ecc.Edwards25519Sha512 {
+		if jwk.Kty != ktyOKP || jwk.Y != "" {
+			return nil, fmt.Errorf("jose: %w", internal.ErrParamInvalidPointEncoding)
+		}
+
+		e, err := g.ElementFromMontgomeryU(x, false)
+		if err != nil {
+			return nil, fmt.Errorf("jose: %w", err)
+		}
+
+		return e, nil
+	}
+
+	y, err := base64.RawURLEncoding.DecodeString(jwk.Y)
+	if err != nil || jwk.Kty != ktyEC {
+		return nil, fmt.Errorf("jose: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	n := g.ElementLength() - 1
+	if len(x) != n || len(y) != n {
+		return nil, fmt.Errorf("jose: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	e, err := encoding.ParseSEC1(g, append(append([]byte{0x04}, x...), y...))
+	if err != nil {
+		return nil, fmt.Errorf("jose: %w", err)
+	}
+
+	return e, nil
+}
+
+// SharedSecret returns the ECDH shared secret Z of the private scalar and the public element, i.e. the x-coordinate
+// of their product for the Weierstrass groups, and its u-coordinate for Edwards25519. For interoperability with
+// X25519 keys, the scalar must be the clamped X25519 private key reduced modulo the group order.
+func SharedSecret(secret *ecc.Scalar, public *ecc.Element) ([]byte, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("jose: %w", internal.ErrParamNilScalar)
+	}
+
+	if public == nil {
+		return nil, fmt.Errorf("jose: %w", internal.ErrParamNilPoint)
+	}
+
+	if _, err := Curve(public.Group()); err != nil {
+		return nil, err
+	}
+
+	if secret.Group() != public.Group() {
+		return nil, fmt.Errorf("jose: %w", internal.ErrCastScalar)
+	}
+
+	p := public.Copy().Multiply(secret)
+	if p.IsIdentity() {
+		return nil, fmt.Errorf("jose: %w", internal.ErrIdentity)
+	}
+
+	if p.Group() == ecc.Edwards25519Sha512 {
+		return p.ToMontgomeryU(), nil
+	}
+
+	return p.XCoordinate(), nil
+}
+
+// ConcatKDF returns keyDataLen bytes derived from the shared secret z with the single-step Concat KDF of NIST SP
+// 800-56A and SHA-256, as specified in RFC 7518 section 4.6.2. The algorithm is the "enc" value for direct key
+// agreement with "ECDH-ES", and the "alg" value otherwise, e.g. "ECDH-ES+A128KW". The apu and apv are the decoded
+// "apu" and "apv" header values, and can be empty.
+func ConcatKDF(z []byte, algorithm string, apu, apv []byte, keyDataLen int) []byte {
+	otherInfo := make([]byte, 0, 16+len(algorithm)+len(apu)+len(apv))
+	otherInfo = appendLengthPrefixed(otherInfo, []byte(algorithm))
+	otherInfo = appendLengthPrefixed(otherInfo, apu)
+	otherInfo = appendLengthPrefixed(otherInfo, apv)
+	otherInfo = binary.BigEndian.AppendUint32(otherInfo, uint32(keyDataLen)*8)
+
+	out := make([]byte, 0, keyDataLen+sha256.Size)
+
+	for counter := uint32(1); len(out) < keyDataLen; counter++ {
+		h := sha256.New()
+		_, _ = h.Write(binary.BigEndian.AppendUint32(nil, counter))
+		_, _ = h.Write(z)
+		_, _ = h.Write(otherInfo)
+		out = h.Sum(out)
+	}
+
+	return out[:keyDataLen]
+}
+
+func appendLengthPrefixed(b, data []byte) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(data)))
+	return append(b, data...)
+}
+
+// DeriveKey returns the keyDataLen bytes key agreed between the private scalar and the public element, as
+// ConcatKDF(SharedSecret(secret, public), algorithm, apu, apv, keyDataLen). The sender uses its ephemeral scalar and
+// the recipient's public key, and the recipient its private scalar and the "epk" element.
+func DeriveKey(
+	secret *ecc.Scalar,
+	public *ecc.Element,
+	algorithm string,
+	apu, apv []byte,
+	keyDataLen int,
+) ([]byte, error) {
+	z, err := SharedSecret(secret, public)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConcatKDF(z, algorithm, apu, apv, keyDataLen), nil
+}
@@ -0,0 +1,334 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package kem implements the Diffie-Hellman based Key Encapsulation Mechanisms (DHKEM) of HPKE (RFC 9180) over the
+// ecc groups, with their base and authenticated modes.
+package kem
+
+import (
+	"crypto"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// Identifier is the HPKE KEM identifier of a DHKEM.
+type Identifier uint16
+
+const (
+	// DHKEMP256 identifies DHKEM(P-256, HKDF-SHA256).
+	DHKEMP256 Identifier = 0x0010
+
+	// DHKEMP384 identifies DHKEM(P-384, HKDF-SHA384).
+	DHKEMP384 Identifier = 0x0011
+
+	// DHKEMP521 identifies DHKEM(P-521, HKDF-SHA512).
+	DHKEMP521 Identifier = 0x0012
+
+	// DHKEMX25519 identifies DHKEM(X25519, HKDF-SHA256), which is implemented over the Edwards25519 group.
+	DHKEMX25519 Identifier = 0x0020
+)
+
+const (
+	hpkeVersionLabel = "HPKE-v1"
+	x25519Length     = 32
+	maxDeriveCounter = 255
+)
+
+// Available returns whether the KEM is implemented.
+func (id Identifier) Available() bool {
+	switch id {
+	case DHKEMP256, DHKEMP384, DHKEMP521, DHKEMX25519:
+		return true
+	default:
+		return false
+	}
+}
+
+// Group returns the ecc group of the KEM, and 0 if it is not available.
+func (id Identifier) Group() ecc.Group {
+	switch id {
+	case DHKEMP256:
+		return ecc.P256Sha256
+	case DHKEMP384:
+		return ecc.P384Sha384
+	case DHKEMP521:
+		return ecc.P521Sha512
+	case DHKEMX25519:
+		return ecc.Edwards25519Sha512
+	default:
+		return 0
+	}
+}
+
+// DHKEM is a Diffie-Hellman based KEM of RFC 9180 section 4.1.
+type DHKEM struct {
+	suiteID []byte
+	hash    crypto.Hash
+	group   ecc.Group
+	id      Identifier
+	nSecret int
+	nSk     int
+	nPk     int
+	bitmask byte
+}
+
+// New returns the DHKEM for the identifier.
+func New(id Identifier) (*DHKEM, error) {
+	k := &DHKEM{
+		suiteID: binary.BigEndian.AppendUint16([]byte("KEM"), uint16(id)),
+		group:   id.Group(),
+		id:      id,
+		bitmask: 0xff,
+	}
+
+	switch id {
+	case DHKEMP256:
+		k.hash = crypto.SHA256
+	case DHKEMP384:
+		k.hash = crypto.SHA384
+	case DHKEMP521:
+		k.hash, k.bitmask = crypto.SHA512, 0x01
+	case DHKEMX25519:
+		k.hash = crypto.SHA256
+	default:
+		return nil, fmt.Errorf("kem: %w", internal.ErrInvalidGroup)
+	}
+
+	k.nSecret = k.hash.Size()
+
+	if id == DHKEMX25519 {
+		k.nSk, k.nPk = x25519Length, x25519Length
+	} else {
+		k.nSk, k.nPk = k.group.ScalarLength(), 2*k.group.ScalarLength()+1
+	}
+
+	return k, nil
+}
+
+// ID returns the KEM identifier.
+func (k *DHKEM) ID() Identifier {
+	return k.id
+}
+
+// Group returns the ecc group of the KEM.
+func (k *DHKEM) Group() ecc.Group {
+	return k.group
+}
+
+// SharedSecretLength returns the byte length of the shared secrets, Nsecret.
+func (k *DHKEM) SharedSecretLength() int {
+	return k.nSecret
+}
+
+// EncapsulationLength returns the byte length of the encapsulated keys, Nenc.
+func (k *DHKEM) EncapsulationLength() int {
+	return k.nPk
+}
+
+// PublicKeyLength returns the byte length of the serialized public keys, Npk.
+func (k *DHKEM) PublicKeyLength() int {
+	return k.nPk
+}
+
+// PrivateKeyLength returns the byte length of the serialized private keys, Nsk.
+func (k *DHKEM) PrivateKeyLength() int {
+	return k.nSk
+}
+
+func (k *DHKEM) labeledExtract(salt []byte, label string, ikm []byte) []byte {
+	labeledIKM := make([]byte, 0, len(hpkeVersionLabel)+len(k.suiteID)+len(label)+len(ikm))
+	labeledIKM = append(labeledIKM, hpkeVersionLabel...)
+	labeledIKM = append(labeledIKM, k.suiteID...)
+	labeledIKM = append(labeledIKM, label...)
+	labeledIKM = append(labeledIKM, ikm...)
+
+	return hkdf.Extract(k.hash.New, labeledIKM, salt)
+}
+
+func (k *DHKEM) labeledExpand(prk []byte, label string, info []byte, length int) []byte {
+	labeledInfo := make([]byte, 0, 2+len(hpkeVersionLabel)+len(k.suiteID)+len(label)+len(info))
+	labeledInfo = binary.BigEndian.AppendUint16(labeledInfo, uint16(length))
+	labeledInfo = append(labeledInfo, hpkeVersionLabel...)
+	labeledInfo = append(labeledInfo, k.suiteID...)
+	labeledInfo = append(labeledInfo, label...)
+	labeledInfo = append(labeledInfo, info...)
+
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(k.hash.New, prk, labeledInfo), out); err != nil {
+		// Only happens if length exceeds 255 times the hash size, which can't be the case here.
+		panic(err)
+	}
+
+	return out
+}
+
+func (k *DHKEM) extractAndExpand(dh, kemContext []byte) []byte {
+	prk := k.labeledExtract(nil, "eae_prk", dh)
+	return k.labeledExpand(prk, "shared_secret", kemContext, k.nSecret)
+}
+
+// GenerateKeyPair returns a new random key pair.
+func (k *DHKEM) GenerateKeyPair() (*PrivateKey, error) {
+	return k.DeriveKeyPair(internal.RandomBytes(k.nSk))
+}
+
+// DeriveKeyPair deterministically derives a key pair from the input keying material, which must have at least
+// PrivateKeyLength() bytes of entropy.
+func (k *DHKEM) DeriveKeyPair(ikm []byte) (*PrivateKey, error) {
+	prk := k.labeledExtract(nil, "dkp_prk", ikm)
+
+	if k.id == DHKEMX25519 {
+		return k.NewPrivateKey(k.labeledExpand(prk, "sk", nil, k.nSk))
+	}
+
+	for counter := 0; counter <= maxDeriveCounter; counter++ {
+		candidate := k.labeledExpand(prk, "candidate", []byte{byte(counter)}, k.nSk)
+		candidate[0] &= k.bitmask
+
+		if sk, err := k.NewPrivateKey(candidate); err == nil {
+			return sk, nil
+		}
+	}
+
+	return nil, fmt.Errorf("kem: %w", internal.ErrDeriveKeyPair)
+}
+
+// Encap returns a fresh shared secret and its encapsulation for the recipient's public key.
+func (k *DHKEM) Encap(pkR *PublicKey) (sharedSecret, enc []byte, err error) {
+	return k.EncapDeterministically(pkR, internal.RandomBytes(k.nSk))
+}
+
+// EncapDeterministically is like Encap, but derives the ephemeral key pair from ikmE. It is meant for test vectors,
+// and ikmE must never be reused.
+func (k *DHKEM) EncapDeterministically(pkR *PublicKey, ikmE []byte) (sharedSecret, enc []byte, err error) {
+	return k.encap(pkR, nil, ikmE)
+}
+
+// AuthEncap is like Encap, but additionally authenticates the sender's private key.
+func (k *DHKEM) AuthEncap(pkR *PublicKey, skS *PrivateKey) (sharedSecret, enc []byte, err error) {
+	return k.AuthEncapDeterministically(pkR, skS, internal.RandomBytes(k.nSk))
+}
+
+// AuthEncapDeterministically is like AuthEncap, but derives the ephemeral key pair from ikmE. It is meant for test
+// vectors, and ikmE must never be reused.
+func (k *DHKEM) AuthEncapDeterministically(
+	pkR *PublicKey,
+	skS *PrivateKey,
+	ikmE []byte,
+) (sharedSecret, enc []byte, err error) {
+	if err = k.checkPrivateKey(skS); err != nil {
+		return nil, nil, err
+	}
+
+	return k.encap(pkR, skS, ikmE)
+}
+
+func (k *DHKEM) encap(pkR *PublicKey, skS *PrivateKey, ikmE []byte) (sharedSecret, enc []byte, err error) {
+	if err = k.checkPublicKey(pkR); err != nil {
+		return nil, nil, err
+	}
+
+	skE, err := k.DeriveKeyPair(ikmE)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dh, err := skE.dh(pkR)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	enc = skE.public.Bytes()
+	kemContext := append(append([]byte(nil), enc...), pkR.encoded...)
+
+	if skS != nil {
+		dhS, err := skS.dh(pkR)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, skS.public.encoded...)
+	}
+
+	return k.extractAndExpand(dh, kemContext), enc, nil
+}
+
+// Decap returns the shared secret encapsulated in enc for the recipient's private key.
+func (k *DHKEM) Decap(enc []byte, skR *PrivateKey) ([]byte, error) {
+	return k.decap(enc, skR, nil)
+}
+
+// AuthDecap is like Decap, but additionally authenticates the sender's public key.
+func (k *DHKEM) AuthDecap(enc []byte, skR *PrivateKey, pkS *PublicKey) ([]byte, error) {
+	if err := k.checkPublicKey(pkS); err != nil {
+		return nil, err
+	}
+
+	return k.decap(enc, skR, pkS)
+}
+
+func (k *DHKEM) decap(enc []byte, skR *PrivateKey, pkS *PublicKey) ([]byte, error) {
+	if err := k.checkPrivateKey(skR); err != nil {
+		return nil, err
+	}
+
+	pkE, err := k.NewPublicKey(enc)
+	if err != nil {
+		return nil, err
+	}
+
+	dh, err := skR.dh(pkE)
+	if err != nil {
+		return nil, err
+	}
+
+	kemContext := append(append([]byte(nil), enc...), skR.public.encoded...)
+
+	if pkS != nil {
+		dhS, err := skR.dh(pkS)
+		if err != nil {
+			return nil, err
+		}
+
+		dh = append(dh, dhS...)
+		kemContext = append(kemContext, pkS.encoded...)
+	}
+
+	return k.extractAndExpand(dh, kemContext), nil
+}
+
+func (k *DHKEM) checkPublicKey(pk *PublicKey) error {
+	if pk == nil {
+		return fmt.Errorf("kem: %w", internal.ErrParamNilPoint)
+	}
+
+	if pk.kem.id != k.id {
+		return fmt.Errorf("kem: %w", internal.ErrCastElement)
+	}
+
+	return nil
+}
+
+func (k *DHKEM) checkPrivateKey(sk *PrivateKey) error {
+	if sk == nil {
+		return fmt.Errorf("kem: %w", internal.ErrParamNilScalar)
+	}
+
+	if sk.kem.id != k.id {
+		return fmt.Errorf("kem: %w", internal.ErrCastScalar)
+	}
+
+	return nil
+}
@@ -0,0 +1,249 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package kem
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+// PublicKey is a DHKEM public key.
+type PublicKey struct {
+	kem     *DHKEM
+	element *ecc.Element
+	encoded []byte
+}
+
+// Bytes returns the serialized public key, i.e. the SEC 1 uncompressed encoding for the NIST curves and the RFC 7748
+// u-coordinate for X25519.
+func (pk *PublicKey) Bytes() []byte {
+	return append([]byte(nil), pk.encoded...)
+}
+
+// Element returns a copy of the public key's group element. For X25519, this is one of the two Edwards25519 points
+// mapping to the u-coordinate.
+func (pk *PublicKey) Element() *ecc.Element {
+	return pk.element.Copy()
+}
+
+// PrivateKey is a DHKEM private key, and holds its public key.
+type PrivateKey struct {
+	kem     *DHKEM
+	public  *PublicKey
+	scalar  *ecc.Scalar
+	encoded []byte
+
+	// x25519 is the clamped X25519 scalar divided by the cofactor, and is only set for DHKEMX25519.
+	x25519 *ecc.Scalar
+
+	// remote is set instead of the scalar for keys held outside the process.
+	remote ecc.RemoteScalar
+}
+
+// Bytes returns the serialized private key, i.e. the big-endian scalar for the NIST curves and the clamped X25519
+// private key for X25519. It returns nil for remote keys.
+func (sk *PrivateKey) Bytes() []byte {
+	if sk.remote != nil {
+		return nil
+	}
+
+	return append([]byte(nil), sk.encoded...)
+}
+
+// PublicKey returns the public key of the private key.
+func (sk *PrivateKey) PublicKey() *PublicKey {
+	return sk.public
+}
+
+// Scalar returns a copy of the private key's group scalar, such that the public key is the product of the base point
+// by it. For X25519, this is the clamped private key reduced modulo the group order. It returns nil for remote keys.
+func (sk *PrivateKey) Scalar() *ecc.Scalar {
+	if sk.remote != nil {
+		return nil
+	}
+
+	return sk.scalar.Copy()
+}
+
+// dh returns the Diffie-Hellman shared secret of the private and public keys, and rejects the identity element.
+func (sk *PrivateKey) dh(pk *PublicKey) ([]byte, error) {
+	var p *ecc.Element
+
+	switch {
+	case sk.remote != nil:
+		var err error
+		if p, err = sk.remoteDH(pk); err != nil {
+			return nil, fmt.Errorf("kem: %w", err)
+		}
+	case sk.x25519 != nil:
+		// X25519 multiplies by the cofactor-cleared clamped scalar, which kills any small order component of the point.
+		eight := sk.kem.group.NewScalar().SetUInt64(8)
+		p = pk.element.Copy().Multiply(eight).Multiply(sk.x25519)
+	default:
+		p = pk.element.Copy().Multiply(sk.scalar)
+	}
+
+	if p.IsIdentity() {
+		return nil, fmt.Errorf("kem: %w", internal.ErrIdentity)
+	}
+
+	return p.XCoordinate(), nil
+}
+
+// remoteDH returns the product of the public key by the remote scalar. For X25519, the remote scalar is the clamped
+// private key reduced modulo the group order, so the point's small order component is removed beforehand, as the
+// clamped integer would.
+func (sk *PrivateKey) remoteDH(pk *PublicKey) (*ecc.Element, error) {
+	p := pk.element
+
+	if sk.kem.id == DHKEMX25519 {
+		eight := sk.kem.group.NewScalar().SetUInt64(8)
+		p = p.Copy().Multiply(eight).Multiply(eight.Copy().Invert())
+	}
+
+	return sk.remote.MultiplyElement(p)
+}
+
+// NewRemotePrivateKey returns a private key whose scalar is held by the remote, e.g. a hardware device or KMS, and
+// never enters the process. For X25519, the remote scalar must be the clamped private key reduced modulo the group
+// order. Such keys can't be serialized.
+func (k *DHKEM) NewRemotePrivateKey(remote ecc.RemoteScalar) (*PrivateKey, error) {
+	if remote == nil {
+		return nil, fmt.Errorf("kem: %w", internal.ErrParamNilScalar)
+	}
+
+	if remote.Group() != k.group {
+		return nil, fmt.Errorf("kem: %w", internal.ErrCastScalar)
+	}
+
+	e := remote.PublicElement()
+	if e == nil || e.IsIdentity() || e.Group() != k.group {
+		return nil, fmt.Errorf("kem: %w", internal.ErrParamNilPoint)
+	}
+
+	public, err := k.encodePublicKey(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{
+		kem:    k,
+		public: public,
+		remote: remote,
+	}, nil
+}
+
+// NewPublicKey returns the public key deserialized from its encoding, which must be the SEC 1 uncompressed encoding
+// for the NIST curves and the RFC 7748 u-coordinate for X25519.
+func (k *DHKEM) NewPublicKey(data []byte) (*PublicKey, error) {
+	if len(data) != k.nPk {
+		return nil, fmt.Errorf("kem: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	var (
+		e   *ecc.Element
+		err error
+	)
+
+	if k.id == DHKEMX25519 {
+		e, err = k.group.ElementFromMontgomeryU(data, false)
+	} else {
+		e, err = encoding.ParseSEC1(k.group, data)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("kem: %w", err)
+	}
+
+	return &PublicKey{
+		kem:     k,
+		element: e,
+		encoded: append([]byte(nil), data...),
+	}, nil
+}
+
+// NewPrivateKey returns the private key deserialized from its encoding, which must be a non-zero big-endian scalar
+// for the NIST curves, and any 32 bytes, which are clamped, for X25519.
+func (k *DHKEM) NewPrivateKey(data []byte) (*PrivateKey, error) {
+	if len(data) != k.nSk {
+		return nil, fmt.Errorf("kem: %w", internal.ErrParamScalarLength)
+	}
+
+	if k.id == DHKEMX25519 {
+		return k.newX25519PrivateKey(data)
+	}
+
+	s := k.group.NewScalar()
+	if err := s.Decode(data); err != nil {
+		return nil, fmt.Errorf("kem: %w", err)
+	}
+
+	if s.IsZero() {
+		return nil, fmt.Errorf("kem: %w", internal.ErrParamNilScalar)
+	}
+
+	return k.newPrivateKey(s, nil, data)
+}
+
+func (k *DHKEM) newX25519PrivateKey(data []byte) (*PrivateKey, error) {
+	clamped := append([]byte(nil), data...)
+	clamped[0] &= 248
+	clamped[31] &= 127
+	clamped[31] |= 64
+
+	// The clamped scalar is 8 * c, with c < 2^252, hence always a canonical scalar.
+	c := make([]byte, x25519Length)
+	for i := range c {
+		c[i] = clamped[i] >> 3
+		if i+1 < len(clamped) {
+			c[i] |= clamped[i+1] << 5
+		}
+	}
+
+	x25519 := k.group.NewScalar()
+	if err := x25519.Decode(c); err != nil {
+		return nil, fmt.Errorf("kem: %w", err)
+	}
+
+	s := x25519.Copy().Multiply(k.group.NewScalar().SetUInt64(8))
+
+	return k.newPrivateKey(s, x25519, clamped)
+}
+
+func (k *DHKEM) newPrivateKey(s, x25519 *ecc.Scalar, encoded []byte) (*PrivateKey, error) {
+	public, err := k.encodePublicKey(k.group.Base().Multiply(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{
+		kem:     k,
+		public:  public,
+		scalar:  s,
+		encoded: append([]byte(nil), encoded...),
+		x25519:  x25519,
+	}, nil
+}
+
+func (k *DHKEM) encodePublicKey(e *ecc.Element) (*PublicKey, error) {
+	var pk []byte
+	if k.id == DHKEMX25519 {
+		pk = e.ToMontgomeryU()
+	} else {
+		var err error
+		if pk, err = encoding.MarshalUncompressed(e); err != nil {
+			return nil, fmt.Errorf("kem: %w", err)
+		}
+	}
+
+	return &PublicKey{kem: k, element: e, encoded: pk}, nil
+}
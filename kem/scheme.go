@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package kem
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	circl "github.com/cloudflare/circl/kem"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+var (
+	_ circl.AuthScheme = (*Scheme)(nil)
+	_ circl.PublicKey  = (*PublicKey)(nil)
+	_ circl.PrivateKey = (*PrivateKey)(nil)
+)
+
+// String returns the RFC 9180 name of the KEM, e.g. "DHKEM(P-256, HKDF-SHA256)".
+func (id Identifier) String() string {
+	switch id {
+	case DHKEMP256:
+		return "DHKEM(P-256, HKDF-SHA256)"
+	case DHKEMP384:
+		return "DHKEM(P-384, HKDF-SHA384)"
+	case DHKEMP521:
+		return "DHKEM(P-521, HKDF-SHA512)"
+	case DHKEMX25519:
+		return "DHKEM(X25519, HKDF-SHA256)"
+	default:
+		return ""
+	}
+}
+
+// Scheme exposes a DHKEM through the github.com/cloudflare/circl/kem interfaces, e.g. to compose it with an ML-KEM
+// implementation into a hybrid KEM. Its public and private keys are the *PublicKey and *PrivateKey of the DHKEM, and
+// the ciphertexts are the encapsulations.
+type Scheme struct {
+	kem *DHKEM
+}
+
+// Scheme returns the circl kem.AuthScheme of the DHKEM.
+func (k *DHKEM) Scheme() *Scheme {
+	return &Scheme{kem: k}
+}
+
+func schemePublicKey(pk circl.PublicKey) (*PublicKey, error) {
+	p, ok := pk.(*PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("kem: %w", circl.ErrTypeMismatch)
+	}
+
+	return p, nil
+}
+
+func schemePrivateKey(sk circl.PrivateKey) (*PrivateKey, error) {
+	p, ok := sk.(*PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("kem: %w", circl.ErrTypeMismatch)
+	}
+
+	return p, nil
+}
+
+// Name returns the RFC 9180 name of the KEM.
+func (s *Scheme) Name() string {
+	return s.kem.id.String()
+}
+
+// GenerateKeyPair returns a new random key pair.
+func (s *Scheme) GenerateKeyPair() (circl.PublicKey, circl.PrivateKey, error) {
+	sk, err := s.kem.GenerateKeyPair()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return sk.public, sk, nil
+}
+
+// Encapsulate returns a fresh shared secret and its encapsulation for the public key.
+func (s *Scheme) Encapsulate(pk circl.PublicKey) (ct, ss []byte, err error) {
+	return s.EncapsulateDeterministically(pk, internal.RandomBytes(s.kem.nSk))
+}
+
+// EncapsulateDeterministically is like Encapsulate, but derives the ephemeral key pair from the seed, which must never
+// be reused.
+func (s *Scheme) EncapsulateDeterministically(pk circl.PublicKey, seed []byte) (ct, ss []byte, err error) {
+	pkR, err := schemePublicKey(pk)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ss, ct, err = s.kem.EncapDeterministically(pkR, seed)
+
+	return ct, ss, err
+}
+
+// Decapsulate returns the shared secret encapsulated in ct for the private key.
+func (s *Scheme) Decapsulate(sk circl.PrivateKey, ct []byte) ([]byte, error) {
+	skR, err := schemePrivateKey(sk)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.kem.Decap(ct, skR)
+}
+
+// AuthEncapsulate is like Encapsulate, but additionally authenticates the sender's private key.
+func (s *Scheme) AuthEncapsulate(pkr circl.PublicKey, sks circl.PrivateKey) (ct, ss []byte, err error) {
+	return s.AuthEncapsulateDeterministically(pkr, sks, internal.RandomBytes(s.kem.nSk))
+}
+
+// AuthEncapsulateDeterministically is like AuthEncapsulate, but derives the ephemeral key pair from the seed, which
+// must never be reused.
+func (s *Scheme) AuthEncapsulateDeterministically(
+	pkr circl.PublicKey,
+	sks circl.PrivateKey,
+	seed []byte,
+) (ct, ss []byte, err error) {
+	pkR, err := schemePublicKey(pkr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	skS, err := schemePrivateKey(sks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ss, ct, err = s.kem.AuthEncapDeterministically(pkR, skS, seed)
+
+	return ct, ss, err
+}
+
+// AuthDecapsulate is like Decapsulate, but additionally authenticates the sender's public key.
+func (s *Scheme) AuthDecapsulate(skr circl.PrivateKey, ct []byte, pks circl.PublicKey) ([]byte, error) {
+	skR, err := schemePrivateKey(skr)
+	if err != nil {
+		return nil, err
+	}
+
+	pkS, err := schemePublicKey(pks)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.kem.AuthDecap(ct, skR, pkS)
+}
+
+// UnmarshalBinaryPublicKey returns the public key deserialized from its encoding.
+func (s *Scheme) UnmarshalBinaryPublicKey(data []byte) (circl.PublicKey, error) {
+	return s.kem.NewPublicKey(data)
+}
+
+// UnmarshalBinaryPrivateKey returns the private key deserialized from its encoding.
+func (s *Scheme) UnmarshalBinaryPrivateKey(data []byte) (circl.PrivateKey, error) {
+	return s.kem.NewPrivateKey(data)
+}
+
+// CiphertextSize returns the byte length of the encapsulations, Nenc.
+func (s *Scheme) CiphertextSize() int {
+	return s.kem.EncapsulationLength()
+}
+
+// SharedKeySize returns the byte length of the shared secrets, Nsecret.
+func (s *Scheme) SharedKeySize() int {
+	return s.kem.SharedSecretLength()
+}
+
+// PrivateKeySize returns the byte length of the serialized private keys, Nsk.
+func (s *Scheme) PrivateKeySize() int {
+	return s.kem.PrivateKeyLength()
+}
+
+// PublicKeySize returns the byte length of the serialized public keys, Npk.
+func (s *Scheme) PublicKeySize() int {
+	return s.kem.PublicKeyLength()
+}
+
+// DeriveKeyPair deterministically derives a key pair from the seed, with DeriveKeyPair of RFC 9180. As required by
+// the interface, it panics if the seed is not SeedSize() bytes long.
+func (s *Scheme) DeriveKeyPair(seed []byte) (circl.PublicKey, circl.PrivateKey) {
+	if len(seed) != s.SeedSize() {
+		panic(circl.ErrSeedSize)
+	}
+
+	sk, err := s.kem.DeriveKeyPair(seed)
+	if err != nil {
+		// Only happens with negligible probability.
+		panic(err)
+	}
+
+	return sk.public, sk
+}
+
+// SeedSize returns the byte length of the seeds of DeriveKeyPair, i.e. Nsk.
+func (s *Scheme) SeedSize() int {
+	return s.kem.nSk
+}
+
+// EncapsulationSeedSize returns the byte length of the seeds of EncapsulateDeterministically, i.e. Nsk.
+func (s *Scheme) EncapsulationSeedSize() int {
+	return s.kem.nSk
+}
+
+// Scheme returns the circl kem.Scheme of the public key.
+func (pk *PublicKey) Scheme() circl.Scheme {
+	return pk.kem.Scheme()
+}
+
+// MarshalBinary returns the serialized public key, as Bytes.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	return pk.Bytes(), nil
+}
+
+// Equal returns whether the public keys are the same and of the same KEM.
+func (pk *PublicKey) Equal(other circl.PublicKey) bool {
+	o, ok := other.(*PublicKey)
+	if !ok || o == nil {
+		return false
+	}
+
+	return pk.kem.id == o.kem.id && subtle.ConstantTimeCompare(pk.encoded, o.encoded) == 1
+}
+
+// Scheme returns the circl kem.Scheme of the private key.
+func (sk *PrivateKey) Scheme() circl.Scheme {
+	return sk.kem.Scheme()
+}
+
+// MarshalBinary returns the serialized private key, as Bytes, and an error for remote keys.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	if sk.remote != nil {
+		return nil, fmt.Errorf("kem: %w", internal.ErrParamNilScalar)
+	}
+
+	return sk.Bytes(), nil
+}
+
+// Equal returns whether the private keys are the same and of the same KEM. Remote keys are only equal if they use
+// the same remote.
+func (sk *PrivateKey) Equal(other circl.PrivateKey) bool {
+	o, ok := other.(*PrivateKey)
+	if !ok || o == nil || sk.kem.id != o.kem.id {
+		return false
+	}
+
+	if sk.remote != nil || o.remote != nil {
+		return sk.remote == o.remote
+	}
+
+	return subtle.ConstantTimeCompare(sk.encoded, o.encoded) == 1
+}
+
+// Public returns the public key of the private key, as PublicKey.
+func (sk *PrivateKey) Public() circl.PublicKey {
+	return sk.public
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package keyblinding implements the signature key blinding of draft-irtf-cfrg-signature-key-blinding for Ed25519,
+// over the Edwards25519Sha512 group, and for ECDSA, over the NIST groups and secp256k1. A blinding key bk and a context
+// derive a blind scalar, and the blinded key pair is the original one multiplied by it: signatures with the blinded
+// private key verify with the blinded public key, which is unlinkable to the original one without bk, and unblinds to
+// it with bk.
+package keyblinding
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+
+	ed "filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ecdsa"
+	"github.com/bytemare/ecc/eddsa"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	dstEd25519 = "Ed25519 Key Blind"
+	dstECDSA   = "ECDSA Key Blind"
+)
+
+var errInvalidBlindingKey = errors.New("invalid blinding key")
+
+// DeriveBlindKey returns the blind scalar of the blinding key and the context in the group. The blinding key must have
+// the length of the group's scalars.
+func DeriveBlindKey(g ecc.Group, bk, ctx []byte) (*ecc.Scalar, error) {
+	if len(bk) != g.ScalarLength() {
+		return nil, fmt.Errorf("keyblinding: %w", errInvalidBlindingKey)
+	}
+
+	var s *ecc.Scalar
+
+	switch g {
+	case ecc.Edwards25519Sha512:
+		// As the Ed25519 expansion of a secret key, the clamped first half of SHA-512(bk || 0x00 || ctx).
+		h := sha512.New()
+		_, _ = h.Write(bk)
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write(ctx)
+
+		es, err := ed.NewScalar().SetBytesWithClamping(h.Sum(nil)[:32])
+		if err != nil {
+			return nil, fmt.Errorf("keyblinding: %w", err)
+		}
+
+		s = g.NewScalar()
+		if err = s.SetEdwards25519Scalar(es); err != nil {
+			return nil, fmt.Errorf("keyblinding: %w", err)
+		}
+	case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256:
+		s = g.HashToScalar(bk, append([]byte(dstECDSA), ctx...))
+	default:
+		return nil, fmt.Errorf("keyblinding: %w", internal.ErrInvalidGroup)
+	}
+
+	if s.IsZero() {
+		return nil, fmt.Errorf("keyblinding: %w", errInvalidBlindingKey)
+	}
+
+	return s, nil
+}
+
+// BlindPublicKey returns the blinded public key of the public key with the blinding key and the context.
+func BlindPublicKey(public *ecc.Element, bk, ctx []byte) (*ecc.Element, error) {
+	if public == nil || public.IsIdentity() {
+		return nil, fmt.Errorf("keyblinding: %w", internal.ErrParamNilPoint)
+	}
+
+	skB, err := DeriveBlindKey(public.Group(), bk, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return public.Copy().Multiply(skB), nil
+}
+
+// UnblindPublicKey returns the public key blinded to the blinded public key with the blinding key and the context.
+func UnblindPublicKey(blinded *ecc.Element, bk, ctx []byte) (*ecc.Element, error) {
+	if blinded == nil || blinded.IsIdentity() {
+		return nil, fmt.Errorf("keyblinding: %w", internal.ErrParamNilPoint)
+	}
+
+	skB, err := DeriveBlindKey(blinded.Group(), bk, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return blinded.Copy().Multiply(skB.Invert()), nil
+}
+
+// BlindPrivateKey returns the blinded private scalar of the private scalar with the blinding key and the context.
+func BlindPrivateKey(secret *ecc.Scalar, bk, ctx []byte) (*ecc.Scalar, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("keyblinding: %w", internal.ErrParamNilScalar)
+	}
+
+	skB, err := DeriveBlindKey(secret.Group(), bk, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return skB.Multiply(secret), nil
+}
+
+// BlindKeySignEd25519 returns the Ed25519 signature of the message with the blinded private key, which verifies with
+// the blinded public key using any RFC 8032 implementation.
+func BlindKeySignEd25519(key *eddsa.PrivateKey, bk, ctx, message []byte) ([]byte, error) {
+	if key == nil {
+		return nil, fmt.Errorf("keyblinding: %w", internal.ErrParamNilScalar)
+	}
+
+	skR, err := BlindPrivateKey(key.Scalar(), bk, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	blinded, err := eddsa.NewKeyFromScalar(skR)
+	if err != nil {
+		return nil, fmt.Errorf("keyblinding: %w", err)
+	}
+
+	return blinded.Sign(message), nil
+}
+
+// BlindKeySignECDSA returns the ECDSA signature of the digest, the hash of the message with the hash function, with
+// the blinded private key, which verifies with the blinded public key.
+func BlindKeySignECDSA(
+	secret *ecc.Scalar,
+	bk, ctx []byte,
+	hash crypto.Hash,
+	digest []byte,
+) (*ecdsa.Signature, error) {
+	skR, err := BlindPrivateKey(secret, bk, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if skR.Group() == ecc.Edwards25519Sha512 {
+		return nil, fmt.Errorf("keyblinding: %w", internal.ErrInvalidGroup)
+	}
+
+	sig, err := ecdsa.Sign(skR, hash, digest)
+	if err != nil {
+		return nil, fmt.Errorf("keyblinding: %w", err)
+	}
+
+	return sig, nil
+}
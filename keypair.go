@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// keyPairSecurityMargin is the number of random bytes read beyond the length of a scalar when generating a secret,
+// so that its reduction modulo the group order has a statistical bias of at most 2^-128.
+const keyPairSecurityMargin = 16
+
+var errKeyPairMismatch = errors.New("public key does not match the secret key")
+
+// KeyPair is a secret scalar and its public element, Public = Secret * G for the group's base point G.
+type KeyPair struct {
+	Secret *Scalar
+	Public *Element
+}
+
+// SetRandomSource sets the source of randomness read by Scalar.Random, by Group.GenerateKeyPair and the functions of
+// the other packages of the module when they are given no reader, and by the nonces and ephemeral keys these packages
+// generate, e.g. to a DRBG in deployments that require one, or to a fixed stream to replay tests and fuzz
+// deterministically. A nil random restores crypto/rand, the default. The source must be safe for concurrent use if
+// the module is used concurrently, and must never be predictable outside of tests.
+func SetRandomSource(random io.Reader) {
+	internal.SetRandomSource(random)
+}
+
+// GenerateKeyPair returns a new key pair with a uniformly random non-zero secret, read from random, or from the
+// source set with SetRandomSource, crypto/rand by default, if it is nil.
+func (g Group) GenerateKeyPair(random io.Reader) (*KeyPair, error) {
+	if random == nil {
+		random = internal.RandomSource()
+	}
+
+	secret, err := g.randomScalar(random)
+	if err != nil {
+		return nil, fmt.Errorf("group GenerateKeyPair: %w", err)
+	}
+
+	return &KeyPair{Secret: secret, Public: g.ScalarBaseMult(secret)}, nil
+}
+
+// randomScalar returns a non-zero scalar, reduced from random bytes of the reader.
+func (g Group) randomScalar(random io.Reader) (*Scalar, error) {
+	order := g.Order()
+	if g.littleEndian() {
+		slices.Reverse(order)
+	}
+
+	n := new(big.Int).SetBytes(order)
+	buf := make([]byte, g.ScalarLength()+keyPairSecurityMargin)
+	enc := make([]byte, g.ScalarLength())
+	s := g.NewScalar()
+
+	for {
+		if _, err := io.ReadFull(random, buf); err != nil {
+			return nil, fmt.Errorf("%w", err)
+		}
+
+		r := new(big.Int).SetBytes(buf)
+		if r.Mod(r, n).Sign() == 0 {
+			continue
+		}
+
+		r.FillBytes(enc)
+
+		if g.littleEndian() {
+			slices.Reverse(enc)
+		}
+
+		if err := s.Decode(enc); err != nil {
+			return nil, err
+		}
+
+		clear(buf)
+		clear(enc)
+
+		return s, nil
+	}
+}
+
+// NewKeyPair returns the key pair of the secret, whose public element it computes. It returns an error if the secret
+// is nil or zero.
+func NewKeyPair(secret *Scalar) (*KeyPair, error) {
+	if secret == nil || secret.Scalar == nil || secret.IsZero() {
+		return nil, fmt.Errorf("NewKeyPair: %w", internal.ErrParamNilScalar)
+	}
+
+	return &KeyPair{Secret: secret.Copy(), Public: secret.Group().ScalarBaseMult(secret)}, nil
+}
+
+// Group returns the group of the key pair.
+func (k *KeyPair) Group() Group {
+	return k.Secret.Group()
+}
+
+// Validate returns an error if the key pair has a nil or zero secret, a nil public element, values of different
+// groups, or a public element that isn't that of the secret, e.g. for key pairs loaded from storage.
+func (k *KeyPair) Validate() error {
+	if k.Secret == nil || k.Secret.Scalar == nil || k.Secret.IsZero() {
+		return fmt.Errorf("key pair: %w", internal.ErrParamNilScalar)
+	}
+
+	if k.Public == nil || k.Public.Element == nil {
+		return fmt.Errorf("key pair: %w", internal.ErrParamNilPoint)
+	}
+
+	if k.Public.Group() != k.Secret.Group() {
+		return fmt.Errorf("key pair: %w", internal.ErrCastElement)
+	}
+
+	if !k.Public.Equal(k.Group().ScalarBaseMult(k.Secret)) {
+		return fmt.Errorf("key pair: %w", errKeyPairMismatch)
+	}
+
+	return nil
+}
+
+// Encode returns the encoding of the key pair, i.e. the group identifier followed by the encodings of the secret and
+// of the public element. It contains the secret, and must be stored accordingly.
+func (k *KeyPair) Encode() []byte {
+	out := make([]byte, 0, 1+k.Group().ScalarLength()+k.Group().ElementLength())
+	out = append(out, byte(k.Group()))
+	out = append(out, k.Secret.Encode()...)
+
+	return append(out, k.Public.Encode()...)
+}
+
+// Decode sets the key pair to the decoding of the output of Encode, and returns an error if it is invalid or if the
+// public element doesn't match the secret.
+func (k *KeyPair) Decode(data []byte) error {
+	if err := k.decode(data); err != nil {
+		return fmt.Errorf("key pair Decode: %w", err)
+	}
+
+	return nil
+}
+
+func (k *KeyPair) decode(data []byte) error {
+	if len(data) == 0 {
+		return internal.ErrDecodingInvalidLength
+	}
+
+	g := Group(data[0])
+	if !g.Available() {
+		return internal.ErrInvalidGroup
+	}
+
+	sLen, eLen := g.ScalarLength(), g.ElementLength()
+	if len(data) != 1+sLen+eLen {
+		return internal.ErrDecodingInvalidLength
+	}
+
+	secret, public := g.NewScalar(), g.NewElement()
+
+	if err := secret.Decode(data[1 : 1+sLen]); err != nil {
+		return err
+	}
+
+	if err := public.Decode(data[1+sLen:]); err != nil {
+		return err
+	}
+
+	kp := KeyPair{Secret: secret, Public: public}
+	if err := kp.Validate(); err != nil {
+		return err
+	}
+
+	*k = kp
+
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, with the encoding of Encode.
+func (k *KeyPair) MarshalBinary() ([]byte, error) {
+	return k.Encode(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, as Decode.
+func (k *KeyPair) UnmarshalBinary(data []byte) error {
+	if err := k.decode(data); err != nil {
+		return fmt.Errorf("key pair UnmarshalBinary: %w", err)
+	}
+
+	return nil
+}
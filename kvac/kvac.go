@@ -0,0 +1,345 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package kvac implements keyed-verification anonymous credentials with the MAC_GGM algebraic MAC of Chase,
+// Meiklejohn, and Zaverucha ("Algebraic MACs and Keyed-Verification Anonymous Credentials", CCS 2014), as used by
+// Signal's private group system. An issuer, which is also the verifier, issues credentials on scalar attributes with
+// a proof that it used its published parameters, and the holder presents them unlinkably, disclosing some attributes
+// and proving knowledge of the others. The proofs are those of the zkp package. Use a prime-order group, such as
+// Ristretto255.
+package kvac
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/zkp"
+)
+
+const (
+	dstApp     = "ecc-kvac"
+	dstVersion = 1
+)
+
+var (
+	errInvalidAttributes   = errors.New("invalid number of attributes")
+	errInvalidCredential   = errors.New("invalid credential")
+	errInvalidPresentation = errors.New("invalid presentation")
+)
+
+// Params are the issuer's public parameters: the commitment Cx0 = x0 * G + x0Blinding * H to the first key scalar,
+// and X_i = x_i * H for the attribute key scalars.
+type Params struct {
+	H, Cx0 *ecc.Element
+	X      []*ecc.Element
+	group  ecc.Group
+}
+
+// SecretKey is the issuer's secret key.
+type SecretKey struct {
+	params     *Params
+	x0         *ecc.Scalar
+	x0Blinding *ecc.Scalar
+	x          []*ecc.Scalar
+}
+
+// Credential is a MAC (U, U' = (x0 + sum(x_i * m_i)) * U) on the attributes m_i.
+type Credential struct {
+	U, UPrime  *ecc.Element
+	Attributes []*ecc.Scalar
+}
+
+// Issuance is an issued MAC, with the proof that it was computed with the keys of the issuer's parameters.
+type Issuance struct {
+	U, UPrime *ecc.Element
+	Proof     *zkp.Proof
+}
+
+// Presentation is a randomized credential, with the attributes either disclosed or hidden in commitments: for each
+// index, exactly one of Attributes and Commitments is set. The proof shows knowledge of a valid credential on the
+// disclosed and committed attributes.
+type Presentation struct {
+	U, CUPrime  *ecc.Element
+	Attributes  []*ecc.Scalar
+	Commitments []*ecc.Element
+	Proof       *zkp.Proof
+}
+
+// generatorH returns the second generator, whose discrete logarithm to the base is unknown.
+func generatorH(g ecc.Group) *ecc.Element {
+	return g.HashToGroup([]byte("H"), g.DST(dstApp, dstVersion))
+}
+
+// KeyGen returns a new issuer secret key for credentials with n attributes, and its public parameters.
+func KeyGen(g ecc.Group, n int) (*SecretKey, *Params, error) {
+	if n < 1 {
+		return nil, nil, fmt.Errorf("kvac: %w", errInvalidAttributes)
+	}
+
+	sk := &SecretKey{
+		x0:         g.NewScalar().Random(),
+		x0Blinding: g.NewScalar().Random(),
+		x:          make([]*ecc.Scalar, n),
+	}
+	params := &Params{
+		H:     generatorH(g),
+		X:     make([]*ecc.Element, n),
+		group: g,
+	}
+
+	params.Cx0 = g.Base().Multiply(sk.x0).Add(params.H.Copy().Multiply(sk.x0Blinding))
+
+	for i := range n {
+		sk.x[i] = g.NewScalar().Random()
+		params.X[i] = params.H.Copy().Multiply(sk.x[i])
+	}
+
+	sk.params = params
+
+	return sk, params, nil
+}
+
+// Params returns the public parameters of the secret key.
+func (sk *SecretKey) Params() *Params {
+	return sk.params
+}
+
+// Group returns the group of the parameters.
+func (p *Params) Group() ecc.Group {
+	return p.group
+}
+
+func (p *Params) checkAttributes(attributes []*ecc.Scalar) error {
+	if len(attributes) != len(p.X) {
+		return errInvalidAttributes
+	}
+
+	for _, m := range attributes {
+		if m == nil {
+			return internal.ErrParamNilScalar
+		}
+
+		if m.Group() != p.group {
+			return internal.ErrCastScalar
+		}
+	}
+
+	return nil
+}
+
+// issuanceStatement returns the statement of knowledge of (x0, x0Blinding, x_1, ..., x_n) such that
+// Cx0 = x0 * G + x0Blinding * H, X_i = x_i * H, and U' = x0 * U + sum(x_i * m_i * U).
+func (p *Params) issuanceStatement(u, uPrime *ecc.Element, attributes []*ecc.Scalar) *zkp.Statement {
+	st := zkp.NewStatement(p.group, 2+len(p.X))
+	st.AddEquation(p.Cx0, zkp.Term{Base: p.group.Base(), Witness: 0}, zkp.Term{Base: p.H, Witness: 1})
+
+	terms := []zkp.Term{{Base: u, Witness: 0}}
+
+	for i, x := range p.X {
+		st.AddEquation(x, zkp.Term{Base: p.H, Witness: 2 + i})
+		terms = append(terms, zkp.Term{Base: u.Copy().Multiply(attributes[i]), Witness: 2 + i})
+	}
+
+	return st.AddEquation(uPrime, terms...)
+}
+
+// Issue returns a credential on the attributes, with the proof that it was computed with the issuer's parameters.
+// The context, e.g. a session identifier, is bound to the proof.
+func (sk *SecretKey) Issue(context []byte, attributes []*ecc.Scalar) (*Issuance, error) {
+	p := sk.params
+	if err := p.checkAttributes(attributes); err != nil {
+		return nil, fmt.Errorf("kvac: %w", err)
+	}
+
+	g := p.group
+	u := g.Base().Multiply(g.NewScalar().Random())
+
+	exponent := sk.x0.Copy()
+	for i, m := range attributes {
+		exponent.Add(sk.x[i].Copy().Multiply(m))
+	}
+
+	uPrime := u.Copy().Multiply(exponent)
+	witnesses := append([]*ecc.Scalar{sk.x0, sk.x0Blinding}, sk.x...)
+
+	proof, err := zkp.Prove(context, p.issuanceStatement(u, uPrime, attributes), witnesses)
+	if err != nil {
+		return nil, fmt.Errorf("kvac: %w", err)
+	}
+
+	return &Issuance{U: u, UPrime: uPrime, Proof: proof}, nil
+}
+
+// Receive verifies the issuance on the attributes, and returns the credential.
+func (p *Params) Receive(context []byte, attributes []*ecc.Scalar, issuance *Issuance) (*Credential, error) {
+	if err := p.checkAttributes(attributes); err != nil {
+		return nil, fmt.Errorf("kvac: %w", err)
+	}
+
+	if issuance == nil || issuance.U == nil || issuance.UPrime == nil || issuance.U.IsIdentity() ||
+		issuance.U.Group() != p.group || issuance.UPrime.Group() != p.group {
+		return nil, fmt.Errorf("kvac: %w", errInvalidCredential)
+	}
+
+	st := p.issuanceStatement(issuance.U, issuance.UPrime, attributes)
+	if err := zkp.Verify(context, st, issuance.Proof); err != nil {
+		return nil, fmt.Errorf("kvac: %w", err)
+	}
+
+	cred := &Credential{
+		U:          issuance.U.Copy(),
+		UPrime:     issuance.UPrime.Copy(),
+		Attributes: make([]*ecc.Scalar, len(attributes)),
+	}
+
+	for i, m := range attributes {
+		cred.Attributes[i] = m.Copy()
+	}
+
+	return cred, nil
+}
+
+// presentationStatement returns the statement of knowledge of the hidden m_i and z_i, and of r, such that
+// C_i = m_i * U + z_i * H for the hidden attributes, and V = sum(z_i * X_i) + r * G.
+func (p *Params) presentationStatement(pres *Presentation, v *ecc.Element) *zkp.Statement {
+	hidden := 0
+	for _, c := range pres.Commitments {
+		if c != nil {
+			hidden++
+		}
+	}
+
+	st := zkp.NewStatement(p.group, 2*hidden+1)
+	terms := []zkp.Term{{Base: p.group.Base(), Witness: 2 * hidden}}
+	k := 0
+
+	for i, c := range pres.Commitments {
+		if c == nil {
+			continue
+		}
+
+		st.AddEquation(c, zkp.Term{Base: pres.U, Witness: 2 * k}, zkp.Term{Base: p.H, Witness: 2*k + 1})
+		terms = append(terms, zkp.Term{Base: p.X[i], Witness: 2*k + 1})
+		k++
+	}
+
+	return st.AddEquation(v, terms...)
+}
+
+// Present returns an unlinkable presentation of the credential, disclosing the attributes at the indices of
+// disclose, and hiding the others. The context, e.g. a session identifier, is bound to the proof.
+func (p *Params) Present(context []byte, cred *Credential, disclose []int) (*Presentation, error) {
+	if cred == nil || cred.U == nil || cred.UPrime == nil {
+		return nil, fmt.Errorf("kvac: %w", errInvalidCredential)
+	}
+
+	if err := p.checkAttributes(cred.Attributes); err != nil {
+		return nil, fmt.Errorf("kvac: %w", err)
+	}
+
+	g := p.group
+	n := len(p.X)
+	disclosed := make([]bool, n)
+
+	for _, i := range disclose {
+		if i < 0 || i >= n {
+			return nil, fmt.Errorf("kvac: %w", errInvalidAttributes)
+		}
+
+		disclosed[i] = true
+	}
+
+	// Randomize the MAC with a, and commit to U' with r: C_U' = a * U' + r * G.
+	a := g.NewScalar().Random()
+	r := g.NewScalar().Random()
+	pres := &Presentation{
+		U:           cred.U.Copy().Multiply(a),
+		CUPrime:     cred.UPrime.Copy().Multiply(a).Add(g.Base().Multiply(r)),
+		Attributes:  make([]*ecc.Scalar, n),
+		Commitments: make([]*ecc.Element, n),
+	}
+
+	// V = sum(z_i * X_i) - r * G, over the hidden attributes.
+	minusR := g.NewScalar().Subtract(r)
+	v := g.Base().Multiply(minusR)
+
+	var witnesses []*ecc.Scalar
+
+	for i, m := range cred.Attributes {
+		if disclosed[i] {
+			pres.Attributes[i] = m.Copy()
+			continue
+		}
+
+		z := g.NewScalar().Random()
+		pres.Commitments[i] = pres.U.Copy().Multiply(m).Add(p.H.Copy().Multiply(z))
+		v.Add(p.X[i].Copy().Multiply(z))
+		witnesses = append(witnesses, m, z)
+	}
+
+	proof, err := zkp.Prove(context, p.presentationStatement(pres, v), append(witnesses, minusR))
+	if err != nil {
+		return nil, fmt.Errorf("kvac: %w", err)
+	}
+
+	pres.Proof = proof
+
+	return pres, nil
+}
+
+func (p *Params) checkPresentation(pres *Presentation) error {
+	if pres == nil || pres.U == nil || pres.CUPrime == nil || pres.U.IsIdentity() ||
+		pres.U.Group() != p.group || pres.CUPrime.Group() != p.group ||
+		len(pres.Attributes) != len(p.X) || len(pres.Commitments) != len(p.X) {
+		return errInvalidPresentation
+	}
+
+	for i := range p.X {
+		m, c := pres.Attributes[i], pres.Commitments[i]
+		if (m == nil) == (c == nil) ||
+			(m != nil && m.Group() != p.group) ||
+			(c != nil && c.Group() != p.group) {
+			return errInvalidPresentation
+		}
+	}
+
+	return nil
+}
+
+// Verify returns nil if the presentation proves knowledge of a credential issued with the secret key, on the
+// disclosed attributes in the presentation and the committed ones. The caller then checks the disclosed attributes.
+func (sk *SecretKey) Verify(context []byte, pres *Presentation) error {
+	p := sk.params
+	if err := p.checkPresentation(pres); err != nil {
+		return fmt.Errorf("kvac: %w", err)
+	}
+
+	g := p.group
+
+	// V = (x0 + sum_disclosed(x_i * m_i)) * U + sum_hidden(x_i * C_i) - C_U'.
+	exponent := sk.x0.Copy()
+	v := pres.CUPrime.Copy().Multiply(g.NewScalar().MinusOne())
+
+	for i, x := range sk.x {
+		if m := pres.Attributes[i]; m != nil {
+			exponent.Add(x.Copy().Multiply(m))
+		} else {
+			v.Add(pres.Commitments[i].Copy().Multiply(x))
+		}
+	}
+
+	v.Add(pres.U.Copy().Multiply(exponent))
+
+	if err := zkp.Verify(context, p.presentationStatement(pres, v), pres.Proof); err != nil {
+		return fmt.Errorf("kvac: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+	"math/bits"
+	"slices"
+
+	"filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	msmWindow     = 4
+	msmTableSize  = 1 << msmWindow
+	msmWindowMask = msmTableSize - 1
+
+	// pippengerThreshold is the number of terms from which the bucket method is faster than Straus' method.
+	pippengerThreshold = 128
+
+	// pippengerMaxWindow bounds the number of buckets, and thus the memory, of the bucket method.
+	pippengerMaxWindow = 16
+)
+
+// MultiScalarMult returns the multi-scalar multiplication sum(scalars_i * elements_i) in the group, and the identity
+// for empty inputs. It is much faster than summing the individual multiplications, but is not constant-time: use it
+// with public scalars only, e.g. to verify signatures or proofs. Large inputs use Pippenger's bucket method, with a
+// window chosen from the number of terms, so that the cost per term decreases as the input grows.
+func (g Group) MultiScalarMult(scalars []*Scalar, elements []*Element) (*Element, error) {
+	if err := g.checkMSM(scalars, elements); err != nil {
+		return nil, fmt.Errorf("group MultiScalarMult: %w", err)
+	}
+
+	if r := g.edwards25519MSM(scalars, elements); r != nil {
+		return r, nil
+	}
+
+	if len(scalars) >= pippengerThreshold {
+		return g.pippengerMSM(scalars, elements), nil
+	}
+
+	return g.strausMSM(scalars, elements), nil
+}
+
+// checkMSM returns an error if the inputs of a multi-scalar multiplication have different lengths, or are nil or of
+// another group.
+func (g Group) checkMSM(scalars []*Scalar, elements []*Element) error {
+	if len(scalars) != len(elements) {
+		return internal.ErrParamLengthMismatch
+	}
+
+	for i := range scalars {
+		if scalars[i] == nil {
+			return internal.ErrParamNilScalar
+		}
+
+		if elements[i] == nil {
+			return internal.ErrParamNilPoint
+		}
+
+		if scalars[i].Group() != g {
+			return internal.ErrCastScalar
+		}
+
+		if elements[i].Group() != g {
+			return internal.ErrCastElement
+		}
+	}
+
+	return nil
+}
+
+// edwards25519MSM uses the backend's multi-scalar multiplication for the Edwards25519 and Ristretto255 groups, and
+// returns nil if it is not available.
+func (g Group) edwards25519MSM(scalars []*Scalar, elements []*Element) *Element {
+	if g != Edwards25519Sha512 && g != Ristretto255Sha512 {
+		return nil
+	}
+
+	s := make([]*edwards25519.Scalar, len(scalars))
+	p := make([]*edwards25519.Point, len(elements))
+
+	for i := range scalars {
+		s[i] = scalars[i].Edwards25519Scalar()
+		p[i] = elements[i].Edwards25519Point()
+
+		if s[i] == nil || p[i] == nil {
+			return nil
+		}
+	}
+
+	r := g.NewElement()
+	if err := r.SetEdwards25519Point(new(edwards25519.Point).VarTimeMultiScalarMult(s, p)); err != nil {
+		return nil
+	}
+
+	return r
+}
+
+// bigEndian returns the big-endian encoding of the scalar, which is little-endian in the Edwards25519 and
+// Ristretto255 groups.
+func (s *Scalar) bigEndian() []byte {
+	enc := s.Encode()
+
+	if s.Group().littleEndian() {
+		slices.Reverse(enc)
+	}
+
+	return enc
+}
+
+// strausMSM implements Straus' interleaved multi-scalar multiplication with fixed windows.
+func (g Group) strausMSM(scalars []*Scalar, elements []*Element) *Element {
+	tables := make([][msmTableSize]*Element, len(elements))
+	for i := range tables {
+		for j := 1; j < msmTableSize; j++ {
+			tables[i][j] = g.NewElement()
+		}
+	}
+
+	return g.straus(g.NewElement(), tables, make([][]byte, len(scalars)), scalars, elements)
+}
+
+// straus sets r to sum(scalars_i * elements_i) with Straus' method, filling the given tables of multiples of the
+// elements and the scalars' digits, and returns it.
+func (g Group) straus(r *Element, tables [][msmTableSize]*Element, digits [][]byte, scalars []*Scalar,
+	elements []*Element,
+) *Element {
+	for i, e := range elements {
+		tables[i][1].Set(e)
+		for j := 2; j < msmTableSize; j++ {
+			tables[i][j].Set(tables[i][j-1]).Add(e)
+		}
+
+		digits[i] = scalars[i].bigEndian()
+	}
+
+	r.Identity()
+
+	for b := range g.ScalarLength() {
+		for _, shift := range []uint{msmWindow, 0} {
+			for range msmWindow {
+				r.Double()
+			}
+
+			for i := range digits {
+				if d := (digits[i][b] >> shift) & msmWindowMask; d != 0 {
+					r.Add(tables[i][d])
+				}
+			}
+		}
+	}
+
+	return r
+}
+
+// pippengerWindow returns the window width of the bucket method for n terms, about log2(n) - 2, so that the
+// 2^(c+1) additions to sum the buckets of a window are amortized over the n additions to fill them.
+func pippengerWindow(n int) int {
+	return min(max(bits.Len(uint(n))-2, msmWindow), pippengerMaxWindow)
+}
+
+// window returns the width bits of the big-endian encoding, starting at the offset from the least significant bit.
+func window(enc []byte, offset, width int) uint {
+	var w uint
+
+	for j := range width {
+		bit := offset + j
+		if bit >= 8*len(enc) {
+			break
+		}
+
+		w |= uint(enc[len(enc)-1-bit/8]>>(bit%8)&1) << j
+	}
+
+	return w
+}
+
+// pippengerMSM implements Pippenger's bucket method: for each window of c bits, from the most significant, every
+// element is added to the bucket of its scalar's digit, and the buckets are summed with their digits as weights with
+// a running sum, for a cost of about n + 2^(c+1) additions per window instead of n scalar multiplications.
+func (g Group) pippengerMSM(scalars []*Scalar, elements []*Element) *Element {
+	c := pippengerWindow(len(scalars))
+	digits := make([][]byte, len(scalars))
+
+	for i, s := range scalars {
+		digits[i] = s.bigEndian()
+	}
+
+	buckets := make([]*Element, 1<<c)
+	r := g.NewElement()
+
+	for w := (8*g.ScalarLength()+c-1)/c - 1; w >= 0; w-- {
+		for range c {
+			r.Double()
+		}
+
+		clear(buckets)
+
+		for i, d := range digits {
+			k := window(d, w*c, c)
+
+			switch {
+			case k == 0:
+				continue
+			case buckets[k] == nil:
+				buckets[k] = elements[i].Copy()
+			default:
+				buckets[k].Add(elements[i])
+			}
+		}
+
+		// sum(k * bucket_k) = sum over k of the running sums of the buckets from the highest digit down to k.
+		running, sum := g.NewElement(), g.NewElement()
+
+		for k := len(buckets) - 1; k > 0; k-- {
+			if buckets[k] != nil {
+				running.Add(buckets[k])
+			}
+
+			sum.Add(running)
+		}
+
+		r.Add(sum)
+	}
+
+	return r
+}
@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package musig2 implements MuSig2 multi-signatures: key aggregation with hashed coefficients, two-element nonces,
+// and the aggregation of partial signatures into a single Schnorr signature of the aggregate public key. Over
+// secp256k1, it follows BIP-327 without tweaks, and the signatures verify with the bip340 package. Over the other
+// groups, the same protocol uses the group's hash-to-scalar, and the signatures verify with the schnorr package.
+package musig2
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/bip340"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	tagKeyAggList  = "KeyAgg list"
+	tagKeyAggCoeff = "KeyAgg coefficient"
+	tagAux         = "MuSig/aux"
+	tagNonce       = "MuSig/nonce"
+	tagNonceCoeff  = "MuSig/noncecoef"
+
+	dstApp     = "ecc-musig2"
+	dstVersion = 1
+)
+
+var (
+	errNoKeys        = errors.New("no public keys")
+	errUnknownKey    = errors.New("public key is not part of the aggregate key")
+	errNonceReuse    = errors.New("secret nonce was already used")
+	errInvalidPsig   = errors.New("invalid partial signature")
+	errKeyMismatch   = errors.New("secret key does not match the nonce's public key")
+	errInvalidNonces = errors.New("invalid public nonce")
+)
+
+// isBIP327 returns whether the group follows BIP-327, with x-only keys and nonces of even y-coordinates.
+func isBIP327(g ecc.Group) bool {
+	return g == ecc.Secp256k1Sha256
+}
+
+// hashBytes returns the tagged hash of the data, which is that of BIP-340 for secp256k1, and otherwise the group's
+// hash function over the length-prefixed tag and the data.
+func hashBytes(g ecc.Group, tag string, data ...[]byte) []byte {
+	if isBIP327(g) {
+		return bip340.TaggedHash(tag, data...)
+	}
+
+	h := g.HashFunc().New()
+	_, _ = h.Write(binary.BigEndian.AppendUint16(nil, uint16(len(tag))))
+	_, _ = h.Write([]byte(tag))
+
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+
+	return h.Sum(nil)
+}
+
+// hashScalar returns the tagged hash of the data as a scalar, which is the BIP-340 tagged hash reduced modulo the
+// order for secp256k1, and otherwise the group's HashToScalar over the length-prefixed tag and the data.
+func hashScalar(g ecc.Group, tag string, data ...[]byte) *ecc.Scalar {
+	if isBIP327(g) {
+		return bip340.TaggedHashToScalar(tag, data...)
+	}
+
+	input := binary.BigEndian.AppendUint16(nil, uint16(len(tag)))
+	input = append(input, tag...)
+
+	for _, d := range data {
+		input = append(input, d...)
+	}
+
+	return g.HashToScalar(input, g.DST(dstApp, dstVersion))
+}
+
+// SortKeys returns a copy of the public keys sorted by their encodings, i.e. KeySort.
+func SortKeys(publics []*ecc.Element) []*ecc.Element {
+	sorted := slices.Clone(publics)
+	slices.SortFunc(sorted, func(a, b *ecc.Element) int {
+		return bytes.Compare(a.Encode(), b.Encode())
+	})
+
+	return sorted
+}
+
+// KeyAggContext is the aggregation of the signers' public keys, in a given order.
+type KeyAggContext struct {
+	q            *ecc.Element
+	publics      [][]byte
+	coefficients []*ecc.Scalar
+	group        ecc.Group
+}
+
+// KeyAgg returns the aggregation of the public keys, whose order matters. Use SortKeys for an order-independent
+// aggregate key.
+func KeyAgg(publics []*ecc.Element) (*KeyAggContext, error) {
+	if len(publics) == 0 || publics[0] == nil {
+		return nil, fmt.Errorf("musig2: %w", errNoKeys)
+	}
+
+	g := publics[0].Group()
+	ctx := &KeyAggContext{
+		q:            g.NewElement(),
+		publics:      make([][]byte, len(publics)),
+		coefficients: make([]*ecc.Scalar, len(publics)),
+		group:        g,
+	}
+
+	for i, p := range publics {
+		if p == nil || p.IsIdentity() || p.Group() != g {
+			return nil, fmt.Errorf("musig2: %w", internal.ErrParamNilPoint)
+		}
+
+		ctx.publics[i] = p.Encode()
+	}
+
+	list := hashBytes(g, tagKeyAggList, ctx.publics...)
+
+	// The coefficient of the first key different from the first one is 1, i.e. GetSecondKey.
+	var second []byte
+
+	for _, p := range ctx.publics {
+		if !bytes.Equal(p, ctx.publics[0]) {
+			second = p
+			break
+		}
+	}
+
+	for i, p := range publics {
+		if second != nil && bytes.Equal(ctx.publics[i], second) {
+			ctx.coefficients[i] = g.NewScalar().One()
+		} else {
+			ctx.coefficients[i] = hashScalar(g, tagKeyAggCoeff, list, ctx.publics[i])
+		}
+
+		ctx.q.Add(p.Copy().Multiply(ctx.coefficients[i]))
+	}
+
+	if ctx.q.IsIdentity() {
+		return nil, fmt.Errorf("musig2: %w", internal.ErrIdentity)
+	}
+
+	return ctx, nil
+}
+
+// AggregatePublicKey returns the aggregate public key Q. Over secp256k1, signatures verify with its x-only encoding.
+func (c *KeyAggContext) AggregatePublicKey() *ecc.Element {
+	return c.q.Copy()
+}
+
+// encodeAggregate returns the encoding of the aggregate key in hashes, which is x-only for secp256k1.
+func (c *KeyAggContext) encodeAggregate() []byte {
+	if isBIP327(c.group) {
+		return c.q.EncodeXOnly()
+	}
+
+	return c.q.Encode()
+}
+
+// coefficient returns the aggregation coefficient of the public key.
+func (c *KeyAggContext) coefficient(public *ecc.Element) (*ecc.Scalar, error) {
+	enc := public.Encode()
+	for i, p := range c.publics {
+		if bytes.Equal(p, enc) {
+			return c.coefficients[i], nil
+		}
+	}
+
+	return nil, errUnknownKey
+}
+
+// parity returns the scalar g of BIP-327, i.e. -1 if the aggregate key has an odd y-coordinate over secp256k1, and 1
+// otherwise.
+func (c *KeyAggContext) parity() *ecc.Scalar {
+	if isBIP327(c.group) && !c.q.HasEvenY() {
+		return c.group.NewScalar().MinusOne()
+	}
+
+	return c.group.NewScalar().One()
+}
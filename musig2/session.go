@@ -0,0 +1,306 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package musig2
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/bip340"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+const randSize = 32
+
+// PublicNonce is a signer's public nonce (R1, R2), or the aggregate of all signers' nonces, whose elements may then
+// be the identity.
+type PublicNonce struct {
+	R1, R2 *ecc.Element
+}
+
+// encodeExt returns the encoding of the element, with the identity encoded as zeros.
+func encodeExt(e *ecc.Element) []byte {
+	if e.IsIdentity() {
+		return make([]byte, e.Group().ElementLength())
+	}
+
+	return e.Encode()
+}
+
+// Encode returns the encoding R1 || R2 of the nonce.
+func (n *PublicNonce) Encode() []byte {
+	return append(encodeExt(n.R1), encodeExt(n.R2)...)
+}
+
+func decodeExt(g ecc.Group, data []byte) (*ecc.Element, error) {
+	e := g.NewElement()
+
+	for _, b := range data {
+		if b != 0 {
+			if err := e.Decode(data); err != nil {
+				return nil, err
+			}
+
+			return e, nil
+		}
+	}
+
+	return e, nil
+}
+
+// DecodePublicNonce returns the public nonce over the group decoded from its encoding.
+func DecodePublicNonce(g ecc.Group, data []byte) (*PublicNonce, error) {
+	l := g.ElementLength()
+	if len(data) != 2*l {
+		return nil, fmt.Errorf("musig2: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	r1, err := decodeExt(g, data[:l])
+	if err != nil {
+		return nil, fmt.Errorf("musig2: %w", err)
+	}
+
+	r2, err := decodeExt(g, data[l:])
+	if err != nil {
+		return nil, fmt.Errorf("musig2: %w", err)
+	}
+
+	return &PublicNonce{R1: r1, R2: r2}, nil
+}
+
+// SecretNonce is a signer's secret nonce, which must only be used once and is erased by Sign.
+type SecretNonce struct {
+	k1, k2 *ecc.Scalar
+	public []byte
+}
+
+// NonceGen returns a fresh secret and public nonce for the signer's public key. The secret key, the aggregate key,
+// the message, and the extra input are optional, i.e. can be nil, and only strengthen the nonces against a weak
+// random number generator.
+func NonceGen(
+	secret *ecc.Scalar,
+	public, aggregate *ecc.Element,
+	message, extra []byte,
+) (*SecretNonce, *PublicNonce, error) {
+	if public == nil || public.IsIdentity() {
+		return nil, nil, fmt.Errorf("musig2: %w", internal.ErrParamNilPoint)
+	}
+
+	g := public.Group()
+	rand := internal.RandomBytes(randSize)
+
+	if secret != nil {
+		if isBIP327(g) {
+			// rand = bytes(secret) XOR hash_aux(rand').
+			sk := secret.Encode()
+			for i, b := range hashBytes(g, tagAux, rand) {
+				rand[i] = sk[i] ^ b
+			}
+		} else {
+			rand = append(rand, secret.Encode()...)
+		}
+	}
+
+	pk := public.Encode()
+
+	var agg []byte
+	if aggregate != nil {
+		agg = aggregate.Encode()
+		if isBIP327(g) {
+			agg = aggregate.EncodeXOnly()
+		}
+	}
+
+	var msg []byte
+	if message != nil {
+		msg = binary.BigEndian.AppendUint64([]byte{1}, uint64(len(message)))
+		msg = append(msg, message...)
+	} else {
+		msg = []byte{0}
+	}
+
+	k := [2]*ecc.Scalar{}
+	for i := range k {
+		k[i] = hashScalar(g, tagNonce,
+			rand,
+			[]byte{byte(len(pk))}, pk,
+			[]byte{byte(len(agg))}, agg,
+			msg,
+			binary.BigEndian.AppendUint32(nil, uint32(len(extra))), extra,
+			[]byte{byte(i)},
+		)
+	}
+
+	return &SecretNonce{k1: k[0], k2: k[1], public: pk},
+		&PublicNonce{R1: g.Base().Multiply(k[0]), R2: g.Base().Multiply(k[1])},
+		nil
+}
+
+// NonceAgg returns the aggregate of the signers' public nonces.
+func NonceAgg(nonces []*PublicNonce) (*PublicNonce, error) {
+	if len(nonces) == 0 || nonces[0] == nil || nonces[0].R1 == nil {
+		return nil, fmt.Errorf("musig2: %w", errInvalidNonces)
+	}
+
+	g := nonces[0].R1.Group()
+	agg := &PublicNonce{R1: g.NewElement(), R2: g.NewElement()}
+
+	for _, n := range nonces {
+		if err := checkNonce(g, n); err != nil {
+			return nil, fmt.Errorf("musig2: %w", err)
+		}
+
+		agg.R1.Add(n.R1)
+		agg.R2.Add(n.R2)
+	}
+
+	return agg, nil
+}
+
+func checkNonce(g ecc.Group, n *PublicNonce) error {
+	if n == nil || n.R1 == nil || n.R2 == nil || n.R1.Group() != g || n.R2.Group() != g {
+		return errInvalidNonces
+	}
+
+	return nil
+}
+
+// Session holds the values shared by all signers for a message and an aggregate nonce.
+type Session struct {
+	ctx     *KeyAggContext
+	b, e    *ecc.Scalar
+	r       *ecc.Element
+	encodeR []byte
+}
+
+// NewSession returns the signing session of the message with the aggregate nonce for the aggregate key.
+func NewSession(ctx *KeyAggContext, aggNonce *PublicNonce, message []byte) (*Session, error) {
+	g := ctx.group
+	if err := checkNonce(g, aggNonce); err != nil {
+		return nil, fmt.Errorf("musig2: %w", err)
+	}
+
+	q := ctx.encodeAggregate()
+	b := hashScalar(g, tagNonceCoeff, aggNonce.Encode(), q, message)
+
+	// R = R1 + b * R2, or the base element if that is the identity.
+	r := aggNonce.R2.Copy().Multiply(b).Add(aggNonce.R1)
+	if r.IsIdentity() {
+		r = g.Base()
+	}
+
+	s := &Session{ctx: ctx, b: b, r: r}
+
+	if isBIP327(g) {
+		s.encodeR = r.EncodeXOnly()
+		s.e = bip340.Challenge(s.encodeR, q, message)
+	} else {
+		s.encodeR = r.Encode()
+		s.e = schnorr.Challenge(g, s.encodeR, q, message)
+	}
+
+	return s, nil
+}
+
+// nonceParity returns whether the nonces must be negated, i.e. whether R has an odd y-coordinate over secp256k1.
+func (s *Session) nonceParity() bool {
+	return isBIP327(s.ctx.group) && !s.r.HasEvenY()
+}
+
+// Sign returns the signer's partial signature s = k1 + b * k2 + e * a * g * secret, and erases the secret nonce.
+func (s *Session) Sign(nonce *SecretNonce, secret *ecc.Scalar) (*ecc.Scalar, error) {
+	g := s.ctx.group
+
+	if nonce == nil || nonce.k1 == nil {
+		return nil, fmt.Errorf("musig2: %w", errNonceReuse)
+	}
+
+	if secret == nil || secret.IsZero() || secret.Group() != g {
+		return nil, fmt.Errorf("musig2: %w", internal.ErrParamNilScalar)
+	}
+
+	public := g.Base().Multiply(secret)
+	if string(public.Encode()) != string(nonce.public) {
+		return nil, fmt.Errorf("musig2: %w", errKeyMismatch)
+	}
+
+	a, err := s.ctx.coefficient(public)
+	if err != nil {
+		return nil, fmt.Errorf("musig2: %w", err)
+	}
+
+	k1, k2 := nonce.k1, nonce.k2
+	nonce.k1, nonce.k2 = nil, nil
+
+	if s.nonceParity() {
+		k1, k2 = g.NewScalar().Subtract(k1), g.NewScalar().Subtract(k2)
+	}
+
+	d := s.ctx.parity().Multiply(secret)
+
+	return k1.Add(k2.Multiply(s.b)).Add(s.e.Copy().Multiply(a).Multiply(d)), nil
+}
+
+// PartialVerify returns nil if the partial signature is valid for the signer's public nonce and public key.
+func (s *Session) PartialVerify(psig *ecc.Scalar, nonce *PublicNonce, public *ecc.Element) error {
+	g := s.ctx.group
+
+	if psig == nil || psig.Group() != g {
+		return fmt.Errorf("musig2: %w", errInvalidPsig)
+	}
+
+	if err := checkNonce(g, nonce); err != nil {
+		return fmt.Errorf("musig2: %w", err)
+	}
+
+	if public == nil || public.Group() != g {
+		return fmt.Errorf("musig2: %w", internal.ErrParamNilPoint)
+	}
+
+	a, err := s.ctx.coefficient(public)
+	if err != nil {
+		return fmt.Errorf("musig2: %w", err)
+	}
+
+	// s * G == ±(R1 + b * R2) + e * a * g * P, with the negation of the nonce moved to the left-hand side to only
+	// negate scalars.
+	re := nonce.R2.Copy().Multiply(s.b).Add(nonce.R1)
+	lhs := g.Base().Multiply(psig)
+	rhs := public.Copy().Multiply(s.e.Copy().Multiply(a).Multiply(s.ctx.parity()))
+
+	if s.nonceParity() {
+		lhs.Add(re)
+	} else {
+		rhs.Add(re)
+	}
+
+	if !lhs.Equal(rhs) {
+		return fmt.Errorf("musig2: %w", errInvalidPsig)
+	}
+
+	return nil
+}
+
+// Aggregate returns the signature of the partial signatures, R || sum(s_i), which verifies with the bip340 package
+// over secp256k1, and the schnorr package otherwise.
+func (s *Session) Aggregate(psigs []*ecc.Scalar) ([]byte, error) {
+	sum := s.ctx.group.NewScalar()
+
+	for _, p := range psigs {
+		if p == nil || p.Group() != s.ctx.group {
+			return nil, fmt.Errorf("musig2: %w", errInvalidPsig)
+		}
+
+		sum.Add(p)
+	}
+
+	return append(append([]byte(nil), s.encodeR...), sum.Encode()...), nil
+}
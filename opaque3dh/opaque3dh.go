@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package opaque3dh implements the building blocks of the 3DH authenticated key exchange of OPAQUE (RFC 9807), over
+// the Ristretto255 and NIST groups: key pair derivation, the preamble, the combination of the three Diffie-Hellman
+// outputs, and the key schedule with its MACs. The hash function of HKDF and HMAC is that of the group, as in the
+// OPAQUE configurations. Message framing and the rest of the protocol are left to OPAQUE implementations.
+package opaque3dh
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/oprf"
+)
+
+const (
+	preamblePrefix  = "OPAQUEv1-"
+	labelPrefix     = "OPAQUE-"
+	labelDeriveKey  = "OPAQUE-DeriveDiffieHellmanKeyPair"
+	labelHandshake  = "HandshakeSecret"
+	labelSessionKey = "SessionKey"
+	labelServerMAC  = "ServerMAC"
+	labelClientMAC  = "ClientMAC"
+)
+
+var errInvalidSharedSecret = errors.New("invalid Diffie-Hellman output")
+
+// Suite is the 3DH configuration of an OPAQUE ciphersuite.
+type Suite struct {
+	oprf  *oprf.Suite
+	group ecc.Group
+	hash  crypto.Hash
+}
+
+// New returns the 3DH configuration of the group's OPAQUE ciphersuite.
+func New(g ecc.Group) (*Suite, error) {
+	o, err := oprf.New(g, oprf.ModeOPRF)
+	if err != nil {
+		return nil, fmt.Errorf("opaque3dh: %w", err)
+	}
+
+	return &Suite{oprf: o, group: g, hash: g.HashFunc()}, nil
+}
+
+// Group returns the group of the configuration.
+func (s *Suite) Group() ecc.Group {
+	return s.group
+}
+
+// DeriveKeyPair returns the key pair deterministically derived from the seed, i.e. DeriveDiffieHellmanKeyPair.
+func (s *Suite) DeriveKeyPair(seed []byte) (*ecc.Scalar, *ecc.Element, error) {
+	sk, pk, err := s.oprf.DeriveKeyPair(seed, []byte(labelDeriveKey))
+	if err != nil {
+		return nil, nil, fmt.Errorf("opaque3dh: %w", err)
+	}
+
+	return sk, pk, nil
+}
+
+func appendLengthPrefixed(b, data []byte) []byte {
+	b = binary.BigEndian.AppendUint16(b, uint16(len(data)))
+	return append(b, data...)
+}
+
+// Preamble returns the preamble of the key schedule, from the context, the identities, the serialized KE1 message,
+// and the fields of the KE2 message preceding the server MAC.
+func Preamble(
+	context, clientIdentity, ke1, serverIdentity, credentialResponse, serverNonce, serverKeyshare []byte,
+) []byte {
+	p := appendLengthPrefixed([]byte(preamblePrefix), context)
+	p = appendLengthPrefixed(p, clientIdentity)
+	p = append(p, ke1...)
+	p = appendLengthPrefixed(p, serverIdentity)
+	p = append(p, credentialResponse...)
+	p = append(p, serverNonce...)
+
+	return append(p, serverKeyshare...)
+}
+
+// combine returns the concatenation of the encodings of the Diffie-Hellman outputs sk_i * pk_i.
+func (s *Suite) combine(sks [3]*ecc.Scalar, pks [3]*ecc.Element) ([]byte, error) {
+	out := make([]byte, 0, 3*s.group.ElementLength())
+
+	for i := range sks {
+		if sks[i] == nil || sks[i].Group() != s.group {
+			return nil, fmt.Errorf("opaque3dh: %w", internal.ErrParamNilScalar)
+		}
+
+		if pks[i] == nil || pks[i].Group() != s.group {
+			return nil, fmt.Errorf("opaque3dh: %w", internal.ErrParamNilPoint)
+		}
+
+		dh := pks[i].Copy().Multiply(sks[i])
+		if dh.IsIdentity() {
+			return nil, fmt.Errorf("opaque3dh: %w", errInvalidSharedSecret)
+		}
+
+		out = append(out, dh.Encode()...)
+	}
+
+	return out, nil
+}
+
+// ClientIKM returns the client's input keying material of the key schedule, from its ephemeral and long-term private
+// keys, and the server's ephemeral and long-term public keys.
+func (s *Suite) ClientIKM(
+	clientEphemeral, clientSecret *ecc.Scalar,
+	serverEphemeral, serverPublic *ecc.Element,
+) ([]byte, error) {
+	return s.combine(
+		[3]*ecc.Scalar{clientEphemeral, clientEphemeral, clientSecret},
+		[3]*ecc.Element{serverEphemeral, serverPublic, serverEphemeral},
+	)
+}
+
+// ServerIKM returns the server's input keying material of the key schedule, from its ephemeral and long-term private
+// keys, and the client's ephemeral and long-term public keys.
+func (s *Suite) ServerIKM(
+	serverEphemeral, serverSecret *ecc.Scalar,
+	clientEphemeral, clientPublic *ecc.Element,
+) ([]byte, error) {
+	return s.combine(
+		[3]*ecc.Scalar{serverEphemeral, serverSecret, serverEphemeral},
+		[3]*ecc.Element{clientEphemeral, clientEphemeral, clientPublic},
+	)
+}
+
+// Keys are the outputs of the key schedule.
+type Keys struct {
+	// SessionKey is the key shared by the client and the server after a successful exchange.
+	SessionKey []byte
+
+	// ServerMACKey and ClientMACKey are the keys of the server and client MACs.
+	ServerMACKey, ClientMACKey []byte
+
+	preamble     []byte
+	preambleHash []byte
+}
+
+// expandLabel returns Expand-Label(secret, label, context, Nh).
+func (s *Suite) expandLabel(secret []byte, label string, context []byte) []byte {
+	length := s.hash.Size()
+
+	info := binary.BigEndian.AppendUint16(nil, uint16(length))
+	info = append(info, byte(len(labelPrefix)+len(label)))
+	info = append(info, labelPrefix...)
+	info = append(info, label...)
+	info = append(info, byte(len(context)))
+	info = append(info, context...)
+
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(s.hash.New, secret, info), out); err != nil {
+		// Only happens when requesting more than 255 * Nh bytes.
+		panic(err)
+	}
+
+	return out
+}
+
+// DeriveKeys returns the session and MAC keys of the input keying material and the preamble.
+func (s *Suite) DeriveKeys(ikm, preamble []byte) *Keys {
+	h := s.hash.New()
+	_, _ = h.Write(preamble)
+	preambleHash := h.Sum(nil)
+
+	prk := hkdf.Extract(s.hash.New, ikm, nil)
+	handshake := s.expandLabel(prk, labelHandshake, preambleHash)
+
+	return &Keys{
+		SessionKey:   s.expandLabel(prk, labelSessionKey, preambleHash),
+		ServerMACKey: s.expandLabel(handshake, labelServerMAC, nil),
+		ClientMACKey: s.expandLabel(handshake, labelClientMAC, nil),
+		preamble:     append([]byte(nil), preamble...),
+		preambleHash: preambleHash,
+	}
+}
+
+func (s *Suite) mac(key, message []byte) []byte {
+	m := hmac.New(s.hash.New, key)
+	_, _ = m.Write(message)
+
+	return m.Sum(nil)
+}
+
+// ServerMAC returns the server MAC over the preamble, sent in KE2.
+func (s *Suite) ServerMAC(keys *Keys) []byte {
+	return s.mac(keys.ServerMACKey, keys.preambleHash)
+}
+
+// ClientMAC returns the client MAC over the preamble and the server MAC, sent in KE3.
+func (s *Suite) ClientMAC(keys *Keys, serverMAC []byte) []byte {
+	h := s.hash.New()
+	_, _ = h.Write(keys.preamble)
+	_, _ = h.Write(serverMAC)
+
+	return s.mac(keys.ClientMACKey, h.Sum(nil))
+}
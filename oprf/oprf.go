@@ -0,0 +1,363 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package oprf implements the OPRF, VOPRF, and POPRF protocols of RFC 9497 for the ristretto255-SHA512,
+// P256-SHA256, P384-SHA384, and P521-SHA512 ciphersuites. decaf448 is not supported, as this module has no such
+// group. All functions evaluate batches of inputs, a single input being a batch of one, and the VOPRF and POPRF
+// proofs cover the whole batch.
+package oprf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+// Mode identifies an RFC 9497 protocol variant.
+type Mode byte
+
+const (
+	// ModeOPRF is the base OPRF mode.
+	ModeOPRF Mode = iota
+
+	// ModeVOPRF is the verifiable mode, in which the server proves it used the committed key.
+	ModeVOPRF
+
+	// ModePOPRF is the partially-oblivious mode, which additionally binds a public info to the evaluation.
+	ModePOPRF
+)
+
+const (
+	versionPrefix = "OPRFV1-"
+	labelFinalize = "Finalize"
+	labelInfo     = "Info"
+	maxCounter    = 255
+)
+
+var (
+	errInvalidMode  = errors.New("invalid mode")
+	errInvalidInput = errors.New("invalid input")
+	errBatchLength  = errors.New("mismatching batch lengths")
+	errInvalidProof = errors.New("invalid proof")
+)
+
+// Suite is an RFC 9497 protocol variant over a ciphersuite.
+type Suite struct {
+	context []byte
+	group   ecc.Group
+	mode    Mode
+}
+
+// identifier returns the RFC 9497 ciphersuite identifier of the group.
+func identifier(g ecc.Group) (string, error) {
+	switch g {
+	case ecc.Ristretto255Sha512:
+		return "ristretto255-SHA512", nil
+	case ecc.P256Sha256:
+		return "P256-SHA256", nil
+	case ecc.P384Sha384:
+		return "P384-SHA384", nil
+	case ecc.P521Sha512:
+		return "P521-SHA512", nil
+	default:
+		return "", internal.ErrInvalidGroup
+	}
+}
+
+// New returns the protocol variant of the mode over the ciphersuite of the group.
+func New(g ecc.Group, mode Mode) (*Suite, error) {
+	id, err := identifier(g)
+	if err != nil {
+		return nil, fmt.Errorf("oprf: %w", err)
+	}
+
+	if mode > ModePOPRF {
+		return nil, fmt.Errorf("oprf: %w", errInvalidMode)
+	}
+
+	context := make([]byte, 0, len(versionPrefix)+2+len(id))
+	context = append(context, versionPrefix...)
+	context = append(context, byte(mode), '-')
+	context = append(context, id...)
+
+	return &Suite{context: context, group: g, mode: mode}, nil
+}
+
+// Group returns the group of the ciphersuite.
+func (s *Suite) Group() ecc.Group {
+	return s.group
+}
+
+// Mode returns the protocol variant.
+func (s *Suite) Mode() Mode {
+	return s.mode
+}
+
+func (s *Suite) dst(prefix string) []byte {
+	return append([]byte(prefix), s.context...)
+}
+
+func (s *Suite) hashToScalar(input []byte, prefix string) *ecc.Scalar {
+	return s.group.HashToScalar(input, s.dst(prefix))
+}
+
+// lengthPrefixed returns the concatenation of the inputs, each prefixed with its two-byte length.
+func lengthPrefixed(inputs ...[]byte) []byte {
+	size := 0
+	for _, in := range inputs {
+		size += 2 + len(in)
+	}
+
+	out := make([]byte, 0, size)
+	for _, in := range inputs {
+		out = binary.BigEndian.AppendUint16(out, uint16(len(in)))
+		out = append(out, in...)
+	}
+
+	return out
+}
+
+// DeriveKeyPair deterministically derives a private and public key pair from the seed and the info, as in RFC 9497
+// section 3.2.1.
+func (s *Suite) DeriveKeyPair(seed, info []byte) (*ecc.Scalar, *ecc.Element, error) {
+	input := append(append(seed[:len(seed):len(seed)], lengthPrefixed(info)...), 0)
+	dst := s.dst("DeriveKeyPair")
+
+	for counter := 0; counter <= maxCounter; counter++ {
+		input[len(input)-1] = byte(counter)
+
+		sk := s.group.HashToScalar(input, dst)
+		if !sk.IsZero() {
+			return sk, s.group.Base().Multiply(sk), nil
+		}
+	}
+
+	return nil, nil, fmt.Errorf("oprf: %w", internal.ErrDeriveKeyPair)
+}
+
+// framedInfo returns the POPRF scalar of the info, m = HashToScalar("Info" || len(info) || info).
+func (s *Suite) framedInfo(info []byte) *ecc.Scalar {
+	return s.hashToScalar(append([]byte(labelInfo), lengthPrefixed(info)...), "HashToScalar-")
+}
+
+func (s *Suite) hashToGroup(input []byte) (*ecc.Element, error) {
+	e := s.group.HashToGroup(input, s.dst("HashToGroup-"))
+	if e.IsIdentity() {
+		return nil, errInvalidInput
+	}
+
+	return e, nil
+}
+
+// Blind returns the blinded elements of the inputs, and the blinds to later unblind the evaluations with. The blinds
+// are random if nil, and otherwise used as given, e.g. for test vectors.
+func (s *Suite) Blind(inputs [][]byte, blinds []*ecc.Scalar) ([]*ecc.Scalar, []*ecc.Element, error) {
+	if blinds == nil {
+		blinds = make([]*ecc.Scalar, len(inputs))
+		for i := range blinds {
+			blinds[i] = s.group.NewScalar().Random()
+		}
+	}
+
+	if len(blinds) != len(inputs) {
+		return nil, nil, fmt.Errorf("oprf: %w", errBatchLength)
+	}
+
+	blinded := make([]*ecc.Element, len(inputs))
+
+	for i, input := range inputs {
+		if blinds[i] == nil || blinds[i].IsZero() || blinds[i].Group() != s.group {
+			return nil, nil, fmt.Errorf("oprf: %w", internal.ErrParamNilScalar)
+		}
+
+		e, err := s.hashToGroup(input)
+		if err != nil {
+			return nil, nil, fmt.Errorf("oprf: %w", err)
+		}
+
+		blinded[i] = e.Multiply(blinds[i])
+	}
+
+	return blinds, blinded, nil
+}
+
+// tweakedKey returns the POPRF server key of the info, which is the private key plus the framed info scalar.
+func (s *Suite) tweakedKey(sk *ecc.Scalar, info []byte) (*ecc.Scalar, error) {
+	t := s.framedInfo(info).Add(sk)
+	if t.IsZero() {
+		return nil, errInvalidInput
+	}
+
+	return t, nil
+}
+
+// TweakedPublicKey returns the POPRF public key of the info, against which the client verifies the proof.
+func (s *Suite) TweakedPublicKey(public *ecc.Element, info []byte) (*ecc.Element, error) {
+	if public == nil || public.IsIdentity() || public.Group() != s.group {
+		return nil, fmt.Errorf("oprf: %w", internal.ErrParamNilPoint)
+	}
+
+	t := s.group.Base().Multiply(s.framedInfo(info)).Add(public)
+	if t.IsIdentity() {
+		return nil, fmt.Errorf("oprf: %w", errInvalidInput)
+	}
+
+	return t, nil
+}
+
+func (s *Suite) checkElements(elements []*ecc.Element) error {
+	for _, e := range elements {
+		if e == nil || e.IsIdentity() || e.Group() != s.group {
+			return internal.ErrParamNilPoint
+		}
+	}
+
+	return nil
+}
+
+// BlindEvaluate returns the server's evaluations of the blinded elements with the private key and, in the VOPRF and
+// POPRF modes, the proof of their correctness, computed with the random scalar r, or a fresh one if nil. The info is
+// only used in the POPRF mode. As in RFC 9497, a single constant-size proof covers the whole batch, which the client
+// verifies at once in Finalize, so issuers should evaluate all the elements of a request in one call.
+func (s *Suite) BlindEvaluate(
+	sk *ecc.Scalar,
+	blinded []*ecc.Element,
+	info []byte,
+	r *ecc.Scalar,
+) ([]*ecc.Element, *Proof, error) {
+	if sk == nil || sk.IsZero() || sk.Group() != s.group {
+		return nil, nil, fmt.Errorf("oprf: %w", internal.ErrParamNilScalar)
+	}
+
+	if err := s.checkElements(blinded); err != nil {
+		return nil, nil, fmt.Errorf("oprf: %w", err)
+	}
+
+	if s.mode != ModePOPRF {
+		evaluated := make([]*ecc.Element, len(blinded))
+		for i, b := range blinded {
+			evaluated[i] = b.Copy().Multiply(sk)
+		}
+
+		if s.mode == ModeOPRF {
+			return evaluated, nil, nil
+		}
+
+		return evaluated, s.generateProof(sk, s.group.Base().Multiply(sk), blinded, evaluated, r), nil
+	}
+
+	t, err := s.tweakedKey(sk, info)
+	if err != nil {
+		return nil, nil, fmt.Errorf("oprf: %w", err)
+	}
+
+	inverse := t.Copy().Invert()
+
+	evaluated := make([]*ecc.Element, len(blinded))
+	for i, b := range blinded {
+		evaluated[i] = b.Copy().Multiply(inverse)
+	}
+
+	// The roles of the blinded and evaluated elements are swapped, as the tweaked key is the inverse's.
+	return evaluated, s.generateProof(t, s.group.Base().Multiply(t), evaluated, blinded, r), nil
+}
+
+// Finalize verifies the proof in the VOPRF and POPRF modes, unblinds the evaluations, and returns the PRF outputs of
+// the inputs. The public key is that of the server, and is ignored in the OPRF mode, as are the proof and the info.
+func (s *Suite) Finalize(
+	inputs [][]byte,
+	blinds []*ecc.Scalar,
+	blinded, evaluated []*ecc.Element,
+	public *ecc.Element,
+	proof *Proof,
+	info []byte,
+) ([][]byte, error) {
+	if len(blinds) != len(inputs) || len(blinded) != len(inputs) || len(evaluated) != len(inputs) {
+		return nil, fmt.Errorf("oprf: %w", errBatchLength)
+	}
+
+	if err := s.checkElements(evaluated); err != nil {
+		return nil, fmt.Errorf("oprf: %w", err)
+	}
+
+	switch s.mode {
+	case ModeVOPRF:
+		if public == nil || public.IsIdentity() || public.Group() != s.group {
+			return nil, fmt.Errorf("oprf: %w", internal.ErrParamNilPoint)
+		}
+
+		if !s.verifyProof(public, blinded, evaluated, proof) {
+			return nil, fmt.Errorf("oprf: %w", errInvalidProof)
+		}
+	case ModePOPRF:
+		tweaked, err := s.TweakedPublicKey(public, info)
+		if err != nil {
+			return nil, err
+		}
+
+		if !s.verifyProof(tweaked, evaluated, blinded, proof) {
+			return nil, fmt.Errorf("oprf: %w", errInvalidProof)
+		}
+	}
+
+	outputs := make([][]byte, len(inputs))
+
+	for i, input := range inputs {
+		if blinds[i] == nil || blinds[i].IsZero() {
+			return nil, fmt.Errorf("oprf: %w", internal.ErrParamNilScalar)
+		}
+
+		n := evaluated[i].Copy().Multiply(blinds[i].Copy().Invert())
+		outputs[i] = s.output(input, info, n)
+	}
+
+	return outputs, nil
+}
+
+// Evaluate returns the PRF output of the input with the private key, computed by the server without a client. The
+// info is only used in the POPRF mode.
+func (s *Suite) Evaluate(sk *ecc.Scalar, input, info []byte) ([]byte, error) {
+	if sk == nil || sk.IsZero() || sk.Group() != s.group {
+		return nil, fmt.Errorf("oprf: %w", internal.ErrParamNilScalar)
+	}
+
+	e, err := s.hashToGroup(input)
+	if err != nil {
+		return nil, fmt.Errorf("oprf: %w", err)
+	}
+
+	if s.mode == ModePOPRF {
+		t, err := s.tweakedKey(sk, info)
+		if err != nil {
+			return nil, fmt.Errorf("oprf: %w", err)
+		}
+
+		return s.output(input, info, e.Multiply(t.Invert())), nil
+	}
+
+	return s.output(input, info, e.Multiply(sk)), nil
+}
+
+// output returns Hash(len(input) || input [|| len(info) || info] || len(element) || element || "Finalize").
+func (s *Suite) output(input, info []byte, element *ecc.Element) []byte {
+	var transcript []byte
+	if s.mode == ModePOPRF {
+		transcript = lengthPrefixed(input, info, element.Encode())
+	} else {
+		transcript = lengthPrefixed(input, element.Encode())
+	}
+
+	h := s.group.HashFunc().New()
+	_, _ = h.Write(transcript)
+	_, _ = h.Write([]byte(labelFinalize))
+
+	return h.Sum(nil)
+}
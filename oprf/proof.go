@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package oprf
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	labelChallenge = "Challenge"
+	labelComposite = "Composite"
+)
+
+// Proof is the discrete logarithm equivalence proof (c, s) of RFC 9497 section 2.2.
+type Proof struct {
+	C, S *ecc.Scalar
+}
+
+// Encode returns the serialization c || s of the proof.
+func (p *Proof) Encode() []byte {
+	return append(p.C.Encode(), p.S.Encode()...)
+}
+
+// DecodeProof returns the proof deserialized from its encoding.
+func (s *Suite) DecodeProof(data []byte) (*Proof, error) {
+	l := s.group.ScalarLength()
+	if len(data) != 2*l {
+		return nil, fmt.Errorf("oprf: %w", errInvalidProof)
+	}
+
+	p := &Proof{C: s.group.NewScalar(), S: s.group.NewScalar()}
+
+	if err := p.C.Decode(data[:l]); err != nil {
+		return nil, fmt.Errorf("oprf: %w", err)
+	}
+
+	if err := p.S.Decode(data[l:]); err != nil {
+		return nil, fmt.Errorf("oprf: %w", err)
+	}
+
+	return p, nil
+}
+
+// composites returns the composite elements M = sum(d_i * C_i) and, if k is nil, Z = sum(d_i * D_i), and otherwise
+// Z = k * M, as ComputeComposites and ComputeCompositesFast. The sums are multi-scalar multiplications, since the
+// weights and the elements are public, so that the cost of a batch of n evaluations stays well below n proofs.
+func (s *Suite) composites(k *ecc.Scalar, b *ecc.Element, c, d []*ecc.Element) (m, z *ecc.Element) {
+	seedH := s.group.HashFunc().New()
+	_, _ = seedH.Write(lengthPrefixed(b.Encode(), s.dst("Seed-")))
+	seed := seedH.Sum(nil)
+
+	weights := make([]*ecc.Scalar, len(c))
+
+	for i := range c {
+		transcript := lengthPrefixed(seed)
+		transcript = binary.BigEndian.AppendUint16(transcript, uint16(i))
+		transcript = append(transcript, lengthPrefixed(c[i].Encode(), d[i].Encode())...)
+		transcript = append(transcript, labelComposite...)
+
+		weights[i] = s.hashToScalar(transcript, "HashToScalar-")
+	}
+
+	// The elements were checked, and the lengths match, so the multi-scalar multiplications can't fail.
+	m, _ = s.group.MultiScalarMult(weights, c)
+
+	if k != nil {
+		return m, m.Copy().Multiply(k)
+	}
+
+	z, _ = s.group.MultiScalarMult(weights, d)
+
+	return m, z
+}
+
+func (s *Suite) challenge(b, m, z, t2, t3 *ecc.Element) *ecc.Scalar {
+	transcript := lengthPrefixed(b.Encode(), m.Encode(), z.Encode(), t2.Encode(), t3.Encode())
+	return s.hashToScalar(append(transcript, labelChallenge...), "HashToScalar-")
+}
+
+// generateProof returns the proof that k is the discrete logarithm of B = k * A and of all D_i = k * C_i, with the
+// random scalar r, or a fresh one if nil.
+func (s *Suite) generateProof(k *ecc.Scalar, b *ecc.Element, c, d []*ecc.Element, r *ecc.Scalar) *Proof {
+	if r == nil {
+		r = s.group.NewScalar().Random()
+	}
+
+	m, z := s.composites(k, b, c, d)
+	t2 := s.group.Base().Multiply(r)
+	t3 := m.Copy().Multiply(r)
+
+	ch := s.challenge(b, m, z, t2, t3)
+
+	return &Proof{C: ch, S: r.Copy().Subtract(ch.Copy().Multiply(k))}
+}
+
+// verifyProof returns whether the proof shows that B, and the D_i, have the same discrete logarithm to the base
+// element and the C_i.
+func (s *Suite) verifyProof(b *ecc.Element, c, d []*ecc.Element, proof *Proof) bool {
+	if proof == nil || proof.C == nil || proof.S == nil ||
+		proof.C.Group() != s.group || proof.S.Group() != s.group ||
+		len(c) != len(d) || s.checkElements(c) != nil || s.checkElements(d) != nil {
+		return false
+	}
+
+	m, z := s.composites(nil, b, c, d)
+	t2 := s.group.Base().Multiply(proof.S).Add(b.Copy().Multiply(proof.C))
+	t3 := m.Copy().Multiply(proof.S).Add(z.Copy().Multiply(proof.C))
+
+	return s.challenge(b, m, z, t2, t3).Equal(proof.C)
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package ot implements the 1-out-of-2 oblivious transfer of Chou and Orlandi ("The Simplest Protocol for Oblivious
+// Transfer"), for all groups: the sender gets two keys, and the receiver gets the one of its choice, while the sender
+// doesn't learn the choice, and the receiver learns nothing about the other key. The sender then encrypts each of
+// its messages with a key. As recommended by Hauck and Loss, the keys are derived from both parties' messages.
+//
+// It is a building block for base OTs of OT extension, and is secure against passive adversaries and malicious
+// receivers.
+package ot
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	dstApp     = "ecc-ot"
+	dstVersion = 1
+
+	// edwards25519Cofactor clears the small order component of Edwards25519 points.
+	edwards25519Cofactor = 8
+)
+
+var (
+	errInvalidChoice  = errors.New("invalid choice bit")
+	errInvalidMessage = errors.New("invalid message")
+)
+
+// Sender holds the sender's secret. A Sender can run the transfer with several receiver messages, e.g. for the base
+// OTs of OT extension, each yielding independent keys.
+type Sender struct {
+	secret  *ecc.Scalar
+	message []byte
+}
+
+// NewSender returns a new Sender over the group, and its message A = a * G to send to the receiver.
+func NewSender(g ecc.Group) (*Sender, []byte) {
+	a := g.NewScalar().Random()
+	message := g.Base().Multiply(a).Encode()
+
+	return &Sender{secret: a, message: message}, append([]byte(nil), message...)
+}
+
+// Keys returns the two keys of the transfer with the receiver's message B, i.e. k0 = H(A, B, a * B) and
+// k1 = H(A, B, a * (B - A)).
+func (s *Sender) Keys(receiverMessage []byte) (k0, k1 []byte, err error) {
+	g := s.secret.Group()
+
+	b, err := decode(g, receiverMessage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// a * (B - A) is computed as a * B - a^2 * G, as A is the sender's own element.
+	ab := b.Copy().Multiply(s.secret)
+	minusA2 := g.NewScalar().Subtract(s.secret.Copy().Multiply(s.secret))
+	aba := g.Base().Multiply(minusA2).Add(ab)
+
+	if aba.IsIdentity() {
+		return nil, nil, fmt.Errorf("ot: %w", errInvalidMessage)
+	}
+
+	return kdf(g, s.message, receiverMessage, ab), kdf(g, s.message, receiverMessage, aba), nil
+}
+
+// Receive returns the receiver's message B for the sender's message A, i.e. B = b * G for choice 0, and
+// B = A + b * G for choice 1, and the key of the choice.
+func Receive(g ecc.Group, choice uint8, senderMessage []byte) (message, key []byte, err error) {
+	if choice > 1 {
+		return nil, nil, fmt.Errorf("ot: %w", errInvalidChoice)
+	}
+
+	a, err := decode(g, senderMessage)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The choice is applied with a scalar multiplication rather than a branch.
+	b := g.NewScalar().Random()
+	c := g.NewScalar().SetUInt64(uint64(choice))
+	message = a.Copy().Multiply(c).Add(g.Base().Multiply(b)).Encode()
+
+	return message, kdf(g, senderMessage, message, a.Multiply(b)), nil
+}
+
+// decode returns the decoded element, and an error if it is the identity or of small order.
+func decode(g ecc.Group, message []byte) (*ecc.Element, error) {
+	e := g.NewElement()
+	if err := e.Decode(message); err != nil {
+		return nil, fmt.Errorf("ot: %w", errInvalidMessage)
+	}
+
+	if e.IsIdentity() {
+		return nil, fmt.Errorf("ot: %w", internal.ErrIdentity)
+	}
+
+	if g == ecc.Edwards25519Sha512 && e.Copy().Multiply(g.NewScalar().SetUInt64(edwards25519Cofactor)).IsIdentity() {
+		return nil, fmt.Errorf("ot: %w", internal.ErrIdentity)
+	}
+
+	return e, nil
+}
+
+// kdf returns H(dst || len(A) || A || len(B) || B || P), with the group's hash function.
+func kdf(g ecc.Group, senderMessage, receiverMessage []byte, point *ecc.Element) []byte {
+	h := g.HashFunc().New()
+	_, _ = h.Write(g.DST(dstApp, dstVersion))
+	_, _ = h.Write(binary.BigEndian.AppendUint16(nil, uint16(len(senderMessage))))
+	_, _ = h.Write(senderMessage)
+	_, _ = h.Write(binary.BigEndian.AppendUint16(nil, uint16(len(receiverMessage))))
+	_, _ = h.Write(receiverMessage)
+	_, _ = h.Write(point.Encode())
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"encoding/hex"
+	"slices"
+)
+
+const (
+	// fieldOrder25519 is 2^255 - 19 in big-endian.
+	fieldOrder25519   = "7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed"
+	fieldOrderSecp256 = "fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f"
+)
+
+// Cofactor returns the cofactor of the group's curve, i.e. the number of its points divided by the order of the
+// group. It is 8 for Edwards25519, whose decoding accepts points outside of the prime-order subgroup, and 1 for the
+// others, as Ristretto255 is a prime-order group built from Curve25519.
+func (g Group) Cofactor() uint64 {
+	_ = g.get()
+
+	if g == Edwards25519Sha512 {
+		return 8
+	}
+
+	return 1
+}
+
+// FieldOrder returns the order of the base field of the group's curve, with the length and in the byte order of the
+// scalar encodings, i.e. in little-endian for Ristretto255 and Edwards25519, and in big-endian for the other groups.
+func (g Group) FieldOrder() []byte {
+	_ = g.get()
+
+	switch {
+	case g.littleEndian():
+		p, _ := hex.DecodeString(fieldOrder25519)
+		slices.Reverse(p)
+
+		return p
+	case g == Secp256k1Sha256:
+		p, _ := hex.DecodeString(fieldOrderSecp256)
+		return p
+	default:
+		return g.EllipticCurve().Params().P.FillBytes(make([]byte, g.ScalarLength()))
+	}
+}
+
+// littleEndian returns whether the group encodes its scalars in little-endian, as Edwards25519 and Ristretto255 do.
+func (g Group) littleEndian() bool {
+	return g == Edwards25519Sha512 || g == Ristretto255Sha512
+}
+
+// SecurityLevel returns the target security level of the group in bits, i.e. the parameter k of its RFC 9380
+// hash-to-curve suite: 128 for Ristretto255, P-256, Edwards25519, and secp256k1, 192 for P-384, and 256 for P-521.
+func (g Group) SecurityLevel() int {
+	_ = g.get()
+
+	switch g {
+	case P384Sha384:
+		return 192
+	case P521Sha512:
+		return 256
+	default:
+		return 128
+	}
+}
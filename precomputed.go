@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	precomputedWindow     = 4
+	precomputedTableSize  = 1 << precomputedWindow
+	precomputedWindowMask = precomputedTableSize - 1
+)
+
+// Precomputed holds the multiples of an element for all the windows of a scalar, so that its multiplications only
+// need one addition per 4 bits of the scalar, and no doublings. Use it for elements multiplied many times, e.g. a
+// long-term public key in a server, as it takes a few multiplications to build and 16 elements per byte of scalar.
+// It is safe for concurrent use.
+type Precomputed struct {
+	group Group
+
+	// table[i][d] is d * 16^i * P, and table[i][0] is the identity.
+	table [][precomputedTableSize]internal.Element
+}
+
+// NewPrecomputed returns the precomputed multiples of the element, which it doesn't reference.
+func (e *Element) NewPrecomputed() *Precomputed {
+	g := e.Group()
+	p := &Precomputed{
+		group: g,
+		table: make([][precomputedTableSize]internal.Element, 2*g.ScalarLength()),
+	}
+
+	base := e.Element.Copy()
+
+	for i := range p.table {
+		p.table[i][0] = base.Copy().Identity()
+		p.table[i][1] = base.Copy()
+
+		for d := 2; d < precomputedTableSize; d++ {
+			p.table[i][d] = p.table[i][d-1].Copy().Add(base)
+		}
+
+		// 16^(i + 1) * P
+		base.Add(p.table[i][precomputedTableSize-1])
+	}
+
+	return p
+}
+
+// Group returns the group of the precomputed element.
+func (p *Precomputed) Group() Group {
+	return p.group
+}
+
+// Multiply returns a new element set to scalar * P, for the precomputed element P, and the identity if the scalar is
+// nil. It performs the same additions for all scalars, but the table entries it reads depend on the scalar. It panics
+// if the scalar is of another group.
+func (p *Precomputed) Multiply(scalar *Scalar) *Element {
+	r := p.group.NewElement()
+	if scalar == nil {
+		return r
+	}
+
+	if scalar.Group() != p.group {
+		panic(internal.ErrCastScalar)
+	}
+
+	enc := scalar.bigEndian()
+
+	for i := range p.table {
+		d := (enc[len(enc)-1-i/2] >> (precomputedWindow * (i % 2))) & precomputedWindowMask
+		r.Element.Add(p.table[i][d])
+	}
+
+	return r
+}
@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package privacypass
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/oprf"
+)
+
+// maxBatchSize is the maximum number of elements of batched messages, whose lists have a 2-byte length prefix.
+const maxBatchSize = 0xffff / 49
+
+// TokenRequest is the client's request of a single token.
+type TokenRequest struct {
+	BlindedElement      *ecc.Element
+	TokenType           uint16
+	TruncatedTokenKeyID byte
+}
+
+// Marshal returns the serialization token_type || truncated_token_key_id || blinded_msg of the request.
+func (r *TokenRequest) Marshal() []byte {
+	out := binary.BigEndian.AppendUint16(nil, r.TokenType)
+	out = append(out, r.TruncatedTokenKeyID)
+
+	return append(out, r.BlindedElement.Encode()...)
+}
+
+// UnmarshalTokenRequest returns the token request deserialized from its encoding.
+func UnmarshalTokenRequest(data []byte) (*TokenRequest, error) {
+	if len(data) != 3+group.ElementLength() {
+		return nil, fmt.Errorf("privacypass: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	e, err := decodeElements(data[3:], 1)
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	return &TokenRequest{
+		TokenType:           binary.BigEndian.Uint16(data),
+		TruncatedTokenKeyID: data[2],
+		BlindedElement:      e[0],
+	}, nil
+}
+
+// TokenResponse is the issuer's response to a token request.
+type TokenResponse struct {
+	EvaluatedElement *ecc.Element
+	Proof            *oprf.Proof
+}
+
+// Marshal returns the serialization evaluate_msg || evaluate_proof of the response.
+func (r *TokenResponse) Marshal() []byte {
+	return append(r.EvaluatedElement.Encode(), r.Proof.Encode()...)
+}
+
+// UnmarshalTokenResponse returns the token response deserialized from its encoding.
+func UnmarshalTokenResponse(data []byte) (*TokenResponse, error) {
+	el := group.ElementLength()
+	if len(data) != el+2*group.ScalarLength() {
+		return nil, fmt.Errorf("privacypass: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	e, err := decodeElements(data[:el], 1)
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	proof, err := newSuite().DecodeProof(data[el:])
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	return &TokenResponse{EvaluatedElement: e[0], Proof: proof}, nil
+}
+
+// BatchedTokenRequest is the client's request of several tokens at once.
+type BatchedTokenRequest struct {
+	BlindedElements     []*ecc.Element
+	TokenType           uint16
+	TruncatedTokenKeyID byte
+}
+
+func encodeElements(out []byte, elements []*ecc.Element) []byte {
+	out = binary.BigEndian.AppendUint16(out, uint16(len(elements)*group.ElementLength()))
+	for _, e := range elements {
+		out = append(out, e.Encode()...)
+	}
+
+	return out
+}
+
+func decodeElements(data []byte, count int) ([]*ecc.Element, error) {
+	el := group.ElementLength()
+	elements := make([]*ecc.Element, count)
+
+	for i := range elements {
+		elements[i] = group.NewElement()
+		if err := elements[i].Decode(data[i*el : (i+1)*el]); err != nil {
+			return nil, err
+		}
+	}
+
+	return elements, nil
+}
+
+// decodeList returns the elements of the 2-byte length-prefixed list, and the remaining data.
+func decodeList(data []byte) ([]*ecc.Element, []byte, error) {
+	if len(data) < 2 {
+		return nil, nil, internal.ErrDecodingInvalidLength
+	}
+
+	l := int(binary.BigEndian.Uint16(data))
+	data = data[2:]
+
+	if l == 0 || l%group.ElementLength() != 0 || len(data) < l {
+		return nil, nil, internal.ErrDecodingInvalidLength
+	}
+
+	elements, err := decodeElements(data[:l], l/group.ElementLength())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return elements, data[l:], nil
+}
+
+// Marshal returns the serialization token_type || truncated_token_key_id || blinded_elements<2> of the request.
+func (r *BatchedTokenRequest) Marshal() []byte {
+	out := binary.BigEndian.AppendUint16(nil, r.TokenType)
+	out = append(out, r.TruncatedTokenKeyID)
+
+	return encodeElements(out, r.BlindedElements)
+}
+
+// UnmarshalBatchedTokenRequest returns the batched token request deserialized from its encoding.
+func UnmarshalBatchedTokenRequest(data []byte) (*BatchedTokenRequest, error) {
+	if len(data) < 3 {
+		return nil, fmt.Errorf("privacypass: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	elements, rest, err := decodeList(data[3:])
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("privacypass: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	return &BatchedTokenRequest{
+		TokenType:           binary.BigEndian.Uint16(data),
+		TruncatedTokenKeyID: data[2],
+		BlindedElements:     elements,
+	}, nil
+}
+
+// BatchedTokenResponse is the issuer's response to a batched token request, with a single proof for all tokens.
+type BatchedTokenResponse struct {
+	EvaluatedElements []*ecc.Element
+	Proof             *oprf.Proof
+}
+
+// Marshal returns the serialization evaluated_elements<2> || evaluate_proof of the response.
+func (r *BatchedTokenResponse) Marshal() []byte {
+	return append(encodeElements(nil, r.EvaluatedElements), r.Proof.Encode()...)
+}
+
+// UnmarshalBatchedTokenResponse returns the batched token response deserialized from its encoding.
+func UnmarshalBatchedTokenResponse(data []byte) (*BatchedTokenResponse, error) {
+	elements, rest, err := decodeList(data)
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	proof, err := newSuite().DecodeProof(rest)
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	return &BatchedTokenResponse{EvaluatedElements: elements, Proof: proof}, nil
+}
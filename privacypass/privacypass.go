@@ -0,0 +1,342 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package privacypass implements the issuance and redemption of the privately verifiable Privacy Pass tokens of RFC
+// 9578 section 5, token type 0x0001, built on the VOPRF(P-384, SHA-384) of the oprf package, and their batched
+// issuance, token type 0xF91A, in which a single request and proof cover several tokens.
+package privacypass
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/oprf"
+)
+
+const (
+	// TokenType is the type of the privately verifiable tokens of RFC 9578.
+	TokenType uint16 = 0x0001
+
+	// BatchedTokenType is the type of the tokens of batched issuance.
+	BatchedTokenType uint16 = 0xF91A
+
+	// NonceSize is the size of the token nonces, in bytes.
+	NonceSize = 32
+
+	keyIDSize         = sha256.Size
+	authenticatorSize = 48
+	deriveKeyInfo     = "PrivacyPass"
+	group             = ecc.P384Sha384
+)
+
+var (
+	errInvalidTokenType = errors.New("invalid token type")
+	errInvalidKeyID     = errors.New("token key ID mismatch")
+	errInvalidToken     = errors.New("invalid token")
+	errBatchLength      = errors.New("mismatching batch lengths")
+)
+
+func newSuite() *oprf.Suite {
+	s, err := oprf.New(group, oprf.ModeVOPRF)
+	if err != nil {
+		// P-384 is a valid VOPRF group.
+		panic(err)
+	}
+
+	return s
+}
+
+// tokenKeyID returns the SHA-256 hash of the issuer's serialized public key.
+func tokenKeyID(public *ecc.Element) []byte {
+	id := sha256.Sum256(public.Encode())
+	return id[:]
+}
+
+// Token is a Privacy Pass token, i.e. the token input and its authenticator.
+type Token struct {
+	Nonce           []byte
+	ChallengeDigest []byte
+	TokenKeyID      []byte
+	Authenticator   []byte
+	TokenType       uint16
+}
+
+// input returns the token input, the serialized token without its authenticator.
+func (t *Token) input() []byte {
+	in := binary.BigEndian.AppendUint16(nil, t.TokenType)
+	in = append(in, t.Nonce...)
+	in = append(in, t.ChallengeDigest...)
+
+	return append(in, t.TokenKeyID...)
+}
+
+// Marshal returns the serialization of the token.
+func (t *Token) Marshal() []byte {
+	return append(t.input(), t.Authenticator...)
+}
+
+// UnmarshalToken returns the token deserialized from its encoding.
+func UnmarshalToken(data []byte) (*Token, error) {
+	if len(data) != 2+NonceSize+sha256.Size+keyIDSize+authenticatorSize {
+		return nil, fmt.Errorf("privacypass: %w", internal.ErrDecodingInvalidLength)
+	}
+
+	t := &Token{TokenType: binary.BigEndian.Uint16(data)}
+	data = data[2:]
+	t.Nonce, data = data[:NonceSize:NonceSize], data[NonceSize:]
+	t.ChallengeDigest, data = data[:sha256.Size:sha256.Size], data[sha256.Size:]
+	t.TokenKeyID, t.Authenticator = data[:keyIDSize:keyIDSize], data[keyIDSize:]
+
+	if t.TokenType != TokenType && t.TokenType != BatchedTokenType {
+		return nil, fmt.Errorf("privacypass: %w", errInvalidTokenType)
+	}
+
+	return t, nil
+}
+
+// Issuer holds the issuer's private key, with which it issues and redeems tokens.
+type Issuer struct {
+	suite  *oprf.Suite
+	secret *ecc.Scalar
+	public *ecc.Element
+	keyID  []byte
+}
+
+// NewIssuer returns the issuer of the P-384 private key.
+func NewIssuer(secret *ecc.Scalar) (*Issuer, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("privacypass: %w", internal.ErrParamNilScalar)
+	}
+
+	if secret.Group() != group {
+		return nil, fmt.Errorf("privacypass: %w", internal.ErrInvalidGroup)
+	}
+
+	public := group.Base().Multiply(secret)
+
+	return &Issuer{suite: newSuite(), secret: secret.Copy(), public: public, keyID: tokenKeyID(public)}, nil
+}
+
+// DeriveIssuer returns the issuer of the private key deterministically derived from the seed.
+func DeriveIssuer(seed []byte) (*Issuer, error) {
+	secret, _, err := newSuite().DeriveKeyPair(seed, []byte(deriveKeyInfo))
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	return NewIssuer(secret)
+}
+
+// PublicKey returns the issuer's public key, to be published to clients.
+func (i *Issuer) PublicKey() *ecc.Element {
+	return i.public.Copy()
+}
+
+// evaluate returns the evaluated elements of the blinded elements, with the proof covering all of them.
+func (i *Issuer) evaluate(truncatedKeyID byte, blinded []*ecc.Element) ([]*ecc.Element, *oprf.Proof, error) {
+	if truncatedKeyID != i.keyID[keyIDSize-1] {
+		return nil, nil, errInvalidKeyID
+	}
+
+	return i.suite.BlindEvaluate(i.secret, blinded, nil, nil)
+}
+
+// Issue returns the token response to the token request.
+func (i *Issuer) Issue(request *TokenRequest) (*TokenResponse, error) {
+	if request == nil || request.TokenType != TokenType {
+		return nil, fmt.Errorf("privacypass: %w", errInvalidTokenType)
+	}
+
+	evaluated, proof, err := i.evaluate(request.TruncatedTokenKeyID, []*ecc.Element{request.BlindedElement})
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	return &TokenResponse{EvaluatedElement: evaluated[0], Proof: proof}, nil
+}
+
+// IssueBatch returns the batched token response to the batched token request.
+func (i *Issuer) IssueBatch(request *BatchedTokenRequest) (*BatchedTokenResponse, error) {
+	if request == nil || request.TokenType != BatchedTokenType {
+		return nil, fmt.Errorf("privacypass: %w", errInvalidTokenType)
+	}
+
+	evaluated, proof, err := i.evaluate(request.TruncatedTokenKeyID, request.BlindedElements)
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	return &BatchedTokenResponse{EvaluatedElements: evaluated, Proof: proof}, nil
+}
+
+// Redeem returns nil if the token was issued with the issuer's key. Preventing double spending, e.g. by recording
+// the nonces of redeemed tokens, and checking the challenge digest are left to the caller.
+func (i *Issuer) Redeem(token *Token) error {
+	if token == nil || (token.TokenType != TokenType && token.TokenType != BatchedTokenType) {
+		return fmt.Errorf("privacypass: %w", errInvalidTokenType)
+	}
+
+	if !hmac.Equal(token.TokenKeyID, i.keyID) {
+		return fmt.Errorf("privacypass: %w", errInvalidKeyID)
+	}
+
+	expected, err := i.suite.Evaluate(i.secret, token.input(), nil)
+	if err != nil {
+		return fmt.Errorf("privacypass: %w", err)
+	}
+
+	if !hmac.Equal(expected, token.Authenticator) {
+		return fmt.Errorf("privacypass: %w", errInvalidToken)
+	}
+
+	return nil
+}
+
+// Client requests tokens from an issuer, and finalizes them from the issuer's responses.
+type Client struct {
+	suite  *oprf.Suite
+	public *ecc.Element
+	keyID  []byte
+}
+
+// NewClient returns the client of the issuer's public key.
+func NewClient(issuerPublic *ecc.Element) (*Client, error) {
+	if issuerPublic == nil || issuerPublic.IsIdentity() {
+		return nil, fmt.Errorf("privacypass: %w", internal.ErrParamNilPoint)
+	}
+
+	if issuerPublic.Group() != group {
+		return nil, fmt.Errorf("privacypass: %w", internal.ErrInvalidGroup)
+	}
+
+	return &Client{suite: newSuite(), public: issuerPublic.Copy(), keyID: tokenKeyID(issuerPublic)}, nil
+}
+
+// State is the client's state between a token request and the issuer's response.
+type State struct {
+	tokens  []*Token
+	blinds  []*ecc.Scalar
+	blinded []*ecc.Element
+}
+
+// request returns the state of count tokens of the type for the challenge, with fresh nonces.
+func (c *Client) request(tokenType uint16, challenge []byte, count int) (*State, error) {
+	digest := sha256.Sum256(challenge)
+	st := &State{tokens: make([]*Token, count)}
+	inputs := make([][]byte, count)
+
+	for i := range count {
+		st.tokens[i] = &Token{
+			TokenType:       tokenType,
+			Nonce:           internal.RandomBytes(NonceSize),
+			ChallengeDigest: digest[:],
+			TokenKeyID:      c.keyID,
+		}
+		inputs[i] = st.tokens[i].input()
+	}
+
+	blinds, blinded, err := c.suite.Blind(inputs, nil)
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	st.blinds, st.blinded = blinds, blinded
+
+	return st, nil
+}
+
+// CreateTokenRequest returns the client's state and the token request of a token for the serialized challenge.
+func (c *Client) CreateTokenRequest(challenge []byte) (*State, *TokenRequest, error) {
+	st, err := c.request(TokenType, challenge, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return st, &TokenRequest{
+		TokenType:           TokenType,
+		TruncatedTokenKeyID: c.keyID[keyIDSize-1],
+		BlindedElement:      st.blinded[0],
+	}, nil
+}
+
+// CreateBatchedTokenRequest returns the client's state and the batched token request of count tokens for the
+// serialized challenge.
+func (c *Client) CreateBatchedTokenRequest(challenge []byte, count int) (*State, *BatchedTokenRequest, error) {
+	if count <= 0 || count > maxBatchSize {
+		return nil, nil, fmt.Errorf("privacypass: %w", errBatchLength)
+	}
+
+	st, err := c.request(BatchedTokenType, challenge, count)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return st, &BatchedTokenRequest{
+		TokenType:           BatchedTokenType,
+		TruncatedTokenKeyID: c.keyID[keyIDSize-1],
+		BlindedElements:     st.blinded,
+	}, nil
+}
+
+// finalize verifies the proof of the evaluations, and returns the tokens with their authenticators.
+func (c *Client) finalize(st *State, evaluated []*ecc.Element, proof *oprf.Proof) ([]*Token, error) {
+	if len(evaluated) != len(st.tokens) {
+		return nil, fmt.Errorf("privacypass: %w", errBatchLength)
+	}
+
+	inputs := make([][]byte, len(st.tokens))
+	for i, t := range st.tokens {
+		inputs[i] = t.input()
+	}
+
+	outputs, err := c.suite.Finalize(inputs, st.blinds, st.blinded, evaluated, c.public, proof, nil)
+	if err != nil {
+		return nil, fmt.Errorf("privacypass: %w", err)
+	}
+
+	tokens := make([]*Token, len(st.tokens))
+	for i, t := range st.tokens {
+		tokens[i] = &Token{
+			TokenType:       t.TokenType,
+			Nonce:           t.Nonce,
+			ChallengeDigest: t.ChallengeDigest,
+			TokenKeyID:      t.TokenKeyID,
+			Authenticator:   outputs[i],
+		}
+	}
+
+	return tokens, nil
+}
+
+// FinalizeToken returns the token of the issuer's response, after verifying its proof.
+func (c *Client) FinalizeToken(st *State, response *TokenResponse) (*Token, error) {
+	if response == nil {
+		return nil, fmt.Errorf("privacypass: %w", errInvalidToken)
+	}
+
+	tokens, err := c.finalize(st, []*ecc.Element{response.EvaluatedElement}, response.Proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokens[0], nil
+}
+
+// FinalizeBatchedTokens returns the tokens of the issuer's batched response, after verifying its proof.
+func (c *Client) FinalizeBatchedTokens(st *State, response *BatchedTokenResponse) ([]*Token, error) {
+	if response == nil {
+		return nil, fmt.Errorf("privacypass: %w", errInvalidToken)
+	}
+
+	return c.finalize(st, response.EvaluatedElements, response.Proof)
+}
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"crypto"
+	"io"
+)
+
+// RemoteScalar is an opaque private scalar, which may be held outside the process, e.g. in a PKCS#11 device or a
+// cloud KMS, and only exposes the operations that use it, so that such keys can be used without their bytes ever
+// being loaded in memory. It is accepted in place of a raw Scalar by ecdh.DHRemote and ecdh.DeriveKeyRemote,
+// ecdsa.SignRemote, eddsa.SignRemote, schnorr.SignRemote for Ristretto255, (*kem.DHKEM).NewRemotePrivateKey, and
+// signer.NewRemote. The other packages require a Scalar. signer.Signer implements it for in-memory scalars.
+type RemoteScalar interface {
+	// Group returns the group of the scalar.
+	Group() Group
+
+	// PublicElement returns the product of the group's base element by the scalar.
+	PublicElement() *Element
+
+	// MultiplyElement returns the product of the element by the scalar, e.g. for Diffie-Hellman. It must return an
+	// error if the element is nil or of another group.
+	MultiplyElement(element *Element) (*Element, error)
+
+	// Sign signs the digest with the scalar, with the signature scheme and semantics of signer.Signer.
+	Sign(random io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error)
+}
@@ -9,9 +9,12 @@
 package ecc
 
 import (
+	"encoding/base64"
 	"fmt"
 	"strings"
 
+	"filippo.io/edwards25519"
+
 	"github.com/bytemare/ecc/internal"
 )
 
@@ -49,9 +52,22 @@ func (s *Scalar) MinusOne() *Scalar {
 }
 
 // Random sets the current scalar to a new random scalar and returns it.
-// The random source is crypto/rand, and this functions is guaranteed to return a non-zero scalar.
+// The random source is crypto/rand, unless set otherwise with SetRandomSource, and this functions is guaranteed to
+// return a non-zero scalar. It panics if the source set with SetRandomSource fails.
 func (s *Scalar) Random() *Scalar {
+	if random := internal.CustomRandomSource(); random != nil {
+		r, err := s.Group().randomScalar(random)
+		if err != nil {
+			panic(fmt.Errorf("scalar Random: %w", err))
+		}
+
+		s.Scalar.Set(r.Scalar)
+
+		return s
+	}
+
 	s.Scalar.Random()
+
 	return s
 }
 
@@ -105,7 +121,7 @@ func (s *Scalar) Invert() *Scalar {
 	return s
 }
 
-// Equal returns true if the elements are equivalent, and false otherwise.
+// Equal returns true if the scalars are equal, and false otherwise. It runs in constant time in all backends.
 func (s *Scalar) Equal(scalar *Scalar) bool {
 	if scalar == nil {
 		return false
@@ -166,10 +182,39 @@ func (s *Scalar) Encode() []byte {
 	return s.Scalar.Encode()
 }
 
+type edwards25519Scalar interface {
+	EdwardsScalar() *edwards25519.Scalar
+	SetEdwardsScalar(scalar *edwards25519.Scalar) error
+}
+
+// Edwards25519Scalar returns a copy of the scalar as a filippo.io/edwards25519 scalar, for the Edwards25519 and
+// Ristretto255 groups, which share the same scalar field. It returns nil for the other groups.
+func (s *Scalar) Edwards25519Scalar() *edwards25519.Scalar {
+	if es, ok := s.Scalar.(edwards25519Scalar); ok {
+		return es.EdwardsScalar()
+	}
+
+	return nil
+}
+
+// SetEdwards25519Scalar sets s to the filippo.io/edwards25519 scalar, for the Edwards25519 and Ristretto255 groups.
+func (s *Scalar) SetEdwards25519Scalar(scalar *edwards25519.Scalar) error {
+	es, ok := s.Scalar.(edwards25519Scalar)
+	if !ok {
+		return fmt.Errorf("scalar SetEdwards25519Scalar: %w", internal.ErrInvalidGroup)
+	}
+
+	if err := es.SetEdwardsScalar(scalar); err != nil {
+		return fmt.Errorf("scalar SetEdwards25519Scalar: %w", err)
+	}
+
+	return nil
+}
+
 // Decode sets the receiver to a decoding of the input data, and returns an error on failure.
 func (s *Scalar) Decode(data []byte) error {
 	if err := s.Scalar.Decode(data); err != nil {
-		return fmt.Errorf("scalar Decode: %w", err)
+		return scalarDecodingError("Decode", err)
 	}
 
 	return nil
@@ -183,7 +228,26 @@ func (s *Scalar) Hex() string {
 // DecodeHex sets s to the decoding of the hex encoded scalar.
 func (s *Scalar) DecodeHex(h string) error {
 	if err := s.Scalar.DecodeHex(h); err != nil {
-		return fmt.Errorf("scalar DecodeHex: %w", err)
+		return scalarDecodingError("DecodeHex", err)
+	}
+
+	return nil
+}
+
+// EncodeBase64 returns the unpadded base64url encoding (RFC 4648 section 5) of s.
+func (s *Scalar) EncodeBase64() string {
+	return base64.RawURLEncoding.EncodeToString(s.Scalar.Encode())
+}
+
+// DecodeBase64 sets s to the decoding of the unpadded base64url encoded scalar.
+func (s *Scalar) DecodeBase64(b64 string) error {
+	b, err := base64.RawURLEncoding.DecodeString(b64)
+	if err != nil {
+		return scalarDecodingError("DecodeBase64", err)
+	}
+
+	if err = s.Scalar.Decode(b); err != nil {
+		return scalarDecodingError("DecodeBase64", err)
 	}
 
 	return nil
@@ -200,6 +264,21 @@ func (s *Scalar) UnmarshalJSON(data []byte) error {
 	return s.DecodeHex(j)
 }
 
+// MarshalText implements the encoding.TextMarshaler interface, returning the hexadecimal encoding of the scalar. This
+// is what makes the scalar usable as a plain string in YAML, TOML, or XML documents, and as a JSON map key.
+func (s *Scalar) MarshalText() ([]byte, error) {
+	return []byte(s.Hex()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface, decoding the hexadecimal encoding of the scalar.
+func (s *Scalar) UnmarshalText(text []byte) error {
+	if err := s.Scalar.DecodeHex(string(text)); err != nil {
+		return scalarDecodingError("UnmarshalText", err)
+	}
+
+	return nil
+}
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (s *Scalar) MarshalBinary() ([]byte, error) {
 	return s.Scalar.Encode(), nil
@@ -208,7 +287,54 @@ func (s *Scalar) MarshalBinary() ([]byte, error) {
 // UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
 func (s *Scalar) UnmarshalBinary(data []byte) error {
 	if err := s.Scalar.Decode(data); err != nil {
-		return fmt.Errorf("scalar UnmarshalBinary: %w", err)
+		return scalarDecodingError("UnmarshalBinary", err)
+	}
+
+	return nil
+}
+
+// EncodeTagged returns the self-describing encoding of the scalar, i.e. the group identifier followed by the scalar's
+// binary encoding.
+func (s *Scalar) EncodeTagged() []byte {
+	return append([]byte{byte(s.Group())}, s.Scalar.Encode()...)
+}
+
+func (s *Scalar) decodeTagged(data []byte) error {
+	if len(data) == 0 {
+		return internal.ErrParamNilScalar
+	}
+
+	g := Group(data[0])
+	if !g.Available() {
+		return internal.ErrInvalidGroup
+	}
+
+	if s.Scalar == nil || s.Group() != g {
+		s.Scalar = g.get().NewScalar()
+	}
+
+	return s.Scalar.Decode(data[1:])
+}
+
+// DecodeTaggedScalar returns the scalar decoded from its self-describing encoding produced by EncodeTagged.
+func DecodeTaggedScalar(data []byte) (*Scalar, error) {
+	s := new(Scalar)
+	if err := s.decodeTagged(data); err != nil {
+		return nil, scalarDecodingError("DecodeTagged", err)
+	}
+
+	return s, nil
+}
+
+// GobEncode implements the gob.GobEncoder interface, using the self-describing encoding of EncodeTagged.
+func (s *Scalar) GobEncode() ([]byte, error) {
+	return s.EncodeTagged(), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface, and sets s to the decoding of the self-describing encoding.
+func (s *Scalar) GobDecode(data []byte) error {
+	if err := s.decodeTagged(data); err != nil {
+		return scalarDecodingError("GobDecode", err)
 	}
 
 	return nil
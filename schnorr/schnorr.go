@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package schnorr implements Schnorr signatures over any ecc group. A signature is the encoding of the commitment
+// R = k * G followed by that of the scalar z = k + c * secret, where the challenge c is the group's HashToScalar of R,
+// the public key, and the message, with a domain separation tag built as in RFC 9380. Nonces are either derived
+// deterministically from the private key and the message, or hedged with fresh randomness.
+package schnorr
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	challengeDSTApp  = "ecc-schnorr"
+	nonceDSTApp      = "ecc-schnorr-nonce"
+	dstVersion       = 1
+	nonceEntropySize = 32
+)
+
+var errInvalidSignature = errors.New("invalid signature")
+
+// KeyGen returns a new random private scalar and its public key.
+func KeyGen(g ecc.Group) (*ecc.Scalar, *ecc.Element) {
+	secret := g.NewScalar().Random()
+	return secret, g.Base().Multiply(secret)
+}
+
+// SignatureLength returns the byte length of the signatures over the group.
+func SignatureLength(g ecc.Group) int {
+	return g.ElementLength() + g.ScalarLength()
+}
+
+// Sign returns the signature of the message with the private scalar, with a nonce hedged with randomness read from
+// random, or from the source set with ecc.SetRandomSource if nil, so that neither a weak rand nor a fault on the
+// deterministic part leaks the key.
+func Sign(secret *ecc.Scalar, message []byte, random io.Reader) ([]byte, error) {
+	if random == nil {
+		random = internal.RandomSource()
+	}
+
+	entropy := make([]byte, nonceEntropySize)
+	if _, err := io.ReadFull(random, entropy); err != nil {
+		return nil, fmt.Errorf("schnorr: %w", err)
+	}
+
+	return sign(secret, message, entropy)
+}
+
+// SignDeterministic returns the signature of the message with the private scalar, with a nonce derived from the
+// private scalar and the message only, so that signing the same message twice yields the same signature.
+func SignDeterministic(secret *ecc.Scalar, message []byte) ([]byte, error) {
+	return sign(secret, message, nil)
+}
+
+func sign(secret *ecc.Scalar, message, entropy []byte) ([]byte, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("schnorr: %w", internal.ErrParamNilScalar)
+	}
+
+	g := secret.Group()
+
+	input := make([]byte, 0, len(entropy)+g.ScalarLength()+len(message))
+	input = append(input, entropy...)
+	input = append(input, secret.Encode()...)
+	input = append(input, message...)
+
+	k := g.HashToScalar(input, g.DST(nonceDSTApp, dstVersion))
+	r := g.Base().Multiply(k).Encode()
+	c := Challenge(g, r, g.Base().Multiply(secret).Encode(), message)
+
+	return append(r, k.Add(c.Multiply(secret)).Encode()...), nil
+}
+
+// SignRemote returns the signature of the message with a private scalar held by the remote, e.g. a hardware device or
+// KMS. Only Ristretto255 is supported, as signer.Signer, and remotes with the same semantics, produce the signatures
+// of this package in that group only. The signature is verified against the remote's public key before being
+// returned.
+func SignRemote(remote ecc.RemoteScalar, message []byte, random io.Reader) ([]byte, error) {
+	if remote == nil {
+		return nil, fmt.Errorf("schnorr: %w", internal.ErrParamNilScalar)
+	}
+
+	if remote.Group() != ecc.Ristretto255Sha512 {
+		return nil, fmt.Errorf("schnorr: %w", internal.ErrInvalidGroup)
+	}
+
+	sig, err := remote.Sign(random, message, crypto.Hash(0))
+	if err != nil {
+		return nil, fmt.Errorf("schnorr: %w", err)
+	}
+
+	if err = Verify(remote.PublicElement(), message, sig); err != nil {
+		return nil, err
+	}
+
+	return sig, nil
+}
+
+// Challenge returns the challenge scalar of the encoded commitment, the encoded public key, and the message.
+func Challenge(g ecc.Group, commitment, public, message []byte) *ecc.Scalar {
+	input := make([]byte, 0, len(commitment)+len(public)+len(message))
+	input = append(input, commitment...)
+	input = append(input, public...)
+	input = append(input, message...)
+
+	return g.HashToScalar(input, g.DST(challengeDSTApp, dstVersion))
+}
+
+// Verify returns nil if the signature is valid for the message and the public key. The public key and the commitment
+// must not be the identity.
+func Verify(public *ecc.Element, message, signature []byte) error {
+	if public == nil || public.IsIdentity() {
+		return fmt.Errorf("schnorr: %w", internal.ErrParamNilPoint)
+	}
+
+	g := public.Group()
+	if len(signature) != SignatureLength(g) {
+		return fmt.Errorf("schnorr: %w", errInvalidSignature)
+	}
+
+	r := g.NewElement()
+	if err := r.Decode(signature[:g.ElementLength()]); err != nil || r.IsIdentity() {
+		return fmt.Errorf("schnorr: %w", errInvalidSignature)
+	}
+
+	z := g.NewScalar()
+	if err := z.Decode(signature[g.ElementLength():]); err != nil {
+		return fmt.Errorf("schnorr: %w", errInvalidSignature)
+	}
+
+	c := Challenge(g, signature[:g.ElementLength()], public.Encode(), message)
+
+	// z * G - c * P == R, in variable time, as all the values are public.
+	zg, err := g.VarTime().DoubleMultiply(g.NewScalar().Subtract(c), public, z)
+	if err != nil || !zg.Equal(r) {
+		return fmt.Errorf("schnorr: %w", errInvalidSignature)
+	}
+
+	return nil
+}
@@ -0,0 +1,338 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package signer implements crypto.Signer for private keys held as ecc scalars, so that they can be used with x509,
+// TLS, and any other API accepting a crypto.Signer. The signature scheme depends on the group: ECDSA for the NIST
+// groups and secp256k1, Ed25519 for Edwards25519, and the signatures of the schnorr package for Ristretto255.
+package signer
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	ed "filippo.io/edwards25519"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+const (
+	nonceDSTApp      = "ecc-signer-nonce"
+	dstVersion       = 1
+	nonceEntropySize = 32
+)
+
+var (
+	errInvalidHash      = errors.New("invalid hash function for the signature scheme")
+	errInvalidDigest    = errors.New("digest length does not match the hash function")
+	errInvalidSignature = errors.New("invalid signature")
+)
+
+// Signer is a crypto.Signer over an ecc private scalar, which is either held in memory or a RemoteScalar. It
+// implements ecc.RemoteScalar.
+type Signer struct {
+	public crypto.PublicKey
+	secret *ecc.Scalar
+	remote ecc.RemoteScalar
+	key    *ecc.Element
+	group  ecc.Group
+}
+
+// New returns a Signer for the private scalar, which must not be zero. The scalar is copied.
+func New(secret *ecc.Scalar) (*Signer, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("signer: %w", internal.ErrParamNilScalar)
+	}
+
+	g := secret.Group()
+	key := g.Base().Multiply(secret)
+
+	public, err := publicKey(g, key)
+	if err != nil {
+		return nil, fmt.Errorf("signer: %w", err)
+	}
+
+	return &Signer{
+		public: public,
+		secret: secret.Copy(),
+		key:    key,
+		group:  g,
+	}, nil
+}
+
+// NewRemote returns a Signer delegating all private key operations to the remote scalar, and exposing its public key
+// in the standard library's types.
+func NewRemote(remote ecc.RemoteScalar) (*Signer, error) {
+	if remote == nil {
+		return nil, fmt.Errorf("signer: %w", internal.ErrParamNilScalar)
+	}
+
+	key := remote.PublicElement()
+	if key == nil || key.IsIdentity() || key.Group() != remote.Group() {
+		return nil, fmt.Errorf("signer: %w", internal.ErrParamNilPoint)
+	}
+
+	public, err := publicKey(key.Group(), key)
+	if err != nil {
+		return nil, fmt.Errorf("signer: %w", err)
+	}
+
+	return &Signer{
+		public: public,
+		remote: remote,
+		key:    key.Copy(),
+		group:  key.Group(),
+	}, nil
+}
+
+func publicKey(g ecc.Group, key *ecc.Element) (crypto.PublicKey, error) {
+	switch g {
+	case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512:
+		return encoding.ToECDSAPublicKey(key, nil)
+	case ecc.Secp256k1Sha256:
+		return encoding.ToECDSAPublicKey(key, secp256k1.S256())
+	case ecc.Edwards25519Sha512:
+		return encoding.ToEd25519PublicKey(key)
+	case ecc.Ristretto255Sha512:
+		return key.Copy(), nil
+	default:
+		return nil, internal.ErrInvalidGroup
+	}
+}
+
+// Public returns the public key, as an *ecdsa.PublicKey for the NIST groups and secp256k1, an ed25519.PublicKey for
+// Edwards25519, and an *ecc.Element for Ristretto255.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Group returns the group of the signer.
+func (s *Signer) Group() ecc.Group {
+	return s.group
+}
+
+// PublicElement returns the public key as a group element.
+func (s *Signer) PublicElement() *ecc.Element {
+	return s.key.Copy()
+}
+
+// MultiplyElement returns the product of the element by the private scalar.
+func (s *Signer) MultiplyElement(element *ecc.Element) (*ecc.Element, error) {
+	if s.remote != nil {
+		e, err := s.remote.MultiplyElement(element)
+		if err != nil {
+			return nil, fmt.Errorf("signer: %w", err)
+		}
+
+		return e, nil
+	}
+
+	if element == nil {
+		return nil, fmt.Errorf("signer: %w", internal.ErrParamNilPoint)
+	}
+
+	if element.Group() != s.group {
+		return nil, fmt.Errorf("signer: %w", internal.ErrCastElement)
+	}
+
+	return element.Copy().Multiply(s.secret), nil
+}
+
+// Sign signs the digest with the private key, and implements crypto.Signer. The nonce is derived from the private
+// key, the digest, and randomness read from random, or the source set with ecc.SetRandomSource if nil, so that a weak
+// rand doesn't leak the key.
+//
+// For ECDSA, digest must be the hash of the message with opts.HashFunc(), and the signature is ASN.1 DER encoded as
+// in crypto/ecdsa. For Ed25519 and Schnorr, digest is the message itself, opts.HashFunc() must be zero, and the
+// signature is the concatenation of the encoded commitment and scalar. Ed25519ph and Ed25519ctx are not supported.
+// A Signer returned by NewRemote delegates to the remote scalar.
+func (s *Signer) Sign(random io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if s.remote != nil {
+		sig, err := s.remote.Sign(random, digest, opts)
+		if err != nil {
+			return nil, fmt.Errorf("signer: %w", err)
+		}
+
+		return sig, nil
+	}
+
+	var hash crypto.Hash
+	if opts != nil {
+		hash = opts.HashFunc()
+	}
+
+	k, err := s.nonce(random, digest)
+	if err != nil {
+		return nil, fmt.Errorf("signer: %w", err)
+	}
+
+	switch s.group {
+	case ecc.Edwards25519Sha512, ecc.Ristretto255Sha512:
+		if hash != 0 {
+			return nil, fmt.Errorf("signer: %w", errInvalidHash)
+		}
+
+		return s.signSchnorr(k, digest), nil
+	default:
+		if hash == 0 {
+			return nil, fmt.Errorf("signer: %w", errInvalidHash)
+		}
+
+		if len(digest) != hash.Size() {
+			return nil, fmt.Errorf("signer: %w", errInvalidDigest)
+		}
+
+		return s.signECDSA(k, digest)
+	}
+}
+
+// nonce returns a hedged nonce, derived from fresh randomness, the private key, and the digest.
+func (s *Signer) nonce(random io.Reader, digest []byte) (*ecc.Scalar, error) {
+	if random == nil {
+		random = internal.RandomSource()
+	}
+
+	input := make([]byte, nonceEntropySize, nonceEntropySize+s.group.ScalarLength()+len(digest))
+	if _, err := io.ReadFull(random, input); err != nil {
+		return nil, fmt.Errorf("%w", err)
+	}
+
+	input = append(input, s.secret.Encode()...)
+	input = append(input, digest...)
+
+	return s.group.HashToScalar(input, s.group.DST(nonceDSTApp, dstVersion)), nil
+}
+
+// signSchnorr returns R || S, with S = k + c * secret.
+func (s *Signer) signSchnorr(k *ecc.Scalar, message []byte) []byte {
+	r := s.group.Base().Multiply(k).Encode()
+	c := challenge(s.group, r, s.key.Encode(), message)
+
+	return append(r, k.Add(c.Multiply(s.secret)).Encode()...)
+}
+
+// challenge returns the Schnorr challenge, computed as in Ed25519 for Edwards25519, and as in the schnorr package
+// otherwise.
+func challenge(g ecc.Group, r, key, message []byte) *ecc.Scalar {
+	if g == ecc.Edwards25519Sha512 {
+		h := sha512.New()
+		_, _ = h.Write(r)
+		_, _ = h.Write(key)
+		_, _ = h.Write(message)
+
+		c, _ := ed.NewScalar().SetUniformBytes(h.Sum(nil))
+		s := g.NewScalar()
+		_ = s.SetEdwards25519Scalar(c)
+
+		return s
+	}
+
+	return schnorr.Challenge(g, r, key, message)
+}
+
+// VerifySchnorr returns nil if the signature produced by a Ristretto255 or Edwards25519 Signer is valid for the
+// message and the public key. Ed25519 signatures can also be verified with crypto/ed25519.
+func VerifySchnorr(public *ecc.Element, message, signature []byte) error {
+	if public == nil {
+		return fmt.Errorf("signer: %w", internal.ErrParamNilPoint)
+	}
+
+	g := public.Group()
+	if g != ecc.Ristretto255Sha512 && g != ecc.Edwards25519Sha512 {
+		return fmt.Errorf("signer: %w", internal.ErrInvalidGroup)
+	}
+
+	if len(signature) != g.ElementLength()+g.ScalarLength() {
+		return fmt.Errorf("signer: %w", errInvalidSignature)
+	}
+
+	r := g.NewElement()
+	if err := r.Decode(signature[:g.ElementLength()]); err != nil {
+		return fmt.Errorf("signer: %w", errInvalidSignature)
+	}
+
+	z := g.NewScalar()
+	if err := z.Decode(signature[g.ElementLength():]); err != nil {
+		return fmt.Errorf("signer: %w", errInvalidSignature)
+	}
+
+	c := challenge(g, signature[:g.ElementLength()], public.Encode(), message)
+
+	if !g.Base().Multiply(z).Equal(r.Add(public.Copy().Multiply(c))) {
+		return fmt.Errorf("signer: %w", errInvalidSignature)
+	}
+
+	return nil
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// signECDSA returns the ASN.1 DER encoded ECDSA signature (r, s), with r = x(k * G) mod n and
+// s = (e + r * secret) / k mod n.
+func (s *Signer) signECDSA(k *ecc.Scalar, digest []byte) ([]byte, error) {
+	order := new(big.Int).SetBytes(s.group.Order())
+
+	r := s.toScalar(new(big.Int).SetBytes(s.group.Base().Multiply(k).XCoordinate()), order)
+	if r.IsZero() {
+		return nil, fmt.Errorf("signer: %w", errInvalidSignature)
+	}
+
+	e := s.toScalar(hashToInt(digest, order), order)
+	sig := r.Copy().Multiply(s.secret).Add(e).Multiply(k.Invert())
+
+	if sig.IsZero() {
+		return nil, fmt.Errorf("signer: %w", errInvalidSignature)
+	}
+
+	out, err := asn1.Marshal(ecdsaSignature{
+		R: new(big.Int).SetBytes(r.Encode()),
+		S: new(big.Int).SetBytes(sig.Encode()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("signer: %w", err)
+	}
+
+	return out, nil
+}
+
+// toScalar returns the scalar of i reduced modulo the order.
+func (s *Signer) toScalar(i, order *big.Int) *ecc.Scalar {
+	sc := s.group.NewScalar()
+	if err := sc.Decode(i.Mod(i, order).FillBytes(make([]byte, s.group.ScalarLength()))); err != nil {
+		// A reduced integer is always a valid scalar.
+		panic(err)
+	}
+
+	return sc
+}
+
+// hashToInt converts the digest to an integer as in SEC 1 section 4.1.3, keeping its leftmost bits up to the bit
+// length of the order.
+func hashToInt(digest []byte, order *big.Int) *big.Int {
+	orderBits := order.BitLen()
+	if orderBytes := (orderBits + 7) / 8; len(digest) > orderBytes {
+		digest = digest[:orderBytes]
+	}
+
+	i := new(big.Int).SetBytes(digest)
+	if excess := len(digest)*8 - orderBits; excess > 0 {
+		i.Rsh(i, uint(excess))
+	}
+
+	return i
+}
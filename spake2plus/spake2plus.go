@@ -0,0 +1,386 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package spake2plus implements the SPAKE2+ augmented password-authenticated key exchange of RFC 9383, as used by
+// Matter device commissioning, over the NIST groups and Edwards25519, with the standard M and N points of RFC 9382.
+// The prover knows the password, from which it derives w0 and w1, while the verifier only stores the registration
+// record (w0, L), so that its compromise does not directly allow impersonating the prover.
+package spake2plus
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	labelConfirmation = "ConfirmationKeys"
+	labelShared       = "SharedKey"
+
+	// securityMargin is the length of the additional bytes of w0s and w1s, to reduce them with a negligible bias.
+	securityMargin = 8
+)
+
+var (
+	errInvalidHash         = errors.New("invalid hash function")
+	errInvalidShare        = errors.New("invalid share")
+	errInvalidConfirmation = errors.New("invalid key confirmation")
+	errInvalidLength       = errors.New("invalid PBKDF output length")
+)
+
+// points are the hex encodings of the M and N points of RFC 9382 for each group.
+var points = map[ecc.Group][2]string{
+	ecc.P256Sha256: {
+		"02886e2f97ace46e55ba9dd7242579f2993b64e16ef3dcab95afd497333d8fa12f",
+		"03d8bbd6c639c62937b04d997f38c3770719c629d7014d49a24b4f98baa1292b49",
+	},
+	ecc.P384Sha384: {
+		"030ff0895ae5ebf6187080a82d82b42e2765e3b2f8749c7e05eba366434b363d3dc36f15314739074d2eb8613fceec2853",
+		"02c72cf2e390853a1c1c4ad816a62fd15824f56078918f43f922ca21518f9c543bb252c5490214cf9aa3f0baab4b665c10",
+	},
+	ecc.P521Sha512: {
+		"02003f06f38131b2ba2600791e82488e8d20ab889af753a41806c5db18d37d85608cfae06b82e4a72cd744c719193562a65" +
+			"3ea1f119eef9356907edc9b56979962d7aa",
+		"0200c7924b9ec017f3094562894336a53c50167ba8c5963876880542bc669e494b2532d76c5b53dfb349fdf69154b9e0048" +
+			"c58a42e8ed04cef052a3bc349d95575cd25",
+	},
+	ecc.Edwards25519Sha512: {
+		"d048032c6ea0b6d697ddc2e86bda85a33adac920f1bf18e1b0c6d166a5cecdaf",
+		"d3bfb518f44f3430f29d0c92af503865a1ed3281dc69b35dd868ba85f886c4ab",
+	},
+}
+
+// Suite is a SPAKE2+ ciphersuite: a group, and the hash function of the transcript, HKDF, and HMAC.
+type Suite struct {
+	m, n     *ecc.Element
+	cofactor *ecc.Scalar
+	group    ecc.Group
+	hash     crypto.Hash
+}
+
+// New returns the SPAKE2+ ciphersuite of the group and the hash function, e.g. P256Sha256 and crypto.SHA256 for
+// P256-SHA256-HKDF-SHA256-HMAC-SHA256.
+func New(g ecc.Group, hash crypto.Hash) (*Suite, error) {
+	mn, ok := points[g]
+	if !ok {
+		return nil, fmt.Errorf("spake2plus: %w", internal.ErrInvalidGroup)
+	}
+
+	if !hash.Available() {
+		return nil, fmt.Errorf("spake2plus: %w", errInvalidHash)
+	}
+
+	s := &Suite{m: g.NewElement(), n: g.NewElement(), cofactor: g.NewScalar().One(), group: g, hash: hash}
+
+	if err := s.m.DecodeHex(mn[0]); err != nil {
+		panic(err)
+	}
+
+	if err := s.n.DecodeHex(mn[1]); err != nil {
+		panic(err)
+	}
+
+	if g == ecc.Edwards25519Sha512 {
+		s.cofactor.SetUInt64(8)
+	}
+
+	return s, nil
+}
+
+// Group returns the group of the ciphersuite.
+func (s *Suite) Group() ecc.Group {
+	return s.group
+}
+
+// PBKDFLength returns the length of the PBKDF output from which w0 and w1 are derived.
+func (s *Suite) PBKDFLength() int {
+	return 2 * s.halfLength()
+}
+
+func (s *Suite) halfLength() int {
+	return (s.order().BitLen()+7)/8 + securityMargin
+}
+
+// order returns the group order, whose encoding is little-endian for Edwards25519.
+func (s *Suite) order() *big.Int {
+	if s.group == ecc.Edwards25519Sha512 {
+		return new(big.Int).SetBytes(reverse(s.group.Order()))
+	}
+
+	return new(big.Int).SetBytes(s.group.Order())
+}
+
+// DeriveScalars returns w0 and w1 from the output w0s || w1s of a PBKDF over the password and the identities, e.g.
+// scrypt or Argon2, which must be PBKDFLength bytes long.
+func (s *Suite) DeriveScalars(pbkdfOutput []byte) (w0, w1 *ecc.Scalar, err error) {
+	l := s.halfLength()
+	if len(pbkdfOutput) != 2*l {
+		return nil, nil, fmt.Errorf("spake2plus: %w", errInvalidLength)
+	}
+
+	return s.reduce(pbkdfOutput[:l]), s.reduce(pbkdfOutput[l:]), nil
+}
+
+// reduce returns the big-endian integer reduced modulo the group order.
+func (s *Suite) reduce(data []byte) *ecc.Scalar {
+	i := new(big.Int).SetBytes(data)
+
+	return s.fromBigEndian(i.Mod(i, s.order()).FillBytes(make([]byte, s.group.ScalarLength())))
+}
+
+func (s *Suite) fromBigEndian(b []byte) *ecc.Scalar {
+	if s.group == ecc.Edwards25519Sha512 {
+		b = reverse(b)
+	}
+
+	sc := s.group.NewScalar()
+	if err := sc.Decode(b); err != nil {
+		// A reduced integer is always a valid scalar.
+		panic(err)
+	}
+
+	return sc
+}
+
+func (s *Suite) bigEndian(sc *ecc.Scalar) []byte {
+	if s.group == ecc.Edwards25519Sha512 {
+		return reverse(sc.Encode())
+	}
+
+	return sc.Encode()
+}
+
+func reverse(b []byte) []byte {
+	out := make([]byte, len(b))
+	for i := range b {
+		out[len(b)-1-i] = b[i]
+	}
+
+	return out
+}
+
+// RegistrationRecord returns L = w1 * G, which the verifier stores with w0.
+func (s *Suite) RegistrationRecord(w1 *ecc.Scalar) *ecc.Element {
+	return s.group.Base().Multiply(w1)
+}
+
+// encode returns the SEC 1 uncompressed encoding of elements of the NIST groups, and the standard encoding otherwise.
+func (s *Suite) encode(e *ecc.Element) []byte {
+	if s.group == ecc.Edwards25519Sha512 {
+		return e.Encode()
+	}
+
+	b, err := encoding.MarshalUncompressed(e)
+	if err != nil {
+		panic(err)
+	}
+
+	return b
+}
+
+func (s *Suite) decode(data []byte) (*ecc.Element, error) {
+	if s.group == ecc.Edwards25519Sha512 {
+		e := s.group.NewElement()
+		if err := e.Decode(data); err != nil {
+			return nil, errInvalidShare
+		}
+
+		return e, nil
+	}
+
+	if len(data) == 0 || data[0] != 0x04 {
+		return nil, errInvalidShare
+	}
+
+	e, err := encoding.ParseSEC1(s.group, data)
+	if err != nil || e.IsIdentity() {
+		return nil, errInvalidShare
+	}
+
+	return e, nil
+}
+
+// Identities are the optional context and party identities bound to the session.
+type Identities struct {
+	Context, Prover, Verifier []byte
+}
+
+// transcript returns the TT of RFC 9383 section 3.3, where each input is prefixed with its 8-byte little-endian length.
+func (s *Suite) transcript(ids *Identities, shareP, shareV []byte, z, v *ecc.Element, w0 *ecc.Scalar) []byte {
+	var tt []byte
+	for _, in := range [][]byte{
+		ids.Context, ids.Prover, ids.Verifier,
+		s.encode(s.m), s.encode(s.n),
+		shareP, shareV,
+		s.encode(z), s.encode(v),
+		s.bigEndian(w0),
+	} {
+		tt = binary.LittleEndian.AppendUint64(tt, uint64(len(in)))
+		tt = append(tt, in...)
+	}
+
+	return tt
+}
+
+// keys holds the confirmation keys and the shared key derived from the transcript.
+type keys struct {
+	confirmP, confirmV, shared []byte
+}
+
+func (s *Suite) keySchedule(tt []byte) *keys {
+	h := s.hash.New()
+	_, _ = h.Write(tt)
+	kMain := h.Sum(nil)
+	nh := s.hash.Size()
+
+	confirmation := make([]byte, 2*nh)
+	if _, err := io.ReadFull(hkdf.New(s.hash.New, kMain, nil, []byte(labelConfirmation)), confirmation); err != nil {
+		panic(err)
+	}
+
+	shared := make([]byte, nh)
+	if _, err := io.ReadFull(hkdf.New(s.hash.New, kMain, nil, []byte(labelShared)), shared); err != nil {
+		panic(err)
+	}
+
+	return &keys{confirmP: confirmation[:nh], confirmV: confirmation[nh:], shared: shared}
+}
+
+func (s *Suite) mac(key, message []byte) []byte {
+	m := hmac.New(s.hash.New, key)
+	_, _ = m.Write(message)
+
+	return m.Sum(nil)
+}
+
+// Prover is the state of the party knowing the password.
+type Prover struct {
+	suite  *Suite
+	ids    Identities
+	x      *ecc.Scalar
+	w0, w1 *ecc.Scalar
+	shareP []byte
+}
+
+// NewProver returns the prover's state and its share to send to the verifier.
+func (s *Suite) NewProver(ids Identities, w0, w1 *ecc.Scalar) (*Prover, []byte, error) {
+	if err := s.checkScalar(w0); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.checkScalar(w1); err != nil {
+		return nil, nil, err
+	}
+
+	x := s.group.NewScalar().Random()
+	shareP := s.encode(s.group.Base().Multiply(x).Add(s.m.Copy().Multiply(w0)))
+
+	return &Prover{suite: s, ids: ids, x: x, w0: w0.Copy(), w1: w1.Copy(), shareP: shareP}, shareP, nil
+}
+
+func (s *Suite) checkScalar(sc *ecc.Scalar) error {
+	if sc == nil {
+		return fmt.Errorf("spake2plus: %w", internal.ErrParamNilScalar)
+	}
+
+	if sc.Group() != s.group {
+		return fmt.Errorf("spake2plus: %w", internal.ErrCastScalar)
+	}
+
+	return nil
+}
+
+// Finish verifies the verifier's share and key confirmation, and returns the prover's key confirmation to send to
+// the verifier, and the shared key.
+func (p *Prover) Finish(shareV, confirmV []byte) (confirmP, sharedKey []byte, err error) {
+	s := p.suite
+
+	y, err := s.decode(shareV)
+	if err != nil {
+		return nil, nil, fmt.Errorf("spake2plus: %w", err)
+	}
+
+	// Y - w0 * N, multiplied by the cofactor.
+	t := y.Subtract(s.n.Copy().Multiply(p.w0)).Multiply(s.cofactor)
+	z := t.Copy().Multiply(p.x)
+	v := t.Multiply(p.w1)
+
+	if z.IsIdentity() || v.IsIdentity() {
+		return nil, nil, fmt.Errorf("spake2plus: %w", errInvalidShare)
+	}
+
+	k := s.keySchedule(s.transcript(&p.ids, p.shareP, shareV, z, v, p.w0))
+
+	if !hmac.Equal(s.mac(k.confirmV, p.shareP), confirmV) {
+		return nil, nil, fmt.Errorf("spake2plus: %w", errInvalidConfirmation)
+	}
+
+	return s.mac(k.confirmP, shareV), k.shared, nil
+}
+
+// Verifier is the state of the party holding the registration record.
+type Verifier struct {
+	confirmP []byte
+	shared   []byte
+}
+
+// NewVerifier returns the verifier's state, its share, and its key confirmation to send to the prover, from the
+// registration record (w0, L) and the prover's share.
+func (s *Suite) NewVerifier(
+	ids Identities,
+	w0 *ecc.Scalar,
+	record *ecc.Element,
+	shareP []byte,
+) (*Verifier, []byte, []byte, error) {
+	if err := s.checkScalar(w0); err != nil {
+		return nil, nil, nil, err
+	}
+
+	if record == nil || record.IsIdentity() || record.Group() != s.group {
+		return nil, nil, nil, fmt.Errorf("spake2plus: %w", internal.ErrParamNilPoint)
+	}
+
+	x, err := s.decode(shareP)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("spake2plus: %w", err)
+	}
+
+	y := s.group.NewScalar().Random()
+	shareV := s.encode(s.group.Base().Multiply(y).Add(s.n.Copy().Multiply(w0)))
+
+	hy := s.cofactor.Copy().Multiply(y)
+	z := x.Subtract(s.m.Copy().Multiply(w0)).Multiply(hy)
+	v := record.Copy().Multiply(hy)
+
+	if z.IsIdentity() {
+		return nil, nil, nil, fmt.Errorf("spake2plus: %w", errInvalidShare)
+	}
+
+	k := s.keySchedule(s.transcript(&ids, shareP, shareV, z, v, w0))
+
+	return &Verifier{confirmP: s.mac(k.confirmP, shareV), shared: k.shared}, shareV, s.mac(k.confirmV, shareP), nil
+}
+
+// Finish verifies the prover's key confirmation, and returns the shared key.
+func (v *Verifier) Finish(confirmP []byte) ([]byte, error) {
+	if !hmac.Equal(v.confirmP, confirmP) {
+		return nil, fmt.Errorf("spake2plus: %w", errInvalidConfirmation)
+	}
+
+	return v.shared, nil
+}
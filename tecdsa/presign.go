@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package tecdsa
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ecdsa"
+	"github.com/bytemare/ecc/internal"
+)
+
+var errInvalidDelta = errors.New("the sum of the delta shares is zero")
+
+// Presignature is the nonce commitment R = k^-1 * G of a threshold signature, and its signature component r. Each
+// party holds additive shares k_i of k and chi_i of k * x, where x is the private key.
+type Presignature struct {
+	Commitment *ecc.Element
+	R          *ecc.Scalar
+}
+
+// NewPresignature returns the presignature of the parties' Gamma_i = gamma_i * G and delta_i, which are additive
+// shares of k * gamma, e.g. obtained with multiplicative-to-additive conversions: R = (sum(delta_i))^-1 * sum(Gamma_i).
+func NewPresignature(gammas []*ecc.Element, deltas []*ecc.Scalar) (*Presignature, error) {
+	if len(gammas) == 0 || len(gammas) != len(deltas) {
+		return nil, fmt.Errorf("tecdsa: %w", internal.ErrParamLengthMismatch)
+	}
+
+	if gammas[0] == nil {
+		return nil, fmt.Errorf("tecdsa: %w", internal.ErrParamNilPoint)
+	}
+
+	g := gammas[0].Group()
+	gamma := g.NewElement()
+	delta := g.NewScalar()
+
+	for i := range gammas {
+		if gammas[i] == nil || deltas[i] == nil {
+			return nil, fmt.Errorf("tecdsa: %w", internal.ErrParamNilPoint)
+		}
+
+		if gammas[i].Group() != g || deltas[i].Group() != g {
+			return nil, fmt.Errorf("tecdsa: %w", internal.ErrInvalidGroup)
+		}
+
+		gamma.Add(gammas[i])
+		delta.Add(deltas[i])
+	}
+
+	if delta.IsZero() {
+		return nil, fmt.Errorf("tecdsa: %w", errInvalidDelta)
+	}
+
+	commitment := gamma.Multiply(delta.Invert())
+
+	r, err := ecdsa.CommitmentToR(commitment)
+	if err != nil {
+		return nil, fmt.Errorf("tecdsa: %w", err)
+	}
+
+	return &Presignature{Commitment: commitment, R: r}, nil
+}
+
+// SignatureShare returns the party's share sigma_i = k_i * e + r * chi_i of the signature of the digest.
+func (p *Presignature) SignatureShare(digest []byte, k, chi *ecc.Scalar) (*ecc.Scalar, error) {
+	if k == nil || chi == nil {
+		return nil, fmt.Errorf("tecdsa: %w", internal.ErrParamNilScalar)
+	}
+
+	if k.Group() != p.R.Group() || chi.Group() != p.R.Group() {
+		return nil, fmt.Errorf("tecdsa: %w", internal.ErrCastScalar)
+	}
+
+	e, err := ecdsa.DigestToScalar(p.R.Group(), digest)
+	if err != nil {
+		return nil, fmt.Errorf("tecdsa: %w", err)
+	}
+
+	return e.Multiply(k).Add(p.R.Copy().Multiply(chi)), nil
+}
+
+// Combine returns the low-S signature of the digest from the parties' signature shares, and an error if it is not
+// valid for the public key, e.g. if a party sent an invalid share.
+func (p *Presignature) Combine(public *ecc.Element, digest []byte, shares []*ecc.Scalar) (*ecdsa.Signature, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("tecdsa: %w", internal.ErrParamNilScalar)
+	}
+
+	s := p.R.Group().NewScalar()
+
+	for _, share := range shares {
+		if share == nil {
+			return nil, fmt.Errorf("tecdsa: %w", internal.ErrParamNilScalar)
+		}
+
+		if share.Group() != s.Group() {
+			return nil, fmt.Errorf("tecdsa: %w", internal.ErrCastScalar)
+		}
+
+		s.Add(share)
+	}
+
+	sig := (&ecdsa.Signature{R: p.R.Copy(), S: s}).Normalize()
+	if err := ecdsa.Verify(public, digest, sig); err != nil {
+		return nil, fmt.Errorf("tecdsa: %w", err)
+	}
+
+	return sig, nil
+}
@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package tecdsa implements the group arithmetic of threshold ECDSA protocols, such as GG18 and CGGMP21, so that MPC
+// stacks can delegate all curve math to ecc: Feldman verifiable secret sharing and the re-sharing of shares to a new
+// set of parties, the Lagrange-weighted aggregation of public shares, and the construction of presignatures and
+// signatures from the parties' nonce and multiplicative shares. The multiplicative-to-additive conversions, e.g.
+// with Paillier, and the proofs of the protocols are not in scope.
+package tecdsa
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+var (
+	errInvalidThreshold  = errors.New("invalid threshold")
+	errInvalidIdentifier = errors.New("invalid, duplicate, or missing identifier")
+	errInvalidShare      = errors.New("share does not match the commitments")
+)
+
+// Share is a party's share of a secret, i.e. the evaluation of the sharing polynomial at the party's identifier.
+type Share struct {
+	Secret *ecc.Scalar
+	ID     uint64
+}
+
+// checkIDs returns an error if an identifier is zero or duplicated.
+func checkIDs(ids []uint64) error {
+	seen := make(map[uint64]bool, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			return errInvalidIdentifier
+		}
+
+		seen[id] = true
+	}
+
+	return nil
+}
+
+// LagrangeCoefficient returns the Lagrange coefficient at 0 of the identifier among the identifiers, which must be
+// distinct, non-zero, and include id.
+func LagrangeCoefficient(g ecc.Group, id uint64, ids []uint64) (*ecc.Scalar, error) {
+	if err := checkIDs(ids); err != nil {
+		return nil, fmt.Errorf("tecdsa: %w", err)
+	}
+
+	xi := g.NewScalar().SetUInt64(id)
+	num := g.NewScalar().One()
+	den := g.NewScalar().One()
+	found := false
+
+	for _, other := range ids {
+		if other == id {
+			found = true
+			continue
+		}
+
+		xj := g.NewScalar().SetUInt64(other)
+		num.Multiply(xj)
+		den.Multiply(xj.Copy().Subtract(xi))
+	}
+
+	if !found {
+		return nil, fmt.Errorf("tecdsa: %w", errInvalidIdentifier)
+	}
+
+	return num.Multiply(den.Invert()), nil
+}
+
+// AggregatePublicShares returns the public key sum(lambda_i * Y_i) of the public shares Y_i = x_i * G of the parties
+// with the identifiers, at least threshold of them.
+func AggregatePublicShares(g ecc.Group, ids []uint64, publicShares []*ecc.Element) (*ecc.Element, error) {
+	if len(ids) == 0 || len(ids) != len(publicShares) {
+		return nil, fmt.Errorf("tecdsa: %w", internal.ErrParamLengthMismatch)
+	}
+
+	coefficients := make([]*ecc.Scalar, len(ids))
+
+	for i, id := range ids {
+		l, err := LagrangeCoefficient(g, id, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		coefficients[i] = l
+	}
+
+	public, err := g.MultiScalarMult(coefficients, publicShares)
+	if err != nil {
+		return nil, fmt.Errorf("tecdsa: %w", err)
+	}
+
+	return public, nil
+}
+
+// Deal returns the shares of the secret for the identifiers with a random polynomial of degree threshold - 1, so
+// that any threshold of them can reconstruct it, and the Feldman commitments to the polynomial's coefficients, the
+// first of which is secret * G.
+func Deal(secret *ecc.Scalar, threshold int, ids []uint64) ([]*Share, []*ecc.Element, error) {
+	if secret == nil {
+		return nil, nil, fmt.Errorf("tecdsa: %w", internal.ErrParamNilScalar)
+	}
+
+	if threshold < 1 || threshold > len(ids) {
+		return nil, nil, fmt.Errorf("tecdsa: %w", errInvalidThreshold)
+	}
+
+	if err := checkIDs(ids); err != nil {
+		return nil, nil, fmt.Errorf("tecdsa: %w", err)
+	}
+
+	g := secret.Group()
+	coefficients := make([]*ecc.Scalar, threshold)
+	commitments := make([]*ecc.Element, threshold)
+
+	for i := range coefficients {
+		if i == 0 {
+			coefficients[i] = secret.Copy()
+		} else {
+			coefficients[i] = g.NewScalar().Random()
+		}
+
+		commitments[i] = g.Base().Multiply(coefficients[i])
+	}
+
+	shares := make([]*Share, len(ids))
+	for i, id := range ids {
+		shares[i] = &Share{Secret: evaluate(coefficients, id), ID: id}
+	}
+
+	return shares, commitments, nil
+}
+
+// evaluate returns the evaluation of the polynomial at x, with Horner's method.
+func evaluate(coefficients []*ecc.Scalar, x uint64) *ecc.Scalar {
+	g := coefficients[0].Group()
+	xs := g.NewScalar().SetUInt64(x)
+	y := g.NewScalar()
+
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		y.Multiply(xs).Add(coefficients[i])
+	}
+
+	return y
+}
+
+// PublicShare returns the public share x_i * G of the identifier, from the Feldman commitments of the polynomial.
+func PublicShare(commitments []*ecc.Element, id uint64) (*ecc.Element, error) {
+	if len(commitments) == 0 || commitments[0] == nil {
+		return nil, fmt.Errorf("tecdsa: %w", internal.ErrParamNilPoint)
+	}
+
+	g := commitments[0].Group()
+	powers := make([]*ecc.Scalar, len(commitments))
+	x := g.NewScalar().SetUInt64(id)
+
+	for i := range powers {
+		if i == 0 {
+			powers[i] = g.NewScalar().One()
+		} else {
+			powers[i] = powers[i-1].Copy().Multiply(x)
+		}
+	}
+
+	public, err := g.MultiScalarMult(powers, commitments)
+	if err != nil {
+		return nil, fmt.Errorf("tecdsa: %w", err)
+	}
+
+	return public, nil
+}
+
+// VerifyShare returns nil if the share is the evaluation of the polynomial of the Feldman commitments.
+func VerifyShare(share *Share, commitments []*ecc.Element) error {
+	if share == nil || share.Secret == nil {
+		return fmt.Errorf("tecdsa: %w", internal.ErrParamNilScalar)
+	}
+
+	public, err := PublicShare(commitments, share.ID)
+	if err != nil {
+		return err
+	}
+
+	if !share.Secret.Group().Base().Multiply(share.Secret).Equal(public) {
+		return fmt.Errorf("tecdsa: %w", errInvalidShare)
+	}
+
+	return nil
+}
+
+// Reshare returns the sub-shares of the share for the new identifiers, and their Feldman commitments, the first of
+// which is lambda_i * Y_i for the share's public share Y_i. The share's party is one of the old quorum of at least
+// threshold parties all re-sharing to the new parties, e.g. to refresh shares or change the set of parties, without
+// changing the secret and the public key. The new threshold can differ from the old one.
+func Reshare(share *Share, quorum []uint64, threshold int, newIDs []uint64) ([]*Share, []*ecc.Element, error) {
+	if share == nil || share.Secret == nil {
+		return nil, nil, fmt.Errorf("tecdsa: %w", internal.ErrParamNilScalar)
+	}
+
+	l, err := LagrangeCoefficient(share.Secret.Group(), share.ID, quorum)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return Deal(l.Multiply(share.Secret), threshold, newIDs)
+}
+
+// CombineReshares returns a new party's share from the sub-shares it received from each party of the old quorum,
+// and the Feldman commitments of the new sharing, after verifying each sub-share against its commitments. The first
+// commitment of the result is the public key, which the new parties should compare to the old one.
+func CombineReshares(subShares []*Share, commitments [][]*ecc.Element) (*Share, []*ecc.Element, error) {
+	if len(subShares) == 0 || len(subShares) != len(commitments) {
+		return nil, nil, fmt.Errorf("tecdsa: %w", internal.ErrParamLengthMismatch)
+	}
+
+	for i, s := range subShares {
+		if err := VerifyShare(s, commitments[i]); err != nil {
+			return nil, nil, err
+		}
+
+		if s.ID != subShares[0].ID {
+			return nil, nil, fmt.Errorf("tecdsa: %w", errInvalidIdentifier)
+		}
+
+		if len(commitments[i]) != len(commitments[0]) {
+			return nil, nil, fmt.Errorf("tecdsa: %w", internal.ErrParamLengthMismatch)
+		}
+	}
+
+	g := subShares[0].Secret.Group()
+	share := &Share{Secret: g.NewScalar(), ID: subShares[0].ID}
+	combined := make([]*ecc.Element, len(commitments[0]))
+
+	for k := range combined {
+		combined[k] = g.NewElement()
+	}
+
+	for i, s := range subShares {
+		share.Secret.Add(s.Secret)
+
+		for k, c := range commitments[i] {
+			combined[k].Add(c)
+		}
+	}
+
+	return share, combined, nil
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/adaptor"
+	"github.com/bytemare/ecc/bip340"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+// verifyAdapted verifies the signature with the scheme of the group.
+func verifyAdapted(public *ecc.Element, message, signature []byte) error {
+	if public.Group() == ecc.Secp256k1Sha256 {
+		return bip340.Verify(public.EncodeXOnly(), message, signature)
+	}
+
+	return schnorr.Verify(public, message, signature)
+}
+
+func TestAdaptor(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		message := []byte("message")
+
+		for range 4 {
+			// Random keys cover both y parities of secp256k1 public keys.
+			secret, public := schnorr.KeyGen(g)
+			tSecret := g.NewScalar().Random()
+			tPoint := g.Base().Multiply(tSecret)
+
+			pre, err := adaptor.PreSign(secret, message, tPoint)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = adaptor.PreVerify(public, message, tPoint, pre); err != nil {
+				t.Fatal(err)
+			}
+
+			// The pre-signature is not a valid signature.
+			if err = verifyAdapted(public, message, append(pre.R.Encode(), pre.S.Encode()...)); err == nil {
+				t.Fatal("expected error on pre-signature")
+			}
+
+			sig, err := adaptor.Adapt(pre, tSecret)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = verifyAdapted(public, message, sig); err != nil {
+				t.Fatal(err)
+			}
+
+			extracted, err := adaptor.Extract(pre, sig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !extracted.Equal(tSecret) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			// Wrong adaptor point, message, and key.
+			if err = adaptor.PreVerify(public, message, g.Base(), pre); err == nil {
+				t.Fatal("expected error on wrong adaptor point")
+			}
+
+			if err = adaptor.PreVerify(public, []byte("other"), tPoint, pre); err == nil {
+				t.Fatal("expected error on wrong message")
+			}
+
+			if err = adaptor.PreVerify(g.Base(), message, tPoint, pre); err == nil {
+				t.Fatal("expected error on wrong public key")
+			}
+
+			// Adapting with the wrong secret yields an invalid signature.
+			sig, _ = adaptor.Adapt(pre, g.NewScalar().Random())
+			if err = verifyAdapted(public, message, sig); err == nil {
+				t.Fatal("expected error on wrong adaptor secret")
+			}
+		}
+
+		secret := g.NewScalar().Random()
+		if _, err := adaptor.PreSign(secret, nil, g.NewElement()); !errors.Is(err, internal.ErrParamNilPoint) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		pre, _ := adaptor.PreSign(secret, nil, g.Base())
+		if _, err := adaptor.Extract(pre, []byte{1}); err == nil {
+			t.Fatal("expected error on invalid signature")
+		}
+	})
+}
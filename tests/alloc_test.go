@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+// allocationFree returns whether arithmetic doesn't allocate in the group. The secp256k1 backend uses math/big, and
+// so do the circl backend's elements.
+func allocationFree(g ecc.Group) bool {
+	if g == ecc.Secp256k1Sha256 {
+		return false
+	}
+
+	return !circlBackend || (g != ecc.Ristretto255Sha512 && g != ecc.P384Sha384)
+}
+
+// schnorrVerifyChain computes s * G - c * P and compares it to r, with preallocated receivers, as in Schnorr
+// signature verification.
+func schnorrVerifyChain(r, p, sg, cp *ecc.Element, s, c *ecc.Scalar) bool {
+	sg.Base().Multiply(s)
+	cp.Set(p).Multiply(c)
+
+	return sg.Subtract(cp).Equal(r)
+}
+
+func TestAllocations(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		if !allocationFree(g) {
+			return
+		}
+
+		s, s2 := g.NewScalar().Random(), g.NewScalar().Random()
+		e, e2 := g.Base().Multiply(s), g.Base().Multiply(s2)
+		re, rs := g.NewElement(), g.NewElement()
+		r := g.NewScalar()
+		c := g.NewScalar().Random()
+		commitment := g.Base().Multiply(s).Subtract(e2.Copy().Multiply(c))
+		encoding := e.Encode()
+
+		if !schnorrVerifyChain(commitment, e2, re, rs, s, c) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		for name, f := range map[string]func(){
+			"Element.Add":         func() { re.Set(e).Add(e2) },
+			"Element.Double":      func() { re.Set(e).Double() },
+			"Element.Negate":      func() { re.Set(e).Negate() },
+			"Element.Subtract":    func() { re.Set(e).Subtract(e2) },
+			"Element.Multiply":    func() { re.Set(e).Multiply(s) },
+			"Element.Base":        func() { re.Base().Multiply(s) },
+			"Element.Equal":       func() { _ = e.Equal(e2) },
+			"Element.Identity":    func() { _ = e.IsIdentity() },
+			"Scalar.Add":          func() { r.Set(s).Add(s2) },
+			"Scalar.Subtract":     func() { r.Set(s).Subtract(s2) },
+			"Scalar.Equal":        func() { _ = s.Equal(s2) },
+			"Scalar.IsZero":       func() { _ = s.IsZero() },
+			"Schnorr.Verify":      func() { _ = schnorrVerifyChain(commitment, e2, re, rs, s, c) },
+			"Element.MultiplyAdd": func() { re.Set(e2).Multiply(c).Add(e) },
+			"Element.Decode":      func() { _ = re.Decode(encoding) },
+		} {
+			if n := testing.AllocsPerRun(10, f); n != 0 {
+				t.Errorf("%s: %.0f allocations", name, n)
+			}
+		}
+	})
+}
+
+// TestAllocations_Curve25519 checks that the edwards25519 and ristretto255 wrappers reuse their backend objects in
+// the operations that the NIST groups implement with math/big, and that copies only allocate the returned value.
+func TestAllocations_Curve25519(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		if g != ecc.Edwards25519Sha512 && (g != ecc.Ristretto255Sha512 || circlBackend) {
+			return
+		}
+
+		s, s2, r := g.NewScalar().Random(), g.NewScalar().Random(), g.NewScalar()
+		e := g.Base().Multiply(s)
+
+		for name, f := range map[string]func(){
+			"Scalar.Pow":         func() { r.Set(s).Pow(s2) },
+			"Scalar.LessOrEqual": func() { _ = s.LessOrEqual(s2) },
+		} {
+			if n := testing.AllocsPerRun(10, f); n != 0 {
+				t.Errorf("%s: %.0f allocations", name, n)
+			}
+		}
+
+		if n := testing.AllocsPerRun(10, func() { _ = e.Copy() }); n > 2 {
+			t.Errorf("Element.Copy: %.0f allocations", n)
+		}
+	})
+}
+
+// maxHashToScalarAllocs bounds the allocations of HashToScalar, which only allocates the returned scalar and the
+// reduction modulo the order.
+const maxHashToScalarAllocs = 10
+
+func TestAllocations_HashToScalar(t *testing.T) {
+	input, dst := []byte("input"), []byte("hash-to-scalar-allocations")
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		if circlBackend && (g == ecc.Ristretto255Sha512 || g == ecc.P384Sha384) {
+			return
+		}
+
+		if n := testing.AllocsPerRun(100, func() { g.HashToScalar(input, dst) }); n > maxHashToScalarAllocs {
+			t.Errorf("%s HashToScalar: %.0f allocations", g, n)
+		}
+	})
+}
+
+func TestHashToGroup_Concurrent(t *testing.T) {
+	dst := []byte("hash-to-group-concurrent")
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		expected := g.HashToGroup([]byte("input"), dst)
+
+		var wg sync.WaitGroup
+
+		errs := make(chan string, 8)
+		for range cap(errs) {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for range 16 {
+					if !g.HashToGroup([]byte("input"), dst).Equal(expected) {
+						errs <- errExpectedEquality
+						return
+					}
+				}
+			}()
+		}
+
+		wg.Wait()
+		close(errs)
+
+		for err := range errs {
+			t.Fatal(err)
+		}
+	})
+}
+
+func BenchmarkSchnorrVerifyChain(b *testing.B) {
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		g := group.group
+		s, c := g.NewScalar().Random(), g.NewScalar().Random()
+		p := g.Base().Multiply(g.NewScalar().Random())
+		r := g.Base().Multiply(s).Subtract(p.Copy().Multiply(c))
+		sg, cp := g.NewElement(), g.NewElement()
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if !schnorrVerifyChain(r, p, sg, cp, s, c) {
+				b.Fatal(errExpectedEquality)
+			}
+		}
+	})
+}
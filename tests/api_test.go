@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"math/bits"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+// proveAPI is a Schnorr proof of knowledge of the discrete logarithm of the returned public key, written against the
+// interfaces so that it runs with the real groups and the fake.
+func proveAPI[E ecc.ElementAPI[E, S], S ecc.ScalarAPI[S]](g ecc.GroupAPI[E, S], secret S) (pk, r E, z S) {
+	pk = g.Base().Multiply(secret)
+	k := g.NewScalar().Random()
+	r = g.Base().Multiply(k)
+	c := g.HashToScalar(append(r.Encode(), pk.Encode()...), []byte("api-test"))
+
+	return pk, r, k.Add(c.Multiply(secret))
+}
+
+func verifyAPI[E ecc.ElementAPI[E, S], S ecc.ScalarAPI[S]](g ecc.GroupAPI[E, S], pk, r E, z S) bool {
+	c := g.HashToScalar(append(r.Encode(), pk.Encode()...), []byte("api-test"))
+	return g.Base().Multiply(z).Equal(r.Copy().Add(pk.Copy().Multiply(c)))
+}
+
+func TestAPI_Group(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		pk, r, z := proveAPI(g, g.NewScalar().Random())
+
+		if !verifyAPI(g, pk, r, z) {
+			t.Fatal("expected valid proof")
+		}
+
+		if verifyAPI(g, pk, r, z.Add(g.NewScalar().One())) {
+			t.Fatal("expected invalid proof")
+		}
+	})
+}
+
+func TestAPI_Fake(t *testing.T) {
+	var g fakeGroup
+
+	pk, r, z := proveAPI(g, g.NewScalar().SetUInt64(42))
+
+	if !verifyAPI(g, pk, r, z) {
+		t.Fatal("expected valid proof")
+	}
+
+	if verifyAPI(g, pk, r, z.Add(g.NewScalar().One())) {
+		t.Fatal("expected invalid proof")
+	}
+
+	// The fake's elements are the scalar multiples of 1, which makes the expected values of the protocol trivial.
+	if pk.v != 42 {
+		t.Fatalf("unexpected public key %d", pk.v)
+	}
+}
+
+// fakeOrder is the order of the fake group, the Mersenne prime 2^61 - 1.
+const fakeOrder = 1<<61 - 1
+
+var errFakeEncoding = errors.New("invalid fake encoding")
+
+// fakeGroup is the additive group of integers modulo fakeOrder, whose elements are their own discrete logarithms.
+type fakeGroup struct{}
+
+func (fakeGroup) NewScalar() *fakeScalar {
+	return &fakeScalar{}
+}
+
+func (fakeGroup) NewElement() *fakeElement {
+	return &fakeElement{}
+}
+
+func (fakeGroup) Base() *fakeElement {
+	return &fakeElement{v: 1}
+}
+
+func (fakeGroup) ScalarLength() int {
+	return 8
+}
+
+func (fakeGroup) ElementLength() int {
+	return 8
+}
+
+func (fakeGroup) String() string {
+	return "fake"
+}
+
+func (fakeGroup) Order() []byte {
+	return binary.BigEndian.AppendUint64(nil, fakeOrder)
+}
+
+func (fakeGroup) HashToScalar(input, dst []byte) *fakeScalar {
+	return &fakeScalar{v: fakeHash(input, dst)}
+}
+
+func (fakeGroup) HashToGroup(input, dst []byte) *fakeElement {
+	return &fakeElement{v: fakeHash(input, dst)}
+}
+
+func (fakeGroup) EncodeToGroup(input, dst []byte) *fakeElement {
+	return &fakeElement{v: fakeHash(input, dst)}
+}
+
+func fakeHash(input, dst []byte) uint64 {
+	h := sha256.Sum256(append(append([]byte{}, dst...), input...))
+	return binary.BigEndian.Uint64(h[:]) % fakeOrder
+}
+
+func fakeMul(a, b uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, fakeOrder)
+}
+
+func fakeDecode(data []byte) (uint64, error) {
+	if len(data) != 8 {
+		return 0, errFakeEncoding
+	}
+
+	v := binary.BigEndian.Uint64(data)
+	if v >= fakeOrder {
+		return 0, errFakeEncoding
+	}
+
+	return v, nil
+}
+
+type fakeScalar struct {
+	v uint64
+}
+
+func (s *fakeScalar) Group() ecc.Group {
+	return 0
+}
+
+func (s *fakeScalar) Zero() *fakeScalar {
+	s.v = 0
+	return s
+}
+
+func (s *fakeScalar) One() *fakeScalar {
+	s.v = 1
+	return s
+}
+
+func (s *fakeScalar) MinusOne() *fakeScalar {
+	s.v = fakeOrder - 1
+	return s
+}
+
+func (s *fakeScalar) Random() *fakeScalar {
+	s.v = 1 + rand.Uint64N(fakeOrder-1)
+	return s
+}
+
+func (s *fakeScalar) Add(a *fakeScalar) *fakeScalar {
+	s.v = (s.v + a.v) % fakeOrder
+	return s
+}
+
+func (s *fakeScalar) Subtract(a *fakeScalar) *fakeScalar {
+	s.v = (s.v + fakeOrder - a.v) % fakeOrder
+	return s
+}
+
+func (s *fakeScalar) Multiply(a *fakeScalar) *fakeScalar {
+	s.v = fakeMul(s.v, a.v)
+	return s
+}
+
+func (s *fakeScalar) Equal(a *fakeScalar) bool {
+	return s.v == a.v
+}
+
+func (s *fakeScalar) LessOrEqual(a *fakeScalar) bool {
+	return s.v <= a.v
+}
+
+func (s *fakeScalar) IsZero() bool {
+	return s.v == 0
+}
+
+func (s *fakeScalar) Set(a *fakeScalar) *fakeScalar {
+	s.v = a.v
+	return s
+}
+
+func (s *fakeScalar) SetUInt64(i uint64) *fakeScalar {
+	s.v = i % fakeOrder
+	return s
+}
+
+func (s *fakeScalar) UInt64() (uint64, error) {
+	return s.v, nil
+}
+
+func (s *fakeScalar) Copy() *fakeScalar {
+	return &fakeScalar{v: s.v}
+}
+
+func (s *fakeScalar) Encode() []byte {
+	return binary.BigEndian.AppendUint64(nil, s.v)
+}
+
+func (s *fakeScalar) Hex() string {
+	return hex.EncodeToString(s.Encode())
+}
+
+func (s *fakeScalar) Pow(a *fakeScalar) *fakeScalar {
+	r := uint64(1)
+	for b, e := s.v, a.v; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r = fakeMul(r, b)
+		}
+
+		b = fakeMul(b, b)
+	}
+
+	s.v = r
+
+	return s
+}
+
+func (s *fakeScalar) Invert() *fakeScalar {
+	return s.Pow(&fakeScalar{v: fakeOrder - 2})
+}
+
+func (s *fakeScalar) Decode(data []byte) (err error) {
+	s.v, err = fakeDecode(data)
+	return err
+}
+
+func (s *fakeScalar) DecodeHex(h string) error {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return err
+	}
+
+	return s.Decode(b)
+}
+
+type fakeElement struct {
+	v uint64
+}
+
+func (e *fakeElement) Group() ecc.Group {
+	return 0
+}
+
+func (e *fakeElement) Base() *fakeElement {
+	e.v = 1
+	return e
+}
+
+func (e *fakeElement) Identity() *fakeElement {
+	e.v = 0
+	return e
+}
+
+func (e *fakeElement) Add(a *fakeElement) *fakeElement {
+	e.v = (e.v + a.v) % fakeOrder
+	return e
+}
+
+func (e *fakeElement) Double() *fakeElement {
+	return e.Add(e)
+}
+
+func (e *fakeElement) Negate() *fakeElement {
+	e.v = (fakeOrder - e.v) % fakeOrder
+	return e
+}
+
+func (e *fakeElement) Subtract(a *fakeElement) *fakeElement {
+	e.v = (e.v + fakeOrder - a.v) % fakeOrder
+	return e
+}
+
+func (e *fakeElement) Multiply(s *fakeScalar) *fakeElement {
+	e.v = fakeMul(e.v, s.v)
+	return e
+}
+
+func (e *fakeElement) Equal(a *fakeElement) bool {
+	return e.v == a.v
+}
+
+func (e *fakeElement) IsIdentity() bool {
+	return e.v == 0
+}
+
+func (e *fakeElement) Set(a *fakeElement) *fakeElement {
+	e.v = a.v
+	return e
+}
+
+func (e *fakeElement) Copy() *fakeElement {
+	return &fakeElement{v: e.v}
+}
+
+func (e *fakeElement) Encode() []byte {
+	return binary.BigEndian.AppendUint64(nil, e.v)
+}
+
+func (e *fakeElement) Hex() string {
+	return hex.EncodeToString(e.Encode())
+}
+
+func (e *fakeElement) Decode(data []byte) (err error) {
+	e.v, err = fakeDecode(data)
+	return err
+}
+
+func (e *fakeElement) DecodeHex(h string) error {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		return err
+	}
+
+	return e.Decode(b)
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc/arkg"
+)
+
+func TestARKG(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		info := []byte("info")
+		sk, pk := arkg.GenerateSeed(g)
+
+		public, keyHandle, err := arkg.DerivePublicKey(pk, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		secret, err := arkg.DerivePrivateKey(sk, keyHandle, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !g.Base().Multiply(secret).Equal(public) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Derived keys are unlinkable to the seed and to each other.
+		public2, keyHandle2, err := arkg.DerivePublicKey(pk, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if public2.Equal(public) || public.Equal(pk.Blinding) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		secret2, err := arkg.DerivePrivateKey(sk, keyHandle2, info)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !g.Base().Multiply(secret2).Equal(public2) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// The key handle is bound to the info and the seed.
+		if _, err = arkg.DerivePrivateKey(sk, keyHandle, []byte("other")); err == nil {
+			t.Fatal("expected error on wrong info")
+		}
+
+		otherSeed, _ := arkg.GenerateSeed(g)
+		if _, err = arkg.DerivePrivateKey(otherSeed, keyHandle, info); err == nil {
+			t.Fatal("expected error on wrong seed")
+		}
+
+		tampered := append([]byte(nil), keyHandle...)
+		tampered[0] ^= 1
+
+		if _, err = arkg.DerivePrivateKey(sk, tampered, info); err == nil {
+			t.Fatal("expected error on tampered tag")
+		}
+
+		if _, err = arkg.DerivePrivateKey(sk, keyHandle[1:], info); err == nil {
+			t.Fatal("expected error on short key handle")
+		}
+
+		if _, _, err = arkg.DerivePublicKey(nil, info); err == nil {
+			t.Fatal("expected error on nil seed")
+		}
+
+		if _, err = arkg.DerivePrivateKey(nil, keyHandle, info); err == nil {
+			t.Fatal("expected error on nil seed")
+		}
+	})
+}
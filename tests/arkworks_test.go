@@ -0,0 +1,189 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+// TestDalekLibsodium_Basepoints checks that the base points are encoded as the constants of curve25519-dalek and
+// libsodium, which are those of RFC 8032 and RFC 9496.
+func TestDalekLibsodium_Basepoints(t *testing.T) {
+	ristretto := decodeHex(t, "e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76")
+	if !bytes.Equal(ecc.Ristretto255Sha512.Base().Encode(), ristretto) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	edwards := decodeHex(t, "5866666666666666666666666666666666666666666666666666666666666666")
+	if !bytes.Equal(ecc.Edwards25519Sha512.Base().Encode(), edwards) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Scalars are little-endian in both libraries.
+	s := ecc.Ristretto255Sha512.NewScalar().SetUInt64(1)
+	if !bytes.Equal(s.Encode(), append([]byte{1}, make([]byte, 31)...)) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+// TestArkworks_Generators checks the generators against their definition in the arkworks format: for the
+// Weierstrass groups, the y-coordinates of the generators are "positive", so the encoding is the little-endian
+// x-coordinate with no flags, and for Edwards25519 the x-coordinate is "positive" too.
+func TestArkworks_Generators(t *testing.T) {
+	for _, test := range []struct {
+		group ecc.Group
+		x     string
+	}{
+		{ecc.P256Sha256, "6b17d1f2e12c4247f8bce6e563a440f277037d812deb33a0f4a13945d898c296"},
+		{ecc.Secp256k1Sha256, "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	} {
+		x := decodeHex(t, test.x)
+		slices.Reverse(x)
+		expected := append(x, 0x00)
+
+		encoded, err := encoding.ElementToArkworks(test.group.Base())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(encoded, expected) {
+			t.Fatalf("unexpected encoding %x", encoded)
+		}
+	}
+
+	encoded, err := encoding.ElementToArkworks(ecc.Edwards25519Sha512.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(encoded, ecc.Edwards25519Sha512.Base().Encode()) {
+		t.Fatalf("unexpected encoding %x", encoded)
+	}
+}
+
+func TestArkworks_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+
+		encodedScalar, err := encoding.ScalarToArkworks(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decodedScalar, err := encoding.ScalarFromArkworks(group.group, encodedScalar)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decodedScalar.Equal(s) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		e := group.group.Base().Multiply(s)
+
+		if group.group == ecc.Ristretto255Sha512 {
+			_, err = encoding.ElementFromArkworks(group.group, e.Encode())
+			if !errors.Is(err, internal.ErrInvalidGroup) {
+				t.Fatalf("unexpected error %q", err)
+			}
+
+			return
+		}
+
+		// An element and its negation only differ in their flag.
+		for _, element := range []*ecc.Element{e, e.Copy().Negate()} {
+			encoded, err := encoding.ElementToArkworks(element)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, err := encoding.ElementFromArkworks(group.group, encoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !decoded.Equal(element) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		a, _ := encoding.ElementToArkworks(e)
+		b, _ := encoding.ElementToArkworks(e.Copy().Negate())
+
+		last := len(a) - 1
+		if !bytes.Equal(a[:last], b[:last]) || a[last]^b[last] != 0x80 {
+			t.Fatalf("unexpected encodings %x and %x", a, b)
+		}
+	})
+}
+
+func TestArkworks_Fails(t *testing.T) {
+	if _, err := encoding.ScalarToArkworks(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := encoding.ElementToArkworks(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := encoding.ScalarFromArkworks(ecc.P256Sha256, make([]byte, 31)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		if group.group == ecc.Ristretto255Sha512 {
+			return
+		}
+
+		if _, err := encoding.ElementToArkworks(group.group.NewElement()); !errors.Is(err, internal.ErrIdentity) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		encoded, err := encoding.ElementToArkworks(group.group.Base())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = encoding.ElementFromArkworks(group.group, encoded[1:]); err == nil {
+			t.Fatal("expected error")
+		}
+
+		if group.group == ecc.Edwards25519Sha512 {
+			// y = p is a non-canonical encoding of 0.
+			p := decodeHex(t, "edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+			if _, err = encoding.ElementFromArkworks(group.group, p); err == nil {
+				t.Fatal("expected error")
+			}
+
+			return
+		}
+
+		infinity := slices.Clone(encoded)
+		infinity[len(infinity)-1] |= 0x40
+
+		if _, err = encoding.ElementFromArkworks(group.group, infinity); !errors.Is(err, internal.ErrIdentity) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		// Bits outside of the x-coordinate and the flags must be zero.
+		overflow := slices.Clone(encoded)
+		overflow[len(overflow)-1] |= 0x20
+
+		if _, err = encoding.ElementFromArkworks(group.group, overflow); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
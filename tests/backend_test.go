@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !circl
+
+package ecc_test
+
+// circlBackend is whether the tests run with the circl backend.
+const circlBackend = false
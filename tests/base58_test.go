@@ -0,0 +1,200 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	eccEncoding "github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	wifVersion    = 0x80
+	wifPrivateKey = "0c28fca386c7a227600b2fe50b7cae11ec86d3bf1fbe471be89827e19d72aa1d"
+	wifEncoded    = "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"
+	wifCompressed = "KwdMAjGmerYanjeui5SHS7JkmpZvVipYvB2LJGU1ZxJwYvP98617"
+)
+
+func TestBase58Check_WIF(t *testing.T) {
+	s := decodeScalar(t, ecc.Secp256k1Sha256, wifPrivateKey)
+
+	if enc := eccEncoding.ScalarToBase58Check(s, wifVersion); enc != wifEncoded {
+		t.Fatalf("unexpected WIF encoding\n\twant: %s\n\tgot : %s", wifEncoded, enc)
+	}
+
+	decoded, version, err := eccEncoding.ScalarFromBase58Check(ecc.Secp256k1Sha256, wifEncoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version != wifVersion {
+		t.Fatalf("unexpected version %d", version)
+	}
+
+	if !decoded.Equal(s) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestBase58Check_WIFCompressed(t *testing.T) {
+	s := decodeScalar(t, ecc.Secp256k1Sha256, wifPrivateKey)
+
+	for _, test := range []struct {
+		encoded    string
+		compressed bool
+	}{
+		{wifEncoded, false},
+		{wifCompressed, true},
+	} {
+		enc, err := eccEncoding.ScalarToWIF(s, wifVersion, test.compressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if enc != test.encoded {
+			t.Fatalf("unexpected WIF encoding\n\twant: %s\n\tgot : %s", test.encoded, enc)
+		}
+
+		decoded, version, compressed, err := eccEncoding.ScalarFromWIF(test.encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if version != wifVersion || compressed != test.compressed || !decoded.Equal(s) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+
+	// The compressed form is not a valid scalar for the generic decoder.
+	if _, _, err := eccEncoding.ScalarFromBase58Check(ecc.Secp256k1Sha256, wifCompressed); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestBase58Check_LeadingZeros(t *testing.T) {
+	payload := []byte{0, 0, 1, 2, 3}
+	enc := eccEncoding.Base58CheckEncode(0, payload)
+
+	if enc[:3] != "111" {
+		t.Fatalf("expected leading zeros to be encoded as '1', got %s", enc)
+	}
+
+	version, decoded, err := eccEncoding.Base58CheckDecode(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if version != 0 || !bytes.Equal(decoded, payload) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestBase58Check_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		e := group.group.Base().Multiply(s)
+
+		decodedScalar, _, err := eccEncoding.ScalarFromBase58Check(
+			group.group,
+			eccEncoding.ScalarToBase58Check(s, 1),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decodedScalar.Equal(s) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		decodedElement, version, err := eccEncoding.ElementFromBase58Check(
+			group.group,
+			eccEncoding.ElementToBase58Check(e, 2),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if version != 2 {
+			t.Fatalf("unexpected version %d", version)
+		}
+
+		if !decodedElement.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestBase58Check_Fails(t *testing.T) {
+	// Empty input
+	if _, _, err := eccEncoding.Base58CheckDecode(""); !errors.Is(err, internal.ErrDecodingInvalidBase58) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Invalid character
+	if _, _, err := eccEncoding.Base58CheckDecode("0OIl"); !errors.Is(err, internal.ErrDecodingInvalidBase58) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Too short
+	if _, _, err := eccEncoding.Base58CheckDecode("1111"); !errors.Is(err, internal.ErrDecodingInvalidLength) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Bad checksum
+	bad := []byte(wifEncoded)
+	bad[len(bad)-1] = 'K'
+
+	if _, _, err := eccEncoding.ScalarFromBase58Check(ecc.Secp256k1Sha256, string(bad)); !errors.Is(
+		err,
+		internal.ErrDecodingInvalidChecksum,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// WIF
+	if _, err := eccEncoding.ScalarToWIF(nil, wifVersion, true); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ScalarToWIF(ecc.P256Sha256.NewScalar(), wifVersion, true); !errors.Is(
+		err,
+		internal.ErrInvalidGroup,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, _, _, err := eccEncoding.ScalarFromWIF(string(bad)); !errors.Is(err, internal.ErrDecodingInvalidChecksum) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	s := decodeScalar(t, ecc.Secp256k1Sha256, wifPrivateKey)
+	if _, _, _, err := eccEncoding.ScalarFromWIF(
+		eccEncoding.Base58CheckEncode(wifVersion, append(s.Encode(), 0x02)),
+	); !errors.Is(err, internal.ErrDecodingInvalidLength) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, _, _, err := eccEncoding.ScalarFromWIF(
+		eccEncoding.Base58CheckEncode(wifVersion, s.Encode()[1:]),
+	); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// Wrong group
+	if _, _, err := eccEncoding.ElementFromBase58Check(
+		ecc.P384Sha384,
+		eccEncoding.ElementToBase58Check(ecc.P256Sha256.Base(), 0),
+	); err == nil {
+		t.Fatal("expected error")
+	}
+}
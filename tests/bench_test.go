@@ -11,6 +11,10 @@ package ecc_test
 import (
 	"bytes"
 	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/benchmarks"
+	"github.com/bytemare/ecc/internal"
 )
 
 func benchAll(b *testing.B, f func(*testing.B, *testGroup)) {
@@ -47,6 +51,19 @@ func BenchmarkHashToGroup(b *testing.B) {
 	})
 }
 
+func BenchmarkHashToScalar(b *testing.B) {
+	msg := make([]byte, 256)
+	dst := make([]byte, 10)
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		b.SetBytes(int64(len(msg)))
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			group.group.HashToScalar(msg, dst)
+		}
+	})
+}
+
 func BenchmarkSubtraction(b *testing.B) {
 	benchAll(b, func(b *testing.B, group *testGroup) {
 		b.ResetTimer()
@@ -98,3 +115,109 @@ func BenchmarkMarshalUnmarshal(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkMultiScalarMult(b *testing.B) {
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		scalars := randomScalars(group.group, 256)
+		elements := make([]*ecc.Element, len(scalars))
+		for i := range elements {
+			elements[i] = group.group.Base().Multiply(group.group.NewScalar().Random())
+		}
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := group.group.MultiScalarMult(scalars, elements); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkScalarMultiply(b *testing.B) {
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		s, s2 := group.group.NewScalar().Random(), group.group.NewScalar().Random()
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s.Multiply(s2)
+		}
+	})
+}
+
+func BenchmarkScalarInvert(b *testing.B) {
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		s := group.group.NewScalar().Random()
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			s.Invert()
+		}
+	})
+}
+
+func BenchmarkStandard(b *testing.B) {
+	benchmarks.Run(b, nil)
+}
+
+func BenchmarkGroupDispatch(b *testing.B) {
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		n := 0
+		for i := 0; i < b.N; i++ {
+			n += group.group.ScalarLength()
+		}
+
+		if n == 0 {
+			b.Fatal("unexpected scalar length")
+		}
+	})
+}
+
+func BenchmarkPrecomputedMultiply(b *testing.B) {
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		p := group.group.Base().Multiply(group.group.NewScalar().Random()).NewPrecomputed()
+		s := group.group.NewScalar().Random()
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p.Multiply(s)
+		}
+	})
+}
+
+func BenchmarkHashToGroupBatch(b *testing.B) {
+	inputs := make([][]byte, 256)
+	for i := range inputs {
+		inputs[i] = internal.RandomBytes(32)
+	}
+
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			group.group.HashToGroupBatch(inputs, []byte("benchmark-dst"))
+		}
+	})
+}
+
+func BenchmarkDecodeElements(b *testing.B) {
+	benchAll(b, func(b *testing.B, group *testGroup) {
+		encodings := make([][]byte, 256)
+		for i := range encodings {
+			encodings[i] = group.group.Base().Multiply(group.group.NewScalar().Random()).Encode()
+		}
+
+		opts := &ecc.DecodeOptions{}
+
+		b.ResetTimer()
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := group.group.DecodeElements(encodings, opts); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/benchmarks"
+)
+
+func TestBenchmarks_Groups(t *testing.T) {
+	groups := benchmarks.Groups()
+	if len(groups) != len(testTable) {
+		t.Fatalf("expected %d groups, got %d", len(testTable), len(groups))
+	}
+
+	names := make(map[string]bool)
+	for _, b := range benchmarks.All() {
+		if names[b.Name] || b.Run == nil {
+			t.Fatalf("invalid or duplicate benchmark %q", b.Name)
+		}
+
+		names[b.Name] = true
+	}
+
+	if len(names) != 9+len(benchmarks.MSMSizes) {
+		t.Fatalf("unexpected number of benchmarks %d", len(names))
+	}
+}
+
+func TestBenchmarks_WriteTable(t *testing.T) {
+	results := []benchmarks.Result{
+		{Group: ecc.P256Sha256, Name: "ScalarMult", NsPerOp: 12345, AllocsPerOp: 1, BytesPerOp: 64},
+		{Group: ecc.Ristretto255Sha512, Name: "ScalarMult", NsPerOp: 678, AllocsPerOp: 0, BytesPerOp: 0},
+	}
+
+	var buf bytes.Buffer
+	if err := benchmarks.WriteTable(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1+len(results) {
+		t.Fatalf("expected %d lines, got %d", 1+len(results), len(lines))
+	}
+
+	for i, r := range results {
+		fields := strings.Fields(lines[1+i])
+		if len(fields) != 5 || fields[0] != r.Name || fields[1] != r.Group.String() {
+			t.Fatalf("unexpected row %q", lines[1+i])
+		}
+	}
+
+	if err := benchmarks.WriteTable(failingWriter{}, results); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/bip340"
+	"github.com/bytemare/ecc/internal"
+)
+
+// TestBIP340_Vectors uses the signing test vectors 0 and 1 of BIP-340.
+func TestBIP340_Vectors(t *testing.T) {
+	for _, test := range []struct {
+		secret, public, aux, message, signature string
+	}{
+		{
+			secret:  "0000000000000000000000000000000000000000000000000000000000000003",
+			public:  "f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9",
+			aux:     "0000000000000000000000000000000000000000000000000000000000000000",
+			message: "0000000000000000000000000000000000000000000000000000000000000000",
+			signature: "e907831f80848d1069a5371b402410364bdf1c5f8307b0084c55f1ce2dca8215" +
+				"25f66a4a85ea8b71e482a74f382d2ce5ebeee8fdb2172f477df4900d310536c0",
+		},
+		{
+			secret:  "b7e151628aed2a6abf7158809cf4f3c762e7160f38b4da56a784d9045190cfef",
+			public:  "dff1d77f2a671c5f36183726db2341be58feae1da2deced843240f7b502ba659",
+			aux:     "0000000000000000000000000000000000000000000000000000000000000001",
+			message: "243f6a8885a308d313198a2e03707344a4093822299f31d0082efa98ec4e6c89",
+			signature: "6896bd60eeae296db48a229ff71dfe071bde413e6d43f917dc8dcf8c78de3341" +
+				"8906d11ac976abccb20b091292bff4ea897efcb639ea871cfa95f6de339e4b0a",
+		},
+	} {
+		secret := ecc.Secp256k1Sha256.NewScalar()
+		if err := secret.DecodeHex(test.secret); err != nil {
+			t.Fatal(err)
+		}
+
+		public, err := bip340.PublicKey(secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(public, decodeHex(t, test.public)) {
+			t.Fatalf("unexpected public key %x", public)
+		}
+
+		message := decodeHex(t, test.message)
+
+		sig, err := bip340.Sign(secret, message, decodeHex(t, test.aux))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(sig, decodeHex(t, test.signature)) {
+			t.Fatalf("unexpected signature %x", sig)
+		}
+
+		if err = bip340.Verify(public, message, sig); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestBIP340(t *testing.T) {
+	message := []byte("variable length message")
+
+	for range 20 {
+		secret := ecc.Secp256k1Sha256.NewScalar().Random()
+
+		public, err := bip340.PublicKey(secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The x-only key lifts to the element with an even y-coordinate, i.e. either the public element or its
+		// negation.
+		lifted, err := ecc.Secp256k1Sha256.DecodeXOnly(public)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		element := ecc.Secp256k1Sha256.Base().Multiply(secret)
+		if !lifted.Equal(element) && !lifted.Equal(ecc.Secp256k1Sha256.NewElement().Subtract(element)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if !bytes.Equal(lifted.EncodeXOnly(), public) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		sig, err := bip340.Sign(secret, message, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = bip340.Verify(public, message, sig); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = bip340.Verify(public, message[1:], sig); err == nil {
+			t.Fatal("expected error")
+		}
+
+		for _, i := range []int{0, 63} {
+			tampered := slices.Clone(sig)
+			tampered[i] ^= 1
+
+			if err = bip340.Verify(public, message, tampered); err == nil {
+				t.Fatal("expected error")
+			}
+		}
+	}
+}
+
+func TestBIP340_Fails(t *testing.T) {
+	secret := ecc.Secp256k1Sha256.NewScalar().Random()
+	public, _ := bip340.PublicKey(secret)
+	sig, _ := bip340.Sign(secret, nil, nil)
+
+	if _, err := bip340.PublicKey(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := bip340.Sign(ecc.P256Sha256.NewScalar().Random(), nil, nil); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := bip340.Sign(secret, nil, make([]byte, 31)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err := bip340.Verify(public[1:], nil, sig); !errors.Is(err, internal.ErrParamInvalidPointEncoding) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Test vector 5 of BIP-340: the public key is not on the curve.
+	offCurve := decodeHex(t, "eefdea4cdb677750a420fee807eacf21eb9898ae79b9768766e4faa04a2d4a34")
+	if err := bip340.Verify(offCurve, nil, sig); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err := bip340.Verify(public[1:], nil, sig); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err := bip340.Verify(public, nil, sig[1:]); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// s must be lower than the group order.
+	high := slices.Clone(sig)
+	copy(high[32:], bytes.Repeat([]byte{0xff}, 32))
+
+	if err := bip340.Verify(public, nil, high); err == nil {
+		t.Fatal("expected error")
+	}
+}
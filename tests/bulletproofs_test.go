@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/bulletproofs"
+	"github.com/bytemare/ecc/commitment"
+	"github.com/bytemare/ecc/internal"
+)
+
+func randomScalars(g ecc.Group, n int) []*ecc.Scalar {
+	out := make([]*ecc.Scalar, n)
+	for i := range out {
+		out[i] = g.NewScalar().Random()
+	}
+
+	return out
+}
+
+func TestMultiScalarMult(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		// 130 terms use the bucket method.
+		for _, n := range []int{0, 1, 2, 7, 130} {
+			scalars := randomScalars(g, n)
+			elements := make([]*ecc.Element, n)
+			expected := g.NewElement()
+
+			for i := range elements {
+				elements[i] = g.Base().Multiply(g.NewScalar().Random())
+				expected.Add(elements[i].Copy().Multiply(scalars[i]))
+			}
+
+			if n > 1 {
+				// Zero, one, and the maximal scalar.
+				scalars[0].Zero()
+				scalars[1].MinusOne()
+				expected = g.NewElement()
+
+				for i := range elements {
+					expected.Add(elements[i].Copy().Multiply(scalars[i]))
+				}
+			}
+
+			r, err := g.MultiScalarMult(scalars, elements)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !r.Equal(expected) {
+				t.Fatalf("unexpected result for %d terms", n)
+			}
+		}
+
+		if _, err := g.MultiScalarMult(randomScalars(g, 2), []*ecc.Element{g.Base()}); !errors.Is(
+			err, internal.ErrParamLengthMismatch) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if _, err := g.MultiScalarMult([]*ecc.Scalar{nil}, []*ecc.Element{g.Base()}); !errors.Is(
+			err, internal.ErrParamNilScalar) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if _, err := g.MultiScalarMult(randomScalars(g, 1), []*ecc.Element{nil}); !errors.Is(
+			err, internal.ErrParamNilPoint) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	})
+}
+
+func TestBulletproofs_InnerProduct(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		context := []byte("context")
+
+		for _, n := range []int{1, 2, 8} {
+			gens, err := bulletproofs.NewGenerators(g, n, []byte("label"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			a, b := randomScalars(g, n), randomScalars(g, n)
+
+			p, err := gens.Commit(a, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			c := bulletproofs.InnerProduct(a, b)
+
+			proof, err := bulletproofs.ProveInnerProduct(context, gens, a, b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = bulletproofs.VerifyInnerProduct(context, gens, p, c, proof); err != nil {
+				t.Fatal(err)
+			}
+
+			// Encoding round trip.
+			decoded, err := bulletproofs.DecodeInnerProductProof(g, proof.Encode())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = bulletproofs.VerifyInnerProduct(context, gens, p, c, decoded); err != nil {
+				t.Fatal(err)
+			}
+
+			// Wrong inner product, commitment, and context.
+			if err = bulletproofs.VerifyInnerProduct(context, gens, p, c.Copy().Add(g.NewScalar().One()),
+				proof); err == nil {
+				t.Fatal("expected error on wrong inner product")
+			}
+
+			if err = bulletproofs.VerifyInnerProduct(context, gens, p.Copy().Add(g.Base()), c, proof); err == nil {
+				t.Fatal("expected error on wrong commitment")
+			}
+
+			// A single-element proof reveals the vectors, and has no challenge to bind the context to.
+			if err = bulletproofs.VerifyInnerProduct([]byte("other"), gens, p, c, proof); n > 1 && err == nil {
+				t.Fatal("expected error on wrong context")
+			}
+
+			proof.A.Add(g.NewScalar().One())
+
+			if err = bulletproofs.VerifyInnerProduct(context, gens, p, c, proof); err == nil {
+				t.Fatal("expected error on modified proof")
+			}
+		}
+
+		if _, err := bulletproofs.NewGenerators(g, 3, nil); err == nil {
+			t.Fatal("expected error on invalid length")
+		}
+
+		// Generators are deterministic, and shorter ones are a prefix of longer ones.
+		g4, _ := bulletproofs.NewGenerators(g, 4, []byte("label"))
+		g2, _ := bulletproofs.NewGenerators(g, 2, []byte("label"))
+
+		if !g4.G[1].Equal(g2.G[1]) || !g4.H[1].Equal(g2.H[1]) || !g4.U.Equal(g2.U) || g4.G[0].Equal(g4.H[0]) {
+			t.Fatal("unexpected generators")
+		}
+
+		if _, err := bulletproofs.DecodeInnerProductProof(g, []byte{1}); err == nil {
+			t.Fatal("expected error on invalid encoding")
+		}
+	})
+}
+
+func TestBulletproofs_Range(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		context := []byte("context")
+		params := commitment.Setup(g, []byte("pedersen"))
+
+		for _, test := range []struct {
+			values []uint64
+			n      int
+		}{
+			{n: 8, values: []uint64{0, 1, 85, 255}},
+			{n: 64, values: []uint64{^uint64(0)}},
+		} {
+			n := test.n
+
+			gens, err := bulletproofs.NewGenerators(g, n, []byte("range"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			maxValue := test.values[len(test.values)-1]
+
+			for _, value := range test.values {
+				blinding := g.NewScalar().Random()
+
+				proof, v, err := bulletproofs.ProveRange(context, gens, params, value, blinding)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !params.Verify(v, g.NewScalar().SetUInt64(value), blinding) {
+					t.Fatal("unexpected commitment")
+				}
+
+				if err = bulletproofs.VerifyRange(context, gens, params, v, proof); err != nil {
+					t.Fatal(err)
+				}
+
+				decoded, err := bulletproofs.DecodeRangeProof(g, proof.Encode())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err = bulletproofs.VerifyRange(context, gens, params, v, decoded); err != nil {
+					t.Fatal(err)
+				}
+
+				if err = bulletproofs.VerifyRange([]byte("other"), gens, params, v, proof); err == nil {
+					t.Fatal("expected error on wrong context")
+				}
+
+				// Shifting the committed value by 2^n takes it out of the range.
+				shift := g.NewScalar().SetUInt64(maxValue).Add(g.NewScalar().One())
+				if err = bulletproofs.VerifyRange(context, gens, params, v.Copy().Add(g.Base().Multiply(shift)),
+					proof); err == nil {
+					t.Fatal("expected error on wrong commitment")
+				}
+			}
+
+			if n < 64 {
+				if _, _, err = bulletproofs.ProveRange(context, gens, params, maxValue+1, g.NewScalar()); err == nil {
+					t.Fatal("expected error on out of range value")
+				}
+			}
+		}
+
+		gens, _ := bulletproofs.NewGenerators(g, 128, nil)
+		if _, _, err := bulletproofs.ProveRange(context, gens, params, 1, g.NewScalar()); err == nil {
+			t.Fatal("expected error on too long generators")
+		}
+
+		gens, _ = bulletproofs.NewGenerators(g, 8, nil)
+
+		proof, v, err := bulletproofs.ProveRange(context, gens, params, 1, g.NewScalar().Random())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proof.THat.Add(g.NewScalar().One())
+
+		if err = bulletproofs.VerifyRange(context, gens, params, v, proof); err == nil {
+			t.Fatal("expected error on tampered proof")
+		}
+
+		if err = bulletproofs.VerifyRange(context, gens, params, v, nil); err == nil {
+			t.Fatal("expected error on nil proof")
+		}
+
+		if _, err = bulletproofs.DecodeRangeProof(g, proof.Encode()[1:]); err == nil {
+			t.Fatal("expected error on truncated proof")
+		}
+	})
+}
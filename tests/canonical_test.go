@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	eccEncoding "github.com/bytemare/ecc/encoding"
+)
+
+type canonicalTest struct {
+	Zeta    *ecc.Element      `json:"zeta"`
+	Alpha   *ecc.Scalar       `json:"alpha"`
+	Extra   map[string]any    `json:"extra"`
+	Message string            `json:"message"`
+	Labels  map[string]string `json:"labels"`
+	Big     json.Number       `json:"big"`
+	Group   ecc.Group         `json:"group"`
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		e := group.group.Base().Multiply(s)
+		in := canonicalTest{
+			Zeta:    e,
+			Alpha:   s,
+			Extra:   map[string]any{"b": 2, "a": []int{3, 1}},
+			Message: "<a & b>",
+			Labels:  map[string]string{"y": "1", "x": "2"},
+			Big:     "123456789012345678901234567890",
+			Group:   group.group,
+		}
+
+		expected := fmt.Sprintf(`{"alpha":%q,"big":123456789012345678901234567890,`+
+			`"extra":{"a":[3,1],"b":2},"group":%d,"labels":{"x":"2","y":"1"},"message":"<a & b>","zeta":%q}`,
+			s.Hex(), group.group, e.Hex())
+
+		out, err := eccEncoding.CanonicalJSON(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(out) != expected {
+			t.Fatalf("unexpected canonical encoding\n\twant: %s\n\tgot : %s", expected, out)
+		}
+
+		// Stability over re-encoding
+		var generic map[string]any
+
+		d := json.NewDecoder(bytes.NewReader(out))
+		d.UseNumber()
+
+		if err = d.Decode(&generic); err != nil {
+			t.Fatal(err)
+		}
+
+		out2, err := eccEncoding.CanonicalJSON(generic)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(out2) != string(out) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// The output must decode back into the original types.
+		out3 := canonicalTest{Alpha: group.group.NewScalar(), Zeta: group.group.NewElement()}
+		if err = json.Unmarshal(out, &out3); err != nil {
+			t.Fatal(err)
+		}
+
+		if !out3.Alpha.Equal(s) || !out3.Zeta.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestCanonicalJSON_Fails(t *testing.T) {
+	if _, err := eccEncoding.CanonicalJSON(make(chan int)); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/commitment"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestPedersen(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		params := commitment.Setup(group.group, []byte("label"))
+
+		if params.H.IsIdentity() || params.H.Equal(params.G) || params.Group() != group.group {
+			t.Fatal("unexpected parameters")
+		}
+
+		// The setup is deterministic, and depends on the label.
+		if !commitment.Setup(group.group, []byte("label")).H.Equal(params.H) ||
+			commitment.Setup(group.group, []byte("other")).H.Equal(params.H) {
+			t.Fatal("unexpected setup")
+		}
+
+		v1, r1 := group.group.NewScalar().Random(), group.group.NewScalar().Random()
+		v2, r2 := group.group.NewScalar().Random(), group.group.NewScalar().Random()
+
+		c1, err := params.Commit(v1, r1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c2, err := params.Commit(v2, r2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !params.Verify(c1, v1, r1) || params.Verify(c1, v2, r1) || params.Verify(c1, v1, r2) {
+			t.Fatal("unexpected opening")
+		}
+
+		// Homomorphic addition.
+		sum, err := commitment.Add(c1, c2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !params.Verify(sum, v1.Copy().Add(v2), r1.Copy().Add(r2)) {
+			t.Fatal("unexpected sum opening")
+		}
+
+		if !params.Verify(c1, v1, r1) {
+			t.Fatal("the input was modified")
+		}
+	})
+}
+
+func TestPedersen_OpeningProof(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		params := commitment.Setup(g, []byte("label"))
+		context := []byte("context")
+		value, blinding := g.NewScalar().Random(), g.NewScalar().Random()
+
+		c, err := params.Commit(value, blinding)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		proof, err := params.ProveOpening(context, c, value, blinding)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = params.VerifyOpening(context, c, proof); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = params.VerifyOpening([]byte("other"), c, proof); err == nil {
+			t.Fatal("expected error on wrong context")
+		}
+
+		if err = params.VerifyOpening(context, c.Copy().Add(g.Base()), proof); err == nil {
+			t.Fatal("expected error on wrong commitment")
+		}
+
+		if err = commitment.Setup(g, []byte("other")).VerifyOpening(context, c, proof); err == nil {
+			t.Fatal("expected error on wrong parameters")
+		}
+
+		if _, err = params.ProveOpening(context, c, value, g.NewScalar().Random()); err == nil {
+			t.Fatal("expected error on wrong opening")
+		}
+
+		if _, err = params.ProveOpening(context, nil, value, blinding); !errors.Is(err, internal.ErrParamNilPoint) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if err = params.VerifyOpening(context, c, nil); err == nil {
+			t.Fatal("expected error on nil proof")
+		}
+	})
+}
+
+func TestPedersen_Fails(t *testing.T) {
+	params := commitment.Setup(ecc.P256Sha256, nil)
+	s := ecc.P256Sha256.NewScalar().Random()
+
+	if _, err := params.Commit(nil, s); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := params.Commit(s, ecc.P384Sha384.NewScalar()); !errors.Is(err, internal.ErrCastScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if params.Verify(nil, s, s) || params.Verify(ecc.P384Sha384.Base(), s, s) || params.Verify(params.G, nil, s) {
+		t.Fatal("unexpected opening")
+	}
+
+	if _, err := commitment.Add(); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := commitment.Add(params.G, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := commitment.Add(params.G, ecc.P384Sha384.Base()); !errors.Is(err, internal.ErrCastElement) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
+
+func TestNonceCommitment(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		context := []byte("session")
+		d, e := g.Base().Multiply(g.NewScalar().Random()), g.Base().Multiply(g.NewScalar().Random())
+
+		c, opening, err := commitment.CommitNonces(context, d, e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !commitment.VerifyNonces(context, c, opening, d, e) {
+			t.Fatal("expected valid opening")
+		}
+
+		again, err := commitment.CommitNoncesWithOpening(context, opening, d, e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(c, again) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// The commitment hides the nonces with a fresh opening.
+		if other, _, _ := commitment.CommitNonces(context, d, e); bytes.Equal(c, other) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		// The commitment is bound to the context, the opening, and the nonces and their order.
+		otherOpening := bytes.Clone(opening)
+		otherOpening[0] ^= 1
+
+		if commitment.VerifyNonces([]byte("other"), c, opening, d, e) ||
+			commitment.VerifyNonces(context, c, otherOpening, d, e) ||
+			commitment.VerifyNonces(context, c, opening, e, d) ||
+			commitment.VerifyNonces(context, c, opening, d) ||
+			commitment.VerifyNonces(context, c, opening[1:], d, e) ||
+			commitment.VerifyNonces(context, c, opening) {
+			t.Fatal("unexpected opening")
+		}
+	})
+}
+
+func TestNonceCommitment_Fails(t *testing.T) {
+	d := ecc.P256Sha256.Base()
+
+	if _, _, err := commitment.CommitNonces(nil); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, _, err := commitment.CommitNonces(nil, d, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, _, err := commitment.CommitNonces(nil, d, ecc.P384Sha384.Base()); !errors.Is(err, internal.ErrCastElement) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	_, _, err := commitment.CommitNonces(nil, d, ecc.P256Sha256.NewElement())
+	if !errors.Is(err, internal.ErrIdentity) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := commitment.CommitNoncesWithOpening(nil, []byte("short"), d); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build dudect
+
+package ecc_test
+
+import (
+	"crypto/rand"
+	"flag"
+	"math"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bytemare/ecc"
+)
+
+// The constant-time tests follow dudect (Reparaz, Balasch, and Verbauwhede, "Dude, is my code constant time?"): each
+// operation is timed on inputs of two classes, a fixed one and random ones, interleaved at random, and Welch's t-test
+// checks whether the two distributions of timings differ, on all measurements and on the measurements cropped at
+// several percentiles to remove the noise of the upper tail. They take a while, and only run with the dudect tag:
+//
+//	go test -tags dudect -run ConstantTime ./tests
+var (
+	ctMeasurements = flag.Int("dudect.measurements", 20000, "number of timings of each operation")
+	ctThreshold    = flag.Float64("dudect.threshold", 10, "t statistic above which the timings leak their class")
+)
+
+// ctPercentiles are the percentiles at which the measurements are cropped, in addition to the test on all of them.
+var ctPercentiles = []float64{0.5, 0.75, 0.9, 0.95, 0.99}
+
+// ctCase is an operation timed by the constant-time tests. Its setup returns a function setting the inputs of the i-th
+// of n measurements to those of the fixed or random class, and the operation on the inputs of the i-th measurement.
+type ctCase struct {
+	setup func(g ecc.Group, n int) (prepare func(i int, fixed bool), op func(i int))
+	name  string
+	// vartime lists the groups whose backends don't implement the operation in constant time: the secp256k1 elements
+	// and circl's P-384 elements hold their coordinates in math/big, even to be compared, and so do the scalar
+	// additions and multiplications of the NIST and secp256k1 groups, so that the harness keeps checking the others
+	// without failing on known leaks.
+	vartime []ecc.Group
+}
+
+var ctCases = []ctCase{
+	{
+		name: "Element.Decode",
+		setup: func(g ecc.Group, n int) (func(int, bool), func(int)) {
+			fixed := g.Base().Encode()
+			encodings, elements := make([][]byte, n), newElements(g, n)
+
+			return func(i int, f bool) {
+					encodings[i] = fixed
+					if !f {
+						encodings[i] = g.Base().Multiply(g.NewScalar().Random()).Encode()
+					}
+				}, func(i int) {
+					_ = elements[i].Decode(encodings[i])
+				}
+		},
+		vartime: []ecc.Group{ecc.Secp256k1Sha256},
+	},
+	{
+		name: "Element.Equal",
+		setup: func(g ecc.Group, n int) (func(int, bool), func(int)) {
+			e := g.Base().Multiply(g.NewScalar().Random())
+			elements := newElements(g, n)
+
+			return func(i int, f bool) {
+					elements[i].Set(e)
+					if !f {
+						elements[i].Multiply(g.NewScalar().Random())
+					}
+				}, func(i int) {
+					_ = e.Equal(elements[i])
+				}
+		},
+		vartime: []ecc.Group{ecc.Secp256k1Sha256, ctCirclP384},
+	},
+	{
+		name: "Scalar.Equal",
+		setup: func(g ecc.Group, n int) (func(int, bool), func(int)) {
+			s := g.NewScalar().Random()
+			scalars := newScalars(g, n)
+
+			// Both classes draw a random scalar, so that they allocate alike.
+			return func(i int, f bool) {
+					r := g.NewScalar().Random()
+					if f {
+						r = s
+					}
+
+					scalars[i].Set(r)
+				}, func(i int) {
+					_ = s.Equal(scalars[i])
+				}
+		},
+	},
+	{
+		name: "Element.Multiply",
+		setup: func(g ecc.Group, n int) (func(int, bool), func(int)) {
+			e := g.Base().Multiply(g.NewScalar().Random())
+			elements, scalars := newElements(g, n), newScalars(g, n)
+
+			return func(i int, f bool) {
+					elements[i].Set(e)
+					scalars[i].One()
+					if !f {
+						scalars[i].Random()
+					}
+				}, func(i int) {
+					elements[i].Multiply(scalars[i])
+				}
+		},
+		vartime: []ecc.Group{ecc.Secp256k1Sha256, ctCirclP384},
+	},
+	{
+		name: "Scalar.Add",
+		setup: func(g ecc.Group, n int) (func(int, bool), func(int)) {
+			s := g.NewScalar().Random()
+			scalars := newScalars(g, n)
+
+			return func(i int, f bool) {
+					scalars[i].One()
+					if !f {
+						scalars[i].Random()
+					}
+				}, func(i int) {
+					scalars[i].Add(s)
+				}
+		},
+		vartime: []ecc.Group{ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256},
+	},
+	{
+		name: "Scalar.Multiply",
+		setup: func(g ecc.Group, n int) (func(int, bool), func(int)) {
+			s := g.NewScalar().Random()
+			scalars := newScalars(g, n)
+
+			return func(i int, f bool) {
+					scalars[i].One()
+					if !f {
+						scalars[i].Random()
+					}
+				}, func(i int) {
+					scalars[i].Multiply(s)
+				}
+		},
+		vartime: []ecc.Group{ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256},
+	},
+	{
+		name: "Scalar.Invert",
+		setup: func(g ecc.Group, n int) (func(int, bool), func(int)) {
+			scalars := newScalars(g, n)
+
+			return func(i int, f bool) {
+					scalars[i].One()
+					if !f {
+						scalars[i].Random()
+					}
+				}, func(i int) {
+					scalars[i].Invert()
+				}
+		},
+		vartime: []ecc.Group{ctCirclP384},
+	},
+}
+
+// ctCirclP384 is P-384 if its backend is circl's, whose arithmetic is not constant time, and an invalid group that
+// never matches otherwise.
+var ctCirclP384 = func() ecc.Group {
+	if circlBackend {
+		return ecc.P384Sha384
+	}
+
+	return 0
+}()
+
+func newElements(g ecc.Group, n int) []*ecc.Element {
+	elements := make([]*ecc.Element, n)
+	for i := range elements {
+		elements[i] = g.NewElement()
+	}
+
+	return elements
+}
+
+func newScalars(g ecc.Group, n int) []*ecc.Scalar {
+	scalars := make([]*ecc.Scalar, n)
+	for i := range scalars {
+		scalars[i] = g.NewScalar()
+	}
+
+	return scalars
+}
+
+// ctMeasure times the n executions of the operation of c, and returns the timings of the fixed and random classes.
+func ctMeasure(g ecc.Group, c ctCase, n int) (fixed, random []float64) {
+	prepare, op := c.setup(g, n)
+
+	classes := make([]byte, n)
+	if _, err := rand.Read(classes); err != nil {
+		panic(err)
+	}
+
+	for i, class := range classes {
+		prepare(i, class&1 == 0)
+	}
+
+	timings := make([]time.Duration, n)
+
+	// Warm up the caches and the branch predictors, then measure without the collector interfering.
+	for i := range min(n, 100) {
+		op(i)
+		prepare(i, classes[i]&1 == 0)
+	}
+
+	runtime.GC()
+
+	for i := range n {
+		start := time.Now()
+		op(i)
+		timings[i] = time.Since(start)
+	}
+
+	for i, class := range classes {
+		if class&1 == 0 {
+			fixed = append(fixed, float64(timings[i]))
+		} else {
+			random = append(random, float64(timings[i]))
+		}
+	}
+
+	return fixed, random
+}
+
+// welch returns Welch's t statistic of the two samples.
+func welch(a, b []float64) float64 {
+	meanVar := func(x []float64) (mean, variance float64) {
+		for _, v := range x {
+			mean += v
+		}
+
+		mean /= float64(len(x))
+
+		for _, v := range x {
+			variance += (v - mean) * (v - mean)
+		}
+
+		return mean, variance / float64(len(x)-1)
+	}
+
+	ma, va := meanVar(a)
+	mb, vb := meanVar(b)
+
+	return (ma - mb) / math.Sqrt(va/float64(len(a))+vb/float64(len(b)))
+}
+
+// crop returns the values of x that are not above the threshold.
+func crop(x []float64, threshold float64) []float64 {
+	return slices.DeleteFunc(slices.Clone(x), func(v float64) bool { return v > threshold })
+}
+
+// ctStatistic returns the largest absolute t statistic of the timings, over all of them and their crops.
+func ctStatistic(fixed, random []float64) float64 {
+	all := slices.Sorted(slices.Values(append(slices.Clone(fixed), random...)))
+	t := math.Abs(welch(fixed, random))
+
+	for _, p := range ctPercentiles {
+		threshold := all[int(p*float64(len(all)-1))]
+
+		f, r := crop(fixed, threshold), crop(random, threshold)
+		if len(f) < 2 || len(r) < 2 {
+			continue
+		}
+
+		t = max(t, math.Abs(welch(f, r)))
+	}
+
+	return t
+}
+
+func TestConstantTime(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		for _, c := range ctCases {
+			t.Run(group.name+"/"+c.name, func(t *testing.T) {
+				if slices.Contains(c.vartime, g) {
+					t.Skipf("%s is not constant time in %s", c.name, g)
+				}
+
+				s := ctStatistic(ctMeasure(g, c, *ctMeasurements))
+				t.Logf("|t| = %.2f", s)
+
+				if s > *ctThreshold {
+					t.Errorf("timings leak the class of the inputs: |t| = %.2f > %.2f", s, *ctThreshold)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	eccEncoding "github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestContainer_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+		e := g.Base().Multiply(s)
+
+		// Group
+		enc, err := eccEncoding.Marshal(g)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(enc, []byte{eccEncoding.ContainerVersion, byte(g), 1}) {
+			t.Fatalf("unexpected encoding %v", enc)
+		}
+
+		var decodedGroup ecc.Group
+		if err = eccEncoding.Unmarshal(enc, &decodedGroup); err != nil {
+			t.Fatal(err)
+		}
+
+		if decodedGroup != g {
+			t.Fatal(errWrongGroup)
+		}
+
+		// Scalar
+		if enc, err = eccEncoding.Marshal(s); err != nil {
+			t.Fatal(err)
+		}
+
+		decodedScalar := new(ecc.Scalar)
+		if err = eccEncoding.Unmarshal(enc, decodedScalar); err != nil {
+			t.Fatal(err)
+		}
+
+		if decodedScalar.Group() != g || !decodedScalar.Equal(s) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Element
+		if enc, err = eccEncoding.Marshal(e); err != nil {
+			t.Fatal(err)
+		}
+
+		decodedElement := new(ecc.Element)
+		if err = eccEncoding.Unmarshal(enc, decodedElement); err != nil {
+			t.Fatal(err)
+		}
+
+		if decodedElement.Group() != g || !decodedElement.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Type mismatch
+		if err = eccEncoding.Unmarshal(enc, decodedScalar); !errors.Is(err, internal.ErrUnsupportedType) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	})
+}
+
+func TestContainer_Fails(t *testing.T) {
+	var (
+		nilScalar  *ecc.Scalar
+		nilElement *ecc.Element
+		nilGroup   *ecc.Group
+	)
+
+	marshalErrors := []struct {
+		v   any
+		err error
+	}{
+		{ecc.Group(2), internal.ErrInvalidGroup},
+		{nilScalar, internal.ErrParamNilScalar},
+		{nilElement, internal.ErrParamNilPoint},
+		{"string", internal.ErrUnsupportedType},
+	}
+
+	for _, test := range marshalErrors {
+		if _, err := eccEncoding.Marshal(test.v); !errors.Is(err, test.err) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	valid, err := eccEncoding.Marshal(ecc.P256Sha256.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unmarshalErrors := []struct {
+		v    any
+		err  error
+		data []byte
+	}{
+		{new(ecc.Element), internal.ErrDecodingInvalidLength, valid[:2]},
+		{
+			new(ecc.Element),
+			internal.ErrDecodingInvalidVersion,
+			append([]byte{eccEncoding.ContainerVersion + 1}, valid[1:]...),
+		},
+		{new(ecc.Element), internal.ErrDecodingInvalidVersion, append([]byte{0}, valid[1:]...)},
+		{
+			new(ecc.Element),
+			internal.ErrInvalidGroup,
+			append([]byte{eccEncoding.ContainerVersion, 2}, valid[2:]...),
+		},
+		{new(string), internal.ErrUnsupportedType, valid},
+		{nilElement, internal.ErrUnsupportedType, valid},
+		{nilGroup, internal.ErrUnsupportedType, []byte{eccEncoding.ContainerVersion, 1, 1}},
+		{new(ecc.Group), internal.ErrDecodingInvalidLength, []byte{eccEncoding.ContainerVersion, 1, 1, 0}},
+	}
+
+	for _, test := range unmarshalErrors {
+		if err = eccEncoding.Unmarshal(test.data, test.v); !errors.Is(err, test.err) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	// Bad payloads
+	if err = eccEncoding.Unmarshal(valid[:len(valid)-1], new(ecc.Element)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err = eccEncoding.Unmarshal([]byte{eccEncoding.ContainerVersion, 3, 2, 1}, new(ecc.Scalar)); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/cpace"
+	"github.com/bytemare/ecc/internal"
+)
+
+var cpaceGroups = []ecc.Group{ecc.Ristretto255Sha512, ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512}
+
+func cpaceExchange(t *testing.T, s *cpace.Suite, roleA, roleB cpace.Role, prsA, prsB []byte) ([]byte, []byte) {
+	t.Helper()
+
+	ci, sid := []byte("channel"), []byte("session")
+
+	a, shareA, err := s.Start(roleA, prsA, ci, sid, []byte("ADa"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, shareB, err := s.Start(roleB, prsB, ci, sid, []byte("ADb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iskA, err := a.Finish(shareB, []byte("ADb"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	iskB, err := b.Finish(shareA, []byte("ADa"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return iskA, iskB
+}
+
+func TestCPace(t *testing.T) {
+	for _, g := range cpaceGroups {
+		t.Run(g.String(), func(t *testing.T) {
+			s, err := cpace.New(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			prs := []byte("password")
+
+			iskA, iskB := cpaceExchange(t, s, cpace.Initiator, cpace.Responder, prs, prs)
+			if !bytes.Equal(iskA, iskB) || len(iskA) != g.HashFunc().Size() {
+				t.Fatal(errExpectedEquality)
+			}
+
+			iskA, iskB = cpaceExchange(t, s, cpace.Symmetric, cpace.Symmetric, prs, prs)
+			if !bytes.Equal(iskA, iskB) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			// Different passwords, or mismatching roles, yield different keys.
+			iskA, iskB = cpaceExchange(t, s, cpace.Initiator, cpace.Responder, prs, []byte("wrong"))
+			if bytes.Equal(iskA, iskB) {
+				t.Fatal(errUnExpectedEquality)
+			}
+
+			iskA, iskB = cpaceExchange(t, s, cpace.Initiator, cpace.Initiator, prs, prs)
+			if bytes.Equal(iskA, iskB) {
+				t.Fatal(errUnExpectedEquality)
+			}
+
+			// The generator is deterministic, and depends on all of its inputs.
+			gen := s.Generator(prs, []byte("ci"), []byte("sid"))
+			if !gen.Equal(s.Generator(prs, []byte("ci"), []byte("sid"))) ||
+				gen.Equal(s.Generator(prs, []byte("ci"), []byte("other"))) ||
+				gen.Equal(s.Generator([]byte("other"), []byte("ci"), []byte("sid"))) {
+				t.Fatal("unexpected generator")
+			}
+
+			// Invalid shares are rejected.
+			st, _, err := s.Start(cpace.Initiator, prs, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, share := range [][]byte{nil, {0}, g.NewElement().Encode(), make([]byte, g.ElementLength())} {
+				if _, err = st.Finish(share, nil); err == nil {
+					t.Fatal("expected error on invalid share")
+				}
+			}
+
+			if _, _, err = s.Start(cpace.Symmetric+1, prs, nil, nil, nil); err == nil {
+				t.Fatal("expected error on invalid role")
+			}
+		})
+	}
+
+	if _, err := cpace.New(ecc.Secp256k1Sha256); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func TestDecodeElements(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		elements := make([]*ecc.Element, 37)
+		encodings := make([][]byte, len(elements))
+
+		for i := range elements {
+			elements[i] = g.Base().Multiply(g.NewScalar().Random())
+			encodings[i] = elements[i].Encode()
+		}
+
+		for _, opts := range []*ecc.DecodeOptions{nil, {}, {Workers: 1}, {Workers: 4}, {Workers: 100}} {
+			res, err := g.DecodeElements(encodings, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !ecc.EqualSlices(res, elements) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		if res, err := g.DecodeElements(nil, &ecc.DecodeOptions{}); err != nil || len(res) != 0 {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestDecodeElements_FirstError(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		encodings := make([][]byte, 20)
+
+		for i := range encodings {
+			encodings[i] = g.Base().Multiply(g.NewScalar().Random()).Encode()
+		}
+
+		// The first invalid encoding is reported, whichever worker finds an error first.
+		encodings[17] = nil
+		encodings[6] = nil
+		encodings[11] = nil
+
+		for _, opts := range []*ecc.DecodeOptions{nil, {Workers: 3}, {Workers: 20}} {
+			res, err := g.DecodeElements(encodings, opts)
+			if res != nil || err == nil || !strings.Contains(err.Error(), "encoding 6:") {
+				t.Fatalf("unexpected error %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestDSTBuilder(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		dst, err := ecc.NewDST("app").Version(3).Suite(g).Build()
+		if err != nil || !bytes.Equal(dst, g.MakeDST("app", 3)) {
+			t.Fatalf("unexpected DST %q: %v", dst, err)
+		}
+
+		dst, err = ecc.NewDST("app").Version(3).Suite(g).Context([]byte("session")).Build()
+		if err != nil || string(dst) != string(g.MakeDST("app", 3))+"-CTX-session" {
+			t.Fatalf("unexpected DST %q: %v", dst, err)
+		}
+
+		// Long tags are reduced as in RFC 9380, and hash the same as their long form.
+		context := []byte(strings.Repeat("c", 300))
+		long := append(append(g.MakeDST("app", 3), "-CTX-"...), context...)
+
+		dst, err = ecc.NewDST("app").Version(3).Suite(g).Context(context).Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		h := g.HashFunc().New()
+		h.Write([]byte("H2C-OVERSIZE-DST-"))
+		h.Write(long)
+
+		if !bytes.Equal(dst, h.Sum(nil)) {
+			t.Fatalf("unexpected DST %x", dst)
+		}
+
+		if !g.HashToScalar([]byte("input"), dst).Equal(g.HashToScalar([]byte("input"), long)) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestDSTBuilder_Errors(t *testing.T) {
+	for _, test := range []struct {
+		builder *ecc.DSTBuilder
+		err     error
+	}{
+		{ecc.NewDST("app"), nil},
+		{ecc.NewDST("").Suite(ecc.P256Sha256), nil},
+		{ecc.NewDST("app\n").Suite(ecc.P256Sha256), nil},
+		{ecc.NewDST("app\xff").Suite(ecc.P256Sha256), nil},
+		{ecc.NewDST("app").Suite(2), internal.ErrInvalidGroup},
+		{ecc.NewDST("app").Suite(255), internal.ErrInvalidGroup},
+	} {
+		dst, err := test.builder.Build()
+		if dst != nil || err == nil || test.err != nil && !errors.Is(err, test.err) {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+}
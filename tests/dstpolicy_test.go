@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestDSTPolicy(t *testing.T) {
+	input := []byte("input")
+	short, long := []byte("short"), []byte("a long enough domain separation tag")
+	strict := ecc.WithDSTPolicy(16, ecc.DSTReject)
+	lenient := ecc.WithDSTPolicy(16, ecc.DSTAccept)
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		type hash func(input, dst []byte, policy *ecc.DSTPolicy) (any, error)
+
+		for name, f := range map[string]hash{
+			"TryHashToScalar": func(i, d []byte, p *ecc.DSTPolicy) (any, error) {
+				s, err := g.TryHashToScalar(i, d, p)
+				if err == nil && !s.Equal(g.HashToScalar(i, d)) {
+					t.Fatal(errExpectedEquality)
+				}
+
+				return s, err
+			},
+			"TryHashToGroup": func(i, d []byte, p *ecc.DSTPolicy) (any, error) {
+				e, err := g.TryHashToGroup(i, d, p)
+				if err == nil && !e.Equal(g.HashToGroup(i, d)) {
+					t.Fatal(errExpectedEquality)
+				}
+
+				return e, err
+			},
+			"TryEncodeToGroup": func(i, d []byte, p *ecc.DSTPolicy) (any, error) {
+				e, err := g.TryEncodeToGroup(i, d, p)
+				if err == nil && !e.Equal(g.EncodeToGroup(i, d)) {
+					t.Fatal(errExpectedEquality)
+				}
+
+				return e, err
+			},
+		} {
+			// Long DSTs are accepted, and short ones only with a lenient or nil policy.
+			for _, p := range []*ecc.DSTPolicy{nil, lenient, strict} {
+				if _, err := f(input, long, p); err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+			}
+
+			for _, p := range []*ecc.DSTPolicy{nil, lenient, {}} {
+				if _, err := f(input, short, p); err != nil {
+					t.Fatalf("%s: %v", name, err)
+				}
+			}
+
+			if _, err := f(input, short, strict); !errors.Is(err, ecc.ErrInvalidDST) {
+				t.Fatalf("%s: expected error on short DST, got %v", name, err)
+			}
+
+			// Empty DSTs are always rejected.
+			for _, p := range []*ecc.DSTPolicy{nil, lenient, strict, ecc.WithDSTPolicy(0, ecc.DSTReject)} {
+				if _, err := f(input, nil, p); !errors.Is(err, ecc.ErrInvalidDST) {
+					t.Fatalf("%s: expected error on empty DST, got %v", name, err)
+				}
+			}
+		}
+	})
+
+	if _, err := ecc.Group(0).TryHashToGroup(input, long, nil); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("expected invalid group error, got %v", err)
+	}
+
+	if err := (*ecc.DSTPolicy)(nil).Check(nil); !errors.Is(err, ecc.ErrInvalidDST) {
+		t.Fatal(err)
+	}
+
+	// The panics of the other hashing functions match ErrInvalidDST too.
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ecc.ErrInvalidDST) || err.Error() != errZeroLenDST.Error() {
+			t.Fatalf("unexpected panic: %v", err)
+		}
+	}()
+
+	ecc.Ristretto255Sha512.HashToGroup(input, nil)
+}
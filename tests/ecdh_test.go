@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	cryptoecdh "crypto/ecdh"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ecdh"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestECDH_Agreement(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		skA, pkA := ecdh.GenerateKeyPair(group.group)
+		skB, pkB := ecdh.GenerateKeyPair(group.group)
+
+		s1, err := ecdh.DH(skA, pkB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s2, err := ecdh.DH(skB, pkA)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(s1, s2) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		k1, err := ecdh.DeriveKey(skA, pkB, []byte("info"), 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		k2, err := ecdh.DeriveKey(skB, pkA, []byte("info"), 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(k1, k2) || len(k1) != 32 {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// The key is bound to the info and the public keys.
+		k3, err := ecdh.DeriveKey(skA, pkB, []byte("other"), 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		skC, pkC := ecdh.GenerateKeyPair(group.group)
+
+		k4, err := ecdh.DeriveKey(skC, pkB, []byte("info"), 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bytes.Equal(k1, k3) || bytes.Equal(k1, k4) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		if _, err = ecdh.DeriveKey(skC, pkC, nil, 255*group.group.HashFunc().Size()+1); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+// TestECDH_CryptoECDH checks that the shared secrets are those of crypto/ecdh for the NIST curves.
+func TestECDH_CryptoECDH(t *testing.T) {
+	for g, curve := range map[ecc.Group]cryptoecdh.Curve{
+		ecc.P256Sha256: cryptoecdh.P256(),
+		ecc.P384Sha384: cryptoecdh.P384(),
+		ecc.P521Sha512: cryptoecdh.P521(),
+	} {
+		sk, _ := ecdh.GenerateKeyPair(g)
+
+		peer, err := curve.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		peerElement, err := encoding.ParseSEC1(g, peer.PublicKey().Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		secret, err := ecdh.DH(sk, peerElement)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		private, err := curve.NewPrivateKey(sk.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := private.ECDH(peer.PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(secret, expected) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestECDH_Fails(t *testing.T) {
+	sk, pk := ecdh.GenerateKeyPair(ecc.P256Sha256)
+
+	if _, err := ecdh.DH(nil, pk); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := ecdh.DH(ecc.P256Sha256.NewScalar(), pk); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := ecdh.DH(sk, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := ecdh.DH(sk, ecc.P384Sha384.Base()); !errors.Is(err, internal.ErrCastScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := ecdh.DeriveKey(sk, nil, nil, 32); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		if _, err := ecdh.DH(s, group.group.NewElement()); !errors.Is(err, internal.ErrIdentity) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	})
+}
+
+// TestECDH_SmallOrder checks that the low order points of Edwards25519 are rejected.
+func TestECDH_SmallOrder(t *testing.T) {
+	s := ecc.Edwards25519Sha512.NewScalar().Random()
+
+	for _, encoded := range []string{
+		// (0, -1), of order 2.
+		"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		// Points of order 4 and 8.
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05",
+		"c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a",
+	} {
+		e := ecc.Edwards25519Sha512.NewElement()
+		if err := e.Decode(decodeHex(t, encoded)); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := ecdh.DH(s, e); !errors.Is(err, internal.ErrIdentity) {
+			t.Fatalf("unexpected error %q for %s", err, encoded)
+		}
+
+		// A small order component alone is not rejected, as the product is not small.
+		mixed := e.Copy().Add(ecc.Edwards25519Sha512.Base())
+		if _, err := ecdh.DH(s, mixed); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
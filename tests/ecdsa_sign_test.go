@@ -0,0 +1,262 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto"
+	stdecdsa "crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	dcrecdsa "github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ecdsa"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+var ecdsaGroups = []ecc.Group{ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Secp256k1Sha256}
+
+// TestECDSA_RFC6979 uses the P-256 and SHA-256 test vectors of RFC 6979 section A.2.5.
+func TestECDSA_RFC6979(t *testing.T) {
+	secret := ecc.P256Sha256.NewScalar()
+	if err := secret.DecodeHex("c9afa9d845ba75166b5c215767b1d6934e50c3db36e89b127b8a622b120f6721"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		message, r, s string
+	}{
+		{
+			message: "sample",
+			r:       "efd48b2aacb6a8fd1140dd9cd45e81d69d2c877b56aaf991c34d0ea84eaf3716",
+			s:       "f7cb1c942d657c41d436c7a1b6e29f65f3e900dbb9aff4064dc4ab2f843acda8",
+		},
+		{
+			message: "test",
+			r:       "f1abb023518351cd71d881567b1ea663ed3efcf6c5132b354f28d3b0b7d38367",
+			s:       "019f4113742a2b14bd25926b49c649155f267e60d3814b4c0cc84250e46f0083",
+		},
+	} {
+		digest := sha256.Sum256([]byte(test.message))
+
+		sig, err := ecdsa.Sign(secret, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sig.R.Hex() != test.r || sig.S.Hex() != test.s {
+			t.Fatalf("unexpected signature %s %s", sig.R.Hex(), sig.S.Hex())
+		}
+
+		if err = ecdsa.Verify(ecc.P256Sha256.Base().Multiply(secret), digest[:], sig); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestECDSA_CryptoECDSA checks that the signatures are verified by crypto/ecdsa, and conversely.
+func TestECDSA_CryptoECDSA(t *testing.T) {
+	for _, g := range ecdsaGroups[:3] {
+		secret := g.NewScalar().Random()
+		public := g.Base().Multiply(secret)
+		digest := sha512.Sum512([]byte("message"))
+
+		sig, err := ecdsa.Sign(secret, crypto.SHA512, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		der, err := sig.DER()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := encoding.ToECDSAPrivateKey(secret, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !stdecdsa.VerifyASN1(&key.PublicKey, digest[:], der) {
+			t.Fatal("crypto/ecdsa verification failed")
+		}
+
+		std, err := stdecdsa.SignASN1(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		parsed, err := ecdsa.ParseDER(g, std)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = ecdsa.Verify(public, digest[:], parsed); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestECDSA_Decred checks that low-S signatures are those of decred's (btcec's) secp256k1 ECDSA, which uses RFC 6979
+// with SHA-256.
+func TestECDSA_Decred(t *testing.T) {
+	for range 20 {
+		secret := ecc.Secp256k1Sha256.NewScalar().Random()
+		digest := sha256.Sum256(secret.Encode())
+
+		sig, err := ecdsa.Sign(secret, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		der, err := sig.Normalize().DER()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !sig.IsLowS() {
+			t.Fatal("expected low-S")
+		}
+
+		expected := dcrecdsa.Sign(secp256k1.PrivKeyFromBytes(secret.Encode()), digest[:]).Serialize()
+		if !bytes.Equal(der, expected) {
+			t.Fatalf("unexpected signature %x", der)
+		}
+	}
+}
+
+func TestECDSA_Encodings(t *testing.T) {
+	for _, g := range ecdsaGroups {
+		secret := g.NewScalar().Random()
+		public := g.Base().Multiply(secret)
+		digest := sha256.Sum256([]byte("message"))
+
+		sig, err := ecdsa.Sign(secret, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The high-S form is valid too.
+		high := &ecdsa.Signature{R: sig.R.Copy(), S: sig.S.Copy()}
+		if high.IsLowS() {
+			high.S = g.NewScalar().Subtract(high.S)
+		}
+
+		if high.IsLowS() || !sig.Normalize().IsLowS() {
+			t.Fatal("unexpected low-S")
+		}
+
+		if err = ecdsa.Verify(public, digest[:], high); err != nil {
+			t.Fatal(err)
+		}
+
+		compact := sig.Compact()
+		if len(compact) != 2*g.ScalarLength() {
+			t.Fatalf("unexpected length %d", len(compact))
+		}
+
+		fromCompact, err := ecdsa.ParseCompact(g, compact)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		der, err := sig.DER()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fromDER, err := ecdsa.ParseDER(g, der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, s := range []*ecdsa.Signature{fromCompact, fromDER} {
+			if !s.R.Equal(sig.R) || !s.S.Equal(sig.S) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			if err = ecdsa.Verify(public, digest[:], s); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if err = ecdsa.Verify(public, []byte("wrong digest"), sig); err == nil {
+			t.Fatal("expected error")
+		}
+
+		if _, err = ecdsa.ParseDER(g, append(der, 0)); err == nil {
+			t.Fatal("expected error")
+		}
+
+		if _, err = ecdsa.ParseCompact(g, compact[1:]); err == nil {
+			t.Fatal("expected error")
+		}
+
+		if _, err = ecdsa.ParseCompact(g, make([]byte, len(compact))); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+}
+
+func TestECDSA_SignFails(t *testing.T) {
+	digest := sha256.Sum256([]byte("message"))
+	secret := ecc.P256Sha256.NewScalar().Random()
+
+	if _, err := ecdsa.Sign(nil, crypto.SHA256, digest[:]); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	_, err := ecdsa.Sign(ecc.Edwards25519Sha512.NewScalar().Random(), crypto.SHA256, digest[:])
+	if !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = ecdsa.Sign(secret, crypto.SHA512, digest[:]); err == nil {
+		t.Fatal("expected error")
+	}
+
+	sig, err := ecdsa.Sign(secret, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = ecdsa.Verify(nil, digest[:], sig); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if err = ecdsa.Verify(ecc.Ristretto255Sha512.Base(), digest[:], sig); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	public := ecc.P256Sha256.Base().Multiply(secret)
+	for _, s := range []*ecdsa.Signature{
+		nil,
+		{R: sig.R},
+		{R: sig.R, S: ecc.P256Sha256.NewScalar()},
+		{R: sig.R, S: ecc.P384Sha384.NewScalar().Random()},
+	} {
+		if err = ecdsa.Verify(public, digest[:], s); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+
+	if _, err = ecdsa.ParseDER(ecc.Edwards25519Sha512, nil); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = ecdsa.ParseCompact(ecc.Edwards25519Sha512, nil); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
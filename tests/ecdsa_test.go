@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/bytemare/ecc"
+	eccEncoding "github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+var ecdsaCurves = map[ecc.Group]elliptic.Curve{
+	ecc.P256Sha256:      elliptic.P256(),
+	ecc.P384Sha384:      elliptic.P384(),
+	ecc.P521Sha512:      elliptic.P521(),
+	ecc.Secp256k1Sha256: secp256k1.S256(),
+}
+
+func TestECDSA_RoundTrip(t *testing.T) {
+	message := sha256.Sum256([]byte("message"))
+
+	for g, curve := range ecdsaCurves {
+		s := g.NewScalar().Random()
+
+		key, err := eccEncoding.ToECDSAPrivateKey(s, curve)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if key.Curve != curve {
+			t.Fatalf("unexpected curve %v", key.Curve.Params().Name)
+		}
+
+		if !curve.IsOnCurve(key.X, key.Y) {
+			t.Fatal("expected public key on curve")
+		}
+
+		// Sign with the standard library, and verify with the public key from the element.
+		sig, err := ecdsa.SignASN1(rand.Reader, key, message[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pub, err := eccEncoding.ToECDSAPublicKey(g.Base().Multiply(s), curve)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !ecdsa.VerifyASN1(pub, message[:], sig) {
+			t.Fatal("expected valid signature")
+		}
+
+		s2, g2, err := eccEncoding.FromECDSAPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if g2 != g || !s2.Equal(s) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		e, err := eccEncoding.FromECDSAPublicKey(pub)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !e.Equal(g.Base().Multiply(s)) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestECDSA_FromStdlib(t *testing.T) {
+	for g, curve := range ecdsaCurves {
+		if g == ecc.Secp256k1Sha256 {
+			continue
+		}
+
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s, g2, err := eccEncoding.FromECDSAPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if g2 != g || !bytes.Equal(s.Encode(), key.D.FillBytes(make([]byte, g.ScalarLength()))) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestECDSA_Fails(t *testing.T) {
+	// Groups without ECDSA
+	for _, g := range []ecc.Group{ecc.Ristretto255Sha512, ecc.Edwards25519Sha512} {
+		if _, err := eccEncoding.ToECDSAPublicKey(g.Base(), nil); !errors.Is(err, internal.ErrInvalidGroup) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	// secp256k1 requires a matching curve implementation.
+	for _, curve := range []elliptic.Curve{nil, elliptic.P256()} {
+		if _, err := eccEncoding.ToECDSAPublicKey(
+			ecc.Secp256k1Sha256.Base(), curve,
+		); !errors.Is(err, internal.ErrInvalidGroup) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	if _, err := eccEncoding.ToECDSAPublicKey(nil, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ToECDSAPublicKey(ecc.P256Sha256.NewElement(), nil); !errors.Is(err, internal.ErrIdentity) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ToECDSAPrivateKey(ecc.P256Sha256.NewScalar(), nil); !errors.Is(
+		err, internal.ErrParamNilScalar,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	key, err := eccEncoding.ToECDSAPrivateKey(ecc.P256Sha256.NewScalar().Random(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Off-curve public key
+	bad := *key
+	bad.Y = new(big.Int).Add(key.Y, big.NewInt(1))
+
+	if _, err = eccEncoding.FromECDSAPublicKey(&bad.PublicKey); !errors.Is(
+		err, internal.ErrParamInvalidPointEncoding,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Mismatching public key
+	if _, _, err = eccEncoding.FromECDSAPrivateKey(&bad); err == nil {
+		t.Fatal("expected error")
+	}
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bad = *key
+	bad.PublicKey = other.PublicKey
+
+	if _, _, err = eccEncoding.FromECDSAPrivateKey(&bad); !errors.Is(err, internal.ErrParamInvalidPointEncoding) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Out of range private key
+	bad = *key
+	bad.D = new(big.Int).SetBytes(ecc.P256Sha256.Order())
+	bad.X, bad.Y = nil, nil
+
+	if _, _, err = eccEncoding.FromECDSAPrivateKey(&bad); err == nil {
+		t.Fatal("expected error")
+	}
+
+	bad.D = new(big.Int).Neg(key.D)
+	if _, _, err = eccEncoding.FromECDSAPrivateKey(&bad); !errors.Is(err, internal.ErrParamScalarInvalidEncoding) ||
+		errors.Is(err, ecc.ErrNonCanonical) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, _, err = eccEncoding.FromECDSAPrivateKey(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = eccEncoding.FromECDSAPublicKey(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Unknown curve
+	unknown := &ecdsa.PublicKey{Curve: elliptic.P224(), X: big.NewInt(1), Y: big.NewInt(1)}
+	if _, err = eccEncoding.FromECDSAPublicKey(unknown); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
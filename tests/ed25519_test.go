@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+	eccEncoding "github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestEd25519_PrivateKey(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := eccEncoding.ScalarFromEd25519PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e := ecc.Edwards25519Sha512.Base().Multiply(s)
+
+	if !bytes.Equal(e.Encode(), pub) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	pub2, err := eccEncoding.ToEd25519PublicKey(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !pub.Equal(pub2) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	e2, err := eccEncoding.FromEd25519PublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !e2.Equal(e) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The public key must be usable to verify signatures of the original key.
+	message := []byte("message")
+	if !ed25519.Verify(pub2, message, ed25519.Sign(priv, message)) {
+		t.Fatal("expected valid signature")
+	}
+}
+
+func TestEd25519_Fails(t *testing.T) {
+	if _, err := eccEncoding.ToEd25519PublicKey(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ToEd25519PublicKey(ecc.Ristretto255Sha512.Base()); !errors.Is(
+		err, internal.ErrInvalidGroup,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ToEd25519PublicKey(ecc.Edwards25519Sha512.NewElement()); !errors.Is(
+		err, internal.ErrIdentity,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.FromEd25519PublicKey(make([]byte, 31)); !errors.Is(
+		err, internal.ErrParamInvalidPointEncoding,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.FromEd25519PublicKey(debug.BadElementEncoding(ecc.Edwards25519Sha512)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err := eccEncoding.ScalarFromEd25519PrivateKey(make([]byte, 32)); !errors.Is(
+		err, internal.ErrParamScalarLength,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Private key with a mismatching public key
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	priv[ed25519.PrivateKeySize-1] ^= 1
+
+	if _, err = eccEncoding.ScalarFromEd25519PrivateKey(priv); !errors.Is(
+		err, internal.ErrParamInvalidPointEncoding,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
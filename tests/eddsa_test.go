@@ -0,0 +1,246 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha512"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/eddsa"
+	"github.com/bytemare/ecc/internal"
+)
+
+// TestEdDSA_RFC8032 uses the first Ed25519 test vector of RFC 8032 section 7.1.
+func TestEdDSA_RFC8032(t *testing.T) {
+	key, err := eddsa.NewKeyFromSeed(decodeHex(t, "9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if key.Public().Hex() != "d75a980182b10ab7d54bfed3c964073a0ee172f3daa62325af021a68f707511a" {
+		t.Fatalf("unexpected public key %s", key.Public().Hex())
+	}
+
+	expected := decodeHex(t, "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e06522490155"+
+		"5fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b")
+
+	sig := key.Sign(nil)
+	if !bytes.Equal(sig, expected) {
+		t.Fatalf("unexpected signature %x", sig)
+	}
+
+	if err = eddsa.Verify(key.Public(), nil, sig); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestEdDSA_CryptoEd25519 checks Ed25519, Ed25519ctx, and Ed25519ph against crypto/ed25519.
+func TestEdDSA_CryptoEd25519(t *testing.T) {
+	seed := ecc.Edwards25519Sha512.NewScalar().Random().Encode()
+	message := []byte("message")
+	digest := sha512.Sum512(message)
+
+	key, err := eddsa.NewKeyFromSeed(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	std := ed25519.NewKeyFromSeed(seed)
+	if !bytes.Equal(key.Public().Encode(), std.Public().(ed25519.PublicKey)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	for _, test := range []struct {
+		opts    *eddsa.Options
+		std     *ed25519.Options
+		message []byte
+	}{
+		{nil, &ed25519.Options{}, message},
+		{&eddsa.Options{Context: "context"}, &ed25519.Options{Context: "context"}, message},
+		{&eddsa.Options{Hash: crypto.SHA512}, &ed25519.Options{Hash: crypto.SHA512}, digest[:]},
+		{
+			&eddsa.Options{Hash: crypto.SHA512, Context: "context"},
+			&ed25519.Options{Hash: crypto.SHA512, Context: "context"},
+			digest[:],
+		},
+	} {
+		sig, err := key.SignWithOptions(test.message, test.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected, err := std.Sign(nil, test.message, test.std)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(sig, expected) {
+			t.Fatalf("unexpected signature %x", sig)
+		}
+
+		if err = eddsa.VerifyWithOptions(key.Public(), test.message, sig, test.opts); err != nil {
+			t.Fatal(err)
+		}
+
+		// Signatures are bound to the variant.
+		other := &eddsa.Options{Hash: test.std.Hash, Context: "other"}
+		if err = eddsa.VerifyWithOptions(key.Public(), test.message, sig, other); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+}
+
+// TestEdDSA_VerifyBatch checks batch verification of crypto/ed25519 signatures.
+func TestEdDSA_VerifyBatch(t *testing.T) {
+	publics := make([]*ecc.Element, 32)
+	messages := make([][]byte, len(publics))
+	signatures := make([][]byte, len(publics))
+
+	for i := range publics {
+		public, private, err := ed25519.GenerateKey(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		publics[i] = ecc.Edwards25519Sha512.NewElement()
+		if err = publics[i].Decode(public); err != nil {
+			t.Fatal(err)
+		}
+
+		messages[i] = []byte{byte(i)}
+		signatures[i] = ed25519.Sign(private, messages[i])
+	}
+
+	if err := eddsa.VerifyBatch(publics, messages, signatures); err != nil {
+		t.Fatal(err)
+	}
+
+	messages[0], messages[1] = messages[1], messages[0]
+	if err := eddsa.VerifyBatch(publics, messages, signatures); err == nil {
+		t.Fatal("expected error on swapped messages")
+	}
+
+	messages[0], messages[1] = messages[1], messages[0]
+	signatures[5] = slices.Clone(signatures[5])
+	signatures[5][0] ^= 1
+
+	if err := eddsa.VerifyBatch(publics, messages, signatures); err == nil {
+		t.Fatal("expected error on tampered signature")
+	}
+
+	if err := eddsa.VerifyBatch(publics, messages[1:], signatures); !errors.Is(err, internal.ErrParamLengthMismatch) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	publics[0] = ecc.P256Sha256.Base()
+	if err := eddsa.VerifyBatch(publics, messages, signatures); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
+
+// TestEdDSA_Scalar checks that keys set from a scalar produce signatures verified by crypto/ed25519.
+func TestEdDSA_Scalar(t *testing.T) {
+	scalar := ecc.Edwards25519Sha512.NewScalar().Random()
+	message := []byte("message")
+
+	key, err := eddsa.NewKeyFromScalar(scalar)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !key.Scalar().Equal(scalar) || !key.Public().Equal(ecc.Edwards25519Sha512.Base().Multiply(scalar)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	sig := key.Sign(message)
+	if !bytes.Equal(sig, key.Sign(message)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if !ed25519.Verify(key.Public().Encode(), message, sig) {
+		t.Fatal("crypto/ed25519 verification failed")
+	}
+
+	if err = eddsa.Verify(key.Public(), message, sig); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = eddsa.Verify(key.Public(), []byte("other"), sig); err == nil {
+		t.Fatal("expected error")
+	}
+
+	tampered := slices.Clone(sig)
+	tampered[0] ^= 1
+
+	if err = eddsa.Verify(key.Public(), message, tampered); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// The scalar half of the signature must be canonical.
+	tampered = slices.Clone(sig)
+	tampered[63] |= 0xf0
+
+	if err = eddsa.Verify(key.Public(), message, tampered); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestEdDSA_Fails(t *testing.T) {
+	key, err := eddsa.NewKeyFromScalar(ecc.Edwards25519Sha512.NewScalar().Random())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig := key.Sign(nil)
+
+	if _, err = eddsa.NewKeyFromSeed(make([]byte, 31)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err = eddsa.NewKeyFromScalar(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = eddsa.NewKeyFromScalar(ecc.P256Sha256.NewScalar().Random()); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	for _, opts := range []*eddsa.Options{
+		{Hash: crypto.SHA256},
+		{Context: strings.Repeat("a", 256)},
+		{Hash: crypto.SHA512},
+	} {
+		if _, err = key.SignWithOptions([]byte("not a digest"), opts); err == nil {
+			t.Fatal("expected error")
+		}
+
+		if err = eddsa.VerifyWithOptions(key.Public(), []byte("not a digest"), sig, opts); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+
+	if err = eddsa.Verify(nil, nil, sig); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if err = eddsa.Verify(ecc.Ristretto255Sha512.Base(), nil, sig); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if err = eddsa.Verify(key.Public(), nil, sig[1:]); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,261 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	// Encodings of Edwards25519 torsion points of order 2 and 8.
+	edwards25519Order2 = "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"
+	edwards25519Order8 = "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a"
+)
+
+func decodeEdwards25519Point(t *testing.T, h string) *edwards25519.Point {
+	b, err := hex.DecodeString(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := new(edwards25519.Point).SetBytes(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return p
+}
+
+func TestEdwards25519_EscapeHatch(t *testing.T) {
+	for _, g := range []ecc.Group{ecc.Edwards25519Sha512, ecc.Ristretto255Sha512} {
+		s := g.NewScalar().Random()
+		e := g.Base().Multiply(s)
+
+		es := s.Edwards25519Scalar()
+		if es == nil {
+			t.Fatal("unexpected nil scalar")
+		}
+
+		s2 := g.NewScalar()
+		if err := s2.SetEdwards25519Scalar(es); err != nil {
+			t.Fatal(err)
+		}
+
+		if !s2.Equal(s) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Use the backend's variable-time multi-scalar multiplication, and convert back.
+		r := g.NewScalar().Random()
+		p := new(edwards25519.Point).VarTimeMultiScalarMult(
+			[]*edwards25519.Scalar{es, r.Edwards25519Scalar()},
+			[]*edwards25519.Point{g.Base().Edwards25519Point(), e.Edwards25519Point()},
+		)
+
+		result := g.NewElement()
+		if err := result.SetEdwards25519Point(p); err != nil {
+			t.Fatal(err)
+		}
+
+		expected := g.Base().Multiply(s).Add(e.Copy().Multiply(r))
+		if !result.Equal(expected) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// The returned point is a copy.
+		p = e.Edwards25519Point()
+		p.Add(p, p)
+
+		if e.Edwards25519Point().Equal(p) == 1 {
+			t.Fatal(errUnExpectedEquality)
+		}
+	}
+
+	// Edwards25519 points are the same as the backend's.
+	s := ecc.Edwards25519Sha512.NewScalar().Random()
+	p := new(edwards25519.Point).ScalarBaseMult(s.Edwards25519Scalar())
+
+	if p.Equal(ecc.Edwards25519Sha512.Base().Multiply(s).Edwards25519Point()) != 1 {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestEdwards25519_Ristretto255Torsion(t *testing.T) {
+	e := ecc.Ristretto255Sha512.Base().Multiply(ecc.Ristretto255Sha512.NewScalar().Random())
+	p := e.Edwards25519Point()
+
+	// Adding a 2-torsion point yields another representative of the same element.
+	q := new(edwards25519.Point).Add(p, decodeEdwards25519Point(t, edwards25519Order2))
+	if q.Equal(p) == 1 {
+		t.Fatal(errUnExpectedEquality)
+	}
+
+	e2 := ecc.Ristretto255Sha512.NewElement()
+	if err := e2.SetEdwards25519Point(q); err != nil {
+		t.Fatal(err)
+	}
+
+	if !e2.Equal(e) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Adding an 8-torsion point leaves the subgroup of even elements.
+	q.Add(p, decodeEdwards25519Point(t, edwards25519Order8))
+
+	if err := e2.SetEdwards25519Point(q); !errors.Is(err, internal.ErrParamInvalidPointEncoding) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
+
+func TestEdwards25519_EscapeHatch_Fails(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		switch group.group {
+		case ecc.Edwards25519Sha512, ecc.Ristretto255Sha512:
+			if err := group.group.NewElement().SetEdwards25519Point(nil); !errors.Is(
+				err, internal.ErrParamNilPoint,
+			) {
+				t.Fatalf("unexpected error %q", err)
+			}
+
+			if err := group.group.NewScalar().SetEdwards25519Scalar(nil); !errors.Is(
+				err, internal.ErrParamNilScalar,
+			) {
+				t.Fatalf("unexpected error %q", err)
+			}
+		default:
+			if group.group.Base().Edwards25519Point() != nil {
+				t.Fatal("expected nil point")
+			}
+
+			if group.group.NewScalar().Random().Edwards25519Scalar() != nil {
+				t.Fatal("expected nil scalar")
+			}
+
+			if err := group.group.NewElement().SetEdwards25519Point(
+				edwards25519.NewGeneratorPoint(),
+			); !errors.Is(err, internal.ErrInvalidGroup) {
+				t.Fatalf("unexpected error %q", err)
+			}
+
+			if err := group.group.NewScalar().SetEdwards25519Scalar(
+				edwards25519.NewScalar(),
+			); !errors.Is(err, internal.ErrInvalidGroup) {
+				t.Fatalf("unexpected error %q", err)
+			}
+		}
+	})
+}
+
+func TestEdwards25519_MontgomeryU(t *testing.T) {
+	g := ecc.Edwards25519Sha512
+
+	// The Curve25519 base point u = 9 maps to the Edwards25519 base point, which has a positive x.
+	u := make([]byte, 32)
+	u[0] = 9
+
+	base, err := g.ElementFromMontgomeryU(u, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !base.Equal(g.Base()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	negBase, err := g.ElementFromMontgomeryU(u, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !negBase.Equal(g.Base().Negate()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	for range 10 {
+		e := g.Base().Multiply(g.NewScalar().Random())
+		u = e.ToMontgomeryU()
+
+		pos, err := g.ElementFromMontgomeryU(u, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		neg, err := g.ElementFromMontgomeryU(u, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !pos.Equal(e) && !neg.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if !neg.Equal(pos.Copy().Negate()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// The most significant bit is ignored.
+		u[31] |= 0x80
+
+		masked, err := g.ElementFromMontgomeryU(u, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !masked.Equal(pos) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestEdwards25519_MontgomeryU_Fails(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		if group.group == ecc.Edwards25519Sha512 {
+			return
+		}
+
+		if group.group.Base().ToMontgomeryU() != nil {
+			t.Fatal("expected nil u-coordinate")
+		}
+
+		if _, err := group.group.ElementFromMontgomeryU(make([]byte, 32), false); !errors.Is(
+			err, internal.ErrInvalidGroup,
+		) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	})
+
+	g := ecc.Edwards25519Sha512
+
+	if _, err := g.ElementFromMontgomeryU(make([]byte, 31), false); !errors.Is(
+		err, internal.ErrParamInvalidPointEncoding,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// u = -1 has no Edwards25519 equivalent.
+	minusOne, _ := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	if _, err := g.ElementFromMontgomeryU(minusOne, false); !errors.Is(err, internal.ErrParamInvalidPointEncoding) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// u = 2 is on the twist, not on Curve25519.
+	u := make([]byte, 32)
+	u[0] = 2
+
+	if _, err := g.ElementFromMontgomeryU(u, false); err == nil {
+		t.Fatal("expected error")
+	}
+}
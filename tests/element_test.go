@@ -9,9 +9,12 @@
 package ecc_test
 
 import (
+	"bytes"
 	"encoding/hex"
 	"errors"
 	"log"
+	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/bytemare/ecc"
@@ -80,6 +83,51 @@ func TestElement_Set(t *testing.T) {
 	})
 }
 
+func TestElement_CacheEncoding(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+		e := g.Base().Multiply(s).CacheEncoding()
+		plain := g.Base().Multiply(s)
+
+		check := func() {
+			if !bytes.Equal(e.Encode(), plain.Encode()) || e.Hex() != plain.Hex() {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		check()
+
+		// The returned encodings are copies.
+		e.Encode()[0] ^= 0xff
+		check()
+
+		// The cache follows mutations.
+		for _, mutate := range []func(*ecc.Element){
+			func(x *ecc.Element) { x.Add(g.Base()) },
+			func(x *ecc.Element) { x.Double() },
+			func(x *ecc.Element) { x.Negate() },
+			func(x *ecc.Element) { x.Multiply(s) },
+			func(x *ecc.Element) { x.Identity() },
+			func(x *ecc.Element) { x.Base() },
+			func(x *ecc.Element) { x.Set(g.Base().Multiply(s)) },
+			func(x *ecc.Element) { x.Multiply(nil) },
+			func(x *ecc.Element) { _ = x.Decode(g.Base().Encode()) },
+			func(x *ecc.Element) { _ = x.UnmarshalBinary(g.Base().Double().Encode()) },
+		} {
+			mutate(e)
+			mutate(plain)
+			check()
+		}
+
+		if e.Copy().Add(g.Base()).Equal(e) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		check()
+	})
+}
+
 func TestElement_WrongInput(t *testing.T) {
 	exec := func(f func(*ecc.Element) *ecc.Element, arg *ecc.Element) func() {
 		return func() {
@@ -216,6 +264,9 @@ func TestElement_Decode_Bad(t *testing.T) {
 			errMessage = "invalid Ristretto encoding"
 		case ecc.P256Sha256:
 			errMessage = "invalid P256 element encoding"
+			if !nistecAssembly {
+				errMessage = "invalid P256Element encoding"
+			}
 		case ecc.P384Sha384:
 			errMessage = "invalid P384Element encoding"
 		case ecc.P521Sha512:
@@ -263,6 +314,95 @@ func TestElement_Decode_Bad(t *testing.T) {
 	})
 }
 
+func TestElement_Decode_NonCanonical(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		bad := debug.NonCanonicalElement(g)
+
+		if err := g.NewElement().Decode(bad); err == nil {
+			t.Fatal("expected error on non-canonical encoding")
+		}
+
+		// Reducing the field element modulo p yields a valid encoding.
+		p := fieldOrder(g)
+		reduced := slices.Clone(bad)
+
+		switch g {
+		case ecc.Ristretto255Sha512, ecc.Edwards25519Sha512:
+			slices.Reverse(reduced)
+			new(big.Int).Sub(new(big.Int).SetBytes(reduced), p).FillBytes(reduced)
+			slices.Reverse(reduced)
+		default:
+			new(big.Int).Sub(new(big.Int).SetBytes(reduced[1:]), p).FillBytes(reduced[1:])
+		}
+
+		if err := g.NewElement().Decode(reduced); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	// The sign bit of the Edwards25519 points with x = 0, here y = -1, must not be set.
+	y := new(big.Int).Sub(fieldOrder(ecc.Edwards25519Sha512), big.NewInt(1))
+	encoding := y.FillBytes(make([]byte, 32))
+	slices.Reverse(encoding)
+	encoding[31] |= 0x80
+
+	if err := ecc.Edwards25519Sha512.NewElement().Decode(encoding); !errors.Is(err, ecc.ErrNonCanonical) {
+		t.Fatalf("expected non-canonical error, got %v", err)
+	}
+}
+
+// fieldOrder returns the order of the field of the group, whose encoding is little-endian for the 25519 groups.
+func fieldOrder(g ecc.Group) *big.Int {
+	p := g.FieldOrder()
+	if g == ecc.Ristretto255Sha512 || g == ecc.Edwards25519Sha512 {
+		slices.Reverse(p)
+	}
+
+	return new(big.Int).SetBytes(p)
+}
+
+func TestElement_AffineCoordinates(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		if g.NewElement().AffineCoordinates() != nil {
+			t.Fatal("expected no coordinates for the identity")
+		}
+
+		p := e.AffineCoordinates()
+		if g == ecc.Ristretto255Sha512 {
+			if p != nil {
+				t.Fatal("expected no coordinates for Ristretto255")
+			}
+
+			return
+		}
+
+		// The coordinates have the length of the field, and the x-coordinate is in the encoding, except in Edwards25519
+		// whose encoding is y.
+		size := len(g.FieldOrder())
+		if len(p.X) != size || len(p.Y) != size {
+			t.Fatal("unexpected coordinates length")
+		}
+
+		if g != ecc.Edwards25519Sha512 && !bytes.Equal(p.X, e.XCoordinate()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if g == ecc.Edwards25519Sha512 {
+			y := slices.Clone(p.Y)
+			slices.Reverse(y)
+			y[31] |= p.X[31] & 1 << 7
+
+			if !bytes.Equal(y, e.Encode()) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+	})
+}
+
 func TestElement_XCoordinate(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		baseX := hex.EncodeToString(group.group.Base().XCoordinate())
@@ -346,6 +486,35 @@ func TestElement_Vectors_Mult(t *testing.T) {
 	})
 }
 
+func TestScalarBaseMult(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		for _, s := range []*ecc.Scalar{
+			g.NewScalar().Random(),
+			g.NewScalar(),
+			g.NewScalar().One(),
+			g.NewScalar().MinusOne(),
+		} {
+			// 2G isn't the base point, so that its multiplication doesn't use the fixed-base algorithms.
+			expected := g.Base().Double().Multiply(s)
+
+			if !g.ScalarBaseMult(s).Double().Equal(expected) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			// The base point is detected by value, whatever the operations that set the receiver.
+			if !g.Base().Double().Subtract(g.Base()).Multiply(s).Double().Equal(expected) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		if !g.ScalarBaseMult(nil).IsIdentity() {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
 func TestElement_Arithmetic(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		elementTestEqual(t, group.group)
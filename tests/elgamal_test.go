@@ -0,0 +1,242 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/elgamal"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestElGamal(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sk, pk := elgamal.KeyGen(group.group)
+		message := group.group.HashToGroup([]byte("message"), []byte("elgamal test dst"))
+
+		ct, err := elgamal.Encrypt(pk, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rerandomized, err := elgamal.ReRandomize(pk, ct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if rerandomized.C1.Equal(ct.C1) || rerandomized.C2.Equal(ct.C2) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		for _, c := range []*elgamal.Ciphertext{ct, rerandomized} {
+			decrypted, err := elgamal.Decrypt(sk, c)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !decrypted.Equal(message) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		// Wrong key.
+		other, _ := elgamal.KeyGen(group.group)
+		if decrypted, _ := elgamal.Decrypt(other, ct); decrypted.Equal(message) {
+			t.Fatal(errUnExpectedEquality)
+		}
+	})
+}
+
+func TestElGamal_Exponential(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sk, pk := elgamal.KeyGen(group.group)
+
+		// A tally of votes.
+		var (
+			sum   *elgamal.Ciphertext
+			total uint64
+		)
+
+		for _, vote := range []uint64{1, 0, 1, 1, 0, 1} {
+			ct, err := elgamal.EncryptExponential(pk, vote)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			total += vote
+
+			if sum == nil {
+				sum = ct
+				continue
+			}
+
+			if sum, err = elgamal.Add(sum, ct); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		m, err := elgamal.DecryptExponential(sk, sum, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if m != total {
+			t.Fatalf("unexpected tally %d", m)
+		}
+
+		for _, message := range []uint64{0, 1, 999, 1000} {
+			ct, _ := elgamal.EncryptExponential(pk, message)
+			if m, err = elgamal.DecryptExponential(sk, ct, 1000); err != nil || m != message {
+				t.Fatalf("unexpected message %d (%v)", m, err)
+			}
+		}
+
+		ct, _ := elgamal.EncryptExponential(pk, 1001)
+		if _, err = elgamal.DecryptExponential(sk, ct, 1000); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+// shamirSplit returns the shares at 1..n of the secret with a random polynomial of degree threshold - 1.
+func shamirSplit(secret *ecc.Scalar, threshold, n int) []*ecc.Scalar {
+	g := secret.Group()
+
+	coefficients := []*ecc.Scalar{secret}
+	for range threshold - 1 {
+		coefficients = append(coefficients, g.NewScalar().Random())
+	}
+
+	shares := make([]*ecc.Scalar, n)
+
+	for i := range shares {
+		x := g.NewScalar().SetUInt64(uint64(i + 1))
+		y := g.NewScalar()
+
+		for j := len(coefficients) - 1; j >= 0; j-- {
+			y.Multiply(x).Add(coefficients[j])
+		}
+
+		shares[i] = y
+	}
+
+	return shares
+}
+
+func TestElGamal_Threshold(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		sk, pk := elgamal.KeyGen(group.group)
+		shares := shamirSplit(sk, 3, 5)
+		message := group.group.Base().Multiply(group.group.NewScalar().Random())
+
+		ct, err := elgamal.Encrypt(pk, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, subset := range [][]int{{1, 2, 3}, {5, 1, 4}, {2, 3, 4, 5}} {
+			decryptionShares := make([]*elgamal.DecryptionShare, 0, len(subset))
+
+			for _, id := range subset {
+				share, err := elgamal.PartialDecrypt(uint64(id), shares[id-1], ct)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				decryptionShares = append(decryptionShares, share)
+			}
+
+			decrypted, err := elgamal.Combine(ct, decryptionShares)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !decrypted.Equal(message) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		// Below the threshold.
+		s1, _ := elgamal.PartialDecrypt(1, shares[0], ct)
+		s2, _ := elgamal.PartialDecrypt(2, shares[1], ct)
+
+		if decrypted, _ := elgamal.Combine(ct, []*elgamal.DecryptionShare{s1, s2}); decrypted.Equal(message) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		if _, err = elgamal.Combine(ct, []*elgamal.DecryptionShare{s1, s1}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestElGamal_Fails(t *testing.T) {
+	sk, pk := elgamal.KeyGen(ecc.P256Sha256)
+	ct, _ := elgamal.EncryptExponential(pk, 1)
+
+	if _, err := elgamal.Encrypt(nil, pk); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := elgamal.Encrypt(pk, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := elgamal.Encrypt(pk, ecc.P384Sha384.Base()); !errors.Is(err, internal.ErrCastElement) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	_, err := elgamal.EncryptExponential(ecc.P256Sha256.NewElement(), 1)
+	if !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := elgamal.Decrypt(nil, ct); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := elgamal.Decrypt(sk, &elgamal.Ciphertext{C1: ct.C1}); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := elgamal.DecryptExponential(sk, nil, 1); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := elgamal.ReRandomize(nil, ct); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	_, pk384 := elgamal.KeyGen(ecc.P384Sha384)
+	if _, err := elgamal.ReRandomize(pk384, ct); !errors.Is(err, internal.ErrCastElement) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := elgamal.Add(nil, ct); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := elgamal.Add(ct, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := elgamal.PartialDecrypt(0, sk, ct); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err := elgamal.PartialDecrypt(1, nil, ct); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := elgamal.Combine(ct, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/elliptic"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+var ellipticCurves = map[ecc.Group]struct {
+	curve elliptic.Curve
+	ecdh  ecdh.Curve
+}{
+	ecc.P256Sha256: {elliptic.P256(), ecdh.P256()},
+	ecc.P384Sha384: {elliptic.P384(), ecdh.P384()},
+	ecc.P521Sha512: {elliptic.P521(), ecdh.P521()},
+}
+
+func TestElement_EllipticPoint(t *testing.T) {
+	for g, c := range ellipticCurves {
+		if g.EllipticCurve() != c.curve {
+			t.Fatal(errExpectedEquality)
+		}
+
+		s := g.NewScalar().Random()
+		e := g.Base().Multiply(s)
+
+		curve, x, y := e.ToEllipticPoint()
+		if curve != c.curve {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Compare against the uncompressed encoding of crypto/ecdh.
+		key, err := c.ecdh.NewPrivateKey(s.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		length := (curve.Params().BitSize + 7) / 8
+		expected := key.PublicKey().Bytes()
+
+		if !bytes.Equal(x.FillBytes(make([]byte, length)), expected[1:1+length]) ||
+			!bytes.Equal(y.FillBytes(make([]byte, length)), expected[1+length:]) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		e2, err := ecc.ElementFromEllipticPoint(curve, x, y)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !e2.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestElement_EllipticPoint_Fails(t *testing.T) {
+	// Non-NIST groups and identity
+	testAllGroups(t, func(group *testGroup) {
+		if _, ok := ellipticCurves[group.group]; !ok {
+			if group.group.EllipticCurve() != nil {
+				t.Fatal("expected nil curve")
+			}
+
+			if c, x, y := group.group.Base().ToEllipticPoint(); c != nil || x != nil || y != nil {
+				t.Fatal("expected nil values")
+			}
+		}
+
+		if c, x, y := group.group.NewElement().ToEllipticPoint(); c != nil || x != nil || y != nil {
+			t.Fatal("expected nil values")
+		}
+	})
+
+	curve, x, y := ecc.P256Sha256.Base().ToEllipticPoint()
+
+	for _, c := range []elliptic.Curve{nil, elliptic.P224()} {
+		if _, err := ecc.ElementFromEllipticPoint(c, x, y); !errors.Is(err, internal.ErrInvalidGroup) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	if _, err := ecc.ElementFromEllipticPoint(curve, nil, y); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := ecc.ElementFromEllipticPoint(curve, x, new(big.Int).Neg(y)); !errors.Is(
+		err, internal.ErrParamInvalidPointEncoding,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := ecc.ElementFromEllipticPoint(curve, x, new(big.Int).Lsh(y, 256)); !errors.Is(
+		err, internal.ErrParamInvalidPointEncoding,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Off-curve point
+	if _, err := ecc.ElementFromEllipticPoint(curve, x, new(big.Int).Add(y, big.NewInt(1))); err == nil {
+		t.Fatal("expected error")
+	}
+}
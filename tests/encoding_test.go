@@ -11,6 +11,7 @@ package ecc_test
 import (
 	"bytes"
 	"encoding"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/bytemare/ecc"
 	eccEncoding "github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
 )
 
 type serde interface {
@@ -27,8 +29,12 @@ type serde interface {
 	Decode(data []byte) error
 	Hex() string
 	DecodeHex(h string) error
+	EncodeBase64() string
+	DecodeBase64(b64 string) error
 	MarshalJSON() ([]byte, error)
 	UnmarshalJSON(data []byte) error
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
 	encoding.BinaryMarshaler
 	encoding.BinaryUnmarshaler
 }
@@ -43,6 +49,8 @@ var encodeTesters = []makeEncodeTest{
 	encodeTest,
 	binaryTest,
 	hexTest,
+	base64Test,
+	textTest,
 	jsonTest,
 }
 
@@ -64,6 +72,18 @@ func hexToDecoder(s serde) byteDecoder {
 	}
 }
 
+func base64ToEncoder(s serde) byteEncoder {
+	return func() ([]byte, error) {
+		return []byte(s.EncodeBase64()), nil
+	}
+}
+
+func base64ToDecoder(s serde) byteDecoder {
+	return func(d []byte) error {
+		return s.DecodeBase64(string(d))
+	}
+}
+
 type encodingTest struct {
 	source, receiver serde
 	sourceEncoder    byteEncoder
@@ -99,6 +119,22 @@ func hexTest(t *encodingTest) *encodingTest {
 	return t
 }
 
+func base64Test(t *encodingTest) *encodingTest {
+	t.sourceEncoder = base64ToEncoder(t.source)
+	t.receiverDecoder = base64ToDecoder(t.receiver)
+	t.receiverEncoder = base64ToEncoder(t.receiver)
+
+	return t
+}
+
+func textTest(t *encodingTest) *encodingTest {
+	t.sourceEncoder = t.source.MarshalText
+	t.receiverDecoder = t.receiver.UnmarshalText
+	t.receiverEncoder = t.receiver.MarshalText
+
+	return t
+}
+
 func jsonTest(t *encodingTest) *encodingTest {
 	t.sourceEncoder = t.source.MarshalJSON
 	t.receiverDecoder = t.receiver.UnmarshalJSON
@@ -205,6 +241,10 @@ func testDecodeEmpty(t *testing.T, s serde) {
 		t.Fatal("expected error on empty string")
 	}
 
+	if err := s.DecodeBase64(""); err == nil {
+		t.Fatal("expected error on empty string")
+	}
+
 	if err := json.Unmarshal(nil, s); err == nil {
 		t.Fatal("expected error")
 	}
@@ -290,3 +330,85 @@ func TestJSONReGetGroup(t *testing.T) {
 		}
 	})
 }
+
+func TestEncodeTagged(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		e := group.group.Base().Multiply(s)
+
+		st := s.EncodeTagged()
+		if st[0] != byte(group.group) || !bytes.Equal(st[1:], s.Encode()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		et := e.EncodeTagged()
+		if et[0] != byte(group.group) || !bytes.Equal(et[1:], e.Encode()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		decodedScalar, err := ecc.DecodeTaggedScalar(st)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decodedElement, err := ecc.DecodeTaggedElement(et)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if decodedScalar.Group() != group.group || !decodedScalar.Equal(s) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if decodedElement.Group() != group.group || !decodedElement.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Bad payload
+		if _, err = ecc.DecodeTaggedScalar(st[:len(st)-1]); err == nil {
+			t.Fatal("expected error")
+		}
+
+		if _, err = ecc.DecodeTaggedElement(et[:len(et)-1]); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+
+	if _, err := ecc.DecodeTaggedScalar(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := ecc.DecodeTaggedElement(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := ecc.DecodeTaggedElement([]byte{2, 1}); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
+
+func TestEncoding_Base64(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		e := group.group.Base().Multiply(s)
+
+		if s.EncodeBase64() != base64.RawURLEncoding.EncodeToString(s.Encode()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if e.EncodeBase64() != base64.RawURLEncoding.EncodeToString(e.Encode()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Padded and standard alphabet inputs are rejected.
+		for _, bad := range []string{
+			base64.URLEncoding.EncodeToString(e.Encode()) + "=",
+			"+/" + e.EncodeBase64()[2:],
+		} {
+			if err := group.group.NewElement().DecodeBase64(bad); err == nil ||
+				!strings.HasPrefix(err.Error(), "element DecodeBase64: illegal base64 data") {
+				t.Fatalf("unexpected error %q", err)
+			}
+		}
+	})
+}
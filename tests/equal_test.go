@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestEqualSlices(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		a := []*ecc.Element{g.Base(), g.NewElement(), g.Base().Multiply(g.NewScalar().Random())}
+		b := []*ecc.Element{a[0].Copy(), a[1].Copy(), a[2].Copy()}
+
+		if !ecc.EqualSlices(a, b) || !ecc.EqualSlices(nil, []*ecc.Element{}) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		for i := range b {
+			c := []*ecc.Element{b[0], b[1], b[2]}
+			c[i] = b[i].Copy().Double().Add(g.Base())
+
+			if ecc.EqualSlices(a, c) {
+				t.Fatal(errUnExpectedEquality)
+			}
+
+			c[i] = nil
+
+			if ecc.EqualSlices(a, c) || ecc.EqualSlices(c, a) {
+				t.Fatal(errUnExpectedEquality)
+			}
+		}
+
+		if ecc.EqualSlices(a, b[:2]) {
+			t.Fatal(errUnExpectedEquality)
+		}
+	})
+}
+
+func TestEqualScalarSlices(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		a := []*ecc.Scalar{g.NewScalar().Random(), g.NewScalar(), g.NewScalar().One()}
+		b := []*ecc.Scalar{a[0].Copy(), a[1].Copy(), a[2].Copy()}
+
+		if !ecc.EqualScalarSlices(a, b) || !ecc.EqualScalarSlices(nil, []*ecc.Scalar{}) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		for i := range b {
+			c := []*ecc.Scalar{b[0], b[1], b[2]}
+			c[i] = b[i].Copy().Add(g.NewScalar().One())
+
+			if ecc.EqualScalarSlices(a, c) {
+				t.Fatal(errUnExpectedEquality)
+			}
+
+			c[i] = nil
+
+			if ecc.EqualScalarSlices(a, c) || ecc.EqualScalarSlices(c, a) {
+				t.Fatal(errUnExpectedEquality)
+			}
+		}
+
+		if ecc.EqualScalarSlices(a, b[:2]) {
+			t.Fatal(errUnExpectedEquality)
+		}
+	})
+}
+
+func TestEqualSlices_WrongGroup(t *testing.T) {
+	a := []*ecc.Element{ecc.P256Sha256.Base()}
+	b := []*ecc.Element{ecc.Ristretto255Sha512.Base()}
+
+	if err := testPanic(errWrongGroup, internal.ErrCastElement, func() { _ = ecc.EqualSlices(a, b) }); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestElement_EqualVarTime(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		decoded := g.NewElement()
+		if err := decoded.Decode(e.Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		// The same elements in different internal representations, e.g. projective coordinates.
+		equal := [][2]*ecc.Element{
+			{e, e.Copy()},
+			{e, decoded},
+			{decoded, e},
+			{e.Copy().Negate(), decoded.Copy().Negate()},
+			{e.Copy().Double(), e.Copy().Add(e)},
+			{e.Copy().Add(g.Base()), g.Base().Add(e)},
+			{g.NewElement(), e.Copy().Subtract(e)},
+		}
+
+		for i, c := range equal {
+			if !c[0].Equal(c[1]) || !c[0].EqualVarTime(c[1]) || !c[1].EqualVarTime(c[0]) {
+				t.Fatalf("%d: %s", i, errExpectedEquality)
+			}
+		}
+
+		different := [][2]*ecc.Element{
+			{e, g.Base()},
+			{e, e.Copy().Negate()},
+			{decoded, e.Copy().Negate()},
+			{e, g.NewElement()},
+			{g.NewElement(), e},
+		}
+
+		for i, c := range different {
+			if c[0].Equal(c[1]) || c[0].EqualVarTime(c[1]) {
+				t.Fatalf("%d: %s", i, errUnExpectedEquality)
+			}
+		}
+
+		if e.Equal(nil) || e.EqualVarTime(nil) {
+			t.Fatal(errUnExpectedEquality)
+		}
+	})
+}
+
+func TestScalar_EqualVarTime(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+
+		// A scalar with leading zero bytes, whose big-endian integer representation is shorter than the encoding.
+		small := g.NewScalar().SetUInt64(1 << 8)
+
+		equal := [][2]*ecc.Scalar{
+			{s, s.Copy()},
+			{s.Copy().Add(s), s.Copy().Multiply(g.NewScalar().SetUInt64(2))},
+			{small, g.NewScalar().SetUInt64(1 << 8)},
+			{g.NewScalar(), s.Copy().Subtract(s)},
+		}
+
+		for i, c := range equal {
+			if !c[0].Equal(c[1]) || !c[0].EqualVarTime(c[1]) || !c[1].EqualVarTime(c[0]) {
+				t.Fatalf("%d: %s", i, errExpectedEquality)
+			}
+		}
+
+		different := [][2]*ecc.Scalar{
+			{s, s.Copy().Add(g.NewScalar().One())},
+			{small, g.NewScalar().SetUInt64(1)},
+			{s, g.NewScalar()},
+		}
+
+		for i, c := range different {
+			if c[0].Equal(c[1]) || c[0].EqualVarTime(c[1]) {
+				t.Fatalf("%d: %s", i, errUnExpectedEquality)
+			}
+		}
+
+		if s.Equal(nil) || s.EqualVarTime(nil) {
+			t.Fatal(errUnExpectedEquality)
+		}
+	})
+}
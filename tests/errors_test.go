@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+)
+
+// expectKinds fails if err doesn't match exactly the expected ones among the error kinds.
+func expectKinds(t *testing.T, name string, err error, expected ...error) {
+	t.Helper()
+
+	kinds := []error{
+		ecc.ErrInvalidEncoding, ecc.ErrNonCanonical, ecc.ErrIdentity, ecc.ErrWrongGroup, ecc.ErrInvalidGroup,
+	}
+
+	if err == nil {
+		t.Errorf("%s: expected an error", name)
+		return
+	}
+
+	for _, kind := range kinds {
+		want := false
+
+		for _, e := range expected {
+			want = want || e == kind
+		}
+
+		if errors.Is(err, kind) != want {
+			t.Errorf("%s: errors.Is(%q, %q) should be %v", name, err, kind, want)
+		}
+	}
+}
+
+func TestErrors_Element(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		identity := g.NewElement().Encode()
+
+		expectKinds(t, "Decode identity", g.NewElement().Decode(identity), ecc.ErrInvalidEncoding, ecc.ErrIdentity)
+		expectKinds(t, "UnmarshalBinary identity", g.NewElement().UnmarshalBinary(identity),
+			ecc.ErrInvalidEncoding, ecc.ErrIdentity)
+		expectKinds(t, "Decode bad", g.NewElement().Decode(debug.BadElementEncoding(g)), ecc.ErrInvalidEncoding)
+
+		// The single zero byte is the SEC 1 encoding of the identity in the NIST groups, and invalid in the others.
+		if g == ecc.P256Sha256 || g == ecc.P384Sha384 || g == ecc.P521Sha512 {
+			expectKinds(t, "Decode SEC 1 identity", g.NewElement().Decode([]byte{0}),
+				ecc.ErrInvalidEncoding, ecc.ErrIdentity)
+		} else {
+			expectKinds(t, "Decode zero byte", g.NewElement().Decode([]byte{0}), ecc.ErrInvalidEncoding)
+		}
+		expectKinds(t, "Decode off curve", g.NewElement().Decode(debug.BadElementOffCurve(g)),
+			ecc.ErrInvalidEncoding)
+		expectKinds(t, "Decode short", g.NewElement().Decode([]byte{1, 2}), ecc.ErrInvalidEncoding)
+		expectKinds(t, "DecodeHex", g.NewElement().DecodeHex("not hex"), ecc.ErrInvalidEncoding)
+		expectKinds(t, "DecodeBase64", g.NewElement().DecodeBase64("#"), ecc.ErrInvalidEncoding)
+		expectKinds(t, "UnmarshalJSON", json.Unmarshal([]byte(`"0102"`), g.NewElement()), ecc.ErrInvalidEncoding)
+
+		_, err := ecc.DecodeTaggedElement(append([]byte{byte(g)}, identity...))
+		expectKinds(t, "DecodeTaggedElement identity", err, ecc.ErrInvalidEncoding, ecc.ErrIdentity)
+
+		_, err = ecc.DecodeTaggedElement([]byte{0, 1})
+		expectKinds(t, "DecodeTaggedElement group", err, ecc.ErrInvalidEncoding, ecc.ErrInvalidGroup)
+
+		_, err = ecc.DecodeTaggedElement(nil)
+		expectKinds(t, "DecodeTaggedElement empty", err, ecc.ErrInvalidEncoding)
+	})
+}
+
+func TestErrors_Scalar(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		high := debug.BadScalarHigh(g)
+
+		expectKinds(t, "Decode high", g.NewScalar().Decode(high), ecc.ErrInvalidEncoding, ecc.ErrNonCanonical)
+		expectKinds(t, "UnmarshalBinary high", g.NewScalar().UnmarshalBinary(high),
+			ecc.ErrInvalidEncoding, ecc.ErrNonCanonical)
+		expectKinds(t, "Decode short", g.NewScalar().Decode([]byte{1, 2}), ecc.ErrInvalidEncoding)
+		expectKinds(t, "Decode empty", g.NewScalar().Decode(nil), ecc.ErrInvalidEncoding)
+
+		j, err := json.Marshal(hex.EncodeToString(high))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectKinds(t, "UnmarshalJSON high", json.Unmarshal(j, g.NewScalar()),
+			ecc.ErrInvalidEncoding, ecc.ErrNonCanonical)
+
+		_, err = ecc.DecodeTaggedScalar(append([]byte{byte(g)}, high...))
+		expectKinds(t, "DecodeTaggedScalar high", err, ecc.ErrInvalidEncoding, ecc.ErrNonCanonical)
+
+		_, err = ecc.DecodeTaggedScalar([]byte{0, 1})
+		expectKinds(t, "DecodeTaggedScalar group", err, ecc.ErrInvalidEncoding, ecc.ErrInvalidGroup)
+	})
+}
+
+func TestErrors_WrongGroup(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		other := ecc.Ristretto255Sha512
+		if g == other {
+			other = ecc.P256Sha256
+		}
+
+		_, err := g.Base().TryAdd(other.Base())
+		expectKinds(t, "TryAdd", err, ecc.ErrWrongGroup)
+
+		_, err = g.Base().TryMultiply(other.NewScalar().One())
+		expectKinds(t, "TryMultiply", err, ecc.ErrWrongGroup)
+
+		_, err = g.NewScalar().TryAdd(other.NewScalar())
+		expectKinds(t, "Scalar TryAdd", err, ecc.ErrWrongGroup)
+	})
+
+	_, err := ecc.GroupFromString("unknown")
+	expectKinds(t, "GroupFromString", err, ecc.ErrInvalidGroup)
+}
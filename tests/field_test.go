@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"math/big"
+	"testing"
+
+	"github.com/bytemare/ecc/internal/field"
+)
+
+func TestField_BatchInv(t *testing.T) {
+	p := field.String2Int("0xffffffff00000001000000000000000000000000ffffffffffffffffffffffff")
+	f := field.NewField(&p)
+
+	for _, n := range []int{0, 1, 2, 17} {
+		x := make([]*big.Int, n)
+		res := make([]*big.Int, n)
+
+		for i := range x {
+			x[i] = f.Random(new(big.Int))
+			res[i] = new(big.Int)
+		}
+
+		f.BatchInv(res, x)
+
+		for i := range x {
+			expected := new(big.Int)
+			f.Inv(expected, x[i])
+
+			if expected.Cmp(res[i]) != 0 {
+				t.Fatalf("unexpected inverse %d of %d", i, n)
+			}
+		}
+
+		// In place.
+		f.BatchInv(x, x)
+
+		for i := range x {
+			if x[i].Cmp(res[i]) != 0 {
+				t.Fatalf("unexpected in-place inverse %d of %d", i, n)
+			}
+		}
+	}
+}
+
+func TestField_Montgomery(t *testing.T) {
+	secp256k1 := field.String2Int("0xfffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141")
+	secp256k1P := field.String2Int("0xfffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f")
+	edwards25519P := field.String2Int("0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffed")
+
+	for _, p := range []*big.Int{
+		elliptic.P256().Params().N,
+		elliptic.P256().Params().P,
+		elliptic.P384().Params().N,
+		elliptic.P521().Params().N,
+		elliptic.P521().Params().P,
+		&secp256k1,
+		&secp256k1P,
+		&edwards25519P,
+	} {
+		f := field.NewField(p)
+
+		mont := field.NewMontgomery(p)
+		if mont == nil {
+			t.Fatalf("no Montgomery arithmetic for %v", p)
+		}
+
+		pMinusOne := f.PMinusOne()
+		pairs := [][2]*big.Int{
+			{big.NewInt(0), big.NewInt(1)},
+			{big.NewInt(1), pMinusOne},
+			{pMinusOne, pMinusOne},
+		}
+
+		for range 256 {
+			pairs = append(pairs, [2]*big.Int{f.Random(new(big.Int)), f.Random(new(big.Int))})
+		}
+
+		for _, x := range pairs {
+			res, expected := new(big.Int), new(big.Int)
+
+			mont.Mul(res, x[0], x[1])
+			expected.Mul(x[0], x[1]).Mod(expected, p)
+
+			if res.Cmp(expected) != 0 {
+				t.Fatalf("unexpected product of %v and %v", x[0], x[1])
+			}
+
+			if x[1].Sign() == 0 {
+				continue
+			}
+
+			mont.Inv(res, x[1])
+			expected.ModInverse(x[1], p)
+
+			if res.Cmp(expected) != 0 {
+				t.Fatalf("unexpected inverse of %v", x[1])
+			}
+
+			enc := make([]byte, f.ByteLen())
+			mont.InvBytes(enc, x[1].FillBytes(make([]byte, f.ByteLen())))
+
+			if !bytes.Equal(enc, expected.FillBytes(make([]byte, f.ByteLen()))) {
+				t.Fatalf("unexpected encoded inverse of %v", x[1])
+			}
+		}
+	}
+
+	if field.NewMontgomery(big.NewInt(10)) != nil {
+		t.Fatal("expected no Montgomery arithmetic for an even modulus")
+	}
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+type formatted interface {
+	fmt.Formatter
+	Encode() []byte
+	Hex() string
+}
+
+var formatNames = map[ecc.Group]string{
+	ecc.Ristretto255Sha512: "ristretto255",
+	ecc.P256Sha256:         "P256",
+	ecc.P384Sha384:         "P384",
+	ecc.P521Sha512:         "P521",
+	ecc.Edwards25519Sha512: "edwards25519",
+	ecc.Secp256k1Sha256:    "secp256k1",
+}
+
+func TestFormat(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		name := formatNames[g]
+
+		for _, v := range []formatted{g.Base().Multiply(g.NewScalar().Random()), g.NewScalar().Random()} {
+			short := fmt.Sprintf("%s:%x…", name, v.Encode()[:8])
+
+			if fmt.Sprintf("%v", v) != short || fmt.Sprintf("%s", v) != short {
+				t.Errorf("unexpected %%v: %v", v)
+			}
+
+			if fmt.Sprintf("%x", v) != v.Hex() || fmt.Sprintf("%X", v) != strings.ToUpper(v.Hex()) {
+				t.Errorf("unexpected %%x: %x", v)
+			}
+
+			if !strings.HasPrefix(fmt.Sprintf("%+v", v), name+":"+v.Hex()) {
+				t.Errorf("unexpected %%+v: %+v", v)
+			}
+		}
+
+		var e, s formatted = (*ecc.Element)(nil), (*ecc.Scalar)(nil)
+		if fmt.Sprintf("%v %x", e, s) != "<nil> <nil>" {
+			t.Errorf("unexpected nil format: %v %x", e, s)
+		}
+
+		if got := fmt.Sprintf("%d", g.Base()); !strings.HasPrefix(got, "%!d(*ecc.Element=") {
+			t.Errorf("unexpected bad verb format: %s", got)
+		}
+	})
+}
+
+func TestFormat_Coordinates(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		e := g.Base().Multiply(g.NewScalar().Random())
+		got := fmt.Sprintf("%+v", e)
+		expected := formatNames[g] + ":" + e.Hex()
+
+		var x, y *big.Int
+
+		switch g {
+		case ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512:
+			_, x, y = e.ToEllipticPoint()
+		case ecc.Secp256k1Sha256:
+			// y is the square root of x^3 + 7 with the parity of the encoding.
+			p := new(big.Int).SetBytes(g.FieldOrder())
+			x = new(big.Int).SetBytes(e.XCoordinate())
+			y = new(big.Int).ModSqrt(new(big.Int).Add(new(big.Int).Exp(x, big.NewInt(3), p), big.NewInt(7)), p)
+
+			if y.Bit(0) != uint(e.Encode()[0]&1) {
+				y.Sub(p, y)
+			}
+		}
+
+		if x != nil {
+			size := len(g.FieldOrder())
+			expected += fmt.Sprintf("{x: %x, y: %x}", x.FillBytes(make([]byte, size)), y.FillBytes(make([]byte, size)))
+		}
+
+		if got != expected {
+			t.Errorf("expected %q, got %q", expected, got)
+		}
+
+		// The identity has no affine coordinates.
+		if got = fmt.Sprintf("%+v", g.NewElement()); got != formatNames[g]+":"+g.NewElement().Hex() {
+			t.Errorf("unexpected %%+v of the identity: %s", got)
+		}
+	})
+}
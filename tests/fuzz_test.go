@@ -13,6 +13,7 @@ import (
 
 	"github.com/bytemare/ecc"
 	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/fuzz"
 	"github.com/bytemare/ecc/internal"
 )
 
@@ -46,36 +47,11 @@ func FuzzGroup(f *testing.F) {
 }
 
 func FuzzScalar(f *testing.F) {
-	f.Fuzz(func(t *testing.T, group byte, input []byte, i uint64) {
-		if panicked, err := hasPanic(func() {
-			g := ecc.Group(group)
-			s := g.NewScalar()
-
-			s.SetUInt64(i)
-			_ = s.Decode(input)
-			_ = s.DecodeHex(string(input))
-			_ = s.UnmarshalJSON(input)
-			_ = s.UnmarshalBinary(input)
-		}); panicked && err.Error() != internal.ErrInvalidGroup.Error() {
-			t.Fatal(err)
-		}
-	})
+	fuzz.Scalar(f)
 }
 
 func FuzzElement(f *testing.F) {
-	f.Fuzz(func(t *testing.T, group byte, input []byte) {
-		if panicked, err := hasPanic(func() {
-			g := ecc.Group(group)
-			s := g.NewScalar()
-
-			_ = s.Decode(input)
-			_ = s.DecodeHex(string(input))
-			_ = s.UnmarshalJSON(input)
-			_ = s.UnmarshalBinary(input)
-		}); panicked && err.Error() != internal.ErrInvalidGroup.Error() {
-			t.Fatal(err)
-		}
-	})
+	fuzz.Element(f)
 }
 
 func FuzzJSONReGetGroup(f *testing.F) {
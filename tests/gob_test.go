@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+type gobTest struct {
+	Scalar  *ecc.Scalar
+	Element *ecc.Element
+	Name    string
+}
+
+func TestGob_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		in := gobTest{
+			Scalar:  s,
+			Element: group.group.Base().Multiply(s),
+			Name:    group.name,
+		}
+
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(in); err != nil {
+			t.Fatal(err)
+		}
+
+		var out gobTest
+		if err := gob.NewDecoder(&buf).Decode(&out); err != nil {
+			t.Fatal(err)
+		}
+
+		if out.Name != in.Name {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if out.Scalar.Group() != group.group || !out.Scalar.Equal(in.Scalar) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if out.Element.Group() != group.group || !out.Element.Equal(in.Element) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestGob_GroupSwitch(t *testing.T) {
+	s := ecc.P256Sha256.NewScalar().Random()
+	e := ecc.P256Sha256.Base().Multiply(s)
+
+	sEnc, err := s.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eEnc, err := e.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Decoding into a receiver of another group replaces its group.
+	s2 := ecc.Ristretto255Sha512.NewScalar()
+	if err = s2.GobDecode(sEnc); err != nil {
+		t.Fatal(err)
+	}
+
+	e2 := ecc.Ristretto255Sha512.NewElement()
+	if err = e2.GobDecode(eEnc); err != nil {
+		t.Fatal(err)
+	}
+
+	if !s2.Equal(s) || !e2.Equal(e) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestGob_Fails(t *testing.T) {
+	s := new(ecc.Scalar)
+	e := new(ecc.Element)
+
+	if err := s.GobDecode(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if err := e.GobDecode(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	for _, g := range []byte{0, 2, byte(ecc.Secp256k1Sha256) + 1} {
+		if err := s.GobDecode([]byte{g, 1}); !errors.Is(err, internal.ErrInvalidGroup) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if err := e.GobDecode([]byte{g, 1}); !errors.Is(err, internal.ErrInvalidGroup) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		if err := s.GobDecode([]byte{byte(group.group), 1}); err == nil {
+			t.Fatal("expected error")
+		}
+
+		if err := e.GobDecode([]byte{byte(group.group), 1}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
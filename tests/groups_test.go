@@ -9,8 +9,12 @@
 package ecc_test
 
 import (
+	"crypto/elliptic"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/big"
+	"slices"
 	"testing"
 
 	"github.com/bytemare/ecc"
@@ -91,6 +95,64 @@ func TestDST(t *testing.T) {
 	})
 }
 
+func TestGroup_DST(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		dst := g.DST("app", 1)
+
+		if string(dst) != string(g.MakeDST("app", 1)) || cap(dst) != len(dst) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// The cached slice is returned again, and MakeDST returns copies of it.
+		if d := g.DST("app", 1); &d[0] != &dst[0] {
+			t.Fatal(errExpectedEquality)
+		}
+
+		c := g.MakeDST("app", 1)
+		c[0] = 'b'
+
+		if string(g.DST("app", 1)) != string(g.MakeDST("app", 1)) || &c[0] == &dst[0] {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if string(g.DST("app", 2)) == string(dst) || string(g.DST("other", 1)) == string(dst) {
+			t.Fatal("unexpected equality")
+		}
+
+		if n := testing.AllocsPerRun(10, func() { _ = g.DST("app", 1) }); n != 0 {
+			t.Errorf("%.0f allocations", n)
+		}
+	})
+
+	if string(ecc.P256Sha256.DST("app", 1)) == string(ecc.P384Sha384.DST("app", 1)) {
+		t.Fatal("unexpected equality")
+	}
+}
+
+func TestGroup_DST_ManyApps(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		// More tags than the cache holds are still correct, and the cache keeps working afterwards.
+		for i := range 1000 {
+			app := fmt.Sprintf("app-%d", i)
+			if string(g.DST(app, 1)) != string(g.MakeDST(app, 1)) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		dst := g.DST("app", 1)
+		if d := g.DST("app", 1); &d[0] != &dst[0] || string(dst) != string(g.MakeDST("app", 1)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if n := testing.AllocsPerRun(10, func() { _ = g.DST("app", 1) }); n != 0 {
+			t.Errorf("%.0f allocations", n)
+		}
+	})
+}
+
 func TestGroup_String(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		res := group.group.String()
@@ -217,3 +279,156 @@ func TestGroup_Order(t *testing.T) {
 		}
 	})
 }
+
+func TestGroup_IANANamedGroup(t *testing.T) {
+	expected := map[ecc.Group]uint16{
+		ecc.Ristretto255Sha512: 0,
+		ecc.P256Sha256:         23,
+		ecc.P384Sha384:         24,
+		ecc.P521Sha512:         25,
+		ecc.Edwards25519Sha512: 29,
+		ecc.Secp256k1Sha256:    0,
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		if group.group.IANANamedGroup() != expected[group.group] {
+			t.Fatalf("unexpected codepoint %d", group.group.IANANamedGroup())
+		}
+	})
+
+	if ecc.Group(2).IANANamedGroup() != 0 {
+		t.Fatal("expected no codepoint")
+	}
+}
+
+func TestGroup_OID(t *testing.T) {
+	expected := map[ecc.Group]string{
+		ecc.Ristretto255Sha512: "",
+		ecc.P256Sha256:         "1.2.840.10045.3.1.7",
+		ecc.P384Sha384:         "1.3.132.0.34",
+		ecc.P521Sha512:         "1.3.132.0.35",
+		ecc.Edwards25519Sha512: "1.3.101.112",
+		ecc.Secp256k1Sha256:    "1.3.132.0.10",
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		oid := group.group.OID()
+		if expected[group.group] == "" {
+			if oid != nil {
+				t.Fatalf("expected nil OID, got %s", oid)
+			}
+
+			return
+		}
+
+		if oid.String() != expected[group.group] {
+			t.Fatalf("unexpected OID %s", oid)
+		}
+
+		// Modifying the returned OID must not affect the group's.
+		oid[0] = 0
+		if group.group.OID().String() != expected[group.group] {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestGroupFromString(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g, err := ecc.GroupFromString(group.group.String())
+		if err != nil || g != group.group {
+			t.Fatal(errWrongGroup)
+		}
+
+		if g, err = ecc.GroupFromString(group.e2c); err != nil || g != group.group {
+			t.Fatal(errWrongGroup)
+		}
+	})
+
+	for name, expected := range map[string]ecc.Group{
+		"ristretto255":       ecc.Ristretto255Sha512,
+		"Ristretto255Sha512": ecc.Ristretto255Sha512,
+		"P-256":              ecc.P256Sha256,
+		"p256":               ecc.P256Sha256,
+		"prime256v1":         ecc.P256Sha256,
+		"P-384":              ecc.P384Sha384,
+		"secp384r1":          ecc.P384Sha384,
+		"P-521":              ecc.P521Sha512,
+		"P521Sha512":         ecc.P521Sha512,
+		"edwards25519":       ecc.Edwards25519Sha512,
+		"Ed25519":            ecc.Edwards25519Sha512,
+		"secp256k1":          ecc.Secp256k1Sha256,
+		"SECP256K1":          ecc.Secp256k1Sha256,
+	} {
+		if g, err := ecc.GroupFromString(name); err != nil || g != expected {
+			t.Fatalf("%s: %v", name, err)
+		}
+	}
+
+	for _, name := range []string{"", "P-255", "decaf448", "ristretto255_XMD:SHA-512_R255MAP_NU_", " p256"} {
+		if _, err := ecc.GroupFromString(name); !errors.Is(err, internal.ErrInvalidGroup) {
+			t.Fatalf("%q: unexpected error %v", name, err)
+		}
+	}
+}
+
+func TestGroupFromSuiteID(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		for _, suite := range []string{group.h2c, group.e2c} {
+			g, err := ecc.GroupFromSuiteID(suite)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if g != group.group {
+				t.Fatal(errWrongGroup)
+			}
+		}
+	})
+
+	for _, suite := range []string{"", "ristretto255_XMD:SHA-512_R255MAP_NU_", "P256_XMD:SHA-256_SSWU_", "decaf448"} {
+		if _, err := ecc.GroupFromSuiteID(suite); !errors.Is(err, internal.ErrInvalidGroup) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+}
+
+func TestGroup_Parameters(t *testing.T) {
+	p25519 := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(19))
+	secp256k1P, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+
+	tests := map[ecc.Group]struct {
+		p        *big.Int
+		cofactor uint64
+		security int
+	}{
+		ecc.Ristretto255Sha512: {p25519, 1, 128},
+		ecc.P256Sha256:         {elliptic.P256().Params().P, 1, 128},
+		ecc.P384Sha384:         {elliptic.P384().Params().P, 1, 192},
+		ecc.P521Sha512:         {elliptic.P521().Params().P, 1, 256},
+		ecc.Edwards25519Sha512: {p25519, 8, 128},
+		ecc.Secp256k1Sha256:    {secp256k1P, 1, 128},
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		test := tests[g]
+
+		p := g.FieldOrder()
+		if len(p) != g.ScalarLength() {
+			t.Fatalf("unexpected length %d", len(p))
+		}
+
+		if g == ecc.Ristretto255Sha512 || g == ecc.Edwards25519Sha512 {
+			slices.Reverse(p)
+		}
+
+		if new(big.Int).SetBytes(p).Cmp(test.p) != 0 {
+			t.Fatalf("unexpected field order %x", p)
+		}
+
+		if g.Cofactor() != test.cofactor || g.SecurityLevel() != test.security {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"crypto/elliptic"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"math/big"
@@ -23,6 +24,7 @@ import (
 	"filippo.io/edwards25519/field"
 
 	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
 	edwards255192 "github.com/bytemare/ecc/internal/edwards25519"
 )
 
@@ -49,6 +51,10 @@ type h2cVector struct {
 		X string `json:"x"`
 		Y string `json:"y"`
 	} `json:"Q1"`
+	Q struct {
+		X string `json:"x"`
+		Y string `json:"y"`
+	} `json:"Q"`
 	Msg string   `json:"msg"`
 	U   []string `json:"u"`
 }
@@ -147,6 +153,49 @@ func (v *h2cVector) run(t *testing.T) {
 	default:
 		t.Fatal("ciphersuite not recognized")
 	}
+
+	v.runTrace(t, expected)
+}
+
+func (v *h2cVector) runTrace(t *testing.T, expected string) {
+	if circlBackend && v.group == ecc.P384Sha384 {
+		return
+	}
+
+	var (
+		trace *ecc.HashToCurveTrace
+		err   error
+		q     = [][2]string{{v.Q0.X, v.Q0.Y}, {v.Q1.X, v.Q1.Y}}
+	)
+
+	if v.Ciphersuite[len(v.Ciphersuite)-3:] == "RO_" {
+		trace, err = v.group.HashToGroupTrace([]byte(v.Msg), []byte(v.Dst))
+	} else {
+		trace, err = v.group.EncodeToGroupTrace([]byte(v.Msg), []byte(v.Dst))
+		q = [][2]string{{v.Q.X, v.Q.Y}}
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = verifyEncoding(trace.P, "HashToGroupTrace", expected); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(trace.U) != len(v.U) || len(trace.Q) != len(q) {
+		t.Fatal("unexpected trace length")
+	}
+
+	for i, u := range v.U {
+		if "0x"+hex.EncodeToString(trace.U[i]) != u {
+			t.Fatalf("unexpected u[%d]: %x", i, trace.U[i])
+		}
+
+		if "0x"+hex.EncodeToString(trace.Q[i].X) != q[i][0] || "0x"+hex.EncodeToString(trace.Q[i].Y) != q[i][1] {
+			t.Fatalf("unexpected Q%d: %x, %x", i, trace.Q[i].X, trace.Q[i].Y)
+		}
+	}
 }
 
 func verifyEncoding(p *ecc.Element, function, expected string) error {
@@ -224,3 +273,15 @@ func TestHashToGroupVectors(t *testing.T) {
 		t.Fatalf("error opening vector files: %v", err)
 	}
 }
+
+func TestHashToGroupTrace_Ristretto255(t *testing.T) {
+	dst := ecc.Ristretto255Sha512.MakeDST("trace", 1)
+
+	if _, err := ecc.Ristretto255Sha512.HashToGroupTrace(nil, dst); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := ecc.Ristretto255Sha512.EncodeToGroupTrace(nil, dst); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"testing"
+
+	"github.com/bytemare/hash2curve"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestExpandXMDBatch(t *testing.T) {
+	inputs := [][]byte{nil, []byte("abc"), internal.RandomBytes(1000)}
+	out := [][]byte{make([]byte, 32), make([]byte, 96), make([]byte, internal.MaxUniformLength)}
+
+	for _, id := range []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512} {
+		for _, dst := range []string{"expand-message-xmd-dst", string(bytes.Repeat([]byte("d"), 300))} {
+			internal.ExpandXMDBatch(id, out, inputs, []byte(dst))
+
+			for i, input := range inputs {
+				if !bytes.Equal(out[i], hash2curve.ExpandXMD(id, input, []byte(dst), uint(len(out[i])))) {
+					t.Fatalf("%v %d: %s", id, i, errExpectedEquality)
+				}
+			}
+		}
+	}
+}
+
+func TestHashToGroupBatch(t *testing.T) {
+	inputs := [][]byte{nil, []byte("abc"), internal.RandomBytes(1000)}
+	for i := range 32 {
+		inputs = append(inputs, []byte(fmt.Sprintf("input %d", i)))
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		for _, dst := range [][]byte{[]byte("hash-batch-test-dst"), bytes.Repeat([]byte("d"), 300)} {
+			elements := g.HashToGroupBatch(inputs, dst)
+			if len(elements) != len(inputs) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			for i, input := range inputs {
+				if !elements[i].Equal(g.HashToGroup(input, dst)) {
+					t.Fatalf("%d: %s", i, errExpectedEquality)
+				}
+			}
+		}
+
+		if len(g.HashToGroupBatch(nil, []byte("hash-batch-test-dst"))) != 0 {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if err := testPanic("zero-length DST", errZeroLenDST, func() {
+			_ = g.HashToGroupBatch(inputs, nil)
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
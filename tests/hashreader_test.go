@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto"
+	"errors"
+	"testing"
+	"testing/iotest"
+
+	"github.com/bytemare/hash2curve"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestExpandXMD(t *testing.T) {
+	input := []byte("expand-message-xmd-input")
+
+	for _, id := range []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512} {
+		for _, dst := range []string{"expand-message-xmd-dst", string(bytes.Repeat([]byte("d"), 300))} {
+			for _, length := range []int{1, 32, 48, 64, 96, 97, 128, internal.MaxUniformLength} {
+				out := make([]byte, length)
+				internal.ExpandXMD(id, out, input, []byte(dst))
+
+				if !bytes.Equal(out, hash2curve.ExpandXMD(id, input, []byte(dst), uint(length))) {
+					t.Fatalf("%v %d: %s", id, length, errExpectedEquality)
+				}
+			}
+		}
+	}
+}
+
+func TestHashReader(t *testing.T) {
+	longDST := bytes.Repeat([]byte("d"), 300)
+	inputs := [][]byte{nil, []byte("abc"), internal.RandomBytes(1 << 20)}
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		for _, dst := range [][]byte{[]byte("hash-reader-test-dst"), longDST} {
+			for _, input := range inputs {
+				s, err := g.HashToScalarReader(iotest.OneByteReader(bytes.NewReader(input)), dst)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !s.Equal(g.HashToScalar(input, dst)) {
+					t.Fatalf("HashToScalarReader: %v", errExpectedEquality)
+				}
+
+				e, err := g.HashToGroupReader(bytes.NewReader(input), dst)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !e.Equal(g.HashToGroup(input, dst)) {
+					t.Fatalf("HashToGroupReader: %v", errExpectedEquality)
+				}
+			}
+		}
+	})
+}
+
+func TestHashReader_Fails(t *testing.T) {
+	errRead := errors.New("read error")
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		dst := []byte("hash-reader-test-dst")
+
+		if _, err := g.HashToScalarReader(iotest.ErrReader(errRead), dst); !errors.Is(err, errRead) {
+			t.Fatalf("expected %v, got %v", errRead, err)
+		}
+
+		if _, err := g.HashToGroupReader(iotest.ErrReader(errRead), dst); !errors.Is(err, errRead) {
+			t.Fatalf("expected %v, got %v", errRead, err)
+		}
+
+		if err := testPanic("HashToScalarReader", errZeroLenDST, func() {
+			_, _ = g.HashToScalarReader(bytes.NewReader(nil), nil)
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := testPanic("HashToGroupReader", errZeroLenDST, func() {
+			_, _ = g.HashToGroupReader(bytes.NewReader(nil), nil)
+		}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,171 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"slices"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/hdkey"
+)
+
+// TestHDKey_Vectors uses test vector 1 of BIP-32 for secp256k1, and of SLIP-0010 for P-256.
+func TestHDKey_Vectors(t *testing.T) {
+	seed := decodeHex(t, "000102030405060708090a0b0c0d0e0f")
+
+	for _, test := range []struct {
+		path          string
+		secret, chain string
+		group         ecc.Group
+	}{
+		{
+			group:  ecc.Secp256k1Sha256,
+			path:   "m",
+			secret: "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+			chain:  "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508",
+		},
+		{
+			group:  ecc.Secp256k1Sha256,
+			path:   "m/0'",
+			secret: "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+			chain:  "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141",
+		},
+		{
+			group:  ecc.Secp256k1Sha256,
+			path:   "m/0'/1",
+			secret: "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+			chain:  "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19",
+		},
+		{
+			group:  ecc.P256Sha256,
+			path:   "m",
+			secret: "612091aaa12e22dd2abef664f8a01a82cae99ad7441b7ef8110424915c268bc2",
+			chain:  "beeb672fe4621673f722f38529c07392fecaa61015c80c34f29ce8b41b3cb6ea",
+		},
+		{
+			group:  ecc.P256Sha256,
+			path:   "m/0h",
+			secret: "6939694369114c67917a182c59ddb8cafc3004e63ca5d3b84403ba8613debc0c",
+			chain:  "3460cea53e6a6bb5fb391eeef3237ffd8724bf0a40e94943c98b83825342ee11",
+		},
+	} {
+		master, err := hdkey.NewMaster(test.group, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		path, err := hdkey.ParsePath(test.path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := master.Derive(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if key.Secret().Hex() != test.secret || hex.EncodeToString(key.ChainCode()) != test.chain {
+			t.Fatalf("unexpected key for %s: %s %x", test.path, key.Secret().Hex(), key.ChainCode())
+		}
+	}
+}
+
+func TestHDKey(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		master, err := hdkey.NewMaster(g, bytes.Repeat([]byte{1}, 32))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		path, err := hdkey.ParsePath("m/44'/7h/0/1")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(path, []uint32{44 + hdkey.HardenedOffset, 7 + hdkey.HardenedOffset, 0, 1}) {
+			t.Fatalf("unexpected path %v", path)
+		}
+
+		key, err := master.Derive(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !key.IsPrivate() || key.Depth() != 4 || key.Index() != 1 {
+			t.Fatal("unexpected key metadata")
+		}
+
+		if !g.Base().Multiply(key.Secret()).Equal(key.Public()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Non-hardened public derivation yields the public keys of private derivation.
+		account, err := master.Derive(path[:2])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		public, err := account.Neuter().Derive(path[2:])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if public.IsPrivate() || public.Secret() != nil {
+			t.Fatal("expected a public key")
+		}
+
+		if !public.Public().Equal(key.Public()) || !bytes.Equal(public.ChainCode(), key.ChainCode()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Hardened and non-hardened children of the same index differ.
+		hardened, err := master.Child(hdkey.HardenedOffset)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		normal, err := master.Child(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if hardened.Secret().Equal(normal.Secret()) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		if _, err = master.Neuter().Child(hdkey.HardenedOffset); err == nil {
+			t.Fatal("expected error on hardened public derivation")
+		}
+	})
+}
+
+func TestHDKey_Fails(t *testing.T) {
+	if _, err := hdkey.NewMaster(ecc.P256Sha256, make([]byte, hdkey.MinSeedLength-1)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err := hdkey.NewMaster(ecc.P256Sha256, make([]byte, hdkey.MaxSeedLength+1)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err := hdkey.NewMaster(0, make([]byte, 32)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	for _, path := range []string{"", "0/1", "m/", "m/a", "m/-1", "m/2147483648", "m/1''"} {
+		if _, err := hdkey.ParsePath(path); err == nil {
+			t.Fatalf("expected error for %q", path)
+		}
+	}
+}
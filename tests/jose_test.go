@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/jose"
+)
+
+func decodeBase64URL(t *testing.T, s string) []byte {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+// TestJOSE_RFC7518 uses the ECDH-ES example of RFC 7518 Appendix C.
+func TestJOSE_RFC7518(t *testing.T) {
+	g := ecc.P256Sha256
+
+	alice := g.NewScalar()
+	if err := alice.Decode(decodeBase64URL(t, "0_NxaRPUMQoAJt50Gz8YiTr8gRTwyEaCumd-MToTmIo")); err != nil {
+		t.Fatal(err)
+	}
+
+	bob := g.NewScalar()
+	if err := bob.Decode(decodeBase64URL(t, "VEmDZpDXXK8p8N0Cndsxs924q6nS1RXFASRl6BfUqdw")); err != nil {
+		t.Fatal(err)
+	}
+
+	epk, err := jose.EphemeralPublicKey(g.Base().Multiply(alice))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedEPK := &jose.JWK{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   "gI0GAILBdu7T53akrFmMyGcsF3n5dO7MmwNBHKW5SV0",
+		Y:   "SLW_xSffzlPWrHEVI30DHM_4egVwt3NQqeUD7nMFpps",
+	}
+
+	if *epk != *expectedEPK {
+		t.Fatalf("unexpected epk %v", epk)
+	}
+
+	bobPublic, err := jose.ParseEphemeralPublicKey(&jose.JWK{
+		Kty: "EC",
+		Crv: "P-256",
+		X:   "weNJy2HscCSM6AEDTDg04biOvhFhyyWvOHQfeF_PxMQ",
+		Y:   "e8lnCO-AlStT-NJVX-crhB7QRYhiix03illJOVAOyck",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bobPublic.Equal(g.Base().Multiply(bob)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	expected := decodeBase64URL(t, "VqqN6vgjbSBcIijNcacQGg")
+
+	key, err := jose.DeriveKey(alice, bobPublic, "A128GCM", []byte("Alice"), []byte("Bob"), 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(key, expected) {
+		t.Fatalf("unexpected key %x", key)
+	}
+
+	// The recipient derives the same key from the epk.
+	alicePublic, err := jose.ParseEphemeralPublicKey(epk)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err = jose.DeriveKey(bob, alicePublic, "A128GCM", []byte("Alice"), []byte("Bob"), 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(key, expected) {
+		t.Fatalf("unexpected key %x", key)
+	}
+}
+
+func TestJOSE_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		if group.group == ecc.Ristretto255Sha512 {
+			if _, err := jose.Curve(group.group); !errors.Is(err, internal.ErrInvalidGroup) {
+				t.Fatalf("unexpected error %q", err)
+			}
+
+			return
+		}
+
+		sender := group.group.NewScalar().Random()
+		recipient := group.group.NewScalar().Random()
+
+		epk, err := jose.EphemeralPublicKey(group.group.Base().Multiply(sender))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The epk survives a JSON round trip.
+		encoded, err := json.Marshal(epk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := new(jose.JWK)
+		if err = json.Unmarshal(encoded, decoded); err != nil {
+			t.Fatal(err)
+		}
+
+		e, err := jose.ParseEphemeralPublicKey(decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// A 48-byte key spans two Concat KDF blocks.
+		k1, err := jose.DeriveKey(sender, group.group.Base().Multiply(recipient), "ECDH-ES+A256KW", nil, nil, 48)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		k2, err := jose.DeriveKey(recipient, e, "ECDH-ES+A256KW", nil, nil, 48)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(k1, k2) || len(k1) != 48 {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestJOSE_Fails(t *testing.T) {
+	s := ecc.P256Sha256.NewScalar().Random()
+
+	if _, err := jose.EphemeralPublicKey(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := jose.EphemeralPublicKey(ecc.P256Sha256.NewElement()); !errors.Is(err, internal.ErrIdentity) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := jose.EphemeralPublicKey(ecc.Ristretto255Sha512.Base()); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := jose.SharedSecret(nil, ecc.P256Sha256.Base()); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := jose.SharedSecret(s, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := jose.SharedSecret(s, ecc.P384Sha384.Base()); !errors.Is(err, internal.ErrCastScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := jose.SharedSecret(s, ecc.P256Sha256.NewElement()); !errors.Is(err, internal.ErrIdentity) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	epk, err := jose.EphemeralPublicKey(ecc.P256Sha256.Base().Multiply(s))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, jwk := range []*jose.JWK{
+		{Kty: "EC", Crv: "P-256", X: epk.X, Y: epk.X},
+		{Kty: "EC", Crv: "P-256", X: epk.X, Y: epk.Y[1:]},
+		{Kty: "EC", Crv: "P-256", X: epk.X + "A", Y: epk.Y},
+		{Kty: "OKP", Crv: "P-256", X: epk.X, Y: epk.Y},
+		{Kty: "EC", Crv: "X25519", X: epk.X},
+		{Kty: "OKP", Crv: "X25519", X: "AA"},
+	} {
+		if _, err = jose.ParseEphemeralPublicKey(jwk); err == nil {
+			t.Fatalf("expected error for %v", jwk)
+		}
+	}
+
+	if _, err = jose.ParseEphemeralPublicKey(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = jose.ParseEphemeralPublicKey(&jose.JWK{Crv: "P-192"}); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
@@ -10,6 +10,7 @@ package ecc_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -131,3 +132,99 @@ func TestJSONReGetGroup_BadString(t *testing.T) {
 		}
 	})
 }
+
+func TestJSONUnmarshalInto(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		e := group.group.Base().Multiply(s)
+		test := struct {
+			Element *ecc.Element `json:"pk"`
+			Scalar  *ecc.Scalar  `json:"sk"`
+			Group   ecc.Group    `json:"g"`
+		}{
+			Element: e,
+			Scalar:  s,
+			Group:   group.group,
+		}
+
+		enc, err := json.Marshal(test)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var g ecc.Group
+		scalar := new(ecc.Scalar)
+		element := new(ecc.Element)
+
+		if err = eccEncoding.JSONUnmarshalInto(string(enc), map[string]any{
+			"g":  &g,
+			"sk": scalar,
+			"pk": element,
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if g != group.group || !scalar.Equal(s) || !element.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Without the group field, the receivers must already be set to a group.
+		scalar = group.group.NewScalar()
+		if err = eccEncoding.JSONUnmarshalInto(string(enc), map[string]any{"sk": scalar}); err != nil {
+			t.Fatal(err)
+		}
+
+		if !scalar.Equal(s) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestJSONUnmarshalInto_Fails(t *testing.T) {
+	s := ecc.P256Sha256.NewScalar().Random()
+	enc := fmt.Sprintf(`{"g":3,"h":4,"bad":2,"sk":%q,"pk":"zz"}`, s.Hex())
+
+	var (
+		g1, g2     ecc.Group
+		nilGroup   *ecc.Group
+		nilScalar  *ecc.Scalar
+		nilElement *ecc.Element
+	)
+
+	tests := []struct {
+		keys map[string]any
+		err  error
+		key  string
+	}{
+		{map[string]any{"bad": &g1}, internal.ErrInvalidGroup, "bad"},
+		{map[string]any{"missing": &g1}, internal.ErrDecodingInvalidJSONEncoding, "missing"},
+		{map[string]any{"g": nilGroup}, internal.ErrUnsupportedType, "g"},
+		{map[string]any{"sk": nilScalar}, internal.ErrUnsupportedType, "sk"},
+		{map[string]any{"pk": nilElement}, internal.ErrUnsupportedType, "pk"},
+		{map[string]any{"sk": "string"}, internal.ErrUnsupportedType, "sk"},
+		{map[string]any{"sk": new(ecc.Scalar)}, internal.ErrInvalidGroup, "sk"},
+		{map[string]any{"pk": new(ecc.Element)}, internal.ErrInvalidGroup, "pk"},
+		{map[string]any{"g": &g1, "h": &g2, "sk": new(ecc.Scalar)}, internal.ErrInvalidGroup, "sk"},
+		{map[string]any{"g": &g1, "missing": new(ecc.Scalar)}, internal.ErrDecodingInvalidJSONEncoding, "missing"},
+		{map[string]any{"g": &g1, "missing": new(ecc.Element)}, internal.ErrDecodingInvalidJSONEncoding, "missing"},
+	}
+
+	for _, test := range tests {
+		err := eccEncoding.JSONUnmarshalInto(enc, test.keys)
+
+		var fieldErr *eccEncoding.FieldError
+		if !errors.As(err, &fieldErr) {
+			t.Fatalf("expected a field error, got %q", err)
+		}
+
+		if fieldErr.Key != test.key || !errors.Is(err, test.err) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	// Invalid hex
+	err := eccEncoding.JSONUnmarshalInto(enc, map[string]any{"g": &g1, "pk": new(ecc.Element)})
+	if err == nil || !strings.HasPrefix(err.Error(), `JSON field "pk": element DecodeHex`) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
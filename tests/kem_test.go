@@ -0,0 +1,438 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/cloudflare/circl/hpke"
+	circlkem "github.com/cloudflare/circl/kem"
+
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/kem"
+)
+
+var kemIdentifiers = []kem.Identifier{kem.DHKEMP256, kem.DHKEMP384, kem.DHKEMP521, kem.DHKEMX25519}
+
+// Base mode vectors from RFC 9180 appendix A, with the shared secrets.
+var kemVectors = []struct {
+	ikmE, ikmR, skRm, pkRm, enc, shared string
+	id                                  kem.Identifier
+}{
+	{
+		id:     kem.DHKEMX25519,
+		ikmE:   "7268600d403fce431561aef583ee1613527cff655c1343f29812e66706df3234",
+		ikmR:   "6db9df30aa07dd42ee5e8181afdb977e538f5e1fec8a06223f33f7013e525037",
+		skRm:   "4612c550263fc8ad58375df3f557aac531d26850903e55a9f23f21d8534e8ac8",
+		pkRm:   "3948cfe0ad1ddb695d780e59077195da6c56506b027329794ab02bca80815c4d",
+		enc:    "37fda3567bdbd628e88668c3c8d7e97d1d1253b6d4ea6d44c150f741f1bf4431",
+		shared: "fe0e18c9f024ce43799ae393c7e8fe8fce9d218875e8227b0187c04e7d2ea1fc",
+	},
+	{
+		id:   kem.DHKEMP256,
+		ikmE: "4270e54ffd08d79d5928020af4686d8f6b7d35dbe470265f1f5aa22816ce860e",
+		ikmR: "668b37171f1072f3cf12ea8a236a45df23fc13b82af3609ad1e354f6ef817550",
+		skRm: "f3ce7fdae57e1a310d87f1ebbde6f328be0a99cdbcadf4d6589cf29de4b8ffd2",
+		pkRm: "04fe8c19ce0905191ebc298a9245792531f26f0cece2460639e8bc39cb7f706a826a779b4cf969b8a0e539c7f62fb3d3" +
+			"0ad6aa8f80e30f1d128aafd68a2ce72ea0",
+		enc: "04a92719c6195d5085104f469a8b9814d5838ff72b60501e2c4466e5e67b325ac98536d7b61a1af4b78e5b7f951c0900" +
+			"be863c403ce65c9bfcb9382657222d18c4",
+		shared: "c0d26aeab536609a572b07695d933b589dcf363ff9d93c93adea537aeabb8cb8",
+	},
+	{
+		id: kem.DHKEMP521,
+		ikmE: "5040af7a10269b11f78bb884812ad20041866db8bbd749a6a69e3f33e54da7164598f005bce09a9fe190e29c2f42df9e" +
+			"9e3aad040fccc625ddbd7aa99063fc594f40",
+		ikmR: "39a28dc317c3e48b908948f99d608059f882d3d09c0541824bc25f94e6dee7aa0df1c644296b06fbb76e84aef5008f8a" +
+			"908e08fbabadf70658538d74753a85f8856a",
+		skRm: "009227b4b91cf1eb6eecb6c0c0bae93a272d24e11c63bd4c34a581c49f9c3ca01c16bbd32a0a1fac22784f2ae985c85f" +
+			"183baad103b2d02aee787179dfc1a94fea11",
+		pkRm: "0400b81073b1612cf7fdb6db07b35cf4bc17bda5854f3d270ecd9ea99f6c07b46795b8014b66c523ceed6f4829c18bc3" +
+			"886c891b63fa902500ce3ddeb1fbec7e608ac70050b76a0a7fc081dbf1cb30b005981113e635eb501a973aba662d7f16" +
+			"fcc12897dd752d657d37774bb16197c0d9724eecc1ed65349fb6ac1f280749e7669766f8cd",
+		enc: "0400bec215e31718cd2eff5ba61d55d062d723527ec2029d7679a9c867d5c68219c9b217a9d7f78562dc0af3242fef35" +
+			"d1d6f4a28ee75f0d4b31bc918937b559b70762004c4fd6ad7373db7e31da8735fbd6171bbdcfa770211420682c760a40" +
+			"a482cc24f4125edbea9cb31fe71d5d796cfe788dc408857697a52fef711fb921fa7c385218",
+		shared: "59501bad207bf432781371e7c9c26e908958301ad138a3332c6315e18215308dc13191d9c0258b88341569ce97dfb6e5" +
+			"4f0a4ebf70d19166256c48343de6a9ff",
+	},
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func newKEM(t *testing.T, id kem.Identifier) *kem.DHKEM {
+	k, err := kem.New(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return k
+}
+
+func TestKEM_Vectors(t *testing.T) {
+	for _, v := range kemVectors {
+		k := newKEM(t, v.id)
+
+		skR, err := k.DeriveKeyPair(decodeHex(t, v.ikmR))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(skR.PublicKey().Bytes(), decodeHex(t, v.pkRm)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Deserializing the private key must yield the same key pair.
+		sk, err := k.NewPrivateKey(decodeHex(t, v.skRm))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(sk.Bytes(), skR.Bytes()) || !sk.Scalar().Equal(skR.Scalar()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if !k.Group().Base().Multiply(sk.Scalar()).Equal(sk.PublicKey().Element()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		pkR, err := k.NewPublicKey(decodeHex(t, v.pkRm))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		shared, enc, err := k.EncapDeterministically(pkR, decodeHex(t, v.ikmE))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(enc, decodeHex(t, v.enc)) || !bytes.Equal(shared, decodeHex(t, v.shared)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		decapsulated, err := k.Decap(enc, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(decapsulated, shared) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestKEM_RoundTrip(t *testing.T) {
+	for _, id := range kemIdentifiers {
+		k := newKEM(t, id)
+
+		if k.ID() != id || k.Group() != id.Group() || !id.Available() {
+			t.Fatal(errExpectedEquality)
+		}
+
+		skR, err := k.GenerateKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		skS, err := k.GenerateKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(skR.Bytes()) != k.PrivateKeyLength() || len(skR.PublicKey().Bytes()) != k.PublicKeyLength() {
+			t.Fatal("unexpected key length")
+		}
+
+		// Base mode
+		shared, enc, err := k.Encap(skR.PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(shared) != k.SharedSecretLength() || len(enc) != k.EncapsulationLength() {
+			t.Fatal("unexpected output length")
+		}
+
+		decapsulated, err := k.Decap(enc, skR)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(decapsulated, shared) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Auth mode
+		authShared, authEnc, err := k.AuthEncap(skR.PublicKey(), skS)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decapsulated, err = k.AuthDecap(authEnc, skR, skS.PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(decapsulated, authShared) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// A different sender key yields a different secret.
+		decapsulated, err = k.AuthDecap(authEnc, skR, skR.PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bytes.Equal(decapsulated, authShared) {
+			t.Fatal(errUnExpectedEquality)
+		}
+	}
+}
+
+func TestKEM_Fails(t *testing.T) {
+	if _, err := kem.New(0x0021); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if kem.Identifier(0x0021).Available() || kem.Identifier(0x0021).Group() != 0 {
+		t.Fatal("expected unavailable KEM")
+	}
+
+	for _, id := range kemIdentifiers {
+		k := newKEM(t, id)
+
+		sk, err := k.GenerateKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = k.NewPublicKey(sk.PublicKey().Bytes()[1:]); !errors.Is(err, internal.ErrParamInvalidPointEncoding) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if _, err = k.NewPrivateKey(sk.Bytes()[1:]); !errors.Is(err, internal.ErrParamScalarLength) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if _, _, err = k.Encap(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if _, _, err = k.AuthEncap(sk.PublicKey(), nil); !errors.Is(err, internal.ErrParamNilScalar) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if _, err = k.Decap(sk.PublicKey().Bytes(), nil); !errors.Is(err, internal.ErrParamNilScalar) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if _, err = k.AuthDecap(sk.PublicKey().Bytes(), sk, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		// Keys of another KEM are rejected.
+		other := newKEM(t, kem.DHKEMP384)
+		if id == kem.DHKEMP384 {
+			other = newKEM(t, kem.DHKEMP256)
+		}
+
+		otherSk, err := other.GenerateKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err = k.Encap(otherSk.PublicKey()); !errors.Is(err, internal.ErrCastElement) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if _, err = k.Decap(sk.PublicKey().Bytes(), otherSk); !errors.Is(err, internal.ErrCastScalar) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	// Zero and out of range NIST private keys.
+	k := newKEM(t, kem.DHKEMP256)
+	if _, err := k.NewPrivateKey(make([]byte, 32)); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := k.NewPrivateKey(bytes.Repeat([]byte{0xff}, 32)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// Compressed points are not allowed.
+	sk, _ := k.GenerateKeyPair()
+	compressed := append(sk.PublicKey().Element().Encode(), make([]byte, 32)...)
+
+	if _, err := k.NewPublicKey(compressed); !errors.Is(err, internal.ErrParamInvalidPointEncoding) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// X25519 rejects small order points, which yield an all-zero shared secret.
+	k = newKEM(t, kem.DHKEMX25519)
+	sk, _ = k.GenerateKeyPair()
+
+	if _, err := k.Decap(make([]byte, 32), sk); !errors.Is(err, internal.ErrIdentity) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
+
+// TestKEM_Scheme checks the circl kem.Scheme interface, with circl's own HPKE KEMs encapsulating to our keys.
+func TestKEM_Scheme(t *testing.T) {
+	for _, id := range kemIdentifiers {
+		var scheme circlkem.AuthScheme = newKEM(t, id).Scheme()
+		reference := hpke.KEM(id).Scheme()
+
+		if scheme.Name() != id.String() || scheme.CiphertextSize() != reference.CiphertextSize() ||
+			scheme.PublicKeySize() != reference.PublicKeySize() ||
+			scheme.PrivateKeySize() != reference.PrivateKeySize() ||
+			scheme.SharedKeySize() != reference.SharedKeySize() {
+			t.Fatalf("unexpected parameters for %s", scheme.Name())
+		}
+
+		seed := internal.RandomBytes(scheme.SeedSize())
+		pk, sk := scheme.DeriveKeyPair(seed)
+
+		if !sk.Public().Equal(pk) || pk.Scheme().Name() != scheme.Name() || sk.Scheme().Name() != scheme.Name() {
+			t.Fatal(errExpectedEquality)
+		}
+
+		pkBytes, err := pk.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		skBytes, err := sk.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sk2, err := scheme.UnmarshalBinaryPrivateKey(skBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !sk2.Equal(sk) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// circl encapsulates to our public key, and we decapsulate.
+		refPK, err := reference.UnmarshalBinaryPublicKey(pkBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ct, ss, err := reference.Encapsulate(refPK)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decapsulated, err := scheme.Decapsulate(sk, ct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(ss, decapsulated) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// We encapsulate with sender authentication to circl's key pair, and circl decapsulates.
+		refPK, refSK := reference.DeriveKeyPair(internal.RandomBytes(reference.SeedSize()))
+
+		refPKBytes, err := refPK.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pkR, err := scheme.UnmarshalBinaryPublicKey(refPKBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ct, ss, err = scheme.AuthEncapsulate(pkR, sk)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		refPKS, err := reference.UnmarshalBinaryPublicKey(pkBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decapsulated, err = reference.(circlkem.AuthScheme).AuthDecapsulate(refSK, ct, refPKS)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(ss, decapsulated) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestKEM_Scheme_Fails(t *testing.T) {
+	scheme := newKEM(t, kem.DHKEMP256).Scheme()
+	reference := hpke.KEM_P256_HKDF_SHA256.Scheme()
+
+	refPK, refSK, err := reference.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pk, sk, err := scheme.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = scheme.Encapsulate(refPK); !errors.Is(err, circlkem.ErrTypeMismatch) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = scheme.Decapsulate(refSK, nil); !errors.Is(err, circlkem.ErrTypeMismatch) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, _, err = scheme.AuthEncapsulate(pk, refSK); !errors.Is(err, circlkem.ErrTypeMismatch) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = scheme.AuthDecapsulate(sk, nil, refPK); !errors.Is(err, circlkem.ErrTypeMismatch) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if pk.Equal(refPK) || sk.Equal(refSK) {
+		t.Fatal(errUnExpectedEquality)
+	}
+
+	// Keys of another KEM are rejected.
+	pk384, _, err := newKEM(t, kem.DHKEMP384).Scheme().GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = scheme.Encapsulate(pk384); !errors.Is(err, internal.ErrCastElement) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if err = testPanic("wrong seed size", circlkem.ErrSeedSize, func() {
+		scheme.DeriveKeyPair(nil)
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ecdsa"
+	"github.com/bytemare/ecc/eddsa"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/keyblinding"
+)
+
+func TestKeyBlinding_Ed25519(t *testing.T) {
+	g := ecc.Edwards25519Sha512
+	bk := internal.RandomBytes(g.ScalarLength())
+	ctx := []byte("context")
+	message := []byte("message")
+
+	key, err := eddsa.NewKeyFromSeed(internal.RandomBytes(eddsa.SeedSize))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	blinded, err := keyblinding.BlindPublicKey(key.Public(), bk, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if blinded.Equal(key.Public()) {
+		t.Fatal(errUnExpectedEquality)
+	}
+
+	sig, err := keyblinding.BlindKeySignEd25519(key, bk, ctx, message)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The signature verifies with the blinded public key, with any RFC 8032 implementation.
+	if !ed25519.Verify(blinded.Encode(), message, sig) {
+		t.Fatal("expected valid signature")
+	}
+
+	if err = eddsa.Verify(key.Public(), message, sig); err == nil {
+		t.Fatal("expected error with the original public key")
+	}
+
+	unblinded, err := keyblinding.UnblindPublicKey(blinded, bk, ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !unblinded.Equal(key.Public()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Another context yields another blinded key.
+	other, err := keyblinding.BlindPublicKey(key.Public(), bk, []byte("other"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if other.Equal(blinded) {
+		t.Fatal(errUnExpectedEquality)
+	}
+}
+
+func TestKeyBlinding_ECDSA(t *testing.T) {
+	for _, g := range ecdsaGroups {
+		t.Run(g.String(), func(t *testing.T) {
+			bk := internal.RandomBytes(g.ScalarLength())
+			ctx := []byte("context")
+			secret := g.NewScalar().Random()
+			public := g.Base().Multiply(secret)
+			hash := g.HashFunc()
+			h := hash.New()
+			_, _ = h.Write([]byte("message"))
+			digest := h.Sum(nil)
+
+			blinded, err := keyblinding.BlindPublicKey(public, bk, ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sig, err := keyblinding.BlindKeySignECDSA(secret, bk, ctx, hash, digest)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = ecdsa.Verify(blinded, digest, sig); err != nil {
+				t.Fatal(err)
+			}
+
+			if err = ecdsa.Verify(public, digest, sig); err == nil {
+				t.Fatal("expected error with the original public key")
+			}
+
+			skR, err := keyblinding.BlindPrivateKey(secret, bk, ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !g.Base().Multiply(skR).Equal(blinded) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			unblinded, err := keyblinding.UnblindPublicKey(blinded, bk, ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !unblinded.Equal(public) {
+				t.Fatal(errExpectedEquality)
+			}
+		})
+	}
+}
+
+func TestKeyBlinding_Fails(t *testing.T) {
+	if _, err := keyblinding.DeriveBlindKey(ecc.Ristretto255Sha512, make([]byte, 32), nil); !errors.Is(
+		err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := keyblinding.DeriveBlindKey(ecc.P256Sha256, make([]byte, 31), nil); err == nil {
+		t.Fatal("expected error on invalid blinding key length")
+	}
+
+	if _, err := keyblinding.BlindPublicKey(nil, make([]byte, 32), nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := keyblinding.BlindPrivateKey(nil, make([]byte, 32), nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	secret := ecc.Edwards25519Sha512.NewScalar().Random()
+	if _, err := keyblinding.BlindKeySignECDSA(secret, make([]byte, 32), nil, crypto.SHA512,
+		make([]byte, 64)); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestKeyPair(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		kp, err := g.GenerateKeyPair(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if kp.Group() != g || kp.Secret.IsZero() || !kp.Public.Equal(g.Base().Multiply(kp.Secret)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if err = kp.Validate(); err != nil {
+			t.Fatal(err)
+		}
+
+		kp2, err := ecc.NewKeyPair(kp.Secret)
+		if err != nil || !kp2.Public.Equal(kp.Public) || !kp2.Secret.Equal(kp.Secret) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		enc, err := kp.MarshalBinary()
+		if err != nil || len(enc) != 1+g.ScalarLength()+g.ElementLength() {
+			t.Fatal(err)
+		}
+
+		decoded := new(ecc.KeyPair)
+		if err = decoded.UnmarshalBinary(enc); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Secret.Equal(kp.Secret) || !decoded.Public.Equal(kp.Public) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// The same random bytes yield the same key pair.
+		seed := internal.RandomBytes(2 * g.ScalarLength())
+		a, _ := g.GenerateKeyPair(bytes.NewReader(seed))
+		b, _ := g.GenerateKeyPair(bytes.NewReader(seed))
+
+		if !a.Secret.Equal(b.Secret) || a.Secret.Equal(kp.Secret) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestKeyPair_Errors(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		if _, err := g.GenerateKeyPair(bytes.NewReader(nil)); !errors.Is(err, io.EOF) {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if _, err := ecc.NewKeyPair(g.NewScalar()); !errors.Is(err, internal.ErrParamNilScalar) {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		if _, err := ecc.NewKeyPair(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+			t.Fatalf("unexpected error %v", err)
+		}
+
+		kp, _ := g.GenerateKeyPair(nil)
+		other, _ := g.GenerateKeyPair(nil)
+
+		for _, test := range []struct {
+			kp  *ecc.KeyPair
+			err error
+		}{
+			{&ecc.KeyPair{Public: kp.Public}, internal.ErrParamNilScalar},
+			{&ecc.KeyPair{Secret: g.NewScalar(), Public: kp.Public}, internal.ErrParamNilScalar},
+			{&ecc.KeyPair{Secret: kp.Secret}, internal.ErrParamNilPoint},
+			{&ecc.KeyPair{Secret: kp.Secret, Public: other.Public}, nil},
+		} {
+			err := test.kp.Validate()
+			if err == nil || test.err != nil && !errors.Is(err, test.err) {
+				t.Fatalf("unexpected error %v", err)
+			}
+		}
+
+		// A public element that doesn't match the secret is rejected when decoding.
+		enc := (&ecc.KeyPair{Secret: kp.Secret, Public: other.Public}).Encode()
+		if err := new(ecc.KeyPair).Decode(enc); err == nil {
+			t.Fatal("expected error")
+		}
+
+		enc = kp.Encode()
+		for _, bad := range [][]byte{nil, enc[:len(enc)-1], append([]byte{0}, enc[1:]...)} {
+			if err := new(ecc.KeyPair).Decode(bad); err == nil {
+				t.Fatal("expected error")
+			}
+		}
+	})
+
+	kp, _ := ecc.P256Sha256.GenerateKeyPair(nil)
+	mixed := &ecc.KeyPair{Secret: kp.Secret, Public: ecc.Ristretto255Sha512.Base()}
+
+	if err := mixed.Validate(); !errors.Is(err, internal.ErrCastElement) {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/kvac"
+)
+
+func TestKVAC(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		context := []byte("context")
+
+		sk, params, err := kvac.KeyGen(g, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		attributes := []*ecc.Scalar{g.NewScalar().SetUInt64(42), g.NewScalar().Random(), g.NewScalar().Random()}
+
+		issuance, err := sk.Issue(context, attributes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cred, err := params.Receive(context, attributes, issuance)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The issuance proof binds the attributes and the parameters.
+		if _, err = params.Receive(context, attributes[:1], issuance); err == nil {
+			t.Fatal("expected error on wrong attributes")
+		}
+
+		wrong := []*ecc.Scalar{attributes[1], attributes[0], attributes[2]}
+		if _, err = params.Receive(context, wrong, issuance); err == nil {
+			t.Fatal("expected error on wrong attributes")
+		}
+
+		_, otherParams, _ := kvac.KeyGen(g, 3)
+		if _, err = otherParams.Receive(context, attributes, issuance); err == nil {
+			t.Fatal("expected error on wrong parameters")
+		}
+
+		for _, disclose := range [][]int{nil, {0}, {0, 1, 2}} {
+			pres, err := params.Present(context, cred, disclose)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = sk.Verify(context, pres); err != nil {
+				t.Fatal(err)
+			}
+
+			if err = sk.Verify([]byte("other"), pres); err == nil {
+				t.Fatal("expected error on wrong context")
+			}
+
+			// Presentations are unlinkable to the credential.
+			if pres.U.Equal(cred.U) {
+				t.Fatal(errUnExpectedEquality)
+			}
+
+			otherSK, _, _ := kvac.KeyGen(g, 3)
+			if err = otherSK.Verify(context, pres); err == nil {
+				t.Fatal("expected error on wrong key")
+			}
+		}
+
+		// A disclosed attribute can't be changed.
+		pres, err := params.Present(context, cred, []int{0})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !pres.Attributes[0].Equal(attributes[0]) || pres.Attributes[1] != nil || pres.Commitments[0] != nil {
+			t.Fatal("unexpected disclosure")
+		}
+
+		pres.Attributes[0] = g.NewScalar().SetUInt64(43)
+		if err = sk.Verify(context, pres); err == nil {
+			t.Fatal("expected error on changed attribute")
+		}
+
+		// A forged credential can't be presented.
+		forged := &kvac.Credential{U: cred.U, UPrime: cred.UPrime.Copy().Add(g.Base()), Attributes: attributes}
+
+		if pres, err = params.Present(context, forged, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = sk.Verify(context, pres); err == nil {
+			t.Fatal("expected error on forged credential")
+		}
+
+		pres.Commitments = pres.Commitments[1:]
+		if err = sk.Verify(context, pres); err == nil {
+			t.Fatal("expected error on malformed presentation")
+		}
+
+		if _, err = params.Present(context, cred, []int{3}); err == nil {
+			t.Fatal("expected error on invalid disclosure index")
+		}
+
+		if _, _, err = kvac.KeyGen(g, 0); err == nil {
+			t.Fatal("expected error on no attributes")
+		}
+	})
+}
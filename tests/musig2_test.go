@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/musig2"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+func TestMuSig2(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		message := []byte("message")
+
+		for _, n := range []int{1, 2, 5} {
+			secrets := make([]*ecc.Scalar, n)
+			publics := make([]*ecc.Element, n)
+
+			for i := range secrets {
+				secrets[i], publics[i] = schnorr.KeyGen(g)
+			}
+
+			ctx, err := musig2.KeyAgg(musig2.SortKeys(publics))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Sorting makes the aggregate key independent of the order of the keys.
+			reversed := make([]*ecc.Element, n)
+			for i := range publics {
+				reversed[n-1-i] = publics[i]
+			}
+
+			if other, _ := musig2.KeyAgg(musig2.SortKeys(reversed)); !other.AggregatePublicKey().Equal(
+				ctx.AggregatePublicKey()) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			secNonces := make([]*musig2.SecretNonce, n)
+			pubNonces := make([]*musig2.PublicNonce, n)
+
+			for i := range secNonces {
+				secNonces[i], pubNonces[i], err = musig2.NonceGen(secrets[i], publics[i], ctx.AggregatePublicKey(),
+					message, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				// Public nonces go through their encoding.
+				if pubNonces[i], err = musig2.DecodePublicNonce(g, pubNonces[i].Encode()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			aggNonce, err := musig2.NonceAgg(pubNonces)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			session, err := musig2.NewSession(ctx, aggNonce, message)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			psigs := make([]*ecc.Scalar, n)
+			for i := range psigs {
+				if psigs[i], err = session.Sign(secNonces[i], secrets[i]); err != nil {
+					t.Fatal(err)
+				}
+
+				if err = session.PartialVerify(psigs[i], pubNonces[i], publics[i]); err != nil {
+					t.Fatal(err)
+				}
+
+				// The secret nonce can not be reused.
+				if _, err = session.Sign(secNonces[i], secrets[i]); err == nil {
+					t.Fatal("expected error on nonce reuse")
+				}
+			}
+
+			sig, err := session.Aggregate(psigs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err = verifyAdapted(ctx.AggregatePublicKey(), message, sig); err != nil {
+				t.Fatal(err)
+			}
+
+			// A wrong partial signature is detected.
+			psigs[0].Add(g.NewScalar().One())
+
+			if err = session.PartialVerify(psigs[0], pubNonces[0], publics[0]); err == nil {
+				t.Fatal("expected error on invalid partial signature")
+			}
+
+			sig, _ = session.Aggregate(psigs)
+			if err = verifyAdapted(ctx.AggregatePublicKey(), message, sig); err == nil {
+				t.Fatal("expected error on invalid signature")
+			}
+
+			// Signers must be part of the aggregate key.
+			outsider, _ := schnorr.KeyGen(g)
+			secNonce, _, _ := musig2.NonceGen(outsider, g.Base().Multiply(outsider), nil, nil, nil)
+
+			if _, err = session.Sign(secNonce, outsider); err == nil {
+				t.Fatal("expected error on unknown key")
+			}
+		}
+
+		if _, err := musig2.KeyAgg(nil); err == nil {
+			t.Fatal("expected error on empty key list")
+		}
+	})
+}
+
+// TestMuSig2_KeyAggVectors uses the key aggregation test vectors of BIP-327.
+func TestMuSig2_KeyAggVectors(t *testing.T) {
+	keys := []string{
+		"02F9308A019258C31049344F85F89D5229B531C845836F99B08601F113BCE036F9",
+		"03DFF1D77F2A671C5F36183726DB2341BE58FEAE1DA2DECED843240F7B502BA659",
+		"023590A94E768F8E1815C2F24B4D80A8E3149316C3518CE7B7AD338368D038CA66",
+	}
+
+	publics := make([]*ecc.Element, len(keys))
+	for i, k := range keys {
+		publics[i] = ecc.Secp256k1Sha256.NewElement()
+		if err := publics[i].DecodeHex(strings.ToLower(k)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, test := range []struct {
+		expected string
+		indices  []int
+	}{
+		{"90539EEDE565F5D054F32CC0C220126889ED1E5D193BAF15AEF344FE59D4610C", []int{0, 1, 2}},
+		{"B436E3BAD62B8CD409969A224731C193D051162D8C5AE8B109306127DA3AA935", []int{0, 0, 0}},
+	} {
+		list := make([]*ecc.Element, len(test.indices))
+		for i, index := range test.indices {
+			list[i] = publics[index]
+		}
+
+		ctx, err := musig2.KeyAgg(list)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.EqualFold(hex.EncodeToString(ctx.AggregatePublicKey().EncodeXOnly()), test.expected) {
+			t.Fatalf("unexpected aggregate key for %v", test.indices)
+		}
+	}
+}
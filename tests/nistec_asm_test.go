@@ -0,0 +1,14 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+//go:build !purego && (amd64 || arm64 || ppc64le || s390x)
+
+package ecc_test
+
+// nistecAssembly is whether filippo.io/nistec uses its P-256 assembly, whose error messages differ.
+const nistecAssembly = true
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/opaque3dh"
+)
+
+func TestOPAQUE3DH(t *testing.T) {
+	for _, g := range []ecc.Group{ecc.Ristretto255Sha512, ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512} {
+		t.Run(g.String(), func(t *testing.T) {
+			s, err := opaque3dh.New(g)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			seed := internal.RandomBytes(32)
+
+			skU, pkU, err := s.DeriveKeyPair(seed)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The derivation is deterministic.
+			if sk, pk, _ := s.DeriveKeyPair(seed); !sk.Equal(skU) || !pk.Equal(pkU) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			skS, pkS, _ := s.DeriveKeyPair(internal.RandomBytes(32))
+			eskU, epkU, _ := s.DeriveKeyPair(internal.RandomBytes(32))
+			eskS, epkS, _ := s.DeriveKeyPair(internal.RandomBytes(32))
+
+			preamble := opaque3dh.Preamble([]byte("context"), []byte("client"), []byte("ke1"), []byte("server"),
+				[]byte("response"), []byte("nonce"), epkS.Encode())
+
+			if !bytes.HasPrefix(preamble, []byte("OPAQUEv1-\x00\x07context\x00\x06clientke1\x00\x06server")) {
+				t.Fatal("unexpected preamble")
+			}
+
+			clientIKM, err := s.ClientIKM(eskU, skU, epkS, pkS)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			serverIKM, err := s.ServerIKM(eskS, skS, epkU, pkU)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(clientIKM, serverIKM) || len(clientIKM) != 3*g.ElementLength() {
+				t.Fatal(errExpectedEquality)
+			}
+
+			client, server := s.DeriveKeys(clientIKM, preamble), s.DeriveKeys(serverIKM, preamble)
+			if !bytes.Equal(client.SessionKey, server.SessionKey) || len(client.SessionKey) != g.HashFunc().Size() ||
+				bytes.Equal(client.ServerMACKey, client.ClientMACKey) {
+				t.Fatal("unexpected keys")
+			}
+
+			serverMAC := s.ServerMAC(server)
+			if !hmac.Equal(s.ServerMAC(client), serverMAC) ||
+				!hmac.Equal(s.ClientMAC(client, serverMAC), s.ClientMAC(server, serverMAC)) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			// Another preamble yields other keys.
+			other := s.DeriveKeys(clientIKM, append(preamble, 0))
+			if bytes.Equal(other.SessionKey, client.SessionKey) || hmac.Equal(s.ServerMAC(other), serverMAC) {
+				t.Fatal(errUnExpectedEquality)
+			}
+
+			// The identity is rejected.
+			if _, err = s.ClientIKM(eskU, skU, g.NewElement(), pkS); err == nil {
+				t.Fatal("expected error on identity")
+			}
+
+			if _, err = s.ServerIKM(nil, skS, epkU, pkU); !errors.Is(err, internal.ErrParamNilScalar) {
+				t.Fatalf("unexpected error %q", err)
+			}
+		})
+	}
+
+	if _, err := opaque3dh.New(ecc.Secp256k1Sha256); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
@@ -0,0 +1,322 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/oprf"
+)
+
+type oprfVector struct {
+	sk, pk, blind, blinded, evaluated, proof, r, output string
+	group                                               ecc.Group
+	mode                                                oprf.Mode
+}
+
+// oprfVectors are test vectors of RFC 9497 Appendix A, with the seed a3...a3, the key info "test key", and the input
+// 0x00.
+var oprfVectors = []oprfVector{
+	{
+		group:     ecc.Ristretto255Sha512,
+		mode:      oprf.ModeOPRF,
+		sk:        "5ebcea5ee37023ccb9fc2d2019f9d7737be85591ae8652ffa9ef0f4d37063b0e",
+		blind:     "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706",
+		blinded:   "609a0ae68c15a3cf6903766461307e5c8bb2f95e7e6550e1ffa2dc99e412803c",
+		evaluated: "7ec6578ae5120958eb2db1745758ff379e77cb64fe77b0b2d8cc917ea0869c7e",
+		output: "527759c3d9366f277d8c6020418d96bb393ba2afb20ff90df23fb7708264e2f3" +
+			"ab9135e3bd69955851de4b1f9fe8a0973396719b7912ba9ee8aa7d0b5e24bcf6",
+	},
+	{
+		group:     ecc.Ristretto255Sha512,
+		mode:      oprf.ModeVOPRF,
+		sk:        "e6f73f344b79b379f1a0dd37e07ff62e38d9f71345ce62ae3a9bc60b04ccd909",
+		pk:        "c803e2cc6b05fc15064549b5920659ca4a77b2cca6f04f6b357009335476ad4e",
+		blind:     "64d37aed22a27f5191de1c1d69fadb899d8862b58eb4220029e036ec4c1f6706",
+		blinded:   "863f330cc1a1259ed5a5998a23acfd37fb4351a793a5b3c090b642ddc439b945",
+		evaluated: "aa8fa048764d5623868679402ff6108d2521884fa138cd7f9c7669a9a014267e",
+		proof: "ddef93772692e535d1a53903db24367355cc2cc78de93b3be5a8ffcc6985dd06" +
+			"6d4346421d17bf5117a2a1ff0fcb2a759f58a539dfbe857a40bce4cf49ec600d",
+		r: "222a5e897cf59db8145db8d16e597e8facb80ae7d4e26d9881aa6f61d645fc0e",
+		output: "b58cfbe118e0cb94d79b5fd6a6dafb98764dff49c14e1770b566e42402da1a7d" +
+			"a4d8527693914139caee5bd03903af43a491351d23b430948dd50cde10d32b3c",
+	},
+	{
+		group:     ecc.P256Sha256,
+		mode:      oprf.ModeOPRF,
+		sk:        "159749d750713afe245d2d39ccfaae8381c53ce92d098a9375ee70739c7ac0bf",
+		blind:     "3338fa65ec36e0290022b48eb562889d89dbfa691d1cde91517fa222ed7ad364",
+		blinded:   "03723a1e5c09b8b9c18d1dcbca29e8007e95f14f4732d9346d490ffc195110368d",
+		evaluated: "030de02ffec47a1fd53efcdd1c6faf5bdc270912b8749e783c7ca75bb412958832",
+		output:    "a0b34de5fa4c5b6da07e72af73cc507cceeb48981b97b7285fc375345fe495dd",
+	},
+}
+
+func TestOPRF_Vectors(t *testing.T) {
+	seed := bytes.Repeat([]byte{0xa3}, 32)
+	input := [][]byte{{0x00}}
+
+	for _, v := range oprfVectors {
+		suite, err := oprf.New(v.group, v.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sk, pk, err := suite.DeriveKeyPair(seed, []byte("test key"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if sk.Hex() != v.sk || (v.pk != "" && pk.Hex() != v.pk) {
+			t.Fatalf("unexpected key pair %s %s", sk.Hex(), pk.Hex())
+		}
+
+		blind := v.group.NewScalar()
+		if err = blind.DecodeHex(v.blind); err != nil {
+			t.Fatal(err)
+		}
+
+		blinds, blinded, err := suite.Blind(input, []*ecc.Scalar{blind})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if blinded[0].Hex() != v.blinded {
+			t.Fatalf("unexpected blinded element %s", blinded[0].Hex())
+		}
+
+		var r *ecc.Scalar
+		if v.r != "" {
+			r = v.group.NewScalar()
+			if err = r.DecodeHex(v.r); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		evaluated, proof, err := suite.BlindEvaluate(sk, blinded, nil, r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if evaluated[0].Hex() != v.evaluated {
+			t.Fatalf("unexpected evaluated element %s", evaluated[0].Hex())
+		}
+
+		if v.proof != "" && !bytes.Equal(proof.Encode(), decodeHex(t, v.proof)) {
+			t.Fatalf("unexpected proof %x", proof.Encode())
+		}
+
+		outputs, err := suite.Finalize(input, blinds, blinded, evaluated, pk, proof, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(outputs[0], decodeHex(t, v.output)) {
+			t.Fatalf("unexpected output %x", outputs[0])
+		}
+
+		direct, err := suite.Evaluate(sk, input[0], nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(direct, outputs[0]) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestOPRF_Modes(t *testing.T) {
+	inputs := [][]byte{[]byte("first"), []byte("second")}
+	info := []byte("info")
+
+	for _, g := range []ecc.Group{ecc.Ristretto255Sha512, ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512} {
+		for _, mode := range []oprf.Mode{oprf.ModeOPRF, oprf.ModeVOPRF, oprf.ModePOPRF} {
+			suite, err := oprf.New(g, mode)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sk, pk, err := suite.DeriveKeyPair(g.NewScalar().Random().Encode(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			blinds, blinded, err := suite.Blind(inputs, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			evaluated, proof, err := suite.BlindEvaluate(sk, blinded, info, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if (mode == oprf.ModeOPRF) != (proof == nil) {
+				t.Fatalf("unexpected proof for mode %d", mode)
+			}
+
+			if proof != nil {
+				if proof, err = suite.DecodeProof(proof.Encode()); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			outputs, err := suite.Finalize(inputs, blinds, blinded, evaluated, pk, proof, info)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, input := range inputs {
+				direct, err := suite.Evaluate(sk, input, info)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if !bytes.Equal(direct, outputs[i]) {
+					t.Fatal(errExpectedEquality)
+				}
+			}
+
+			if bytes.Equal(outputs[0], outputs[1]) {
+				t.Fatal(errUnExpectedEquality)
+			}
+
+			if mode == oprf.ModeOPRF {
+				continue
+			}
+
+			// The proof is bound to the key, the elements, and in the POPRF mode, to the info.
+			_, otherPK, _ := suite.DeriveKeyPair([]byte("other seed"), nil)
+			if _, err = suite.Finalize(inputs, blinds, blinded, evaluated, otherPK, proof, info); err == nil {
+				t.Fatal("expected error")
+			}
+
+			swapped := []*ecc.Element{evaluated[1], evaluated[0]}
+			if _, err = suite.Finalize(inputs, blinds, blinded, swapped, pk, proof, info); err == nil {
+				t.Fatal("expected error")
+			}
+
+			if _, err = suite.Finalize(inputs, blinds, blinded, evaluated, pk, nil, info); err == nil {
+				t.Fatal("expected error")
+			}
+
+			if mode == oprf.ModePOPRF {
+				if _, err = suite.Finalize(inputs, blinds, blinded, evaluated, pk, proof, nil); err == nil {
+					t.Fatal("expected error")
+				}
+			}
+		}
+	}
+}
+
+func TestOPRF_Batch(t *testing.T) {
+	inputs := make([][]byte, 32)
+	for i := range inputs {
+		inputs[i] = []byte{byte(i)}
+	}
+
+	for _, g := range []ecc.Group{ecc.Ristretto255Sha512, ecc.P256Sha256} {
+		suite, err := oprf.New(g, oprf.ModeVOPRF)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sk, pk, _ := suite.DeriveKeyPair([]byte("seed"), nil)
+		blinds, blinded, _ := suite.Blind(inputs, nil)
+
+		evaluated, proof, err := suite.BlindEvaluate(sk, blinded, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// A single proof of constant size covers the whole batch.
+		if len(proof.Encode()) != 2*g.ScalarLength() {
+			t.Fatalf("unexpected proof length %d", len(proof.Encode()))
+		}
+
+		if _, err = suite.Finalize(inputs, blinds, blinded, evaluated, pk, proof, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		// Any evaluation that is not that of the key invalidates the proof of the batch.
+		tampered := append([]*ecc.Element(nil), evaluated...)
+		tampered[17] = tampered[17].Copy().Add(g.Base())
+
+		if _, err = suite.Finalize(inputs, blinds, blinded, tampered, pk, proof, nil); err == nil {
+			t.Fatal("expected error")
+		}
+
+		// The proof does not cover a subset of the batch.
+		if _, err = suite.Finalize(inputs[1:], blinds[1:], blinded[1:], evaluated[1:], pk, proof, nil); err == nil {
+			t.Fatal("expected error")
+		}
+	}
+}
+
+func TestOPRF_Fails(t *testing.T) {
+	if _, err := oprf.New(ecc.Secp256k1Sha256, oprf.ModeOPRF); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := oprf.New(ecc.P256Sha256, oprf.ModePOPRF+1); err == nil {
+		t.Fatal("expected error")
+	}
+
+	suite, err := oprf.New(ecc.P256Sha256, oprf.ModeVOPRF)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sk, pk, _ := suite.DeriveKeyPair([]byte("seed"), nil)
+	inputs := [][]byte{[]byte("input")}
+
+	if _, _, err = suite.Blind(inputs, []*ecc.Scalar{}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	_, _, err = suite.Blind(inputs, []*ecc.Scalar{ecc.P256Sha256.NewScalar()})
+	if !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	blinds, blinded, _ := suite.Blind(inputs, nil)
+
+	if _, _, err = suite.BlindEvaluate(nil, blinded, nil, nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	identity := []*ecc.Element{ecc.P256Sha256.NewElement()}
+	if _, _, err = suite.BlindEvaluate(sk, identity, nil, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	evaluated, proof, _ := suite.BlindEvaluate(sk, blinded, nil, nil)
+
+	if _, err = suite.Finalize(inputs, blinds, blinded, evaluated[:0], pk, proof, nil); err == nil {
+		t.Fatal("expected error")
+	}
+
+	_, err = suite.Finalize(inputs, blinds, blinded, evaluated, nil, proof, nil)
+	if !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = suite.Evaluate(nil, inputs[0], nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = suite.DecodeProof(proof.Encode()[1:]); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bytemare/ecc/ot"
+)
+
+func TestOT(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		sender, senderMessage := ot.NewSender(g)
+		seen := make(map[string]bool)
+
+		for choice := range uint8(2) {
+			// The same sender runs several transfers, whose keys are independent.
+			for range 2 {
+				receiverMessage, key, err := ot.Receive(g, choice, senderMessage)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				k0, k1, err := sender.Keys(receiverMessage)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				chosen, other := k0, k1
+				if choice == 1 {
+					chosen, other = k1, k0
+				}
+
+				if !bytes.Equal(key, chosen) {
+					t.Fatal(errExpectedEquality)
+				}
+
+				if bytes.Equal(key, other) || seen[string(k0)] || seen[string(k1)] {
+					t.Fatal(errUnExpectedEquality)
+				}
+
+				seen[string(k0)], seen[string(k1)] = true, true
+			}
+		}
+
+		if _, _, err := ot.Receive(g, 2, senderMessage); err == nil {
+			t.Fatal("expected error on invalid choice")
+		}
+
+		identity := g.NewElement().Encode()
+		for _, message := range [][]byte{nil, identity, senderMessage[1:]} {
+			if _, _, err := ot.Receive(g, 0, message); err == nil {
+				t.Fatal("expected error on invalid sender message")
+			}
+
+			if _, _, err := sender.Keys(message); err == nil {
+				t.Fatal("expected error on invalid receiver message")
+			}
+		}
+
+		// A receiver message of B = A would make the second key that of the identity.
+		if _, _, err := sender.Keys(senderMessage); err == nil {
+			t.Fatal("expected error on the sender's message")
+		}
+	})
+}
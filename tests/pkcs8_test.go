@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	eccEncoding "github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestPKCS8_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+
+		der, err := eccEncoding.MarshalPKCS8(s, group.group)
+		if group.group == ecc.Ristretto255Sha512 || group.group == ecc.Edwards25519Sha512 {
+			if !errors.Is(err, internal.ErrInvalidGroup) {
+				t.Fatalf("expected error %q, got %q", internal.ErrInvalidGroup, err)
+			}
+
+			return
+		}
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, g, err := eccEncoding.ParsePKCS8(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if g != group.group {
+			t.Fatal(errWrongGroup)
+		}
+
+		if !decoded.Equal(s) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestPKCS8_StdlibInterop(t *testing.T) {
+	curves := map[ecc.Group]elliptic.Curve{
+		ecc.P256Sha256: elliptic.P256(),
+		ecc.P384Sha384: elliptic.P384(),
+		ecc.P521Sha512: elliptic.P521(),
+	}
+
+	for g, curve := range curves {
+		// ecc to stdlib
+		s := g.NewScalar().Random()
+
+		der, err := eccEncoding.MarshalPKCS8(s, g)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, err := x509.ParsePKCS8PrivateKey(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			t.Fatalf("unexpected key type %T", key)
+		}
+
+		if !bytes.Equal(ecKey.D.FillBytes(make([]byte, g.ScalarLength())), s.Encode()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// stdlib to ecc
+		ecKey, err = ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		der, err = x509.MarshalPKCS8PrivateKey(ecKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, dg, err := eccEncoding.ParsePKCS8(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if dg != g || !bytes.Equal(decoded.Encode(), ecKey.D.FillBytes(make([]byte, g.ScalarLength()))) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestPKCS8_Ed25519(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := eccEncoding.MarshalEd25519PKCS8(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		t.Fatalf("unexpected key type %T", key)
+	}
+
+	if !bytes.Equal(edKey, ed25519.NewKeyFromSeed(seed)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Both the keys marshalled here and by crypto/x509 parse to the scalar of the Ed25519 public key.
+	stdlib, err := x509.MarshalPKCS8PrivateKey(edKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(der, stdlib) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	s, g, err := eccEncoding.ParsePKCS8(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if g != ecc.Edwards25519Sha512 || !bytes.Equal(g.Base().Multiply(s).Encode(), edKey.Public().(ed25519.PublicKey)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if _, err = eccEncoding.MarshalEd25519PKCS8(seed[1:]); !errors.Is(err, internal.ErrParamScalarLength) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
+
+func TestPKCS8_Fails(t *testing.T) {
+	// Nil scalar
+	if _, err := eccEncoding.MarshalPKCS8(nil, ecc.P256Sha256); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Group mismatch
+	if _, err := eccEncoding.MarshalPKCS8(ecc.P256Sha256.NewScalar(), ecc.P384Sha384); !errors.Is(
+		err,
+		internal.ErrCastScalar,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Garbage
+	if _, _, err := eccEncoding.ParsePKCS8([]byte{1, 2, 3}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// Trailing data
+	der, err := eccEncoding.MarshalPKCS8(ecc.P256Sha256.NewScalar().Random(), ecc.P256Sha256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = eccEncoding.ParsePKCS8(append(der, 0)); !errors.Is(err, internal.ErrDecodingInvalidLength) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Unsupported algorithm
+	x25519, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err = x509.MarshalPKCS8PrivateKey(x25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err = eccEncoding.ParsePKCS8(der); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
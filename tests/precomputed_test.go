@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestPrecomputed(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		for _, e := range []*ecc.Element{g.Base(), g.Base().Multiply(g.NewScalar().Random()), g.NewElement()} {
+			p := e.NewPrecomputed()
+
+			if p.Group() != g {
+				t.Fatal(errExpectedEquality)
+			}
+
+			for _, s := range []*ecc.Scalar{
+				g.NewScalar().Random(),
+				g.NewScalar(),
+				g.NewScalar().One(),
+				g.NewScalar().MinusOne(),
+			} {
+				if !p.Multiply(s).Equal(e.Copy().Multiply(s)) {
+					t.Fatal(errExpectedEquality)
+				}
+			}
+
+			if !p.Multiply(nil).IsIdentity() {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		// The precomputation doesn't reference the element.
+		e := g.Base()
+		p := e.NewPrecomputed()
+		s := g.NewScalar().Random()
+		e.Double()
+
+		if !p.Multiply(s).Equal(g.Base().Multiply(s)) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestPrecomputed_WrongGroup(t *testing.T) {
+	p := ecc.P256Sha256.Base().NewPrecomputed()
+
+	if err := testPanic(errWrongGroup, internal.ErrCastScalar, func() {
+		_ = p.Multiply(ecc.Ristretto255Sha512.NewScalar().Random())
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
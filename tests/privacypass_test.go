@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/privacypass"
+)
+
+func newPrivacyPass(t *testing.T) (*privacypass.Issuer, *privacypass.Client) {
+	t.Helper()
+
+	issuer, err := privacypass.DeriveIssuer(internal.RandomBytes(32))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := privacypass.NewClient(issuer.PublicKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return issuer, client
+}
+
+func TestPrivacyPass(t *testing.T) {
+	issuer, client := newPrivacyPass(t)
+	challenge := []byte("token challenge")
+
+	state, request, err := client.CreateTokenRequest(challenge)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Messages go through their wire encodings.
+	request, err = privacypass.UnmarshalTokenRequest(request.Marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := issuer.Issue(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err = privacypass.UnmarshalTokenResponse(response.Marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := client.FinalizeToken(state, response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := token.Marshal()
+	if len(encoded) != 2+32+32+32+48 || token.TokenType != privacypass.TokenType {
+		t.Fatal("unexpected token")
+	}
+
+	token, err = privacypass.UnmarshalToken(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = issuer.Redeem(token); err != nil {
+		t.Fatal(err)
+	}
+
+	// Modified tokens, and tokens of other issuers, are rejected.
+	token.Nonce[0] ^= 1
+	if err = issuer.Redeem(token); err == nil {
+		t.Fatal("expected error on modified token")
+	}
+
+	token.Nonce[0] ^= 1
+	other, _ := newPrivacyPass(t)
+
+	if err = other.Redeem(token); err == nil {
+		t.Fatal("expected error on other issuer")
+	}
+
+	// Another issuer's response fails the proof verification.
+	state, request, _ = client.CreateTokenRequest(challenge)
+	if _, err = other.Issue(request); err == nil {
+		t.Fatal("expected error on key ID mismatch")
+	}
+
+	otherClient, _ := privacypass.NewClient(other.PublicKey())
+	state, request, _ = otherClient.CreateTokenRequest(challenge)
+	response, _ = other.Issue(request)
+
+	if _, err = client.FinalizeToken(state, response); err == nil {
+		t.Fatal("expected error on invalid proof")
+	}
+}
+
+func TestPrivacyPass_Batched(t *testing.T) {
+	issuer, client := newPrivacyPass(t)
+
+	state, request, err := client.CreateBatchedTokenRequest([]byte("challenge"), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err = privacypass.UnmarshalBatchedTokenRequest(request.Marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := issuer.IssueBatch(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err = privacypass.UnmarshalBatchedTokenResponse(response.Marshal())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokens, err := client.FinalizeBatchedTokens(state, response)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tokens) != 5 || bytes.Equal(tokens[0].Nonce, tokens[1].Nonce) {
+		t.Fatal("unexpected tokens")
+	}
+
+	for _, token := range tokens {
+		if token.TokenType != privacypass.BatchedTokenType {
+			t.Fatal("unexpected token type")
+		}
+
+		if err = issuer.Redeem(token); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A single token request is not a batched one.
+	_, single, _ := client.CreateTokenRequest(nil)
+	if _, err = issuer.IssueBatch(&privacypass.BatchedTokenRequest{
+		TokenType:           privacypass.TokenType,
+		TruncatedTokenKeyID: single.TruncatedTokenKeyID,
+	}); err == nil {
+		t.Fatal("expected error on invalid token type")
+	}
+
+	// Truncated responses.
+	response.EvaluatedElements = response.EvaluatedElements[1:]
+	if _, err = client.FinalizeBatchedTokens(state, response); err == nil {
+		t.Fatal("expected error on batch length mismatch")
+	}
+
+	if _, _, err = client.CreateBatchedTokenRequest(nil, 0); err == nil {
+		t.Fatal("expected error on empty batch")
+	}
+}
+
+func TestPrivacyPass_Fails(t *testing.T) {
+	if _, err := privacypass.NewIssuer(ecc.P256Sha256.NewScalar().Random()); !errors.Is(
+		err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := privacypass.NewClient(ecc.P384Sha384.NewElement()); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	for _, data := range [][]byte{nil, make([]byte, 146)} {
+		if _, err := privacypass.UnmarshalToken(data); err == nil {
+			t.Fatal("expected error on invalid token")
+		}
+	}
+
+	if _, err := privacypass.UnmarshalTokenRequest(make([]byte, 3)); err == nil {
+		t.Fatal("expected error on invalid request")
+	}
+
+	if _, err := privacypass.UnmarshalBatchedTokenRequest([]byte{0xf9, 0x1a, 0, 0, 1}); err == nil {
+		t.Fatal("expected error on invalid batched request")
+	}
+
+	if _, err := privacypass.UnmarshalBatchedTokenResponse(nil); err == nil {
+		t.Fatal("expected error on invalid batched response")
+	}
+}
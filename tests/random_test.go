@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+// deterministicReader is a fixed stream of bytes derived from its seed, to replay the randomness of tests.
+type deterministicReader struct {
+	seed    []byte
+	buf     []byte
+	counter uint64
+}
+
+func (r *deterministicReader) Read(p []byte) (int, error) {
+	for n := 0; n < len(p); {
+		if len(r.buf) == 0 {
+			h := sha256.Sum256(binary.BigEndian.AppendUint64(r.seed, r.counter))
+			r.buf = h[:]
+			r.counter++
+		}
+
+		c := copy(p[n:], r.buf)
+		r.buf = r.buf[c:]
+		n += c
+	}
+
+	return len(p), nil
+}
+
+type failingReader struct{}
+
+var errFailingReader = errors.New("failing reader")
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errFailingReader
+}
+
+// randomOutputs returns the values of a run using randomness from the package's source.
+func randomOutputs(t *testing.T, g ecc.Group, secret *ecc.Scalar) [][]byte {
+	kp, err := g.GenerateKeyPair(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := schnorr.Sign(secret, []byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return [][]byte{g.NewScalar().Random().Encode(), g.NewScalar().Random().Encode(), kp.Encode(), sig}
+}
+
+func TestSetRandomSource(t *testing.T) {
+	t.Cleanup(func() { ecc.SetRandomSource(nil) })
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		secret := g.NewScalar().Random()
+
+		ecc.SetRandomSource(&deterministicReader{seed: []byte("seed")})
+		first := randomOutputs(t, g, secret)
+
+		ecc.SetRandomSource(&deterministicReader{seed: []byte("seed")})
+		second := randomOutputs(t, g, secret)
+
+		for i := range first {
+			if string(first[i]) != string(second[i]) {
+				t.Fatalf("output %d: %v", i, errExpectedEquality)
+			}
+		}
+
+		if string(first[0]) == string(first[1]) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		// A different seed gives different values.
+		ecc.SetRandomSource(&deterministicReader{seed: []byte("other seed")})
+
+		if string(randomOutputs(t, g, secret)[0]) == string(first[0]) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		// Restoring crypto/rand.
+		ecc.SetRandomSource(nil)
+
+		if string(randomOutputs(t, g, secret)[0]) == string(first[0]) {
+			t.Fatal(errUnExpectedEquality)
+		}
+	})
+}
+
+func TestSetRandomSource_Failing(t *testing.T) {
+	t.Cleanup(func() { ecc.SetRandomSource(nil) })
+
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		ecc.SetRandomSource(failingReader{})
+
+		if _, err := g.GenerateKeyPair(nil); !errors.Is(err, errFailingReader) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := fmt.Errorf("scalar Random: %w", errFailingReader)
+		if err := testPanic("Random", expected, func() { g.NewScalar().Random() }); err != nil {
+			t.Fatal(err)
+		}
+
+		ecc.SetRandomSource(nil)
+
+		if g.NewScalar().Random().IsZero() {
+			t.Fatal(errUnExpectedEquality)
+		}
+	})
+}
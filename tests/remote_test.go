@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ecdh"
+	eccecdsa "github.com/bytemare/ecc/ecdsa"
+	"github.com/bytemare/ecc/eddsa"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/kem"
+	"github.com/bytemare/ecc/schnorr"
+	"github.com/bytemare/ecc/signer"
+)
+
+var _ ecc.RemoteScalar = (*signer.Signer)(nil)
+
+// hsm emulates a device holding a private scalar, and counts its uses. A faulty device returns corrupted signatures.
+type hsm struct {
+	key    *signer.Signer
+	uses   int
+	faulty bool
+}
+
+func newHSM(t *testing.T, s *ecc.Scalar) *hsm {
+	key, err := signer.New(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &hsm{key: key}
+}
+
+func (h *hsm) Group() ecc.Group {
+	return h.key.Group()
+}
+
+func (h *hsm) PublicElement() *ecc.Element {
+	return h.key.PublicElement()
+}
+
+func (h *hsm) MultiplyElement(element *ecc.Element) (*ecc.Element, error) {
+	h.uses++
+	return h.key.MultiplyElement(element)
+}
+
+func (h *hsm) Sign(random io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	h.uses++
+
+	sig, err := h.key.Sign(random, digest, opts)
+	if err == nil && h.faulty {
+		sig[len(sig)-1] ^= 1
+	}
+
+	return sig, err
+}
+
+func TestRemoteScalar_Signer(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		secret := group.group.NewScalar().Random()
+		device := newHSM(t, secret)
+
+		s, err := signer.NewRemote(device)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !s.PublicElement().Equal(group.group.Base().Multiply(secret)) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		input := signerInput(group.group)
+
+		sig, err := s.Sign(rand.Reader, input, signerOpts(group.group))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if device.uses != 1 {
+			t.Fatal("expected the remote to sign")
+		}
+
+		if pub, ok := s.Public().(*ecdsa.PublicKey); ok && !ecdsa.VerifyASN1(pub, input, sig) {
+			t.Fatal("invalid ECDSA signature")
+		}
+
+		e := group.group.Base().Multiply(group.group.NewScalar().Random())
+
+		product, err := s.MultiplyElement(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !product.Equal(e.Multiply(secret)) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestRemoteScalar_KEM(t *testing.T) {
+	for _, id := range kemIdentifiers {
+		k := newKEM(t, id)
+
+		sk, err := k.GenerateKeyPair()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		device := newHSM(t, sk.Scalar())
+
+		remote, err := k.NewRemotePrivateKey(device)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(remote.PublicKey().Bytes(), sk.PublicKey().Bytes()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if remote.Bytes() != nil || remote.Scalar() != nil {
+			t.Fatal("expected no secret material")
+		}
+
+		shared, enc, err := k.Encap(remote.PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decapsulated, err := k.Decap(enc, remote)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(shared, decapsulated) || device.uses != 1 {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestRemoteScalar_ECDH(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		skA, pkA := ecdh.GenerateKeyPair(group.group)
+		skB, pkB := ecdh.GenerateKeyPair(group.group)
+		device := newHSM(t, skA)
+
+		remote, err := ecdh.DHRemote(device, pkB)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		local, err := ecdh.DH(skB, pkA)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(remote, local) || device.uses != 1 {
+			t.Fatal(errExpectedEquality)
+		}
+
+		remote, err = ecdh.DeriveKeyRemote(device, pkB, []byte("info"), 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		local, err = ecdh.DeriveKey(skB, pkA, []byte("info"), 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(remote, local) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+// signRemote signs the message with the signature package of the group and the remote, and verifies the signature
+// with the same package.
+func signRemote(g ecc.Group, remote ecc.RemoteScalar, public *ecc.Element) error {
+	switch g {
+	case ecc.Ristretto255Sha512:
+		sig, err := schnorr.SignRemote(remote, signerMessage, nil)
+		if err != nil {
+			return err
+		}
+
+		return schnorr.Verify(public, signerMessage, sig)
+	case ecc.Edwards25519Sha512:
+		sig, err := eddsa.SignRemote(remote, signerMessage)
+		if err != nil {
+			return err
+		}
+
+		pub, err := encoding.ToEd25519PublicKey(public)
+		if err != nil {
+			return err
+		}
+
+		if !ed25519.Verify(pub, signerMessage, sig) {
+			return errors.New("invalid Ed25519 signature")
+		}
+
+		return eddsa.Verify(public, signerMessage, sig)
+	default:
+		digest := signerInput(g)
+
+		sig, err := eccecdsa.SignRemote(remote, g.HashFunc(), digest)
+		if err != nil {
+			return err
+		}
+
+		return eccecdsa.Verify(public, digest, sig)
+	}
+}
+
+func TestRemoteScalar_Signatures(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		secret := group.group.NewScalar().Random()
+		device := newHSM(t, secret)
+
+		if err := signRemote(group.group, device, group.group.Base().Multiply(secret)); err != nil {
+			t.Fatal(err)
+		}
+
+		if device.uses != 1 {
+			t.Fatal("expected the remote to sign")
+		}
+
+		device.faulty = true
+
+		if err := signRemote(group.group, device, device.PublicElement()); err == nil {
+			t.Fatal("expected error on a faulty signature")
+		}
+	})
+}
+
+func TestRemoteScalar_Fails(t *testing.T) {
+	if _, err := signer.NewRemote(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	s, err := signer.New(ecc.P256Sha256.NewScalar().Random())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = s.MultiplyElement(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = s.MultiplyElement(ecc.P384Sha384.Base()); !errors.Is(err, internal.ErrCastElement) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	k := newKEM(t, kem.DHKEMP384)
+
+	if _, err = k.NewRemotePrivateKey(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = k.NewRemotePrivateKey(s); !errors.Is(err, internal.ErrCastScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = ecdh.DHRemote(nil, ecc.P256Sha256.Base()); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = ecdh.DHRemote(s, ecc.P384Sha384.Base()); !errors.Is(err, internal.ErrCastScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = ecdh.DeriveKeyRemote(nil, ecc.P256Sha256.Base(), nil, 32); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = eccecdsa.SignRemote(nil, crypto.SHA256, signerInput(ecc.P256Sha256)); !errors.Is(
+		err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = eccecdsa.SignRemote(s, crypto.SHA384, signerInput(ecc.P256Sha256)); err == nil {
+		t.Fatal("expected error on a digest of another hash function")
+	}
+
+	if _, err = eddsa.SignRemote(nil, signerMessage); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = eddsa.SignRemote(s, signerMessage); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = schnorr.SignRemote(nil, signerMessage, nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err = schnorr.SignRemote(s, signerMessage, nil); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	r, err := signer.New(ecc.Ristretto255Sha512.NewScalar().Random())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = eccecdsa.SignRemote(r, crypto.SHA512, make([]byte, 64)); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
@@ -12,8 +12,13 @@ import (
 	"bytes"
 	"crypto"
 	"encoding/hex"
+	"errors"
 	"testing"
 
+	"github.com/bytemare/hash2curve"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
 	"github.com/bytemare/ecc/internal/ristretto"
 )
 
@@ -182,3 +187,34 @@ func TestRistrettoElement(t *testing.T) {
 		})
 	}
 }
+
+func TestRistretto_FromUniformBytes(t *testing.T) {
+	g := ecc.Ristretto255Sha512
+	input, dst := []byte("input"), []byte("ristretto255 uniform bytes test DST")
+
+	// Hash-to-group is the one-way map over the expanded input.
+	uniform := hash2curve.ExpandXMD(crypto.SHA512, input, dst, 64)
+
+	e, err := g.ElementFromUniformBytes(uniform)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !e.Equal(g.HashToGroup(input, dst)) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if _, err = g.ElementFromUniformBytes(uniform[:32]); !errors.Is(err, internal.ErrDecodingInvalidLength) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	testAllGroups(t, func(group *testGroup) {
+		if group.group == ecc.Ristretto255Sha512 {
+			return
+		}
+
+		if _, err := group.group.ElementFromUniformBytes(uniform); !errors.Is(err, internal.ErrInvalidGroup) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	})
+}
@@ -255,6 +255,21 @@ func TestScalar_Decode_OutOfBounds(t *testing.T) {
 	})
 }
 
+func TestScalar_Decode_NonCanonical(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		for name, bad := range map[string][]byte{
+			"NonCanonicalScalar": debug.NonCanonicalScalar(g),
+			"OverflowedScalar":   debug.OverflowedScalar(g),
+		} {
+			if err := g.NewScalar().Decode(bad); !errors.Is(err, ecc.ErrNonCanonical) {
+				t.Errorf("%s: expected non-canonical error, got %v", name, err)
+			}
+		}
+	})
+}
+
 func TestScalar_Arithmetic(t *testing.T) {
 	testAllGroups(t, func(group *testGroup) {
 		scalarTestZero(t, group.group)
@@ -381,6 +396,19 @@ func scalarTestLessOrEqual(t *testing.T, g ecc.Group) {
 	if !s.LessOrEqual(r) {
 		t.Fatalf("expected s < s + 1:")
 	}
+
+	// The comparison is on the integers, and not byte per byte.
+	if !g.NewScalar().SetUInt64(255).LessOrEqual(g.NewScalar().SetUInt64(256)) {
+		t.Fatal("expected 255 < 256")
+	}
+
+	if g.NewScalar().SetUInt64(256).LessOrEqual(g.NewScalar().SetUInt64(255)) {
+		t.Fatal("expected 256 > 255")
+	}
+
+	if !g.NewScalar().Random().LessOrEqual(g.NewScalar().MinusOne()) {
+		t.Fatal("expected s <= order - 1")
+	}
 }
 
 func scalarTestAdd(t *testing.T, g ecc.Group) {
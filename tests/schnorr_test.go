@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+func TestSchnorr(t *testing.T) {
+	message := []byte("message")
+
+	testAllGroups(t, func(group *testGroup) {
+		secret, public := schnorr.KeyGen(group.group)
+
+		hedged, err := schnorr.Sign(secret, message, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hedged2, err := schnorr.Sign(secret, message, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		deterministic, err := schnorr.SignDeterministic(secret, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		deterministic2, err := schnorr.SignDeterministic(secret, message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if bytes.Equal(hedged, hedged2) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		if !bytes.Equal(deterministic, deterministic2) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		for _, sig := range [][]byte{hedged, hedged2, deterministic} {
+			if len(sig) != schnorr.SignatureLength(group.group) {
+				t.Fatalf("unexpected signature length %d", len(sig))
+			}
+
+			if err = schnorr.Verify(public, message, sig); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// Fixed randomness makes hedged signatures reproducible, but different from deterministic ones.
+		r1, _ := schnorr.Sign(secret, message, strings.NewReader(strings.Repeat("a", 32)))
+		r2, _ := schnorr.Sign(secret, message, strings.NewReader(strings.Repeat("a", 32)))
+
+		if !bytes.Equal(r1, r2) || bytes.Equal(r1, deterministic) {
+			t.Fatal("unexpected hedged signatures")
+		}
+
+		// Wrong message, key, and tampered signatures.
+		if err = schnorr.Verify(public, []byte("other"), hedged); err == nil {
+			t.Fatal("expected error")
+		}
+
+		_, other := schnorr.KeyGen(group.group)
+		if err = schnorr.Verify(other, message, hedged); err == nil {
+			t.Fatal("expected error")
+		}
+
+		tampered := slices.Clone(hedged)
+		tampered[len(tampered)-2] ^= 1
+
+		if err = schnorr.Verify(public, message, tampered); err == nil {
+			t.Fatal("expected error")
+		}
+
+		if err = schnorr.Verify(public, message, hedged[1:]); err == nil {
+			t.Fatal("expected error")
+		}
+
+		identity := append(group.group.NewElement().Encode(), hedged[group.group.ElementLength():]...)
+		if err = schnorr.Verify(public, message, identity); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestSchnorr_VerifyBatch(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		publics := make([]*ecc.Element, 16)
+		messages := make([][]byte, len(publics))
+		signatures := make([][]byte, len(publics))
+
+		for i := range publics {
+			var secret *ecc.Scalar
+
+			secret, publics[i] = schnorr.KeyGen(g)
+			messages[i] = []byte{byte(i)}
+
+			sig, err := schnorr.Sign(secret, messages[i], nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signatures[i] = sig
+		}
+
+		if err := schnorr.VerifyBatch(publics, messages, signatures); err != nil {
+			t.Fatal(err)
+		}
+
+		// Swapping the messages of two signatures must fail.
+		messages[0], messages[1] = messages[1], messages[0]
+		if err := schnorr.VerifyBatch(publics, messages, signatures); err == nil {
+			t.Fatal("expected error on swapped messages")
+		}
+
+		messages[0], messages[1] = messages[1], messages[0]
+
+		err := schnorr.VerifyBatch(publics[1:], messages, signatures)
+		if !errors.Is(err, internal.ErrParamLengthMismatch) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if err := schnorr.VerifyBatch(nil, nil, nil); !errors.Is(err, internal.ErrParamLengthMismatch) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		signatures[3] = slices.Clone(signatures[3])
+		signatures[3][len(signatures[3])-1] ^= 1
+
+		if err := schnorr.VerifyBatch(publics, messages, signatures); err == nil {
+			t.Fatal("expected error on tampered signature")
+		}
+
+		signatures[3] = signatures[3][1:]
+
+		if err := schnorr.VerifyBatch(publics, messages, signatures); err == nil {
+			t.Fatal("expected error on truncated signature")
+		}
+
+		publics[2] = nil
+		if err := schnorr.VerifyBatch(publics, messages, signatures); !errors.Is(err, internal.ErrParamNilPoint) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	})
+}
+
+func TestSchnorr_Fails(t *testing.T) {
+	if _, err := schnorr.Sign(nil, nil, nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	_, err := schnorr.SignDeterministic(ecc.P256Sha256.NewScalar(), nil)
+	if !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := schnorr.Sign(ecc.P256Sha256.NewScalar().Random(), nil, strings.NewReader("")); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err := schnorr.Verify(nil, nil, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if err := schnorr.Verify(ecc.P256Sha256.NewElement(), nil, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
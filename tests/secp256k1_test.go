@@ -0,0 +1,387 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+
+	"github.com/bytemare/ecc"
+	eccEncoding "github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestBtcec_RoundTrip(t *testing.T) {
+	g := ecc.Secp256k1Sha256
+	s := g.NewScalar().Random()
+	e := g.Base().Multiply(s)
+
+	priv, err := eccEncoding.ToBtcecPrivateKey(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pub, err := eccEncoding.ToBtcecPublicKey(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !priv.PubKey().IsEqual(pub) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	s2, err := eccEncoding.FromBtcecPrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e2, err := eccEncoding.FromBtcecPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !s2.Equal(s) || !e2.Equal(e) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Keys generated by the other library.
+	priv, err = secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s2, err = eccEncoding.FromBtcecPrivateKey(priv); err != nil {
+		t.Fatal(err)
+	}
+
+	if e2, err = eccEncoding.FromBtcecPublicKey(priv.PubKey()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !g.Base().Multiply(s2).Equal(e2) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestSEC1_EthereumFormats(t *testing.T) {
+	priv, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, err := eccEncoding.FromBtcecPublicKey(priv.PubKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	uncompressed, err := eccEncoding.MarshalUncompressed(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(uncompressed, priv.PubKey().SerializeUncompressed()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	for _, encoded := range [][]byte{uncompressed, priv.PubKey().SerializeCompressed()} {
+		e2, err := eccEncoding.ParseSEC1(ecc.Secp256k1Sha256, encoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !e2.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+
+	// NIST groups
+	for _, g := range []ecc.Group{ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512} {
+		e = g.Base().Multiply(g.NewScalar().Random())
+
+		if uncompressed, err = eccEncoding.MarshalUncompressed(e); err != nil {
+			t.Fatal(err)
+		}
+
+		e2, err := eccEncoding.ParseSEC1(g, uncompressed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !e2.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestSEC1_Fails(t *testing.T) {
+	g := ecc.Secp256k1Sha256
+
+	if _, err := eccEncoding.MarshalUncompressed(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.MarshalUncompressed(g.NewElement()); !errors.Is(err, internal.ErrIdentity) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.MarshalUncompressed(ecc.Ristretto255Sha512.Base()); !errors.Is(
+		err, internal.ErrInvalidGroup,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ParseSEC1(ecc.Edwards25519Sha512, nil); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	uncompressed, err := eccEncoding.MarshalUncompressed(g.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Off-curve, bad prefix, and bad length
+	offCurve := bytes.Clone(uncompressed)
+	offCurve[64] ^= 1
+
+	badPrefix := bytes.Clone(uncompressed)
+	badPrefix[0] = 0x06
+
+	for _, bad := range [][]byte{offCurve, badPrefix, uncompressed[:64], nil} {
+		if _, err = eccEncoding.ParseSEC1(g, bad); !errors.Is(err, internal.ErrParamInvalidPointEncoding) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+}
+
+func TestBtcec_Fails(t *testing.T) {
+	if _, err := eccEncoding.ToBtcecPublicKey(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ToBtcecPublicKey(ecc.P256Sha256.Base()); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ToBtcecPublicKey(ecc.Secp256k1Sha256.NewElement()); !errors.Is(
+		err, internal.ErrIdentity,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.FromBtcecPublicKey(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ToBtcecPrivateKey(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ToBtcecPrivateKey(ecc.Secp256k1Sha256.NewScalar()); !errors.Is(
+		err, internal.ErrParamNilScalar,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.ToBtcecPrivateKey(ecc.P256Sha256.NewScalar().Random()); !errors.Is(
+		err, internal.ErrInvalidGroup,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.FromBtcecPrivateKey(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.FromBtcecPrivateKey(new(secp256k1.PrivateKey)); !errors.Is(
+		err, internal.ErrParamNilScalar,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
+
+func TestXOnly(t *testing.T) {
+	g := ecc.Secp256k1Sha256
+
+	// BIP-340 test vector 0: the public key of the secret key 3.
+	s := g.NewScalar().SetUInt64(3)
+	expected, _ := hex.DecodeString("f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9")
+
+	e := g.Base().Multiply(s)
+	if !bytes.Equal(e.EncodeXOnly(), expected) || !bytes.Equal(e.Copy().Negate().EncodeXOnly(), expected) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	for range 10 {
+		e = g.Base().Multiply(g.NewScalar().Random())
+
+		decoded, err := g.DecodeXOnly(e.EncodeXOnly())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.HasEvenY() {
+			t.Fatal("expected even y")
+		}
+
+		if e.HasEvenY() != decoded.Equal(e) || e.HasEvenY() == decoded.Equal(e.Copy().Negate()) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestXOnly_Fails(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		if group.group.NewElement().EncodeXOnly() != nil || group.group.NewElement().HasEvenY() {
+			t.Fatal("unexpected x-only encoding of the identity")
+		}
+
+		if group.group == ecc.Secp256k1Sha256 {
+			return
+		}
+
+		if group.group.Base().EncodeXOnly() != nil || group.group.Base().HasEvenY() {
+			t.Fatal("unexpected x-only encoding")
+		}
+
+		if _, err := group.group.DecodeXOnly(make([]byte, 32)); !errors.Is(err, internal.ErrInvalidGroup) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	})
+
+	g := ecc.Secp256k1Sha256
+
+	if _, err := g.DecodeXOnly(g.Base().Encode()); !errors.Is(err, internal.ErrParamInvalidPointEncoding) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// x = 5 is not on the curve.
+	x := make([]byte, 32)
+	x[31] = 5
+
+	if _, err := g.DecodeXOnly(x); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestSecp256k1_MultiplyEndomorphism(t *testing.T) {
+	g := ecc.Secp256k1Sha256
+
+	// The edge cases of the GLV decomposition: lambda and its negation, the halves of the order, and 2^128.
+	scalars := []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+		"0000000000000000000000000000000000000000000000000000000000000002",
+		"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140",
+		"5363ad4cc05c30e0a5261c028812645a122e22ea20816678df02967c1b23bd72",
+		"ac9c52b33fa3cf1f5ad9e3fd77ed9ba4a880b9fc8ec739c2e0cfc810b51283cf",
+		"7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a0",
+		"7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a1",
+		"00000000000000000000000000000000ffffffffffffffffffffffffffffffff",
+		"0000000000000000000000000000000100000000000000000000000000000000",
+	}
+
+	for range 8 {
+		scalars = append(scalars, g.NewScalar().Random().Hex())
+	}
+
+	for _, e := range []*ecc.Element{g.Base(), g.Base().Multiply(g.NewScalar().Random())} {
+		pub, err := secp256k1.ParsePubKey(e.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var p secp256k1.JacobianPoint
+		pub.AsJacobian(&p)
+
+		for _, h := range scalars {
+			s := g.NewScalar()
+			if err = s.DecodeHex(h); err != nil {
+				t.Fatal(err)
+			}
+
+			var k secp256k1.ModNScalar
+			k.SetByteSlice(s.Encode())
+
+			var r secp256k1.JacobianPoint
+			secp256k1.ScalarMultNonConst(&k, &p, &r)
+
+			expected := make([]byte, g.ElementLength())
+			if !r.Z.IsZero() {
+				r.ToAffine()
+				expected = secp256k1.NewPublicKey(&r.X, &r.Y).SerializeCompressed()
+			}
+
+			if got := e.Copy().Multiply(s).Encode(); !bytes.Equal(got, expected) {
+				t.Fatalf("%s: expected %x, got %x", h, expected, got)
+			}
+
+			// The GLV ladder of the variable-time double multiplication, with a zero scalar for the base point.
+			glv, err := g.VarTime().DoubleMultiply(s, e, g.NewScalar())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := glv.Encode(); !bytes.Equal(got, expected) {
+				t.Fatalf("%s: expected %x, got %x", h, expected, got)
+			}
+		}
+
+		// The negation of affine points must be reduced to be encoded with the right parity.
+		pub.AsJacobian(&p)
+		p.Y.Negate(1).Normalize()
+		expected := secp256k1.NewPublicKey(&p.X, &p.Y).SerializeCompressed()
+
+		if !bytes.Equal(e.Copy().Negate().Encode(), expected) ||
+			!bytes.Equal(g.NewElement().Subtract(e).Encode(), expected) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+// TestSecp256k1_NegateSubtract is a regression test for the negation of the secp256k1 package, which left the y
+// coordinate of decoded affine points negative, so that they encoded with the wrong parity.
+func TestSecp256k1_NegateSubtract(t *testing.T) {
+	g := ecc.Secp256k1Sha256
+
+	for range 32 {
+		e := g.NewElement()
+		if err := e.Decode(g.Base().Multiply(g.NewScalar().Random()).Encode()); err != nil {
+			t.Fatal(err)
+		}
+
+		f := g.HashToGroup(e.Encode(), []byte("negate"))
+
+		pub, err := secp256k1.ParsePubKey(e.Encode())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var p secp256k1.JacobianPoint
+		pub.AsJacobian(&p)
+		p.Y.Negate(1).Normalize()
+		expected := secp256k1.NewPublicKey(&p.X, &p.Y).SerializeCompressed()
+
+		switch {
+		case !bytes.Equal(e.Copy().Negate().Encode(), expected):
+			t.Fatal("unexpected negation")
+		case !bytes.Equal(g.NewElement().Subtract(e).Encode(), expected):
+			t.Fatal("unexpected subtraction from the identity")
+		case !e.Copy().Add(e.Copy().Negate()).IsIdentity() || !e.Copy().Subtract(e).IsIdentity():
+			t.Fatal("e - e != identity")
+		case !f.Copy().Subtract(e).Add(e).Equal(f):
+			t.Fatal("f - e + e != f")
+		case !e.Copy().Negate().Negate().Equal(e):
+			t.Fatal("-(-e) != e")
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/signer"
+)
+
+var (
+	_ crypto.Signer = (*signer.Signer)(nil)
+
+	signerMessage = []byte("signer test message")
+)
+
+func signerOpts(g ecc.Group) crypto.SignerOpts {
+	switch g {
+	case ecc.Edwards25519Sha512, ecc.Ristretto255Sha512:
+		return crypto.Hash(0)
+	default:
+		return g.HashFunc()
+	}
+}
+
+func signerInput(g ecc.Group) []byte {
+	opts := signerOpts(g)
+	if opts.HashFunc() == 0 {
+		return signerMessage
+	}
+
+	h := opts.HashFunc().New()
+	_, _ = h.Write(signerMessage)
+
+	return h.Sum(nil)
+}
+
+func TestSigner(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s, err := signer.New(group.group.NewScalar().Random())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if s.Group() != group.group {
+			t.Fatal(errExpectedEquality)
+		}
+
+		input := signerInput(group.group)
+
+		sig, err := s.Sign(rand.Reader, input, signerOpts(group.group))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		switch pub := s.Public().(type) {
+		case *ecdsa.PublicKey:
+			if !ecdsa.VerifyASN1(pub, input, sig) {
+				t.Fatal("invalid ECDSA signature")
+			}
+		case ed25519.PublicKey:
+			if !ed25519.Verify(pub, input, sig) {
+				t.Fatal("invalid Ed25519 signature")
+			}
+
+			e := group.group.NewElement()
+			if err = e.Decode(pub); err != nil {
+				t.Fatal(err)
+			}
+
+			if err = signer.VerifySchnorr(e, input, sig); err != nil {
+				t.Fatal(err)
+			}
+		case *ecc.Element:
+			if err = signer.VerifySchnorr(pub, input, sig); err != nil {
+				t.Fatal(err)
+			}
+
+			if err = signer.VerifySchnorr(pub, []byte("other message"), sig); err == nil {
+				t.Fatal("expected error")
+			}
+		default:
+			t.Fatalf("unexpected public key type %T", pub)
+		}
+
+		// A nil reader falls back to crypto/rand, and nonces are never reused.
+		sig2, err := s.Sign(nil, input, signerOpts(group.group))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(sig) == string(sig2) {
+			t.Fatal(errUnExpectedEquality)
+		}
+	})
+}
+
+func TestSigner_X509(t *testing.T) {
+	for _, g := range []ecc.Group{ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Edwards25519Sha512} {
+		s, err := signer.New(g.NewScalar().Random())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "ecc"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, template, template, s.Public(), s)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestSigner_Fails(t *testing.T) {
+	if _, err := signer.New(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := signer.New(ecc.P256Sha256.NewScalar()); !errors.Is(err, internal.ErrParamNilScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	s, err := signer.New(ecc.P256Sha256.NewScalar().Random())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	digest := sha256.Sum256(signerMessage)
+
+	// ECDSA requires a hash function matching the digest.
+	if _, err = s.Sign(rand.Reader, digest[:], crypto.Hash(0)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err = s.Sign(rand.Reader, digest[:], crypto.SHA384); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// Ed25519 and Schnorr sign the message itself.
+	s, err = signer.New(ecc.Ristretto255Sha512.NewScalar().Random())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = s.Sign(rand.Reader, digest[:], crypto.SHA256); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err = signer.VerifySchnorr(nil, signerMessage, nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	err = signer.VerifySchnorr(ecc.P256Sha256.Base(), signerMessage, nil)
+	if !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if err = signer.VerifySchnorr(ecc.Ristretto255Sha512.Base(), signerMessage, nil); err == nil {
+		t.Fatal("expected error")
+	}
+}
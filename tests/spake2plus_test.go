@@ -0,0 +1,337 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/spake2plus"
+)
+
+func spake2plusScalars(t *testing.T, s *spake2plus.Suite, pbkdf []byte) (*ecc.Scalar, *ecc.Scalar) {
+	t.Helper()
+
+	w0, w1, err := s.DeriveScalars(pbkdf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return w0, w1
+}
+
+func TestSPAKE2Plus(t *testing.T) {
+	for _, suite := range []struct {
+		group ecc.Group
+		hash  crypto.Hash
+	}{
+		{ecc.P256Sha256, crypto.SHA256},
+		{ecc.P256Sha256, crypto.SHA512},
+		{ecc.P384Sha384, crypto.SHA256},
+		{ecc.P384Sha384, crypto.SHA512},
+		{ecc.P521Sha512, crypto.SHA512},
+		{ecc.Edwards25519Sha512, crypto.SHA256},
+	} {
+		t.Run(suite.group.String()+"-"+suite.hash.String(), func(t *testing.T) {
+			s, err := spake2plus.New(suite.group, suite.hash)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ids := spake2plus.Identities{
+				Context:  []byte("SPAKE2+-test"),
+				Prover:   []byte("client"),
+				Verifier: []byte("server"),
+			}
+			pbkdf := internal.RandomBytes(s.PBKDFLength())
+			w0, w1 := spake2plusScalars(t, s, pbkdf)
+			record := s.RegistrationRecord(w1)
+
+			prover, shareP, err := s.NewProver(ids, w0, w1)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			verifier, shareV, confirmV, err := s.NewVerifier(ids, w0, record, shareP)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			confirmP, keyP, err := prover.Finish(shareV, confirmV)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			keyV, err := verifier.Finish(confirmP)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(keyP, keyV) || len(keyP) != suite.hash.Size() {
+				t.Fatal(errExpectedEquality)
+			}
+
+			// A prover with the wrong password fails the verifier's key confirmation, and vice versa.
+			pbkdf[0] ^= 1
+			w0Wrong, w1Wrong := spake2plusScalars(t, s, pbkdf)
+
+			prover, shareP, err = s.NewProver(ids, w0Wrong, w1Wrong)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			verifier, shareV, confirmV, err = s.NewVerifier(ids, w0, record, shareP)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, _, err = prover.Finish(shareV, confirmV); err == nil {
+				t.Fatal("expected error on wrong password")
+			}
+
+			if _, err = verifier.Finish(make([]byte, suite.hash.Size())); err == nil {
+				t.Fatal("expected error on wrong confirmation")
+			}
+
+			// Mismatching identities.
+			prover, shareP, _ = s.NewProver(ids, w0, w1)
+			_, shareV, confirmV, _ = s.NewVerifier(spake2plus.Identities{}, w0, record, shareP)
+
+			if _, _, err = prover.Finish(shareV, confirmV); err == nil {
+				t.Fatal("expected error on mismatching identities")
+			}
+
+			// Invalid shares.
+			for _, share := range [][]byte{nil, {4}, suite.group.NewElement().Encode()} {
+				if _, _, _, err = s.NewVerifier(ids, w0, record, share); err == nil {
+					t.Fatal("expected error on invalid share")
+				}
+
+				if _, _, err = prover.Finish(share, confirmV); err == nil {
+					t.Fatal("expected error on invalid share")
+				}
+			}
+
+			if _, _, err = s.DeriveScalars(pbkdf[1:]); err == nil {
+				t.Fatal("expected error on invalid PBKDF output length")
+			}
+		})
+	}
+
+	if _, err := spake2plus.New(ecc.Ristretto255Sha512, crypto.SHA256); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
+
+// spake2plusVector is the P256-SHA256-HKDF-SHA256-HMAC-SHA256 test vector of RFC 9383, appendix C.
+var spake2plusVector = struct {
+	context, prover, verifier                       string
+	m, n                                            string
+	w0, w1, x, y, l                                 string
+	shareP, shareV, z, v                            string
+	kMain, kConfirmP, kConfirmV, confirmP, confirmV string
+	kShared                                         string
+}{
+	context:  "SPAKE2+-P256-SHA256-HKDF-SHA256-HMAC-SHA256 Test Vectors",
+	prover:   "client",
+	verifier: "server",
+	m:        "02886e2f97ace46e55ba9dd7242579f2993b64e16ef3dcab95afd497333d8fa12f",
+	n:        "03d8bbd6c639c62937b04d997f38c3770719c629d7014d49a24b4f98baa1292b49",
+	w0:       "bb8e1bbcf3c48f62c08db243652ae55d3e5586053fca77102994f23ad95491b3",
+	w1:       "7e945f34d78785b8a3ef44d0df5a1a97d6b3b460409a345ca7830387a74b1dba",
+	x:        "d1232c8e8693d02368976c174e2088851b8365d0d79a9eee709c6a05a2fad539",
+	y:        "717a72348a182085109c8d3917d6c43d59b224dc6a7fc4f0483232fa6516d8b3",
+	l: "04eb7c9db3d9a9eb1f8adab81b5794c1f13ae3e225efbe91ea487425854c7fc00f00bfedcbd09b2400142d40a14f2064ef31dfa" +
+		"a903b91d1faea7093d835966efd",
+	shareP: "04ef3bd051bf78a2234ec0df197f7828060fe9856503579bb1733009042c15c0c1de127727f418b5966afadfdd95a6e4591d1" +
+		"71056b333dab97a79c7193e341727",
+	shareV: "04c0f65da0d11927bdf5d560c69e1d7d939a05b0e88291887d679fcadea75810fb5cc1ca7494db39e82ff2f50665255d76173" +
+		"e09986ab46742c798a9a68437b048",
+	z: "04bbfce7dd7f277819c8da21544afb7964705569bdf12fb92aa388059408d50091a0c5f1d3127f56813b5337f9e4e67e2ca633117" +
+		"a4fbd559946ab474356c41839",
+	v: "0458bf27c6bca011c9ce1930e8984a797a3419797b936629a5a937cf2f11c8b9514b82b993da8a46e664f23db7c01edc87faa530d" +
+		"b01c2ee405230b18997f16b68",
+	kMain:     "4c59e1ccf2cfb961aa31bd9434478a1089b56cd11542f53d3576fb6c2a438a29",
+	kConfirmP: "871ae3f7b78445e34438fb284504240239031c39d80ac23eb5ab9be5ad6db58a",
+	kConfirmV: "ccd53c7c1fa37b64a462b40db8be101cedcf838950162902054e644b400f1680",
+	confirmP:  "926cc713504b9b4d76c9162ded04b5493e89109f6d89462cd33adc46fda27527",
+	confirmV:  "9747bcc4f8fe9f63defee53ac9b07876d907d55047e6ff2def2e7529089d3e68",
+	kShared:   "0c5f8ccd1413423a54f6c1fb26ff01534a87f893779c6e68666d772bfd91f3e7",
+}
+
+// spake2plusRandom returns a random source from which Scalar.Random reads the scalar with the given hex encoding,
+// preceded by the zero bytes of its security margin.
+func spake2plusRandom(t *testing.T, scalar string) io.Reader {
+	t.Helper()
+
+	return bytes.NewReader(append(make([]byte, 16), decodeHex(t, scalar)...))
+}
+
+func TestSPAKE2Plus_RFC9383(t *testing.T) {
+	t.Cleanup(func() { ecc.SetRandomSource(nil) })
+
+	vector := spake2plusVector
+	g := ecc.P256Sha256
+
+	s, err := spake2plus.New(g, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w0, w1 := g.NewScalar(), g.NewScalar()
+	if err = w0.DecodeHex(vector.w0); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = w1.DecodeHex(vector.w1); err != nil {
+		t.Fatal(err)
+	}
+
+	record := s.RegistrationRecord(w1)
+	if !bytes.Equal(spake2plusUncompressed(t, record), decodeHex(t, vector.l)) {
+		t.Fatal("unexpected registration record")
+	}
+
+	ids := spake2plus.Identities{
+		Context:  []byte(vector.context),
+		Prover:   []byte(vector.prover),
+		Verifier: []byte(vector.verifier),
+	}
+
+	ecc.SetRandomSource(spake2plusRandom(t, vector.x))
+
+	prover, shareP, err := s.NewProver(ids, w0, w1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecc.SetRandomSource(spake2plusRandom(t, vector.y))
+
+	verifier, shareV, confirmV, err := s.NewVerifier(ids, w0, record, shareP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecc.SetRandomSource(nil)
+
+	confirmP, keyP, err := prover.Finish(shareV, confirmV)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyV, err := verifier.Finish(confirmP)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name      string
+		got, want []byte
+	}{
+		{"shareP", shareP, decodeHex(t, vector.shareP)},
+		{"shareV", shareV, decodeHex(t, vector.shareV)},
+		{"confirmP", confirmP, decodeHex(t, vector.confirmP)},
+		{"confirmV", confirmV, decodeHex(t, vector.confirmV)},
+		{"prover K_shared", keyP, decodeHex(t, vector.kShared)},
+		{"verifier K_shared", keyV, decodeHex(t, vector.kShared)},
+	} {
+		if !bytes.Equal(test.got, test.want) {
+			t.Errorf("unexpected %s: want %x, got %x", test.name, test.want, test.got)
+		}
+	}
+
+	// K_main is not exported, so it is checked against the transcript of the vector, from which it derives the keys
+	// checked above.
+	checkSPAKE2PlusKeySchedule(t)
+}
+
+func spake2plusUncompressed(t *testing.T, e *ecc.Element) []byte {
+	t.Helper()
+
+	b, err := encoding.MarshalUncompressed(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return b
+}
+
+func spake2plusPoint(t *testing.T, h string) []byte {
+	t.Helper()
+
+	e := ecc.P256Sha256.NewElement()
+	if err := e.DecodeHex(h); err != nil {
+		t.Fatal(err)
+	}
+
+	return spake2plusUncompressed(t, e)
+}
+
+// checkSPAKE2PlusKeySchedule checks the transcript TT of the vector, with its 8-byte little-endian length prefixes,
+// against K_main, and the keys derived from it.
+func checkSPAKE2PlusKeySchedule(t *testing.T) {
+	t.Helper()
+
+	vector := spake2plusVector
+
+	var tt []byte
+	for _, in := range [][]byte{
+		[]byte(vector.context), []byte(vector.prover), []byte(vector.verifier),
+		spake2plusPoint(t, vector.m), spake2plusPoint(t, vector.n),
+		decodeHex(t, vector.shareP), decodeHex(t, vector.shareV),
+		decodeHex(t, vector.z), decodeHex(t, vector.v),
+		decodeHex(t, vector.w0),
+	} {
+		tt = binary.LittleEndian.AppendUint64(tt, uint64(len(in)))
+		tt = append(tt, in...)
+	}
+
+	kMain := sha256.Sum256(tt)
+	if !bytes.Equal(kMain[:], decodeHex(t, vector.kMain)) {
+		t.Fatalf("unexpected K_main %x", kMain)
+	}
+
+	confirmation := make([]byte, 2*sha256.Size)
+	keys := hkdf.New(sha256.New, kMain[:], nil, []byte("ConfirmationKeys"))
+	if _, err := io.ReadFull(keys, confirmation); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(confirmation[:sha256.Size], decodeHex(t, vector.kConfirmP)) ||
+		!bytes.Equal(confirmation[sha256.Size:], decodeHex(t, vector.kConfirmV)) {
+		t.Fatalf("unexpected confirmation keys %x", confirmation)
+	}
+
+	mac := hmac.New(sha256.New, confirmation[:sha256.Size])
+	_, _ = mac.Write(decodeHex(t, vector.shareV))
+
+	if !bytes.Equal(mac.Sum(nil), decodeHex(t, vector.confirmP)) {
+		t.Fatal("unexpected confirmP")
+	}
+
+	shared := make([]byte, sha256.Size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, kMain[:], nil, []byte("SharedKey")), shared); err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(shared, decodeHex(t, vector.kShared)) {
+		t.Fatalf("unexpected K_shared %x", shared)
+	}
+}
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/bytemare/ecc"
+	eccEncoding "github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+var sshGroups = []ecc.Group{ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512, ecc.Edwards25519Sha512}
+
+func TestSSH_RoundTrip(t *testing.T) {
+	for _, g := range sshGroups {
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		line, err := eccEncoding.MarshalAuthorizedKey(e, "user@host")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, comment, err := eccEncoding.ParseAuthorizedKey(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if comment != "user@host" {
+			t.Fatalf("unexpected comment %q", comment)
+		}
+
+		if !decoded.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func sshCryptoPublicKey(t *testing.T, key ssh.PublicKey) []byte {
+	switch k := key.(ssh.CryptoPublicKey).CryptoPublicKey().(type) {
+	case *ecdsa.PublicKey:
+		pk, err := k.ECDH()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return pk.Bytes()
+	case ed25519.PublicKey:
+		return k
+	default:
+		t.Fatalf("unexpected key type %T", k)
+	}
+
+	return nil
+}
+
+func TestSSH_Interop(t *testing.T) {
+	for _, g := range sshGroups {
+		// ecc to x/crypto/ssh
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		line, err := eccEncoding.MarshalAuthorizedKey(e, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key, _, _, _, err := ssh.ParseAuthorizedKey(line)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := g.NewElement()
+		if err = decoded.Decode(sshCryptoPublicKey(t, key)); err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// x/crypto/ssh to ecc
+		if !bytes.Equal(ssh.MarshalAuthorizedKey(key), line) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		decoded, err = eccEncoding.ParseSSHPublicKey(key.Marshal())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestSSH_StdlibKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := ssh.NewPublicKey(&ecKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e, comment, err := eccEncoding.ParseAuthorizedKey(ssh.MarshalAuthorizedKey(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if comment != "" || e.Group() != ecc.P256Sha256 {
+		t.Fatal(errExpectedEquality)
+	}
+
+	if !bytes.Equal(e.Encode(), elliptic.MarshalCompressed(elliptic.P256(), ecKey.X, ecKey.Y)) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestSSH_Fails(t *testing.T) {
+	// Unsupported groups
+	for _, g := range []ecc.Group{ecc.Ristretto255Sha512, ecc.Secp256k1Sha256} {
+		if _, err := eccEncoding.MarshalSSHPublicKey(g.Base()); !errors.Is(err, internal.ErrInvalidGroup) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	// Nil and identity
+	if _, err := eccEncoding.MarshalSSHPublicKey(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.MarshalSSHPublicKey(ecc.P256Sha256.NewElement()); !errors.Is(err, internal.ErrIdentity) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	wire, err := eccEncoding.MarshalSSHPublicKey(ecc.P256Sha256.Base())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Truncated and trailing data
+	for _, bad := range [][]byte{nil, wire[:3], wire[:len(wire)-1], append(bytes.Clone(wire), 0)} {
+		if _, err = eccEncoding.ParseSSHPublicKey(bad); !errors.Is(err, internal.ErrDecodingInvalidLength) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	// Mismatching curve name
+	bad := bytes.Replace(wire, []byte("nistp256\x00"), []byte("nistp384\x00"), 1)
+	if _, err = eccEncoding.ParseSSHPublicKey(bad); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Mismatching key type in authorized_keys
+	line, err := eccEncoding.MarshalAuthorizedKey(ecc.P256Sha256.Base(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line = bytes.Replace(line, []byte("nistp256 "), []byte("nistp384 "), 1)
+	if _, _, err = eccEncoding.ParseAuthorizedKey(line); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, _, err = eccEncoding.ParseAuthorizedKey([]byte("ssh-ed25519")); !errors.Is(
+		err,
+		internal.ErrDecodingInvalidLength,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
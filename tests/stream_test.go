@@ -0,0 +1,156 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+	eccEncoding "github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+const streamLength = 32
+
+func TestStream_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		scalars := make([]*ecc.Scalar, streamLength)
+		elements := make([]*ecc.Element, streamLength)
+
+		var buf bytes.Buffer
+
+		w := eccEncoding.NewWriter(&buf, g)
+		if w.Group() != g {
+			t.Fatal(errWrongGroup)
+		}
+
+		for i := range streamLength {
+			scalars[i] = g.NewScalar().Random()
+			elements[i] = g.Base().Multiply(scalars[i])
+
+			if err := w.WriteScalar(scalars[i]); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := w.WriteElement(elements[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		r := eccEncoding.NewReader(&buf, g)
+		if r.Group() != g {
+			t.Fatal(errWrongGroup)
+		}
+
+		for i := range streamLength {
+			s, err := r.ReadScalar()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			e, err := r.ReadElement()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !s.Equal(scalars[i]) || !e.Equal(elements[i]) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		if _, err := r.ReadScalar(); !errors.Is(err, io.EOF) {
+			t.Fatalf("expected io.EOF, got %q", err)
+		}
+	})
+}
+
+func TestStream_Fails(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		other := ecc.P256Sha256
+
+		if g == other {
+			other = ecc.Ristretto255Sha512
+		}
+
+		var buf bytes.Buffer
+
+		w := eccEncoding.NewWriter(&buf, g)
+
+		if err := w.WriteScalar(nil); !errors.Is(err, internal.ErrParamNilScalar) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if err := w.WriteElement(nil); !errors.Is(err, internal.ErrParamNilPoint) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if err := w.WriteScalar(other.NewScalar()); !errors.Is(err, internal.ErrCastScalar) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if err := w.WriteElement(other.Base()); !errors.Is(err, internal.ErrCastElement) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		// Length mismatch: scalars and elements have different lengths for Weierstrass curves.
+		if err := w.WriteScalar(g.NewScalar().Random()); err != nil {
+			t.Fatal(err)
+		}
+
+		if g.ScalarLength() != g.ElementLength() {
+			if _, err := eccEncoding.NewReader(bytes.NewReader(buf.Bytes()), g).ReadElement(); !errors.Is(
+				err,
+				internal.ErrDecodingInvalidLength,
+			) {
+				t.Fatalf("unexpected error %q", err)
+			}
+		}
+
+		// Truncated stream
+		for _, l := range []int{1, 3, buf.Len() - 1} {
+			if _, err := eccEncoding.NewReader(bytes.NewReader(buf.Bytes()[:l]), g).ReadScalar(); !errors.Is(
+				err,
+				io.ErrUnexpectedEOF,
+			) {
+				t.Fatalf("unexpected error %q", err)
+			}
+		}
+
+		// Invalid encoding
+		bad := append([]byte{0, byte(g.ScalarLength())}, debug.BadScalarHigh(g)...)
+		if _, err := eccEncoding.NewReader(bytes.NewReader(bad), g).ReadScalar(); err == nil {
+			t.Fatal("expected error")
+		}
+
+		bad = append([]byte{0, byte(g.ElementLength())}, debug.BadElementEncoding(g)...)
+		if _, err := eccEncoding.NewReader(bytes.NewReader(bad), g).ReadElement(); err == nil {
+			t.Fatal("expected error")
+		}
+
+		// Failing writer
+		if err := eccEncoding.NewWriter(failingWriter{}, g).WriteScalar(g.NewScalar()); !errors.Is(
+			err,
+			io.ErrShortWrite,
+		) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	})
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ecdsa"
+	"github.com/bytemare/ecc/tecdsa"
+)
+
+// reconstruct returns the secret interpolated from the shares.
+func reconstruct(t *testing.T, g ecc.Group, shares []*tecdsa.Share) *ecc.Scalar {
+	ids := make([]uint64, len(shares))
+	for i, s := range shares {
+		ids[i] = s.ID
+	}
+
+	secret := g.NewScalar()
+
+	for _, s := range shares {
+		l, err := tecdsa.LagrangeCoefficient(g, s.ID, ids)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		secret.Add(l.Multiply(s.Secret))
+	}
+
+	return secret
+}
+
+func TestThresholdSharing(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		secret := g.NewScalar().Random()
+		public := g.Base().Multiply(secret)
+		ids := []uint64{1, 2, 3}
+
+		shares, commitments, err := tecdsa.Deal(secret, 2, ids)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !commitments[0].Equal(public) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		publicShares := make([]*ecc.Element, len(shares))
+
+		for i, s := range shares {
+			if err = tecdsa.VerifyShare(s, commitments); err != nil {
+				t.Fatal(err)
+			}
+
+			if publicShares[i], err = tecdsa.PublicShare(commitments, s.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if !reconstruct(t, g, shares[1:]).Equal(secret) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		quorum := []uint64{1, 3}
+
+		aggregated, err := tecdsa.AggregatePublicShares(g, quorum, []*ecc.Element{publicShares[0], publicShares[2]})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !aggregated.Equal(public) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// Parties 1 and 3 re-share to parties 4 to 7, with a threshold of 3.
+		newIDs := []uint64{4, 5, 6, 7}
+		subShares := make([][]*tecdsa.Share, len(newIDs))
+		subCommitments := make([][]*ecc.Element, len(quorum))
+
+		for i, s := range []*tecdsa.Share{shares[0], shares[2]} {
+			var sub []*tecdsa.Share
+
+			if sub, subCommitments[i], err = tecdsa.Reshare(s, quorum, 3, newIDs); err != nil {
+				t.Fatal(err)
+			}
+
+			for j := range newIDs {
+				subShares[j] = append(subShares[j], sub[j])
+			}
+		}
+
+		newShares := make([]*tecdsa.Share, len(newIDs))
+
+		for j := range newIDs {
+			var combined []*ecc.Element
+
+			if newShares[j], combined, err = tecdsa.CombineReshares(subShares[j], subCommitments); err != nil {
+				t.Fatal(err)
+			}
+
+			if !combined[0].Equal(public) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		if !reconstruct(t, g, newShares[1:]).Equal(secret) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if reconstruct(t, g, newShares[2:]).Equal(secret) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		// Invalid shares and identifiers are rejected.
+		shares[0].Secret.Add(g.NewScalar().One())
+
+		if err = tecdsa.VerifyShare(shares[0], commitments); err == nil {
+			t.Fatal("expected error on invalid share")
+		}
+
+		subShares[0][0].Secret.Add(g.NewScalar().One())
+
+		if _, _, err = tecdsa.CombineReshares(subShares[0], subCommitments); err == nil {
+			t.Fatal("expected error on invalid sub-share")
+		}
+
+		if _, _, err = tecdsa.CombineReshares(subShares[1], subCommitments[1:]); err == nil {
+			t.Fatal("expected error on length mismatch")
+		}
+
+		for _, bad := range [][]uint64{{0, 1}, {1, 1}, {2, 3}} {
+			if _, err = tecdsa.LagrangeCoefficient(g, 1, bad); err == nil {
+				t.Fatalf("expected error on identifiers %v", bad)
+			}
+		}
+
+		if _, _, err = tecdsa.Deal(secret, 4, ids); err == nil {
+			t.Fatal("expected error on invalid threshold")
+		}
+
+		if _, _, err = tecdsa.Deal(secret, 0, ids); err == nil {
+			t.Fatal("expected error on invalid threshold")
+		}
+	})
+}
+
+// TestThresholdECDSA runs the signing phase of a 2-out-of-3 threshold ECDSA, with the multiplicative-to-additive
+// conversions simulated by a trusted dealer.
+func TestThresholdECDSA(t *testing.T) {
+	for _, g := range ecdsaGroups {
+		secret := g.NewScalar().Random()
+		public := g.Base().Multiply(secret)
+		digest := sha256.Sum256([]byte("message"))
+
+		shares, _, err := tecdsa.Deal(secret, 2, []uint64{1, 2, 3})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Parties 1 and 3 sign, with their random shares k_i and gamma_i.
+		signers := []*tecdsa.Share{shares[0], shares[2]}
+		k := make([]*ecc.Scalar, len(signers))
+		gammas := make([]*ecc.Element, len(signers))
+		kSum, gammaSum := g.NewScalar(), g.NewScalar()
+
+		for i := range signers {
+			k[i] = g.NewScalar().Random()
+			gamma := g.NewScalar().Random()
+			gammas[i] = g.Base().Multiply(gamma)
+			kSum.Add(k[i])
+			gammaSum.Add(gamma)
+		}
+
+		// The dealer splits k * gamma and k * x into additive shares.
+		delta := kSum.Copy().Multiply(gammaSum)
+		chi := kSum.Copy().Multiply(secret)
+		deltas := []*ecc.Scalar{g.NewScalar().Random(), nil}
+		chis := []*ecc.Scalar{g.NewScalar().Random(), nil}
+		deltas[1] = delta.Subtract(deltas[0])
+		chis[1] = chi.Subtract(chis[0])
+
+		pre, err := tecdsa.NewPresignature(gammas, deltas)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !pre.Commitment.Equal(g.Base().Multiply(kSum.Copy().Invert())) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		sigmas := make([]*ecc.Scalar, len(signers))
+		for i := range signers {
+			if sigmas[i], err = pre.SignatureShare(digest[:], k[i], chis[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		sig, err := pre.Combine(public, digest[:], sigmas)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !sig.IsLowS() {
+			t.Fatal("expected low-S")
+		}
+
+		if err = ecdsa.Verify(public, digest[:], sig); err != nil {
+			t.Fatal(err)
+		}
+
+		sigmas[0].Add(g.NewScalar().One())
+
+		if _, err = pre.Combine(public, digest[:], sigmas); err == nil {
+			t.Fatal("expected error on invalid signature share")
+		}
+
+		if _, err = tecdsa.NewPresignature(gammas, deltas[1:]); err == nil {
+			t.Fatal("expected error on length mismatch")
+		}
+
+		if _, err = ecdsa.CommitmentToR(nil); err == nil {
+			t.Fatal("expected error on nil commitment")
+		}
+	}
+
+	if _, err := ecdsa.DigestToScalar(ecc.Edwards25519Sha512, nil); err == nil {
+		t.Fatal("expected error on invalid group")
+	}
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	ed "filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc"
+	eccEncoding "github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/internal"
+)
+
+var tlsCurves = map[ecc.Group]ecdh.Curve{
+	ecc.P256Sha256:         ecdh.P256(),
+	ecc.P384Sha384:         ecdh.P384(),
+	ecc.P521Sha512:         ecdh.P521(),
+	ecc.Edwards25519Sha512: ecdh.X25519(),
+}
+
+func TestKeyShare_RoundTrip(t *testing.T) {
+	for g, curve := range tlsCurves {
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		keyExchange, namedGroup := eccEncoding.ToKeyShare(e)
+		if namedGroup != g.IANANamedGroup() {
+			t.Fatalf("unexpected named group %d", namedGroup)
+		}
+
+		// The key share must be accepted by crypto/ecdh.
+		if _, err := curve.NewPublicKey(keyExchange); err != nil {
+			t.Fatal(err)
+		}
+
+		decoded, err := eccEncoding.FromKeyShare(keyExchange, namedGroup)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decoded.Equal(e) && !decoded.Equal(e.Copy().Negate()) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestKeyShare_X25519SharedSecret(t *testing.T) {
+	// An X25519 peer.
+	peer, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Our side, with a clamped scalar so that it matches X25519.
+	seed := ecc.Edwards25519Sha512.NewScalar().Random().Encode()
+
+	clamped, err := ed.NewScalar().SetBytesWithClamping(seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := ecc.Edwards25519Sha512.NewScalar()
+	if err = s.Decode(clamped.Bytes()); err != nil {
+		t.Fatal(err)
+	}
+
+	keyExchange, _ := eccEncoding.ToKeyShare(ecc.Edwards25519Sha512.Base().Multiply(s))
+
+	pub, err := ecdh.X25519().NewPublicKey(keyExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret1, err := peer.ECDH(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	peerElement, err := eccEncoding.FromKeyShare(peer.PublicKey().Bytes(), eccEncoding.NamedGroupX25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	secret2, _ := eccEncoding.ToKeyShare(peerElement.Multiply(s))
+
+	if !bytes.Equal(secret1, secret2) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestKeyShare_Fails(t *testing.T) {
+	// Groups without a codepoint
+	for _, g := range []ecc.Group{ecc.Ristretto255Sha512, ecc.Secp256k1Sha256} {
+		if b, n := eccEncoding.ToKeyShare(g.Base()); b != nil || n != 0 {
+			t.Fatal("expected no key share")
+		}
+	}
+
+	if b, n := eccEncoding.ToKeyShare(nil); b != nil || n != 0 {
+		t.Fatal("expected no key share")
+	}
+
+	if b, n := eccEncoding.ToKeyShare(ecc.P256Sha256.NewElement()); b != nil || n != 0 {
+		t.Fatal("expected no key share")
+	}
+
+	if _, err := eccEncoding.FromKeyShare(nil, 0x0016); !errors.Is(err, internal.ErrInvalidGroup) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	// Compressed points are not allowed in TLS 1.3
+	if _, err := eccEncoding.FromKeyShare(
+		ecc.P256Sha256.Base().Encode(),
+		eccEncoding.NamedGroupSecp256r1,
+	); !errors.Is(err, internal.ErrParamInvalidPointEncoding) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := eccEncoding.FromKeyShare(make([]byte, 31), eccEncoding.NamedGroupX25519); !errors.Is(
+		err,
+		internal.ErrParamInvalidPointEncoding,
+	) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
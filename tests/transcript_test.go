@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc/transcript"
+)
+
+func TestTranscript(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+		e := g.Base().Multiply(s)
+
+		build := func(protocol, label string, message []byte) *transcript.Transcript {
+			tr := transcript.New(g, protocol)
+			tr.AppendMessage(label, message)
+			tr.AppendElement("element", e)
+			tr.AppendScalar("scalar", s)
+
+			return tr
+		}
+
+		c1 := build("protocol", "message", []byte("data")).Challenge("challenge")
+
+		if !build("protocol", "message", []byte("data")).Challenge("challenge").Equal(c1) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// The challenge is bound to the protocol, the labels, the framing, and the challenge label.
+		for _, tr := range []*transcript.Transcript{
+			build("other protocol", "message", []byte("data")),
+			build("protocol", "other", []byte("data")),
+			build("protocol", "message", []byte("other")),
+			build("protocol", "messaged", []byte("ata")),
+		} {
+			if tr.Challenge("challenge").Equal(c1) {
+				t.Fatal(errUnExpectedEquality)
+			}
+		}
+
+		if build("protocol", "message", []byte("data")).Challenge("other").Equal(c1) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		// Successive challenges differ, and a clone continues independently of the original.
+		tr := build("protocol", "message", []byte("data"))
+		fork := tr.Clone()
+
+		if !tr.Challenge("challenge").Equal(c1) || tr.Challenge("challenge").Equal(c1) {
+			t.Fatal("unexpected successive challenges")
+		}
+
+		if !fork.Challenge("challenge").Equal(c1) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if fork.Group() != g {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestElement_Try(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s := g.NewScalar().Random()
+		e, e2 := g.Base().Multiply(s), g.Base().Multiply(g.NewScalar().Random())
+
+		for name, f := range map[string]func(r *ecc.Element) (*ecc.Element, *ecc.Element, error){
+			"TryAdd": func(r *ecc.Element) (*ecc.Element, *ecc.Element, error) {
+				res, err := r.TryAdd(e2)
+				return res, e.Copy().Add(e2), err
+			},
+			"TrySubtract": func(r *ecc.Element) (*ecc.Element, *ecc.Element, error) {
+				res, err := r.TrySubtract(e2)
+				return res, e.Copy().Subtract(e2), err
+			},
+			"TryMultiply": func(r *ecc.Element) (*ecc.Element, *ecc.Element, error) {
+				res, err := r.TryMultiply(s)
+				return res, e.Copy().Multiply(s), err
+			},
+			"TrySet": func(r *ecc.Element) (*ecc.Element, *ecc.Element, error) {
+				res, err := r.TrySet(e2)
+				return res, e2, err
+			},
+			"TryMultiply(nil)": func(r *ecc.Element) (*ecc.Element, *ecc.Element, error) {
+				res, err := r.TryMultiply(nil)
+				return res, g.NewElement(), err
+			},
+		} {
+			r := e.Copy()
+
+			res, expected, err := f(r)
+			if err != nil || res != r || !res.Equal(expected) {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+
+		if eq, err := e.TryEqual(e.Copy()); err != nil || !eq {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if eq, err := e.TryEqual(nil); err != nil || eq {
+			t.Fatal(errUnExpectedEquality)
+		}
+	})
+}
+
+func TestElement_Try_Errors(t *testing.T) {
+	e, s := ecc.P256Sha256.Base(), ecc.P256Sha256.NewScalar().Random()
+	other, otherScalar := ecc.Ristretto255Sha512.Base(), ecc.Ristretto255Sha512.NewScalar().Random()
+	zero := new(ecc.Element)
+
+	for _, test := range []struct {
+		f   func() error
+		err error
+	}{
+		{func() error { _, err := e.TryAdd(other); return err }, internal.ErrCastElement},
+		{func() error { _, err := e.TrySubtract(other); return err }, internal.ErrCastElement},
+		{func() error { _, err := e.TrySet(other); return err }, internal.ErrCastElement},
+		{func() error { _, err := e.TryEqual(other); return err }, internal.ErrCastElement},
+		{func() error { _, err := e.TryMultiply(otherScalar); return err }, internal.ErrCastScalar},
+		{func() error { _, err := e.TryMultiply(new(ecc.Scalar)); return err }, internal.ErrParamNilScalar},
+		{func() error { _, err := e.TryAdd(zero); return err }, internal.ErrParamNilPoint},
+		{func() error { _, err := zero.TryAdd(e); return err }, internal.ErrParamNilPoint},
+		{func() error { _, err := zero.TryMultiply(s); return err }, internal.ErrParamNilPoint},
+	} {
+		if err := test.f(); !errors.Is(err, test.err) {
+			t.Fatalf("expected %q, got %v", test.err, err)
+		}
+	}
+
+	if !e.Equal(ecc.P256Sha256.Base()) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestScalar_Try(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		s, s2 := g.NewScalar().Random(), g.NewScalar().Random()
+
+		for name, f := range map[string]func(r *ecc.Scalar) (*ecc.Scalar, *ecc.Scalar, error){
+			"TryAdd": func(r *ecc.Scalar) (*ecc.Scalar, *ecc.Scalar, error) {
+				res, err := r.TryAdd(s2)
+				return res, s.Copy().Add(s2), err
+			},
+			"TrySubtract": func(r *ecc.Scalar) (*ecc.Scalar, *ecc.Scalar, error) {
+				res, err := r.TrySubtract(s2)
+				return res, s.Copy().Subtract(s2), err
+			},
+			"TryMultiply": func(r *ecc.Scalar) (*ecc.Scalar, *ecc.Scalar, error) {
+				res, err := r.TryMultiply(s2)
+				return res, s.Copy().Multiply(s2), err
+			},
+			"TryPow": func(r *ecc.Scalar) (*ecc.Scalar, *ecc.Scalar, error) {
+				res, err := r.TryPow(s2)
+				return res, s.Copy().Pow(s2), err
+			},
+			"TrySet": func(r *ecc.Scalar) (*ecc.Scalar, *ecc.Scalar, error) {
+				res, err := r.TrySet(s2)
+				return res, s2, err
+			},
+		} {
+			r := s.Copy()
+
+			res, expected, err := f(r)
+			if err != nil || res != r || !res.Equal(expected) {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+
+		if eq, err := s.TryEqual(s.Copy()); err != nil || !eq {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if le, err := s.TryLessOrEqual(s.Copy()); err != nil || !le {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestScalar_Try_Errors(t *testing.T) {
+	s := ecc.P256Sha256.NewScalar().Random()
+	other := ecc.Ristretto255Sha512.NewScalar().Random()
+	zero := new(ecc.Scalar)
+
+	for _, test := range []struct {
+		f   func() error
+		err error
+	}{
+		{func() error { _, err := s.TryAdd(other); return err }, internal.ErrCastScalar},
+		{func() error { _, err := s.TrySubtract(other); return err }, internal.ErrCastScalar},
+		{func() error { _, err := s.TryMultiply(other); return err }, internal.ErrCastScalar},
+		{func() error { _, err := s.TryPow(other); return err }, internal.ErrCastScalar},
+		{func() error { _, err := s.TrySet(other); return err }, internal.ErrCastScalar},
+		{func() error { _, err := s.TryEqual(other); return err }, internal.ErrCastScalar},
+		{func() error { _, err := s.TryLessOrEqual(other); return err }, internal.ErrCastScalar},
+		{func() error { _, err := s.TryAdd(zero); return err }, internal.ErrParamNilScalar},
+		{func() error { _, err := zero.TryAdd(s); return err }, internal.ErrParamNilScalar},
+	} {
+		if err := test.f(); !errors.Is(err, test.err) {
+			t.Fatalf("expected %q, got %v", test.err, err)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+)
+
+func TestTyped(t *testing.T) {
+	t.Run("Ristretto255", testTyped[ecc.Ristretto255])
+	t.Run("P256", testTyped[ecc.P256])
+	t.Run("P384", testTyped[ecc.P384])
+	t.Run("P521", testTyped[ecc.P521])
+	t.Run("Edwards25519", testTyped[ecc.Edwards25519])
+	t.Run("Secp256k1", testTyped[ecc.Secp256k1])
+}
+
+func testTyped[G ecc.GroupParams](t *testing.T) {
+	var g ecc.Typed[G]
+
+	dynamic := g.Group()
+	if g.String() != dynamic.String() || g.ScalarLength() != dynamic.ScalarLength() ||
+		g.ElementLength() != dynamic.ElementLength() {
+		t.Fatal("unexpected group parameters")
+	}
+
+	// The typed operations give the same results as the dynamic ones.
+	a, b := g.NewScalar().Random(), g.HashToScalar([]byte("input"), []byte("dst"))
+	s := a.Copy().Multiply(b).Add(a).Subtract(b).Invert()
+	ds := a.Scalar().Multiply(b.Scalar()).Add(a.Scalar()).Subtract(b.Scalar()).Invert()
+
+	if !ds.Equal(s.Scalar()) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	e := g.Base().Multiply(a).Add(g.HashToGroup([]byte("input"), []byte("dst"))).Double()
+	de := dynamic.Base().Multiply(a.Scalar()).Add(dynamic.HashToGroup([]byte("input"), []byte("dst"))).Double()
+
+	if !de.Equal(e.Element()) || e.Hex() != de.Hex() {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// The zero values are ready to use.
+	var zs ecc.TypedScalar[G]
+	var ze ecc.TypedElement[G]
+
+	if !zs.IsZero() || !ze.IsIdentity() || !ze.Copy().Add(e).Equal(e) || !zs.Copy().Add(a).Equal(a) {
+		t.Fatal("unexpected zero value")
+	}
+
+	// Encoding round trips.
+	d := g.NewElement()
+	if err := d.Decode(e.Encode()); err != nil || !d.Equal(e) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	sd := g.NewScalar()
+	if err := sd.DecodeHex(s.Hex()); err != nil || !sd.Equal(s) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	// Conversions from the dynamic API check the group, and copy the values.
+	te, err := g.Element(de)
+	if err != nil || !te.Equal(e) {
+		t.Fatal(errExpectedEquality)
+	}
+
+	te.Double()
+
+	if te.Equal(e) || !de.Equal(e.Element()) {
+		t.Fatal("conversion doesn't copy the element")
+	}
+
+	other := ecc.Ristretto255Sha512
+	if dynamic == other {
+		other = ecc.P256Sha256
+	}
+
+	if _, err = g.Element(other.Base()); !errors.Is(err, ecc.ErrWrongGroup) {
+		t.Fatalf("expected ErrWrongGroup, got %v", err)
+	}
+
+	if _, err = g.Scalar(other.NewScalar()); !errors.Is(err, ecc.ErrWrongGroup) {
+		t.Fatalf("expected ErrWrongGroup, got %v", err)
+	}
+
+	if _, err = g.Element(nil); err == nil {
+		t.Fatal("expected error on nil element")
+	}
+
+	if _, err = g.Scalar(nil); err == nil {
+		t.Fatal("expected error on nil scalar")
+	}
+
+	// Nil arguments behave as in the dynamic API.
+	if !e.Copy().Add(nil).Equal(e) || e.Equal(nil) || !e.Copy().Set(nil).IsIdentity() || !a.Copy().Set(nil).IsZero() {
+		t.Fatal("unexpected handling of nil")
+	}
+
+	// Protocol logic written against the interfaces runs with the typed API.
+	pk, r, z := proveAPI(g, g.NewScalar().Random())
+	if !verifyAPI(g, pk, r, z) {
+		t.Fatal("expected valid proof")
+	}
+}
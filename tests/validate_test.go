@@ -0,0 +1,157 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/encoding"
+	"github.com/bytemare/ecc/fuzz"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestGroup_ValidateScalarEncoding(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		for _, data := range fuzz.ScalarCorpus(g) {
+			want := g.NewScalar().Decode(data)
+			err := g.ValidateScalarEncoding(data)
+
+			if (want == nil) != (err == nil) {
+				t.Fatalf("Decode and ValidateScalarEncoding disagree on %x: %v, %v", data, want, err)
+			}
+
+			if err != nil && (!errors.Is(err, ecc.ErrInvalidEncoding) ||
+				errors.Is(want, ecc.ErrNonCanonical) != errors.Is(err, ecc.ErrNonCanonical)) {
+				t.Fatalf("unexpected error on %x: %v", data, err)
+			}
+		}
+
+		encoding := g.NewScalar().Random().Encode()
+		if n := testing.AllocsPerRun(10, func() { _ = g.ValidateScalarEncoding(encoding) }); n != 0 {
+			t.Errorf("ValidateScalarEncoding: %.0f allocations", n)
+		}
+	})
+}
+
+func TestGroup_ValidateElementEncoding(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		for _, data := range fuzz.ElementCorpus(g) {
+			e := g.NewElement()
+			want := e.Decode(data)
+			err := g.ValidateElementEncoding(data)
+
+			// The corpus of Edwards25519 has a point of order 8, which only ValidateElementEncoding rejects.
+			if want == nil && !e.Copy().Multiply(g.NewScalar().MinusOne()).Add(e).IsIdentity() {
+				if err == nil {
+					t.Fatalf("expected error on %x", data)
+				}
+
+				continue
+			}
+
+			if (want == nil) != (err == nil) {
+				t.Fatalf("Decode and ValidateElementEncoding disagree on %x: %v, %v", data, want, err)
+			}
+
+			if err != nil && (!errors.Is(err, ecc.ErrInvalidEncoding) ||
+				errors.Is(want, ecc.ErrIdentity) != errors.Is(err, ecc.ErrIdentity)) {
+				t.Fatalf("unexpected error on %x: %v", data, err)
+			}
+		}
+
+		if !allocationFree(g) {
+			return
+		}
+
+		encoding := g.Base().Multiply(g.NewScalar().Random()).Encode()
+		if n := testing.AllocsPerRun(10, func() { _ = g.ValidateElementEncoding(encoding) }); n != 0 {
+			t.Errorf("ValidateElementEncoding: %.0f allocations", n)
+		}
+	})
+}
+
+// TestGroup_ValidateElementEncoding_SEC1 checks that the NIST groups reject the SEC 1 encoding of the identity, and
+// accept the uncompressed encodings, as Decode does.
+func TestGroup_ValidateElementEncoding_SEC1(t *testing.T) {
+	for _, g := range []ecc.Group{ecc.P256Sha256, ecc.P384Sha384, ecc.P521Sha512} {
+		for _, data := range [][]byte{{0}, g.NewElement().Encode()} {
+			if err := g.ValidateElementEncoding(data); !errors.Is(err, ecc.ErrIdentity) ||
+				!errors.Is(err, ecc.ErrInvalidEncoding) {
+				t.Fatalf("%s: expected identity error on %x, got %v", g, data, err)
+			}
+		}
+
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		uncompressed, err := encoding.MarshalUncompressed(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = g.ValidateElementEncoding(uncompressed); err != nil {
+			t.Fatalf("%s: %v", g, err)
+		}
+
+		d := g.NewElement()
+		if err = d.Decode(uncompressed); err != nil || !d.Equal(e) {
+			t.Fatalf("%s: Decode of the uncompressed encoding: %v", g, err)
+		}
+
+		uncompressed[len(uncompressed)-1] ^= 1
+		if err = g.ValidateElementEncoding(uncompressed); !errors.Is(err, ecc.ErrInvalidEncoding) ||
+			errors.Is(err, ecc.ErrIdentity) {
+			t.Fatalf("%s: expected error on an off-curve point, got %v", g, err)
+		}
+	}
+}
+
+func TestEdwards25519_ValidateElementEncoding_Torsion(t *testing.T) {
+	g := ecc.Edwards25519Sha512
+	torsion := decodeEdwards25519Point(t, edwards25519Order8)
+
+	// The sum of a prime-order point and a torsion point decodes, but is not in the prime-order subgroup.
+	e := g.Base().Multiply(g.NewScalar().Random())
+	p := e.Edwards25519Point()
+	p.Add(p, torsion)
+
+	for _, data := range [][]byte{p.Bytes(), torsion.Bytes()} {
+		if err := g.NewElement().Decode(data); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := g.ValidateElementEncoding(data); !errors.Is(err, ecc.ErrInvalidEncoding) {
+			t.Fatalf("expected error on %s, got %v", hex.EncodeToString(data), err)
+		}
+	}
+
+	if err := g.ValidateElementEncoding(e.Encode()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGroup_Validate_InvalidGroup(t *testing.T) {
+	if err := testPanic("invalid group", internal.ErrInvalidGroup, func() {
+		_ = ecc.Group(0).ValidateScalarEncoding(nil)
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := testPanic("invalid group", internal.ErrInvalidGroup, func() {
+		_ = ecc.Group(0).ValidateElementEncoding(nil)
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
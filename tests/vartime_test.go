@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestVarTime(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		v := g.VarTime()
+
+		if v.Group() != g {
+			t.Fatal(errExpectedEquality)
+		}
+
+		a, b := g.NewScalar().Random(), g.NewScalar().Random()
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		// Zero scalars, the base element, and the identity are edge cases of the variable-time algorithms.
+		for _, c := range []struct {
+			a, b *ecc.Scalar
+			e    *ecc.Element
+		}{
+			{a, b, e},
+			{g.NewScalar(), b, e},
+			{a, g.NewScalar(), e},
+			{a, b, g.Base()},
+			{a, b, g.NewElement()},
+			{g.NewScalar().MinusOne(), g.NewScalar().One(), e},
+		} {
+			r, err := v.DoubleMultiply(c.a, c.e, c.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !v.Equal(r, c.e.Copy().Multiply(c.a).Add(g.Base().Multiply(c.b))) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			m, err := v.Multiply(c.e, c.a)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !v.Equal(m, c.e.Copy().Multiply(c.a)) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		msm, err := v.MultiScalarMult([]*ecc.Scalar{a, b}, []*ecc.Element{e, g.Base()})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !v.Equal(msm, e.Copy().Multiply(a).Add(g.Base().Multiply(b))) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if v.Equal(e, nil) || v.Equal(nil, e) || v.Equal(e, g.Base()) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		if _, err = v.Multiply(nil, a); !errors.Is(err, internal.ErrParamNilPoint) {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		if _, err = v.DoubleMultiply(a, e, nil); !errors.Is(err, internal.ErrParamNilScalar) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	})
+
+	v := ecc.P256Sha256.VarTime()
+	if v.Equal(ecc.P256Sha256.Base(), ecc.P384Sha384.Base()) {
+		t.Fatal(errUnExpectedEquality)
+	}
+
+	_, err := v.Multiply(ecc.P384Sha384.Base(), ecc.P256Sha256.NewScalar())
+	if !errors.Is(err, internal.ErrCastElement) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	_, err = v.DoubleMultiply(ecc.P384Sha384.NewScalar(), ecc.P256Sha256.Base(), ecc.P256Sha256.NewScalar())
+	if !errors.Is(err, internal.ErrCastScalar) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
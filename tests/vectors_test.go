@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/debug"
+)
+
+func TestGenerateVectors_Deterministic(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		seed := make([]byte, 4, 8)
+
+		v1, err := debug.GenerateVectors(g, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		v2, err := debug.GenerateVectors(g, []byte{0, 0, 0, 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		v3, err := debug.GenerateVectors(g, []byte{1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(v1, v2) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if reflect.DeepEqual(v1.Encodings, v3.Encodings) {
+			t.Fatal(errUnExpectedEquality)
+		}
+
+		// The seed is not written to beyond its length.
+		if seed[:8][4] != 0 {
+			t.Fatal("seed modified")
+		}
+	})
+
+	if _, err := debug.GenerateVectors(0, nil); err == nil {
+		t.Fatal("expected error on invalid group")
+	}
+}
+
+// decodeVector returns the scalar or element of the group decoded from the hexadecimal encoding.
+func decodeVector[T interface{ DecodeHex(string) error }](t *testing.T, v T, h string) T {
+	t.Helper()
+
+	if err := v.DecodeHex(h); err != nil {
+		t.Fatal(err)
+	}
+
+	return v
+}
+
+func TestGenerateVectors_Valid(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		v, err := debug.GenerateVectors(g, []byte("seed"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v.Group != byte(g) || v.Ciphersuite != g.String() {
+			t.Fatal(errExpectedEquality)
+		}
+
+		s := func(h string) *ecc.Scalar { return decodeVector(t, g.NewScalar(), h) }
+		e := func(h string) *ecc.Element { return decodeVector(t, g.NewElement(), h) }
+
+		for _, ev := range v.Encodings {
+			if !g.Base().Multiply(s(ev.Scalar)).Equal(e(ev.Element)) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		for _, av := range v.Arithmetic {
+			a, b, p, q := s(av.A), s(av.B), e(av.P), e(av.Q)
+
+			if !a.Copy().Add(b).Equal(s(av.AddAB)) || !a.Copy().Subtract(b).Equal(s(av.SubtractAB)) ||
+				!a.Copy().Multiply(b).Equal(s(av.MultiplyAB)) || !s(av.InvertA).Multiply(a).Equal(g.NewScalar().One()) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			if !p.Copy().Add(q).Equal(e(av.AddPQ)) || !p.Copy().Subtract(q).Equal(e(av.SubtractPQ)) ||
+				!p.Copy().Add(p).Equal(e(av.DoubleP)) || !p.Copy().Multiply(a).Equal(e(av.MultiplyAP)) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		for _, h := range v.HashToGroup {
+			if !g.HashToGroup([]byte(h.Msg), []byte("QUUX-V01-CS02-with-"+g.String())).Equal(e(h.Output)) {
+				t.Fatal(errExpectedEquality)
+			}
+		}
+
+		for _, h := range v.InvalidScalars {
+			if err = g.NewScalar().DecodeHex(h); err == nil {
+				t.Fatalf("expected error on decoding %s", h)
+			}
+		}
+
+		for _, h := range v.InvalidElements {
+			if err = g.NewElement().DecodeHex(h); err == nil {
+				t.Fatalf("expected error on decoding %s", h)
+			}
+		}
+	})
+}
+
+// TestGenerateVectors_RFC9380 verifies that the generated hash-to-curve vectors are those of RFC 9380.
+func TestGenerateVectors_RFC9380(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+
+		v, err := debug.GenerateVectors(g, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if v.HashToCurve == nil {
+			if g != ecc.Ristretto255Sha512 && !(circlBackend && g == ecc.P384Sha384) {
+				t.Fatal("missing hash-to-curve vectors")
+			}
+
+			return
+		}
+
+		file := strings.ReplaceAll(g.String(), ":", "-") + ".json"
+
+		expected, err := os.ReadFile(filepath.Join(hashToCurveVectorsFileLocation, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		generated, err := json.Marshal(v.HashToCurve)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var a, b any
+		if err = json.Unmarshal(expected, &a); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = json.Unmarshal(generated, &b); err != nil {
+			t.Fatal(err)
+		}
+
+		if !reflect.DeepEqual(a, b) {
+			t.Fatalf("generated vectors differ from %s", file)
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc/elgamal"
+	"github.com/bytemare/ecc/verenc"
+)
+
+func TestVerifiableEncryption(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		context := []byte("key backup")
+		trusteeSecret, trustee := elgamal.KeyGen(g)
+		secret := g.NewScalar().Random()
+		public := g.Base().Multiply(secret)
+
+		ct, err := verenc.Encrypt(context, trustee, secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = verenc.Verify(context, trustee, public, ct); err != nil {
+			t.Fatal(err)
+		}
+
+		decrypted, err := verenc.Decrypt(trusteeSecret, public, ct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !decrypted.Equal(secret) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if err = verenc.Verify([]byte("other"), trustee, public, ct); err == nil {
+			t.Fatal("expected error on wrong context")
+		}
+
+		if err = verenc.Verify(context, trustee, g.Base(), ct); err == nil {
+			t.Fatal("expected error on wrong public element")
+		}
+
+		_, otherTrustee := elgamal.KeyGen(g)
+		if err = verenc.Verify(context, otherTrustee, public, ct); err == nil {
+			t.Fatal("expected error on wrong trustee")
+		}
+
+		// Swapping two bit ciphertexts and their proofs keeps valid bit proofs, but breaks the link to the public key.
+		ct.Bits[0], ct.Bits[1] = ct.Bits[1], ct.Bits[0]
+		ct.BitProofs[0], ct.BitProofs[1] = ct.BitProofs[1], ct.BitProofs[0]
+
+		if err = verenc.Verify(context, trustee, public, ct); err == nil && !ct.Bits[0].C2.Equal(ct.Bits[1].C2) {
+			t.Fatal("expected error on swapped bits")
+		}
+
+		// A ciphertext of a non-bit is rejected.
+		ct.Bits[0].C2.Add(g.Base())
+
+		if err = verenc.Verify(context, trustee, public, ct); err == nil {
+			t.Fatal("expected error on tampered bit")
+		}
+
+		ct.Bits = ct.Bits[1:]
+
+		if err = verenc.Verify(context, trustee, public, ct); err == nil {
+			t.Fatal("expected error on truncated ciphertext")
+		}
+
+		if _, err = verenc.Decrypt(trusteeSecret, public, ct); err == nil {
+			t.Fatal("expected error on truncated ciphertext")
+		}
+
+		if _, err = verenc.Encrypt(context, nil, secret); err == nil {
+			t.Fatal("expected error on nil trustee")
+		}
+
+		if _, err = verenc.Encrypt(context, trustee, nil); err == nil {
+			t.Fatal("expected error on nil secret")
+		}
+	})
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+)
+
+func TestWorkspace(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		w := g.NewWorkspace(1, 1)
+
+		if w.Group() != g {
+			t.Fatal(errExpectedEquality)
+		}
+
+		s := g.NewScalar().Random()
+		e := g.Base().Multiply(g.NewScalar().Random())
+
+		// Borrowing beyond the preallocated temporaries grows the workspace.
+		e1, e2 := w.Element(), w.Copy(e)
+		s1, s2 := w.Scalar(), w.CopyScalar(s)
+
+		if !e1.IsIdentity() || !e2.Equal(e) || !s1.IsZero() || !s2.Equal(s) || e1 == e2 || s1 == s2 {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if !w.Multiply(e, s).Equal(e.Copy().Multiply(s)) || !e2.Equal(e) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// After a reset, the same temporaries are lent again, reset to the identity and zero.
+		e1.CacheEncoding()
+		w.Reset()
+
+		if r := w.Element(); r != e1 || !r.IsIdentity() || !r.Equal(g.Base().Identity()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		if r := w.Scalar(); r != s1 || !r.IsZero() {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestWorkspace_MultiScalarMult(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		w := g.NewWorkspace(0, 0)
+
+		for _, n := range []int{0, 1, 5, 3} {
+			scalars, elements := make([]*ecc.Scalar, n), make([]*ecc.Element, n)
+			for i := range n {
+				scalars[i] = g.NewScalar().Random()
+				elements[i] = g.Base().Multiply(g.NewScalar().Random())
+			}
+
+			expected, err := g.MultiScalarMult(scalars, elements)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r, err := w.MultiScalarMult(scalars, elements)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !r.Equal(expected) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			w.Reset()
+		}
+
+		if _, err := w.MultiScalarMult([]*ecc.Scalar{g.NewScalar()}, nil); !errors.Is(err,
+			internal.ErrParamLengthMismatch) {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestWorkspace_Allocations(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		if !allocationFree(g) {
+			return
+		}
+
+		w := g.NewWorkspace(4, 2)
+		s, c := g.NewScalar().Random(), g.NewScalar().Random()
+		p := g.Base().Multiply(g.NewScalar().Random())
+		scalars, elements := []*ecc.Scalar{s, c}, []*ecc.Element{g.Base(), p}
+
+		loop := func() {
+			w.Reset()
+			e := w.Multiply(p, w.CopyScalar(c).Add(s))
+			e.Add(w.Copy(p).Double())
+			_ = w.Element().Base().Multiply(w.Scalar().Add(s)).Equal(e)
+		}
+
+		if n := testing.AllocsPerRun(10, loop); n != 0 {
+			t.Errorf("%d allocations", int(n))
+		}
+
+		// Once the tables are allocated, multi-scalar multiplications only allocate the encodings of the scalars.
+		msm := func() {
+			w.Reset()
+
+			if _, err := w.MultiScalarMult(scalars, elements); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if n := testing.AllocsPerRun(10, msm); int(n) > len(scalars) {
+			t.Errorf("MultiScalarMult: %d allocations", int(n))
+		}
+	})
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bytemare/ecc/debug/wycheproof"
+)
+
+// wycheproofVectorsFileLocation holds ECDH and XDH vector files in the Wycheproof format. Upstream vector files can be
+// added to it as is.
+const wycheproofVectorsFileLocation = "wycheproof"
+
+func loadWycheproofCases(t *testing.T) []*wycheproof.Case {
+	files, err := filepath.Glob(filepath.Join(wycheproofVectorsFileLocation, "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var cases []*wycheproof.Case
+
+	for _, path := range files {
+		file, err := os.Open(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c, err := wycheproof.Parse(file)
+		_ = file.Close()
+
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+
+		cases = append(cases, c...)
+	}
+
+	if len(cases) == 0 {
+		t.Fatal("no Wycheproof test cases found")
+	}
+
+	return cases
+}
+
+func TestWycheproof(t *testing.T) {
+	for _, c := range loadWycheproofCases(t) {
+		t.Run(c.Name(), func(t *testing.T) {
+			shared, err := c.Compute()
+
+			switch c.Result {
+			case wycheproof.Valid:
+				if err != nil {
+					t.Fatalf("%s: unexpected error %q", c.Comment, err)
+				}
+
+				if !bytes.Equal(shared, c.Shared) {
+					t.Fatalf("%s: %s", c.Comment, errExpectedEquality)
+				}
+			case wycheproof.Invalid:
+				if err == nil && bytes.Equal(shared, c.Shared) {
+					t.Fatalf("%s: expected an error", c.Comment)
+				}
+			case wycheproof.Acceptable:
+				if err == nil && !bytes.Equal(shared, c.Shared) {
+					t.Fatalf("%s: %s", c.Comment, errExpectedEquality)
+				}
+			default:
+				t.Fatalf("unknown result %q", c.Result)
+			}
+		})
+	}
+}
+
+func TestWycheproof_Parse(t *testing.T) {
+	cases := loadWycheproofCases(t)
+
+	for _, c := range cases {
+		if !c.Group.Available() {
+			t.Fatalf("unexpected group %v for curve %q", c.Group, c.Curve)
+		}
+
+		switch c.Encoding {
+		case wycheproof.EncodingECPoint, wycheproof.EncodingASN, wycheproof.EncodingXDH:
+		default:
+			t.Fatalf("unexpected encoding %q", c.Encoding)
+		}
+	}
+
+	if _, err := wycheproof.Parse(bytes.NewBufferString(`{"algorithm":"EDDSA"}`)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if _, err := wycheproof.Parse(bytes.NewBufferString(`{"testGroups":[{"tests":[{"public":"zz"}]}]}`)); err == nil {
+		t.Fatal("expected error")
+	}
+
+	cases, err := wycheproof.Parse(bytes.NewBufferString(
+		`{"algorithm":"ECDH","testGroups":[{"curve":"brainpoolP256r1","tests":[{"tcId":1}]}]}`,
+	))
+	if err != nil || len(cases) != 0 {
+		t.Fatalf("expected unsupported curves to be skipped, got %d cases and error %v", len(cases), err)
+	}
+}
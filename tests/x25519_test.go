@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/x25519"
+)
+
+func testX25519(t *testing.T, scalar, point []byte) []byte {
+	out, err := x25519.X25519(scalar, point)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return out
+}
+
+// TestX25519_RFC7748 uses the test vectors of RFC 7748 sections 5.2 and 6.1.
+func TestX25519_RFC7748(t *testing.T) {
+	for _, v := range []struct{ scalar, point, expected string }{
+		{
+			"a546e36bf0527c9d3b16154b82465edd62144c0ac1fc5a18506a2244ba449ac4",
+			"e6db6867583030db3594c1a424b15f7c726624ec26b3353b10a903a6d0ab1c4c",
+			"c3da55379de9c6908e94ea4df28d084f32eccf03491c71f754b4075577a28552",
+		},
+		{
+			"4b66e9d4d1b4673c5ad22691957d6af5c11b6421e0ea01d42ca4169e7918ba0d",
+			"e5210f12786811d3f4b7959d0538ae2c31dbe7106fc03c3efc4cd549c715a493",
+			"95cbde9476e8907d7aade45cb4b873f88b595a68799fa152e6f8f7647aac7957",
+		},
+	} {
+		out := testX25519(t, decodeHex(t, v.scalar), decodeHex(t, v.point))
+		if !bytes.Equal(out, decodeHex(t, v.expected)) {
+			t.Fatalf("unexpected output %x", out)
+		}
+	}
+
+	// Iterated: k and u start as the base point, then u takes the value of k, and k the output.
+	k, u := bytes.Clone(x25519.Basepoint), bytes.Clone(x25519.Basepoint)
+
+	for i := 1; i <= 1000; i++ {
+		k, u = testX25519(t, k, u), k
+
+		switch i {
+		case 1:
+			if !bytes.Equal(k, decodeHex(t, "422c8e7a6227d7bca1350b3e2bb7279f7897b87bb6854b783c60e80311ae3079")) {
+				t.Fatalf("unexpected output after 1 iteration %x", k)
+			}
+		case 1000:
+			if !bytes.Equal(k, decodeHex(t, "684cf59ba83309552800ef566f2f4d3c1c3887c49360e3875f2eb94d99532c51")) {
+				t.Fatalf("unexpected output after 1000 iterations %x", k)
+			}
+		}
+	}
+
+	alice := decodeHex(t, "77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a")
+	bob := decodeHex(t, "5dab087e624a8a4b79e17f8b83800ee66f3bb1292618b6fd1c2f8b27ff88e0eb")
+	alicePublic := decodeHex(t, "8520f0098930a754748b7ddcb43ef75a0dbf3a0d26381af4eba4a98eaa9b4e6a")
+	bobPublic := decodeHex(t, "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f")
+	shared := decodeHex(t, "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742")
+
+	if !bytes.Equal(testX25519(t, alice, x25519.Basepoint), alicePublic) ||
+		!bytes.Equal(testX25519(t, bob, x25519.Basepoint), bobPublic) ||
+		!bytes.Equal(testX25519(t, alice, bobPublic), shared) ||
+		!bytes.Equal(testX25519(t, bob, alicePublic), shared) {
+		t.Fatal(errExpectedEquality)
+	}
+}
+
+func TestX25519_Interop(t *testing.T) {
+	for range 32 {
+		key, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		peer, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(testX25519(t, key.Bytes(), x25519.Basepoint), key.PublicKey().Bytes()) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		expected, err := key.ECDH(peer.PublicKey())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(testX25519(t, key.Bytes(), peer.PublicKey().Bytes()), expected) {
+			t.Fatal(errExpectedEquality)
+		}
+
+		// The Edwards25519 element of the public key maps to the same u-coordinate.
+		e, err := ecc.Edwards25519Sha512.ElementFromMontgomeryU(key.PublicKey().Bytes(), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(e.ToMontgomeryU(), key.PublicKey().Bytes()) {
+			t.Fatal(errExpectedEquality)
+		}
+	}
+}
+
+func TestX25519_Fails(t *testing.T) {
+	scalar := internal.RandomBytes(x25519.ScalarSize)
+
+	// Small order points: 0, 1, and the point of order 8 of RFC 7748.
+	for _, u := range []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"e0eb7a7c3b41b8ae1656e3faf19fc46ada098deb9c32b1fd866205165f49b800",
+	} {
+		if _, err := x25519.X25519(scalar, decodeHex(t, u)); !errors.Is(err, internal.ErrIdentity) {
+			t.Fatalf("unexpected error %q", err)
+		}
+	}
+
+	if _, err := x25519.X25519(scalar[1:], x25519.Basepoint); !errors.Is(err, internal.ErrParamScalarLength) {
+		t.Fatalf("unexpected error %q", err)
+	}
+
+	if _, err := x25519.X25519(scalar, x25519.Basepoint[1:]); !errors.Is(err, internal.ErrParamInvalidPointEncoding) {
+		t.Fatalf("unexpected error %q", err)
+	}
+}
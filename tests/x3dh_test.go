@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bytemare/ecc/ecdh"
+	"github.com/bytemare/ecc/x3dh"
+)
+
+func TestX3DH(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		info := []byte("MyProtocol")
+
+		aliceIdentity, aliceIdentityPublic := ecdh.GenerateKeyPair(g)
+		bobIdentity, bobIdentityPublic := ecdh.GenerateKeyPair(g)
+		bobPrekey, bobPrekeyPublic := ecdh.GenerateKeyPair(g)
+		bobOneTime, bobOneTimePublic := ecdh.GenerateKeyPair(g)
+
+		signature, err := x3dh.SignPrekey(bobIdentity, bobPrekeyPublic)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bundle := &x3dh.PrekeyBundle{
+			Identity:        bobIdentityPublic,
+			SignedPrekey:    bobPrekeyPublic,
+			OneTimePrekey:   bobOneTimePublic,
+			PrekeySignature: signature,
+		}
+
+		for _, oneTime := range []bool{true, false} {
+			responderOneTime := bobOneTime
+			if !oneTime {
+				bundle.OneTimePrekey, responderOneTime = nil, nil
+			}
+
+			alice, ephemeral, err := x3dh.Initiate(aliceIdentity, bundle, info)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bob, err := x3dh.Respond(bobIdentity, bobPrekey, responderOneTime, aliceIdentityPublic, ephemeral, info)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(alice.Key, bob.Key) || !bytes.Equal(alice.AssociatedData, bob.AssociatedData) {
+				t.Fatal(errExpectedEquality)
+			}
+
+			if len(alice.Key) != x3dh.KeyLength {
+				t.Fatalf("unexpected key length %d", len(alice.Key))
+			}
+
+			// The info and the one-time prekey are bound to the key.
+			other, err := x3dh.Respond(bobIdentity, bobPrekey, responderOneTime, aliceIdentityPublic, ephemeral, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if bytes.Equal(alice.Key, other.Key) {
+				t.Fatal(errUnExpectedEquality)
+			}
+
+			if oneTime {
+				other, err = x3dh.Respond(bobIdentity, bobPrekey, nil, aliceIdentityPublic, ephemeral, info)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if bytes.Equal(alice.Key, other.Key) {
+					t.Fatal(errUnExpectedEquality)
+				}
+			}
+		}
+
+		// The signed prekey must be signed by the identity key.
+		bundle.SignedPrekey = bobOneTimePublic
+		if _, _, err = x3dh.Initiate(aliceIdentity, bundle, info); err == nil {
+			t.Fatal("expected error on invalid prekey signature")
+		}
+
+		if _, _, err = x3dh.Initiate(aliceIdentity, nil, info); err == nil {
+			t.Fatal("expected error on nil bundle")
+		}
+
+		if _, err = x3dh.Respond(bobIdentity, bobPrekey, nil, nil, bobOneTimePublic, info); err == nil {
+			t.Fatal("expected error on nil peer identity")
+		}
+
+		if _, err = x3dh.SignPrekey(bobIdentity, nil); err == nil {
+			t.Fatal("expected error on nil prekey")
+		}
+	})
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bytemare/ecc"
+)
+
+type yamlConfig struct {
+	Scalar  *ecc.Scalar  `yaml:"scalar"`
+	Element *ecc.Element `yaml:"element"`
+	Group   ecc.Group    `yaml:"group"`
+}
+
+func TestYAML_RoundTrip(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		s := group.group.NewScalar().Random()
+		in := yamlConfig{
+			Scalar:  s,
+			Element: group.group.Base().Multiply(s),
+			Group:   group.group,
+		}
+
+		data, err := yaml.Marshal(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Values must be plain hex strings, without custom tags or structure.
+		if !strings.Contains(string(data), "scalar: "+s.Hex()+"\n") ||
+			!strings.Contains(string(data), "element: "+in.Element.Hex()+"\n") {
+			t.Fatalf("unexpected YAML encoding:\n%s", data)
+		}
+
+		// The receivers must be initialized to the group, as with JSON.
+		out := yamlConfig{
+			Scalar:  group.group.NewScalar(),
+			Element: group.group.NewElement(),
+		}
+
+		if err = yaml.Unmarshal(data, &out); err != nil {
+			t.Fatal(err)
+		}
+
+		if out.Group != group.group || !out.Scalar.Equal(in.Scalar) || !out.Element.Equal(in.Element) {
+			t.Fatal(errExpectedEquality)
+		}
+	})
+}
+
+func TestYAML_Fails(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		out := yamlConfig{Scalar: group.group.NewScalar(), Element: group.group.NewElement()}
+
+		err := yaml.Unmarshal([]byte("scalar: zz\n"), &out)
+		if err == nil || !strings.Contains(err.Error(), "scalar UnmarshalText") {
+			t.Fatalf("unexpected error %q", err)
+		}
+
+		err = yaml.Unmarshal([]byte("element: 0102\n"), &out)
+		if err == nil || !strings.Contains(err.Error(), "element UnmarshalText") {
+			t.Fatalf("unexpected error %q", err)
+		}
+	})
+}
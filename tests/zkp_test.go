@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc_test
+
+import (
+	"testing"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/zkp"
+)
+
+func testProof(t *testing.T, statement *zkp.Statement, witnesses []*ecc.Scalar) {
+	t.Helper()
+
+	context := []byte("context")
+
+	proof, err := zkp.Prove(context, statement, witnesses)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = zkp.Verify(context, statement, proof); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = zkp.Verify([]byte("other"), statement, proof); err == nil {
+		t.Fatal("expected error on wrong context")
+	}
+
+	proof.Responses[0].Add(statement.Group().NewScalar().One())
+
+	if err = zkp.Verify(context, statement, proof); err == nil {
+		t.Fatal("expected error on modified proof")
+	}
+
+	// Wrong witnesses are rejected by the prover.
+	wrong := make([]*ecc.Scalar, len(witnesses))
+	for i := range wrong {
+		wrong[i] = statement.Group().NewScalar().Random()
+	}
+
+	if _, err = zkp.Prove(context, statement, wrong); err == nil {
+		t.Fatal("expected error on wrong witnesses")
+	}
+}
+
+func TestZKP_Statements(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		x, r := g.NewScalar().Random(), g.NewScalar().Random()
+		h := g.HashToGroup([]byte("h"), []byte("dst"))
+
+		// Knowledge of a discrete logarithm.
+		dlog := zkp.DLog(g.Base(), g.Base().Multiply(x))
+		testProof(t, dlog, []*ecc.Scalar{x})
+
+		// Knowledge of a representation, i.e. a Pedersen commitment opening.
+		c := g.Base().Multiply(x).Add(h.Copy().Multiply(r))
+		testProof(t, zkp.Representation(c, g.Base(), h), []*ecc.Scalar{x, r})
+
+		// Equality of discrete logarithms.
+		eq := zkp.Equality([]*ecc.Element{g.Base(), h}, []*ecc.Element{g.Base().Multiply(x), h.Copy().Multiply(x)})
+		testProof(t, eq, []*ecc.Scalar{x})
+
+		// Conjunction.
+		and := zkp.And(zkp.DLog(g.Base(), g.Base().Multiply(x)), zkp.Representation(c, g.Base(), h))
+		testProof(t, and, []*ecc.Scalar{x, x, r})
+
+		// A statement with a shared witness: c commits to the discrete logarithm of y.
+		shared := zkp.NewStatement(g, 2).
+			AddEquation(g.Base().Multiply(x), zkp.Term{Base: g.Base()}).
+			AddEquation(c, zkp.Term{Base: g.Base()}, zkp.Term{Base: h, Witness: 1})
+		testProof(t, shared, []*ecc.Scalar{x, r})
+
+		// Malformed statements.
+		if _, err := zkp.Prove(nil, zkp.NewStatement(g, 1), []*ecc.Scalar{x}); err == nil {
+			t.Fatal("expected error on empty statement")
+		}
+
+		bad := zkp.NewStatement(g, 1).AddEquation(g.Base(), zkp.Term{Base: g.Base(), Witness: 1})
+		if _, err := zkp.Prove(nil, bad, []*ecc.Scalar{x}); err == nil {
+			t.Fatal("expected error on out of range witness")
+		}
+
+		if err := zkp.Verify(nil, dlog, nil); err == nil {
+			t.Fatal("expected error on nil proof")
+		}
+	})
+}
+
+func TestZKP_Or(t *testing.T) {
+	testAllGroups(t, func(group *testGroup) {
+		g := group.group
+		context := []byte("context")
+		x := g.NewScalar().Random()
+
+		statements := []*zkp.Statement{
+			zkp.DLog(g.Base(), g.Base().Multiply(g.NewScalar().Random())),
+			zkp.DLog(g.Base(), g.Base().Multiply(x)),
+			zkp.DLog(g.Base(), g.Base().Multiply(g.NewScalar().Random())),
+		}
+
+		proof, err := zkp.ProveOr(context, statements, 1, []*ecc.Scalar{x})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = zkp.VerifyOr(context, statements, proof); err != nil {
+			t.Fatal(err)
+		}
+
+		if err = zkp.VerifyOr([]byte("other"), statements, proof); err == nil {
+			t.Fatal("expected error on wrong context")
+		}
+
+		if err = zkp.VerifyOr(context, statements[:2], proof); err == nil {
+			t.Fatal("expected error on wrong statements")
+		}
+
+		proof.Challenges[0].Add(g.NewScalar().One())
+
+		if err = zkp.VerifyOr(context, statements, proof); err == nil {
+			t.Fatal("expected error on modified proof")
+		}
+
+		if _, err = zkp.ProveOr(context, statements, 0, []*ecc.Scalar{x}); err == nil {
+			t.Fatal("expected error on wrong witness")
+		}
+
+		if _, err = zkp.ProveOr(context, statements, 3, []*ecc.Scalar{x}); err == nil {
+			t.Fatal("expected error on out of range index")
+		}
+	})
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// AffinePoint holds the big-endian, fixed-length, encodings of the affine coordinates of a curve point.
+type AffinePoint struct {
+	X, Y []byte
+}
+
+// HashToCurveTrace holds the intermediate values of a hash-to-curve or encode-to-curve, as given in the test vectors
+// of RFC 9380 appendix J, for differential testing against other implementations. These values are not secret-safe
+// and must not be used in protocols.
+type HashToCurveTrace struct {
+	// P is the output element.
+	P *Element
+
+	// U are the field elements output by hash_to_field, big-endian and fixed-length.
+	U [][]byte
+
+	// Q are the outputs of map_to_curve for each U, before their addition and cofactor clearing. For Edwards25519,
+	// these are Edwards coordinates.
+	Q []AffinePoint
+}
+
+type hashToCurveTracer interface {
+	HashToCurveTrace(input, dst []byte, count uint) (u [][]byte, q [][2][]byte)
+}
+
+// HashToGroupTrace is like HashToGroup, but also returns the intermediate values of the hash-to-curve. It returns an
+// error for Ristretto255, whose hash-to-group has no such values.
+func (g Group) HashToGroupTrace(input, dst []byte) (*HashToCurveTrace, error) {
+	return g.trace(g.HashToGroup(input, dst), input, dst, 2)
+}
+
+// EncodeToGroupTrace is like EncodeToGroup, but also returns the intermediate values of the encode-to-curve. It
+// returns an error for Ristretto255, whose encode-to-group has no such values.
+func (g Group) EncodeToGroupTrace(input, dst []byte) (*HashToCurveTrace, error) {
+	return g.trace(g.EncodeToGroup(input, dst), input, dst, 1)
+}
+
+func (g Group) trace(p *Element, input, dst []byte, count uint) (*HashToCurveTrace, error) {
+	tracer, ok := g.get().(hashToCurveTracer)
+	if !ok {
+		return nil, fmt.Errorf("hash-to-curve trace: %w", internal.ErrInvalidGroup)
+	}
+
+	u, q := tracer.HashToCurveTrace(input, dst, count)
+
+	t := &HashToCurveTrace{
+		P: p,
+		U: u,
+		Q: make([]AffinePoint, len(q)),
+	}
+
+	for i, c := range q {
+		t.Q[i] = AffinePoint{X: c[0], Y: c[1]}
+	}
+
+	return t, nil
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package transcript implements Merlin-style Fiat-Shamir transcripts over any ecc group: the prover and the verifier
+// absorb the same sequence of labeled messages, elements, and scalars, and squeeze challenge scalars from everything
+// absorbed so far with the group's HashToScalar. Transcripts are bound to the protocol name and to the group's
+// ciphersuite, and every absorbed value is framed with its label and length, so that distinct sequences never share
+// an encoding.
+//
+// Challenges are absorbed back into the transcript, so that successive challenges are independent, and Clone forks a
+// transcript, e.g. to derive challenges for several sub-protocols from a common prefix.
+package transcript
+
+import (
+	"encoding/binary"
+
+	"github.com/bytemare/ecc"
+)
+
+const (
+	dstApp     = "ecc-transcript"
+	dstVersion = 1
+
+	opProtocol  byte = 'P'
+	opMessage   byte = 'M'
+	opChallenge byte = 'C'
+)
+
+// Transcript is the state of a Fiat-Shamir transcript. It is not safe for concurrent use.
+type Transcript struct {
+	state []byte
+	dst   []byte
+	group ecc.Group
+}
+
+// New returns a transcript for the protocol over the group. The protocol name must be unique to the protocol and its
+// version.
+func New(g ecc.Group, protocol string) *Transcript {
+	t := &Transcript{
+		group: g,
+		dst:   g.DST(dstApp, dstVersion),
+	}
+	t.append(opProtocol, "", []byte(protocol))
+
+	return t
+}
+
+// Group returns the group of the transcript.
+func (t *Transcript) Group() ecc.Group {
+	return t.group
+}
+
+func (t *Transcript) append(op byte, label string, data []byte) {
+	t.state = append(t.state, op)
+	t.state = binary.BigEndian.AppendUint16(t.state, uint16(len(label)))
+	t.state = append(t.state, label...)
+	t.state = binary.BigEndian.AppendUint32(t.state, uint32(len(data)))
+	t.state = append(t.state, data...)
+}
+
+// AppendMessage absorbs the labeled message.
+func (t *Transcript) AppendMessage(label string, message []byte) {
+	t.append(opMessage, label, message)
+}
+
+// AppendUint64 absorbs the labeled integer, e.g. a length or a dimension of the statement.
+func (t *Transcript) AppendUint64(label string, n uint64) {
+	t.append(opMessage, label, binary.BigEndian.AppendUint64(nil, n))
+}
+
+// AppendElement absorbs the encoding of the labeled element, which must not be nil.
+func (t *Transcript) AppendElement(label string, e *ecc.Element) {
+	t.append(opMessage, label, e.Encode())
+}
+
+// AppendElements absorbs the encodings of the labeled elements, which must not be nil, and their number.
+func (t *Transcript) AppendElements(label string, elements ...*ecc.Element) {
+	t.AppendUint64(label, uint64(len(elements)))
+
+	for _, e := range elements {
+		t.AppendElement(label, e)
+	}
+}
+
+// AppendScalar absorbs the encoding of the labeled scalar, which must not be nil.
+func (t *Transcript) AppendScalar(label string, s *ecc.Scalar) {
+	t.append(opMessage, label, s.Encode())
+}
+
+// Challenge returns the labeled challenge scalar derived from everything absorbed so far, and absorbs it.
+func (t *Transcript) Challenge(label string) *ecc.Scalar {
+	t.append(opChallenge, label, nil)
+	c := t.group.HashToScalar(t.state, t.dst)
+	t.state = append(t.state, c.Encode()...)
+
+	return c
+}
+
+// Clone returns an independent copy of the transcript.
+func (t *Transcript) Clone() *Transcript {
+	return &Transcript{
+		state: append([]byte(nil), t.state...),
+		dst:   t.dst,
+		group: t.group,
+	}
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// The Try methods mirror the arithmetic of elements and scalars, with the same results and the same handling of nil
+// arguments, but return an error instead of panicking when an operand is of another group, or is an uninitialized
+// zero value, e.g. in servers combining values of untrusted origin. They leave the receiver unchanged on error.
+
+// checkElement returns an error if e or element can't be operated on together, ignoring a nil element.
+func (e *Element) checkElement(element *Element) error {
+	if e == nil || e.Element == nil {
+		return internal.ErrParamNilPoint
+	}
+
+	if element == nil {
+		return nil
+	}
+
+	if element.Element == nil {
+		return internal.ErrParamNilPoint
+	}
+
+	if element.Group() != e.Group() {
+		return internal.ErrCastElement
+	}
+
+	return nil
+}
+
+// checkScalar returns an error if scalar can't be operated on with e, ignoring a nil scalar.
+func (e *Element) checkScalar(scalar *Scalar) error {
+	if e == nil || e.Element == nil {
+		return internal.ErrParamNilPoint
+	}
+
+	if scalar == nil {
+		return nil
+	}
+
+	if scalar.Scalar == nil {
+		return internal.ErrParamNilScalar
+	}
+
+	if scalar.Group() != e.Group() {
+		return internal.ErrCastScalar
+	}
+
+	return nil
+}
+
+// checkScalar returns an error if s or scalar can't be operated on together, ignoring a nil scalar.
+func (s *Scalar) checkScalar(scalar *Scalar) error {
+	if s == nil || s.Scalar == nil {
+		return internal.ErrParamNilScalar
+	}
+
+	if scalar == nil {
+		return nil
+	}
+
+	if scalar.Scalar == nil {
+		return internal.ErrParamNilScalar
+	}
+
+	if scalar.Group() != s.Group() {
+		return internal.ErrCastScalar
+	}
+
+	return nil
+}
+
+// TryAdd is Add, returning an error instead of panicking.
+func (e *Element) TryAdd(element *Element) (*Element, error) {
+	if err := e.checkElement(element); err != nil {
+		return nil, fmt.Errorf("element TryAdd: %w", err)
+	}
+
+	return e.Add(element), nil
+}
+
+// TrySubtract is Subtract, returning an error instead of panicking.
+func (e *Element) TrySubtract(element *Element) (*Element, error) {
+	if err := e.checkElement(element); err != nil {
+		return nil, fmt.Errorf("element TrySubtract: %w", err)
+	}
+
+	return e.Subtract(element), nil
+}
+
+// TryMultiply is Multiply, returning an error instead of panicking.
+func (e *Element) TryMultiply(scalar *Scalar) (*Element, error) {
+	if err := e.checkScalar(scalar); err != nil {
+		return nil, fmt.Errorf("element TryMultiply: %w", err)
+	}
+
+	return e.Multiply(scalar), nil
+}
+
+// TryEqual is Equal, returning an error instead of panicking.
+func (e *Element) TryEqual(element *Element) (bool, error) {
+	if err := e.checkElement(element); err != nil {
+		return false, fmt.Errorf("element TryEqual: %w", err)
+	}
+
+	return e.Equal(element), nil
+}
+
+// TrySet is Set, returning an error instead of panicking.
+func (e *Element) TrySet(element *Element) (*Element, error) {
+	if err := e.checkElement(element); err != nil {
+		return nil, fmt.Errorf("element TrySet: %w", err)
+	}
+
+	return e.Set(element), nil
+}
+
+// TryAdd is Add, returning an error instead of panicking.
+func (s *Scalar) TryAdd(scalar *Scalar) (*Scalar, error) {
+	if err := s.checkScalar(scalar); err != nil {
+		return nil, fmt.Errorf("scalar TryAdd: %w", err)
+	}
+
+	return s.Add(scalar), nil
+}
+
+// TrySubtract is Subtract, returning an error instead of panicking.
+func (s *Scalar) TrySubtract(scalar *Scalar) (*Scalar, error) {
+	if err := s.checkScalar(scalar); err != nil {
+		return nil, fmt.Errorf("scalar TrySubtract: %w", err)
+	}
+
+	return s.Subtract(scalar), nil
+}
+
+// TryMultiply is Multiply, returning an error instead of panicking.
+func (s *Scalar) TryMultiply(scalar *Scalar) (*Scalar, error) {
+	if err := s.checkScalar(scalar); err != nil {
+		return nil, fmt.Errorf("scalar TryMultiply: %w", err)
+	}
+
+	return s.Multiply(scalar), nil
+}
+
+// TryPow is Pow, returning an error instead of panicking.
+func (s *Scalar) TryPow(scalar *Scalar) (*Scalar, error) {
+	if err := s.checkScalar(scalar); err != nil {
+		return nil, fmt.Errorf("scalar TryPow: %w", err)
+	}
+
+	return s.Pow(scalar), nil
+}
+
+// TryEqual is Equal, returning an error instead of panicking.
+func (s *Scalar) TryEqual(scalar *Scalar) (bool, error) {
+	if err := s.checkScalar(scalar); err != nil {
+		return false, fmt.Errorf("scalar TryEqual: %w", err)
+	}
+
+	return s.Equal(scalar), nil
+}
+
+// TryLessOrEqual is LessOrEqual, returning an error instead of panicking.
+func (s *Scalar) TryLessOrEqual(scalar *Scalar) (bool, error) {
+	if err := s.checkScalar(scalar); err != nil {
+		return false, fmt.Errorf("scalar TryLessOrEqual: %w", err)
+	}
+
+	return s.LessOrEqual(scalar), nil
+}
+
+// TrySet is Set, returning an error instead of panicking.
+func (s *Scalar) TrySet(scalar *Scalar) (*Scalar, error) {
+	if err := s.checkScalar(scalar); err != nil {
+		return nil, fmt.Errorf("scalar TrySet: %w", err)
+	}
+
+	return s.Set(scalar), nil
+}
@@ -0,0 +1,471 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// GroupParams is implemented by the types naming the groups in the typed API, e.g. Typed[P256].
+type GroupParams interface {
+	Group() Group
+}
+
+type (
+	// Ristretto255 names Ristretto255Sha512 in the typed API.
+	Ristretto255 struct{}
+
+	// P256 names P256Sha256 in the typed API.
+	P256 struct{}
+
+	// P384 names P384Sha384 in the typed API.
+	P384 struct{}
+
+	// P521 names P521Sha512 in the typed API.
+	P521 struct{}
+
+	// Edwards25519 names Edwards25519Sha512 in the typed API.
+	Edwards25519 struct{}
+
+	// Secp256k1 names Secp256k1Sha256 in the typed API.
+	Secp256k1 struct{}
+)
+
+// Group returns Ristretto255Sha512.
+func (Ristretto255) Group() Group {
+	return Ristretto255Sha512
+}
+
+// Group returns P256Sha256.
+func (P256) Group() Group {
+	return P256Sha256
+}
+
+// Group returns P384Sha384.
+func (P384) Group() Group {
+	return P384Sha384
+}
+
+// Group returns P521Sha512.
+func (P521) Group() Group {
+	return P521Sha512
+}
+
+// Group returns Edwards25519Sha512.
+func (Edwards25519) Group() Group {
+	return Edwards25519Sha512
+}
+
+// Group returns Secp256k1Sha256.
+func (Secp256k1) Group() Group {
+	return Secp256k1Sha256
+}
+
+// The typed types implement the interfaces, so that protocol logic written against them also runs in the typed API.
+var (
+	_ GroupAPI[*TypedElement[P256], *TypedScalar[P256]]   = Typed[P256]{}
+	_ ElementAPI[*TypedElement[P256], *TypedScalar[P256]] = (*TypedElement[P256])(nil)
+	_ ScalarAPI[*TypedScalar[P256]]                       = (*TypedScalar[P256])(nil)
+)
+
+// Typed is the group G in the typed API, whose elements and scalars carry their group in their type, so that mixing
+// those of different groups, e.g. adding a TypedElement[P256] to a TypedElement[Secp256k1], doesn't compile, where
+// the dynamic API of Group, Element, and Scalar panics at runtime. The dynamic API remains the one for code choosing
+// its group at runtime, e.g. when negotiating ciphersuites, and Typed.Element, Typed.Scalar, TypedElement.Element, and
+// TypedScalar.Scalar convert between the two.
+//
+// Typed and the zero values of TypedElement and TypedScalar are ready to use, e.g.
+//
+//	var g ecc.Typed[ecc.P256]
+//	e := g.Base().Multiply(g.NewScalar().Random()) // *ecc.TypedElement[ecc.P256]
+type Typed[G GroupParams] struct{}
+
+func groupOf[G GroupParams]() Group {
+	var params G
+	return params.Group()
+}
+
+// Group returns the group of the dynamic API.
+func (Typed[G]) Group() Group {
+	return groupOf[G]()
+}
+
+// NewScalar returns a new scalar set to 0.
+func (Typed[G]) NewScalar() *TypedScalar[G] {
+	return &TypedScalar[G]{scalar: groupOf[G]().NewScalar()}
+}
+
+// NewElement returns a new element set to the identity point.
+func (Typed[G]) NewElement() *TypedElement[G] {
+	return &TypedElement[G]{element: groupOf[G]().NewElement()}
+}
+
+// Base returns a new element set to the group's base point.
+func (Typed[G]) Base() *TypedElement[G] {
+	return &TypedElement[G]{element: groupOf[G]().Base()}
+}
+
+// HashToScalar returns a safe mapping of the arbitrary input to a scalar, as Group.HashToScalar.
+func (Typed[G]) HashToScalar(input, dst []byte) *TypedScalar[G] {
+	return &TypedScalar[G]{scalar: groupOf[G]().HashToScalar(input, dst)}
+}
+
+// HashToGroup returns a safe mapping of the arbitrary input to an element, as Group.HashToGroup.
+func (Typed[G]) HashToGroup(input, dst []byte) *TypedElement[G] {
+	return &TypedElement[G]{element: groupOf[G]().HashToGroup(input, dst)}
+}
+
+// EncodeToGroup returns a non-uniform mapping of the arbitrary input to an element, as Group.EncodeToGroup.
+func (Typed[G]) EncodeToGroup(input, dst []byte) *TypedElement[G] {
+	return &TypedElement[G]{element: groupOf[G]().EncodeToGroup(input, dst)}
+}
+
+// ScalarLength returns the byte size of an encoded scalar.
+func (Typed[G]) ScalarLength() int {
+	return groupOf[G]().ScalarLength()
+}
+
+// ElementLength returns the byte size of an encoded element.
+func (Typed[G]) ElementLength() int {
+	return groupOf[G]().ElementLength()
+}
+
+// Order returns the order of the canonical group of scalars.
+func (Typed[G]) Order() []byte {
+	return groupOf[G]().Order()
+}
+
+// String returns the hash-to-curve string identifier of the ciphersuite.
+func (Typed[G]) String() string {
+	return groupOf[G]().String()
+}
+
+// Element returns a typed copy of the element of the dynamic API, and an error if it is nil or of another group.
+func (Typed[G]) Element(element *Element) (*TypedElement[G], error) {
+	if element == nil {
+		return nil, fmt.Errorf("typed Element: %w", internal.ErrParamNilPoint)
+	}
+
+	if element.Group() != groupOf[G]() {
+		return nil, fmt.Errorf("typed Element: %w", internal.ErrCastElement)
+	}
+
+	return &TypedElement[G]{element: element.Copy()}, nil
+}
+
+// Scalar returns a typed copy of the scalar of the dynamic API, and an error if it is nil or of another group.
+func (Typed[G]) Scalar(scalar *Scalar) (*TypedScalar[G], error) {
+	if scalar == nil {
+		return nil, fmt.Errorf("typed Scalar: %w", internal.ErrParamNilScalar)
+	}
+
+	if scalar.Group() != groupOf[G]() {
+		return nil, fmt.Errorf("typed Scalar: %w", internal.ErrCastScalar)
+	}
+
+	return &TypedScalar[G]{scalar: scalar.Copy()}, nil
+}
+
+// TypedElement is an element of the group G, whose zero value is the identity point. Its methods are those of Element,
+// restricted to the elements and scalars of G.
+type TypedElement[G GroupParams] struct {
+	element *Element
+}
+
+// get returns the element of the dynamic API, after setting it to the identity point in a zero value, and nil if the
+// receiver is nil, so that the dynamic API handles nil arguments.
+func (e *TypedElement[G]) get() *Element {
+	if e == nil {
+		return nil
+	}
+
+	if e.element == nil {
+		e.element = groupOf[G]().NewElement()
+	}
+
+	return e.element
+}
+
+// Element returns a copy of the element in the dynamic API.
+func (e *TypedElement[G]) Element() *Element {
+	return e.get().Copy()
+}
+
+// Group returns the group's identifier.
+func (e *TypedElement[G]) Group() Group {
+	return groupOf[G]()
+}
+
+// Base sets the element to the group's base point, and returns it.
+func (e *TypedElement[G]) Base() *TypedElement[G] {
+	e.get().Base()
+	return e
+}
+
+// Identity sets the element to the point at infinity, and returns it.
+func (e *TypedElement[G]) Identity() *TypedElement[G] {
+	e.get().Identity()
+	return e
+}
+
+// Add sets the receiver to the sum of the receiver and the argument, and returns the receiver.
+func (e *TypedElement[G]) Add(element *TypedElement[G]) *TypedElement[G] {
+	e.get().Add(element.get())
+	return e
+}
+
+// Double sets the receiver to its double, and returns it.
+func (e *TypedElement[G]) Double() *TypedElement[G] {
+	e.get().Double()
+	return e
+}
+
+// Negate sets the receiver to its negation, and returns it.
+func (e *TypedElement[G]) Negate() *TypedElement[G] {
+	e.get().Negate()
+	return e
+}
+
+// Subtract subtracts the argument from the receiver, and returns the receiver.
+func (e *TypedElement[G]) Subtract(element *TypedElement[G]) *TypedElement[G] {
+	e.get().Subtract(element.get())
+	return e
+}
+
+// Multiply sets the receiver to the scalar multiplication of the receiver with the scalar, and returns the receiver.
+func (e *TypedElement[G]) Multiply(scalar *TypedScalar[G]) *TypedElement[G] {
+	e.get().Multiply(scalar.get())
+	return e
+}
+
+// Equal returns true if the elements are equivalent, and false otherwise, with the timing of Element.Equal.
+func (e *TypedElement[G]) Equal(element *TypedElement[G]) bool {
+	if element == nil {
+		return false
+	}
+
+	return e.get().Equal(element.get())
+}
+
+// IsIdentity returns whether the element is the point at infinity.
+func (e *TypedElement[G]) IsIdentity() bool {
+	return e.get().IsIdentity()
+}
+
+// Set sets the receiver to the argument, and returns the receiver.
+func (e *TypedElement[G]) Set(element *TypedElement[G]) *TypedElement[G] {
+	if element == nil {
+		return e.Identity()
+	}
+
+	e.get().Set(element.get())
+
+	return e
+}
+
+// Copy returns a copy of the receiver.
+func (e *TypedElement[G]) Copy() *TypedElement[G] {
+	return &TypedElement[G]{element: e.get().Copy()}
+}
+
+// Encode returns the compressed byte encoding of the element.
+func (e *TypedElement[G]) Encode() []byte {
+	return e.get().Encode()
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+func (e *TypedElement[G]) Decode(data []byte) error {
+	return e.get().Decode(data)
+}
+
+// Hex returns the fixed-sized hexadecimal encoding of the element.
+func (e *TypedElement[G]) Hex() string {
+	return e.get().Hex()
+}
+
+// DecodeHex sets the receiver to the decoding of the hex encoded element.
+func (e *TypedElement[G]) DecodeHex(h string) error {
+	return e.get().DecodeHex(h)
+}
+
+// MarshalBinary returns the compressed byte encoding of the element.
+func (e *TypedElement[G]) MarshalBinary() ([]byte, error) {
+	return e.get().MarshalBinary()
+}
+
+// UnmarshalBinary sets the receiver to the decoding of the compressed binary encoding of the element.
+func (e *TypedElement[G]) UnmarshalBinary(data []byte) error {
+	return e.get().UnmarshalBinary(data)
+}
+
+// TypedScalar is a scalar of the group G, whose zero value is 0. Its methods are those of Scalar, restricted to the
+// scalars of G.
+type TypedScalar[G GroupParams] struct {
+	scalar *Scalar
+}
+
+// get returns the scalar of the dynamic API, after setting it to 0 in a zero value, and nil if the receiver is nil,
+// so that the dynamic API handles nil arguments.
+func (s *TypedScalar[G]) get() *Scalar {
+	if s == nil {
+		return nil
+	}
+
+	if s.scalar == nil {
+		s.scalar = groupOf[G]().NewScalar()
+	}
+
+	return s.scalar
+}
+
+// Scalar returns a copy of the scalar in the dynamic API.
+func (s *TypedScalar[G]) Scalar() *Scalar {
+	return s.get().Copy()
+}
+
+// Group returns the group's identifier.
+func (s *TypedScalar[G]) Group() Group {
+	return groupOf[G]()
+}
+
+// Zero sets the scalar to 0, and returns it.
+func (s *TypedScalar[G]) Zero() *TypedScalar[G] {
+	s.get().Zero()
+	return s
+}
+
+// One sets the scalar to 1, and returns it.
+func (s *TypedScalar[G]) One() *TypedScalar[G] {
+	s.get().One()
+	return s
+}
+
+// MinusOne sets the scalar to order-1, and returns it.
+func (s *TypedScalar[G]) MinusOne() *TypedScalar[G] {
+	s.get().MinusOne()
+	return s
+}
+
+// Random sets the scalar to a new random scalar, and returns it.
+func (s *TypedScalar[G]) Random() *TypedScalar[G] {
+	s.get().Random()
+	return s
+}
+
+// Add sets the receiver to the sum of the receiver and the argument, and returns the receiver.
+func (s *TypedScalar[G]) Add(scalar *TypedScalar[G]) *TypedScalar[G] {
+	s.get().Add(scalar.get())
+	return s
+}
+
+// Subtract subtracts the argument from the receiver, and returns the receiver.
+func (s *TypedScalar[G]) Subtract(scalar *TypedScalar[G]) *TypedScalar[G] {
+	s.get().Subtract(scalar.get())
+	return s
+}
+
+// Multiply multiplies the receiver with the argument, and returns the receiver.
+func (s *TypedScalar[G]) Multiply(scalar *TypedScalar[G]) *TypedScalar[G] {
+	s.get().Multiply(scalar.get())
+	return s
+}
+
+// Pow sets the receiver to the power of the receiver to the argument, and returns the receiver.
+func (s *TypedScalar[G]) Pow(scalar *TypedScalar[G]) *TypedScalar[G] {
+	s.get().Pow(scalar.get())
+	return s
+}
+
+// Invert sets the receiver to its modular inverse ( 1 / scalar ), and returns it.
+func (s *TypedScalar[G]) Invert() *TypedScalar[G] {
+	s.get().Invert()
+	return s
+}
+
+// Equal returns true if the scalars are equal, and false otherwise, in constant time.
+func (s *TypedScalar[G]) Equal(scalar *TypedScalar[G]) bool {
+	if scalar == nil {
+		return false
+	}
+
+	return s.get().Equal(scalar.get())
+}
+
+// LessOrEqual returns whether the receiver is lower than or equal to the argument.
+func (s *TypedScalar[G]) LessOrEqual(scalar *TypedScalar[G]) bool {
+	if scalar == nil {
+		return false
+	}
+
+	return s.get().LessOrEqual(scalar.get())
+}
+
+// IsZero returns whether the scalar is 0.
+func (s *TypedScalar[G]) IsZero() bool {
+	return s.get().IsZero()
+}
+
+// Set sets the receiver to the argument, and returns the receiver.
+func (s *TypedScalar[G]) Set(scalar *TypedScalar[G]) *TypedScalar[G] {
+	if scalar == nil {
+		return s.Zero()
+	}
+
+	s.get().Set(scalar.get())
+
+	return s
+}
+
+// SetUInt64 sets the scalar to the integer modulo the group order, and returns it.
+func (s *TypedScalar[G]) SetUInt64(i uint64) *TypedScalar[G] {
+	s.get().SetUInt64(i)
+	return s
+}
+
+// UInt64 returns the scalar as an integer, and an error if it doesn't fit in 64 bits.
+func (s *TypedScalar[G]) UInt64() (uint64, error) {
+	return s.get().UInt64()
+}
+
+// Copy returns a copy of the receiver.
+func (s *TypedScalar[G]) Copy() *TypedScalar[G] {
+	return &TypedScalar[G]{scalar: s.get().Copy()}
+}
+
+// Encode returns the byte encoding of the scalar.
+func (s *TypedScalar[G]) Encode() []byte {
+	return s.get().Encode()
+}
+
+// Decode sets the receiver to a decoding of the input data, and returns an error on failure.
+func (s *TypedScalar[G]) Decode(data []byte) error {
+	return s.get().Decode(data)
+}
+
+// Hex returns the fixed-sized hexadecimal encoding of the scalar.
+func (s *TypedScalar[G]) Hex() string {
+	return s.get().Hex()
+}
+
+// DecodeHex sets the receiver to the decoding of the hex encoded scalar.
+func (s *TypedScalar[G]) DecodeHex(h string) error {
+	return s.get().DecodeHex(h)
+}
+
+// MarshalBinary returns the byte encoding of the scalar.
+func (s *TypedScalar[G]) MarshalBinary() ([]byte, error) {
+	return s.get().MarshalBinary()
+}
+
+// UnmarshalBinary sets the receiver to the decoding of the binary encoding of the scalar.
+func (s *TypedScalar[G]) UnmarshalBinary(data []byte) error {
+	return s.get().UnmarshalBinary(data)
+}
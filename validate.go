@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"github.com/bytemare/ecc/internal"
+)
+
+// elementValidator is implemented by the backends that validate an element encoding without allocating an element.
+type elementValidator interface {
+	ValidateElementEncoding(data []byte) error
+}
+
+// ValidateScalarEncoding returns the error of Scalar.Decode on the data, i.e. an error if it is not the canonical
+// encoding of a scalar, without allocating a scalar, e.g. to reject bad input fast in ingestion pipelines. The data is
+// compared with the group's order in constant time, and is not retained.
+func (g Group) ValidateScalarEncoding(data []byte) error {
+	_ = g.get()
+	order := orders[g-1]
+
+	var err error
+
+	switch {
+	case len(data) != len(order):
+		err = internal.ErrParamScalarLength
+	case internal.LessOrEqual(order, data, g.littleEndian()) == 1:
+		err = internal.ErrParamScalarNotReduced
+	default:
+		return nil
+	}
+
+	return scalarDecodingError("ValidateScalarEncoding", err)
+}
+
+// ValidateElementEncoding returns the error of Element.Decode on the data, i.e. an error if it doesn't encode a point
+// on the curve other than the identity, e.g. to reject bad input fast in ingestion pipelines. The data is not
+// retained. All the encodings of the identity are rejected with an error matching ErrIdentity, including the single
+// zero byte of SEC 1 in the NIST groups. As Decode, it accepts in the NIST groups the uncompressed SEC 1 encodings,
+// which Encode doesn't return, so that a nil error doesn't mean that the data is the output of Encode. In
+// Edwards25519, it also returns an error if the point is not in the prime-order subgroup, which Decode accepts, and is
+// thus a few times slower than Decode. The default backends of Ristretto255, the NIST groups, and Edwards25519
+// validate the data without allocating an element, and the others decode it into a temporary one.
+func (g Group) ValidateElementEncoding(data []byte) error {
+	var err error
+
+	switch p := g.get().(type) {
+	case elementValidator:
+		err = p.ValidateElementEncoding(data)
+	default:
+		err = p.NewElement().Decode(data)
+	}
+
+	if err != nil {
+		return elementDecodingError("ValidateElementEncoding", err)
+	}
+
+	return nil
+}
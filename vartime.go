@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+
+	"filippo.io/edwards25519"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+// VarTime is the context of operations on public data only, e.g. to verify signatures or proofs received from
+// untrusted parties, with variable-time algorithms where they are faster than the constant-time defaults. The timing
+// of its operations depends on their inputs: never use it with secret scalars or elements.
+type VarTime struct {
+	group Group
+}
+
+// varTimeEqualer is implemented by the backends with a faster variable-time comparison of elements than their
+// constant-time one.
+type varTimeEqualer interface {
+	EqualVarTime(element internal.Element) int
+}
+
+// doubleMultiplier is implemented by the backends with a faster joint multiplication a * e + b * G, e.g. with an
+// endomorphism.
+type doubleMultiplier interface {
+	DoubleMultiply(a, b internal.Scalar) internal.Element
+}
+
+// VarTime returns the group's context for variable-time operations on public data.
+func (g Group) VarTime() VarTime {
+	return VarTime{group: g}
+}
+
+// Group returns the group of the context.
+func (v VarTime) Group() Group {
+	return v.group
+}
+
+func (v VarTime) check(scalars []*Scalar, elements []*Element) error {
+	for _, s := range scalars {
+		if s == nil {
+			return internal.ErrParamNilScalar
+		}
+
+		if s.Group() != v.group {
+			return internal.ErrCastScalar
+		}
+	}
+
+	for _, e := range elements {
+		if e == nil {
+			return internal.ErrParamNilPoint
+		}
+
+		if e.Group() != v.group {
+			return internal.ErrCastElement
+		}
+	}
+
+	return nil
+}
+
+// Equal returns whether the elements are equal, and false if either is nil or of another group, as
+// Element.EqualVarTime.
+func (v VarTime) Equal(a, b *Element) bool {
+	if v.check(nil, []*Element{a, b}) != nil {
+		return false
+	}
+
+	return a.EqualVarTime(b)
+}
+
+// EqualVarTime returns true if the elements are equivalent, and false otherwise, as Equal but in variable time: the
+// circl P-384 backend compares the coordinates without encoding them, and the others use Equal. Only use it to
+// compare public elements, e.g. in the verification of signatures.
+func (e *Element) EqualVarTime(element *Element) bool {
+	if element == nil {
+		return false
+	}
+
+	if v, ok := e.Element.(varTimeEqualer); ok {
+		return v.EqualVarTime(element.Element) == 1
+	}
+
+	return e.Element.Equal(element.Element) == 1
+}
+
+// EqualVarTime returns true if the scalars are equal, and false otherwise, as Equal. No backend compares scalars
+// faster in variable time, so it is Equal, for symmetry with Element.EqualVarTime.
+func (s *Scalar) EqualVarTime(scalar *Scalar) bool {
+	return s.Equal(scalar)
+}
+
+// Multiply returns a new element set to scalar * element. Only the Edwards25519 and Ristretto255 backends have a
+// faster variable-time single multiplication, and other groups use Element.Multiply.
+func (v VarTime) Multiply(element *Element, scalar *Scalar) (*Element, error) {
+	if err := v.check([]*Scalar{scalar}, []*Element{element}); err != nil {
+		return nil, fmt.Errorf("group VarTime.Multiply: %w", err)
+	}
+
+	if r := v.edwards25519DoubleMult(scalar, element, nil); r != nil {
+		return r, nil
+	}
+
+	return element.Copy().Multiply(scalar), nil
+}
+
+// DoubleMultiply returns a * element + b * G, where G is the base element, the typical equation of signature
+// verification.
+func (v VarTime) DoubleMultiply(a *Scalar, element *Element, b *Scalar) (*Element, error) {
+	if err := v.check([]*Scalar{a, b}, []*Element{element}); err != nil {
+		return nil, fmt.Errorf("group VarTime.DoubleMultiply: %w", err)
+	}
+
+	if r := v.edwards25519DoubleMult(a, element, b); r != nil {
+		return r, nil
+	}
+
+	if d, ok := element.Copy().Element.(doubleMultiplier); ok {
+		return newPoint(d.DoubleMultiply(a.Scalar, b.Scalar)), nil
+	}
+
+	// The backend's assembly and precomputed base tables are faster than interleaving for P-256.
+	if v.group == P256Sha256 {
+		return element.Copy().Multiply(a).Add(v.group.Base().Multiply(b)), nil
+	}
+
+	return v.group.strausMSM([]*Scalar{a, b}, []*Element{element, v.group.Base()}), nil
+}
+
+// MultiScalarMult is Group.MultiScalarMult, which is already variable-time.
+func (v VarTime) MultiScalarMult(scalars []*Scalar, elements []*Element) (*Element, error) {
+	return v.group.MultiScalarMult(scalars, elements)
+}
+
+// edwards25519DoubleMult returns a * A + b * G with the backend's variable-time double-base multiplication for the
+// Edwards25519 and Ristretto255 groups, where b is zero if nil, and returns nil if it is not available.
+func (v VarTime) edwards25519DoubleMult(a *Scalar, element *Element, b *Scalar) *Element {
+	if v.group != Edwards25519Sha512 && v.group != Ristretto255Sha512 {
+		return nil
+	}
+
+	s, p := a.Edwards25519Scalar(), element.Edwards25519Point()
+	if s == nil || p == nil {
+		return nil
+	}
+
+	sb := edwards25519.NewScalar()
+	if b != nil {
+		if sb = b.Edwards25519Scalar(); sb == nil {
+			return nil
+		}
+	}
+
+	r := v.group.NewElement()
+	if err := r.SetEdwards25519Point(new(edwards25519.Point).VarTimeDoubleScalarBaseMult(s, p, sb)); err != nil {
+		return nil
+	}
+
+	return r
+}
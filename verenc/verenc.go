@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package verenc implements verifiable encryption of discrete logarithms, for all groups: a prover encrypts the
+// secret scalar x of a public element X = x * G to a trustee, e.g. to escrow a key share, and anyone can verify that
+// the trustee will recover x. Camenisch-Shoup encryption needs a hidden order group, so the scalar is instead
+// encrypted bit by bit with exponential ElGamal under the trustee's key, with a proof that each ciphertext encrypts a
+// bit, and a proof that the bits are those of the discrete logarithm of X. Ciphertexts hold a pair of elements and an
+// OR proof per bit of the group order, i.e. 256 for 256-bit groups.
+package verenc
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/elgamal"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/zkp"
+)
+
+var errInvalidCiphertext = errors.New("invalid ciphertext")
+
+// Ciphertext is the verifiable encryption of a scalar: the bit ciphertexts, least significant first, the proofs that
+// they encrypt bits, and the proof that the bits are those of the public element's discrete logarithm.
+type Ciphertext struct {
+	Bits      []*elgamal.Ciphertext
+	BitProofs []*zkp.OrProof
+	Proof     *zkp.Proof
+}
+
+// bitLength returns the bit length of the group order.
+func bitLength(g ecc.Group) int {
+	order := g.Order()
+	if g == ecc.Edwards25519Sha512 || g == ecc.Ristretto255Sha512 {
+		order = slices.Clone(order)
+		slices.Reverse(order)
+	}
+
+	return new(big.Int).SetBytes(order).BitLen()
+}
+
+// bit returns the bit of the scalar at index i, least significant first.
+func bit(s *ecc.Scalar, i int) uint {
+	b := s.Encode()
+	if g := s.Group(); g != ecc.Edwards25519Sha512 && g != ecc.Ristretto255Sha512 {
+		slices.Reverse(b)
+	}
+
+	return uint(b[i/8]>>(i%8)) & 1
+}
+
+// bitStatements returns the statements that the ciphertext encrypts 0 or 1, i.e. that (C1, C2) or (C1, C2 - G) have
+// the same discrete logarithm to (G, Y).
+func bitStatements(trustee, minusG *ecc.Element, c *elgamal.Ciphertext) []*zkp.Statement {
+	bases := []*ecc.Element{trustee.Group().Base(), trustee}
+
+	return []*zkp.Statement{
+		zkp.Equality(bases, []*ecc.Element{c.C1, c.C2}),
+		zkp.Equality(bases, []*ecc.Element{c.C1, c.C2.Copy().Add(minusG)}),
+	}
+}
+
+// linkStatement returns the statement that sum(2^i * C2_i) - X and sum(2^i * C1_i) have the same discrete logarithm
+// to (Y, G), i.e. that the bits encrypt the discrete logarithm of X.
+func linkStatement(trustee, public *ecc.Element, bits []*elgamal.Ciphertext) (*zkp.Statement, error) {
+	g := trustee.Group()
+	powers := make([]*ecc.Scalar, len(bits))
+	c1 := make([]*ecc.Element, len(bits))
+	c2 := make([]*ecc.Element, len(bits)+1)
+
+	for i, c := range bits {
+		if i == 0 {
+			powers[i] = g.NewScalar().One()
+		} else {
+			powers[i] = powers[i-1].Copy().Add(powers[i-1])
+		}
+
+		c1[i], c2[i] = c.C1, c.C2
+	}
+
+	a, err := g.MultiScalarMult(powers, c1)
+	if err != nil {
+		return nil, fmt.Errorf("verenc: %w", err)
+	}
+
+	c2[len(bits)] = public
+
+	b, err := g.MultiScalarMult(append(powers, g.NewScalar().MinusOne()), c2)
+	if err != nil {
+		return nil, fmt.Errorf("verenc: %w", err)
+	}
+
+	return zkp.Equality([]*ecc.Element{g.Base(), trustee}, []*ecc.Element{a, b}), nil
+}
+
+func checkKeys(trustee, public *ecc.Element) error {
+	if trustee == nil || public == nil || trustee.IsIdentity() {
+		return internal.ErrParamNilPoint
+	}
+
+	if trustee.Group() != public.Group() {
+		return internal.ErrCastElement
+	}
+
+	return nil
+}
+
+// Encrypt returns the verifiable encryption of the secret to the trustee's public key, which proves that it encrypts
+// the discrete logarithm of secret * G. The context binds the proofs, e.g. to a session or the identity of the prover.
+func Encrypt(context []byte, trustee *ecc.Element, secret *ecc.Scalar) (*Ciphertext, error) {
+	if secret == nil || secret.IsZero() {
+		return nil, fmt.Errorf("verenc: %w", internal.ErrParamNilScalar)
+	}
+
+	g := secret.Group()
+	public := g.Base().Multiply(secret)
+
+	if err := checkKeys(trustee, public); err != nil {
+		return nil, fmt.Errorf("verenc: %w", err)
+	}
+
+	n := bitLength(g)
+	minusG := g.Base().Multiply(g.NewScalar().MinusOne())
+	ct := &Ciphertext{
+		Bits:      make([]*elgamal.Ciphertext, n),
+		BitProofs: make([]*zkp.OrProof, n),
+	}
+
+	// r is the sum of the bit ciphertexts' randomness weighted by the powers of 2.
+	r := g.NewScalar()
+	power := g.NewScalar().One()
+
+	for i := range n {
+		ri := g.NewScalar().Random()
+		b := bit(secret, i)
+
+		ct.Bits[i] = &elgamal.Ciphertext{
+			C1: g.Base().Multiply(ri),
+			C2: trustee.Copy().Multiply(ri),
+		}
+
+		if b == 1 {
+			ct.Bits[i].C2.Add(g.Base())
+		}
+
+		proof, err := zkp.ProveOr(context, bitStatements(trustee, minusG, ct.Bits[i]), int(b), []*ecc.Scalar{ri})
+		if err != nil {
+			return nil, fmt.Errorf("verenc: %w", err)
+		}
+
+		ct.BitProofs[i] = proof
+		r.Add(ri.Multiply(power))
+		power.Add(power)
+	}
+
+	link, err := linkStatement(trustee, public, ct.Bits)
+	if err != nil {
+		return nil, err
+	}
+
+	if ct.Proof, err = zkp.Prove(context, link, []*ecc.Scalar{r}); err != nil {
+		return nil, fmt.Errorf("verenc: %w", err)
+	}
+
+	return ct, nil
+}
+
+// Verify returns nil if the ciphertext encrypts the discrete logarithm of the public element to the trustee.
+func Verify(context []byte, trustee, public *ecc.Element, ct *Ciphertext) error {
+	if err := checkKeys(trustee, public); err != nil {
+		return fmt.Errorf("verenc: %w", err)
+	}
+
+	g := trustee.Group()
+	if err := ct.check(g); err != nil {
+		return fmt.Errorf("verenc: %w", err)
+	}
+
+	// The link proof is much cheaper than the bit proofs, and is verified first to reject invalid ciphertexts early.
+	link, err := linkStatement(trustee, public, ct.Bits)
+	if err != nil {
+		return err
+	}
+
+	if err = zkp.Verify(context, link, ct.Proof); err != nil {
+		return fmt.Errorf("verenc: %w", err)
+	}
+
+	minusG := g.Base().Multiply(g.NewScalar().MinusOne())
+
+	for i, c := range ct.Bits {
+		if err = zkp.VerifyOr(context, bitStatements(trustee, minusG, c), ct.BitProofs[i]); err != nil {
+			return fmt.Errorf("verenc: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (ct *Ciphertext) check(g ecc.Group) error {
+	n := bitLength(g)
+	if ct == nil || ct.Proof == nil || len(ct.Bits) != n || len(ct.BitProofs) != n {
+		return errInvalidCiphertext
+	}
+
+	for i, c := range ct.Bits {
+		if c == nil || c.C1 == nil || c.C2 == nil || ct.BitProofs[i] == nil {
+			return errInvalidCiphertext
+		}
+
+		if c.C1.Group() != g || c.C2.Group() != g {
+			return internal.ErrCastElement
+		}
+	}
+
+	return nil
+}
+
+// Decrypt returns the scalar encrypted to the trustee's private key, and an error if it is not the discrete logarithm
+// of the public element. Verify the ciphertext before accepting it, as Decrypt fails on invalid ciphertexts only once
+// they need to be decrypted.
+func Decrypt(trustee *ecc.Scalar, public *ecc.Element, ct *Ciphertext) (*ecc.Scalar, error) {
+	if trustee == nil || trustee.IsZero() {
+		return nil, fmt.Errorf("verenc: %w", internal.ErrParamNilScalar)
+	}
+
+	g := trustee.Group()
+	if err := checkKeys(g.Base(), public); err != nil {
+		return nil, fmt.Errorf("verenc: %w", err)
+	}
+
+	if err := ct.check(g); err != nil {
+		return nil, fmt.Errorf("verenc: %w", err)
+	}
+
+	minusTrustee := g.NewScalar().Subtract(trustee)
+	secret := g.NewScalar()
+
+	for i := len(ct.Bits) - 1; i >= 0; i-- {
+		m := ct.Bits[i].C1.Copy().Multiply(minusTrustee).Add(ct.Bits[i].C2)
+		secret.Add(secret)
+
+		switch {
+		case m.IsIdentity():
+		case m.Equal(g.Base()):
+			secret.Add(g.NewScalar().One())
+		default:
+			return nil, fmt.Errorf("verenc: %w", errInvalidCiphertext)
+		}
+	}
+
+	if !g.Base().Multiply(secret).Equal(public) {
+		return nil, fmt.Errorf("verenc: %w", errInvalidCiphertext)
+	}
+
+	return secret, nil
+}
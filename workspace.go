@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package ecc
+
+import (
+	"fmt"
+)
+
+// Workspace holds preallocated elements and scalars of a group, which it lends as temporaries until Reset, so that
+// hot loops of protocols don't allocate them again on each iteration, without pooling individual objects. It grows
+// to the largest number of temporaries borrowed between two resets, and then stops allocating. Elements and scalars
+// borrowed from a workspace must not be used after its Reset. A Workspace is not safe for concurrent use.
+type Workspace struct {
+	group    Group
+	elements []*Element
+	scalars  []*Scalar
+	tables   [][msmTableSize]*Element
+	digits   [][]byte
+	nElement int
+	nScalar  int
+}
+
+// NewWorkspace returns a workspace of the group with the given numbers of preallocated elements and scalars.
+func (g Group) NewWorkspace(elements, scalars int) *Workspace {
+	w := &Workspace{
+		group:    g,
+		elements: make([]*Element, elements),
+		scalars:  make([]*Scalar, scalars),
+	}
+
+	for i := range w.elements {
+		w.elements[i] = g.NewElement()
+	}
+
+	for i := range w.scalars {
+		w.scalars[i] = g.NewScalar()
+	}
+
+	return w
+}
+
+// Group returns the group of the workspace.
+func (w *Workspace) Group() Group {
+	return w.group
+}
+
+// Reset returns all the borrowed elements and scalars to the workspace, to be lent again.
+func (w *Workspace) Reset() {
+	w.nElement = 0
+	w.nScalar = 0
+}
+
+// Element returns an element of the workspace set to the identity, valid until the next Reset.
+func (w *Workspace) Element() *Element {
+	if w.nElement == len(w.elements) {
+		w.elements = append(w.elements, w.group.NewElement())
+	}
+
+	e := w.elements[w.nElement]
+	w.nElement++
+
+	e.cached = false
+	e.encoding = nil
+
+	return e.Identity()
+}
+
+// Scalar returns a scalar of the workspace set to zero, valid until the next Reset.
+func (w *Workspace) Scalar() *Scalar {
+	if w.nScalar == len(w.scalars) {
+		w.scalars = append(w.scalars, w.group.NewScalar())
+	}
+
+	s := w.scalars[w.nScalar]
+	w.nScalar++
+
+	return s.Zero()
+}
+
+// Copy returns an element of the workspace set to the value of e, as Element.Copy.
+func (w *Workspace) Copy(e *Element) *Element {
+	return w.Element().Set(e)
+}
+
+// CopyScalar returns a scalar of the workspace set to the value of s, as Scalar.Copy.
+func (w *Workspace) CopyScalar(s *Scalar) *Scalar {
+	return w.Scalar().Set(s)
+}
+
+// Multiply returns an element of the workspace set to scalar * element, leaving element unchanged.
+func (w *Workspace) Multiply(element *Element, scalar *Scalar) *Element {
+	return w.Copy(element).Multiply(scalar)
+}
+
+// MultiScalarMult returns an element of the workspace set to sum(scalars_i * elements_i), as Group.MultiScalarMult,
+// but with Straus' method over tables of multiples kept in the workspace, so that it only allocates the encodings of
+// the scalars once the workspace has grown to the number of terms. It is not constant-time.
+func (w *Workspace) MultiScalarMult(scalars []*Scalar, elements []*Element) (*Element, error) {
+	if err := w.group.checkMSM(scalars, elements); err != nil {
+		return nil, fmt.Errorf("group Workspace.MultiScalarMult: %w", err)
+	}
+
+	for len(w.tables) < len(elements) {
+		var t [msmTableSize]*Element
+		for j := 1; j < msmTableSize; j++ {
+			t[j] = w.group.NewElement()
+		}
+
+		w.tables = append(w.tables, t)
+	}
+
+	if len(w.digits) < len(scalars) {
+		w.digits = make([][]byte, len(scalars))
+	}
+
+	r := w.group.straus(w.Element(), w.tables[:len(elements)], w.digits[:len(scalars)], scalars, elements)
+	clear(w.digits)
+
+	return r, nil
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package x25519 implements the byte-oriented X25519 function of RFC 7748, as a drop-in replacement for
+// golang.org/x/crypto/curve25519, with the Montgomery ladder over the Curve25519 field. It rejects all-zero outputs,
+// i.e. small order inputs. X448 is not supported, as this module has no Curve448 group.
+//
+// Use Edwards25519Sha512 elements' ToMontgomeryU and Group.ElementFromMontgomeryU to convert between the two.
+package x25519
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"filippo.io/edwards25519/field"
+
+	"github.com/bytemare/ecc/internal"
+)
+
+const (
+	// ScalarSize is the size of the scalars used with X25519, in bytes.
+	ScalarSize = 32
+
+	// PointSize is the size of the u-coordinates used with X25519, in bytes.
+	PointSize = 32
+
+	// a24 is (486662 - 2) / 4, for the Curve25519 ladder.
+	a24 = 121665
+)
+
+// Basepoint is the canonical Curve25519 generator, u = 9.
+var Basepoint = []byte{9, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+// X25519 returns the result of the scalar multiplication (scalar * point) of RFC 7748 section 5, where point is a
+// u-coordinate, e.g. Basepoint or a peer's public key. The scalar is clamped, and the point's most significant bit is
+// ignored. It returns an error if the output is all zeros, as it is for small order points.
+func X25519(scalar, point []byte) ([]byte, error) {
+	if len(scalar) != ScalarSize {
+		return nil, fmt.Errorf("x25519: %w", internal.ErrParamScalarLength)
+	}
+
+	if len(point) != PointSize {
+		return nil, fmt.Errorf("x25519: %w", internal.ErrParamInvalidPointEncoding)
+	}
+
+	out := ladder(scalar, point)
+
+	if subtle.ConstantTimeCompare(out, make([]byte, PointSize)) == 1 {
+		return nil, fmt.Errorf("x25519: %w", internal.ErrIdentity)
+	}
+
+	return out, nil
+}
+
+// ladder implements the constant-time Montgomery ladder of RFC 7748 section 5.
+func ladder(scalar, point []byte) []byte {
+	var k [ScalarSize]byte
+
+	copy(k[:], scalar)
+	k[0] &= 248
+	k[31] &= 127
+	k[31] |= 64
+
+	// SetBytes ignores the most significant bit and accepts non-canonical encodings, as required.
+	x1, err := new(field.Element).SetBytes(point)
+	if err != nil {
+		// Only happens with an invalid length, which is checked beforehand.
+		panic(err)
+	}
+
+	x2 := new(field.Element).One()
+	z2 := new(field.Element).Zero()
+	x3 := new(field.Element).Set(x1)
+	z3 := new(field.Element).One()
+
+	var a, aa, b, bb, e, c, d, da, cb field.Element
+
+	swap := 0
+
+	for t := 254; t >= 0; t-- {
+		kt := int(k[t/8]>>(t%8)) & 1
+		swap ^= kt
+		x2.Swap(x3, swap)
+		z2.Swap(z3, swap)
+		swap = kt
+
+		a.Add(x2, z2)
+		aa.Square(&a)
+		b.Subtract(x2, z2)
+		bb.Square(&b)
+		e.Subtract(&aa, &bb)
+		c.Add(x3, z3)
+		d.Subtract(x3, z3)
+		da.Multiply(&d, &a)
+		cb.Multiply(&c, &b)
+
+		x3.Add(&da, &cb)
+		x3.Square(x3)
+		z3.Subtract(&da, &cb)
+		z3.Square(z3)
+		z3.Multiply(z3, x1)
+		x2.Multiply(&aa, &bb)
+		z2.Mult32(&e, a24)
+		z2.Add(z2, &aa)
+		z2.Multiply(z2, &e)
+	}
+
+	x2.Swap(x3, swap)
+	z2.Swap(z3, swap)
+
+	return x2.Multiply(x2, z2.Invert(z2)).Bytes()
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package x3dh implements the Extended Triple Diffie-Hellman (X3DH) key agreement of the Signal protocol, for all
+// groups: an initiator derives a shared key with a responder from the responder's published prekey bundle, and the
+// responder derives the same key from the initiator's identity and ephemeral keys, even if it was offline.
+//
+// The Diffie-Hellman outputs are those of the ecdh package, and the key derivation is that of the X3DH specification
+// with HKDF and the group's hash function. Signed prekeys are signed with the schnorr package and the identity key,
+// instead of XEdDSA.
+package x3dh
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/ecdh"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/schnorr"
+)
+
+// KeyLength is the byte length of the shared keys.
+const KeyLength = 32
+
+var (
+	errInvalidBundle    = errors.New("invalid prekey bundle")
+	errInvalidSignature = errors.New("invalid signed prekey signature")
+)
+
+// PrekeyBundle is the set of public keys a responder publishes so that initiators can agree on keys with it. The
+// one-time prekey is optional, but should be used when available, and deleted by the responder after its use.
+type PrekeyBundle struct {
+	Identity        *ecc.Element
+	SignedPrekey    *ecc.Element
+	OneTimePrekey   *ecc.Element
+	PrekeySignature []byte
+}
+
+// SharedSecret is the result of the key agreement: the shared key, and the associated data binding both identities,
+// which the first messages of the session should authenticate.
+type SharedSecret struct {
+	Key            []byte
+	AssociatedData []byte
+}
+
+// SignPrekey returns the signature of the signed prekey with the identity private key.
+func SignPrekey(identity *ecc.Scalar, prekey *ecc.Element) ([]byte, error) {
+	if prekey == nil {
+		return nil, fmt.Errorf("x3dh: %w", internal.ErrParamNilPoint)
+	}
+
+	sig, err := schnorr.Sign(identity, prekey.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("x3dh: %w", err)
+	}
+
+	return sig, nil
+}
+
+// Initiate verifies the responder's prekey bundle, and returns the shared secret and the ephemeral public key, which
+// the initiator sends to the responder along with its identity public key and the one-time prekey it used, if any.
+func Initiate(identity *ecc.Scalar, bundle *PrekeyBundle, info []byte) (*SharedSecret, *ecc.Element, error) {
+	if identity == nil || identity.IsZero() {
+		return nil, nil, fmt.Errorf("x3dh: %w", internal.ErrParamNilScalar)
+	}
+
+	if bundle == nil || bundle.Identity == nil || bundle.SignedPrekey == nil {
+		return nil, nil, fmt.Errorf("x3dh: %w", errInvalidBundle)
+	}
+
+	if err := schnorr.Verify(bundle.Identity, bundle.SignedPrekey.Encode(), bundle.PrekeySignature); err != nil {
+		return nil, nil, fmt.Errorf("x3dh: %w", errInvalidSignature)
+	}
+
+	g := identity.Group()
+	ephemeral, ephemeralPublic := ecdh.GenerateKeyPair(g)
+
+	pairs := []dhPair{
+		{identity, bundle.SignedPrekey},
+		{ephemeral, bundle.Identity},
+		{ephemeral, bundle.SignedPrekey},
+	}
+
+	if bundle.OneTimePrekey != nil {
+		pairs = append(pairs, dhPair{ephemeral, bundle.OneTimePrekey})
+	}
+
+	secret, err := derive(g, pairs, g.Base().Multiply(identity), bundle.Identity, info)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return secret, ephemeralPublic, nil
+}
+
+// Respond returns the shared secret of the initiator's identity and ephemeral public keys, with the responder's
+// identity, signed prekey, and one-time prekey private keys. The latter must be nil if the initiator did not use it.
+func Respond(identity, signedPrekey, oneTimePrekey *ecc.Scalar,
+	peerIdentity, peerEphemeral *ecc.Element, info []byte,
+) (*SharedSecret, error) {
+	if identity == nil || signedPrekey == nil {
+		return nil, fmt.Errorf("x3dh: %w", internal.ErrParamNilScalar)
+	}
+
+	pairs := []dhPair{
+		{signedPrekey, peerIdentity},
+		{identity, peerEphemeral},
+		{signedPrekey, peerEphemeral},
+	}
+
+	if oneTimePrekey != nil {
+		pairs = append(pairs, dhPair{oneTimePrekey, peerEphemeral})
+	}
+
+	g := identity.Group()
+
+	return derive(g, pairs, peerIdentity, g.Base().Multiply(identity), info)
+}
+
+// dhPair is the private and public keys of one of the Diffie-Hellman operations.
+type dhPair struct {
+	private *ecc.Scalar
+	public  *ecc.Element
+}
+
+// derive returns the shared secret, with SK = HKDF(F || DH1 || DH2 || DH3 [|| DH4]), where F is as many 0xFF bytes
+// as a DH output, and AD = Encode(IK_A) || Encode(IK_B).
+func derive(g ecc.Group, pairs []dhPair, initiator, responder *ecc.Element, info []byte) (*SharedSecret, error) {
+	var ikm []byte
+
+	for _, p := range pairs {
+		dh, err := ecdh.DH(p.private, p.public)
+		if err != nil {
+			return nil, fmt.Errorf("x3dh: %w", err)
+		}
+
+		if ikm == nil {
+			ikm = bytes.Repeat([]byte{0xff}, len(dh))
+		}
+
+		ikm = append(ikm, dh...)
+	}
+
+	salt := make([]byte, g.HashFunc().Size())
+	key := make([]byte, KeyLength)
+
+	if _, err := io.ReadFull(hkdf.New(g.HashFunc().New, ikm, salt, info), key); err != nil {
+		return nil, fmt.Errorf("x3dh: %w", err)
+	}
+
+	return &SharedSecret{
+		Key:            key,
+		AssociatedData: append(initiator.Encode(), responder.Encode()...),
+	}, nil
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package zkp
+
+import (
+	"github.com/bytemare/ecc"
+)
+
+// OrProof is a non-interactive proof that at least one of several statements holds, without revealing which, with
+// the technique of Cramer, Damgard, and Schoenmakers: the challenges of the branches sum to the Fiat-Shamir
+// challenge, and all but the known branch are simulated.
+type OrProof struct {
+	Challenges []*ecc.Scalar
+	Responses  [][]*ecc.Scalar
+}
+
+// ProveOr returns the proof that one of the statements holds, given the witnesses of the statement at index known.
+// All statements must be over the same group.
+func ProveOr(context []byte, statements []*Statement, known int, witnesses []*ecc.Scalar) (*OrProof, error) {
+	if err := checkStatements(statements); err != nil {
+		return nil, wrap(err)
+	}
+
+	if known < 0 || known >= len(statements) {
+		return nil, wrap(errInvalidWitness)
+	}
+
+	if err := statements[known].checkWitnesses(witnesses); err != nil {
+		return nil, wrap(err)
+	}
+
+	g := statements[0].group
+	proof := &OrProof{
+		Challenges: make([]*ecc.Scalar, len(statements)),
+		Responses:  make([][]*ecc.Scalar, len(statements)),
+	}
+	commitments := make([][]*ecc.Element, len(statements))
+
+	var nonces []*ecc.Scalar
+
+	simulated := g.NewScalar()
+
+	for i, s := range statements {
+		if i == known {
+			nonces = randomScalars(g, s.Witnesses)
+			commitments[i] = s.evaluate(nonces)
+
+			continue
+		}
+
+		// Simulate the branch from a random challenge and random responses.
+		proof.Challenges[i] = g.NewScalar().Random()
+		proof.Responses[i] = randomScalars(g, s.Witnesses)
+		commitments[i] = s.commitments(proof.Challenges[i], proof.Responses[i])
+
+		simulated.Add(proof.Challenges[i])
+	}
+
+	c := challenge(g, context, statements, commitments).Subtract(simulated)
+	proof.Challenges[known] = c
+
+	proof.Responses[known] = make([]*ecc.Scalar, len(nonces))
+	for i, r := range nonces {
+		proof.Responses[known][i] = r.Add(c.Copy().Multiply(witnesses[i]))
+	}
+
+	return proof, nil
+}
+
+// VerifyOr returns nil if the proof that one of the statements holds is valid for the context.
+func VerifyOr(context []byte, statements []*Statement, proof *OrProof) error {
+	if err := checkStatements(statements); err != nil {
+		return wrap(err)
+	}
+
+	if proof == nil || len(proof.Challenges) != len(statements) || len(proof.Responses) != len(statements) {
+		return wrap(errInvalidProof)
+	}
+
+	g := statements[0].group
+	commitments := make([][]*ecc.Element, len(statements))
+	sum := g.NewScalar()
+
+	for i, s := range statements {
+		if err := checkResponses(s, proof.Challenges[i], proof.Responses[i]); err != nil {
+			return wrap(err)
+		}
+
+		commitments[i] = s.commitments(proof.Challenges[i], proof.Responses[i])
+		sum.Add(proof.Challenges[i])
+	}
+
+	if !challenge(g, context, statements, commitments).Equal(sum) {
+		return wrap(errInvalidProof)
+	}
+
+	return nil
+}
+
+func checkStatements(statements []*Statement) error {
+	if len(statements) == 0 {
+		return errInvalidStatement
+	}
+
+	for _, s := range statements {
+		if err := s.check(); err != nil {
+			return err
+		}
+
+		if s.group != statements[0].group {
+			return errInvalidStatement
+		}
+	}
+
+	return nil
+}
+
+func randomScalars(g ecc.Group, n int) []*ecc.Scalar {
+	out := make([]*ecc.Scalar, n)
+	for i := range out {
+		out[i] = g.NewScalar().Random()
+	}
+
+	return out
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+package zkp
+
+import (
+	"github.com/bytemare/ecc"
+)
+
+// protocol is the name of the Fiat-Shamir transcripts of the proofs.
+const protocol = "ecc-zkp"
+
+// Proof is a non-interactive proof of a statement, made of the challenge and one response per witness.
+type Proof struct {
+	Challenge *ecc.Scalar
+	Responses []*ecc.Scalar
+}
+
+// Prove returns the proof of knowledge of the witnesses satisfying the statement. The context, e.g. a session
+// identifier, is bound to the proof and must be the same for verification.
+func Prove(context []byte, statement *Statement, witnesses []*ecc.Scalar) (*Proof, error) {
+	if err := statement.check(); err != nil {
+		return nil, wrap(err)
+	}
+
+	if err := statement.checkWitnesses(witnesses); err != nil {
+		return nil, wrap(err)
+	}
+
+	g := statement.group
+
+	nonces := make([]*ecc.Scalar, statement.Witnesses)
+	for i := range nonces {
+		nonces[i] = g.NewScalar().Random()
+	}
+
+	commitments := statement.evaluate(nonces)
+	c := challenge(g, context, []*Statement{statement}, [][]*ecc.Element{commitments})
+
+	responses := make([]*ecc.Scalar, len(nonces))
+	for i, r := range nonces {
+		responses[i] = r.Add(c.Copy().Multiply(witnesses[i]))
+	}
+
+	return &Proof{Challenge: c, Responses: responses}, nil
+}
+
+// checkResponses returns an error if the challenge or the responses are malformed for the statement.
+func checkResponses(statement *Statement, c *ecc.Scalar, responses []*ecc.Scalar) error {
+	if c == nil || c.Group() != statement.group || len(responses) != statement.Witnesses {
+		return errInvalidProof
+	}
+
+	for _, r := range responses {
+		if r == nil || r.Group() != statement.group {
+			return errInvalidProof
+		}
+	}
+
+	return nil
+}
+
+// Verify returns nil if the proof of the statement is valid for the context.
+func Verify(context []byte, statement *Statement, proof *Proof) error {
+	if err := statement.check(); err != nil {
+		return wrap(err)
+	}
+
+	if proof == nil {
+		return wrap(errInvalidProof)
+	}
+
+	if err := checkResponses(statement, proof.Challenge, proof.Responses); err != nil {
+		return wrap(err)
+	}
+
+	commitments := statement.commitments(proof.Challenge, proof.Responses)
+	c := challenge(statement.group, context, []*Statement{statement}, [][]*ecc.Element{commitments})
+
+	if !c.Equal(proof.Challenge) {
+		return wrap(errInvalidProof)
+	}
+
+	return nil
+}
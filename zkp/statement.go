@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: MIT
+//
+// Copyright (C) 2020-2024 Daniel Bourdrez. All Rights Reserved.
+//
+// This source code is licensed under the MIT license found in the
+// LICENSE file in the root directory of this source tree or at
+// https://spdx.org/licenses/MIT.html
+
+// Package zkp implements non-interactive Schnorr-style sigma protocols over any ecc group, with the Fiat-Shamir
+// transform bound to the group's ciphersuite. A Statement is a system of linear relations between secret scalars, the
+// witnesses, and public elements, which covers knowledge of a discrete logarithm, of a representation, and equality of
+// discrete logarithms. Statements compose with And, and with the OR proofs of ProveOr.
+package zkp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bytemare/ecc"
+	"github.com/bytemare/ecc/internal"
+	"github.com/bytemare/ecc/transcript"
+)
+
+var (
+	errInvalidStatement = errors.New("invalid statement")
+	errInvalidWitness   = errors.New("the witnesses do not satisfy the statement")
+	errInvalidProof     = errors.New("invalid proof")
+)
+
+// Term is the product of the witness at index Witness by the public Base element.
+type Term struct {
+	Base    *ecc.Element
+	Witness int
+}
+
+// Equation is the relation Image = sum(witness_i * Base_i) over its terms.
+type Equation struct {
+	Image *ecc.Element
+	Terms []Term
+}
+
+// Statement is a system of equations over a number of witnesses, all proven at once.
+type Statement struct {
+	Equations []Equation
+	Witnesses int
+	group     ecc.Group
+}
+
+// NewStatement returns an empty statement over the given number of witnesses.
+func NewStatement(g ecc.Group, witnesses int) *Statement {
+	return &Statement{group: g, Witnesses: witnesses}
+}
+
+// AddEquation adds the relation image = sum(terms), and returns the statement.
+func (s *Statement) AddEquation(image *ecc.Element, terms ...Term) *Statement {
+	s.Equations = append(s.Equations, Equation{Image: image, Terms: terms})
+	return s
+}
+
+// Group returns the group of the statement.
+func (s *Statement) Group() ecc.Group {
+	return s.group
+}
+
+// DLog returns the statement of knowledge of x such that image = x * base.
+func DLog(base, image *ecc.Element) *Statement {
+	return NewStatement(base.Group(), 1).AddEquation(image, Term{Base: base})
+}
+
+// Representation returns the statement of knowledge of x_i such that image = sum(x_i * bases_i), e.g. the opening of
+// a Pedersen commitment.
+func Representation(image *ecc.Element, bases ...*ecc.Element) *Statement {
+	terms := make([]Term, len(bases))
+	for i, b := range bases {
+		terms[i] = Term{Base: b, Witness: i}
+	}
+
+	return NewStatement(image.Group(), len(bases)).AddEquation(image, terms...)
+}
+
+// Equality returns the statement of knowledge of x such that images_i = x * bases_i for all i, i.e. that the images
+// have the same discrete logarithm to their bases.
+func Equality(bases, images []*ecc.Element) *Statement {
+	s := NewStatement(bases[0].Group(), 1)
+	for i := range bases {
+		s.AddEquation(images[i], Term{Base: bases[i]})
+	}
+
+	return s
+}
+
+// And returns the conjunction of the statements, whose witnesses are those of the statements, in order. Use a single
+// statement with shared witness indices to prove relations between the witnesses.
+func And(statements ...*Statement) *Statement {
+	s := NewStatement(statements[0].group, 0)
+
+	for _, st := range statements {
+		for _, eq := range st.Equations {
+			terms := make([]Term, len(eq.Terms))
+			for i, t := range eq.Terms {
+				terms[i] = Term{Base: t.Base, Witness: t.Witness + s.Witnesses}
+			}
+
+			s.AddEquation(eq.Image, terms...)
+		}
+
+		s.Witnesses += st.Witnesses
+	}
+
+	return s
+}
+
+// check returns an error if the statement is malformed.
+func (s *Statement) check() error {
+	if s == nil || s.Witnesses <= 0 || len(s.Equations) == 0 {
+		return errInvalidStatement
+	}
+
+	for _, eq := range s.Equations {
+		if eq.Image == nil || eq.Image.Group() != s.group || len(eq.Terms) == 0 {
+			return errInvalidStatement
+		}
+
+		for _, t := range eq.Terms {
+			if t.Base == nil || t.Base.Group() != s.group || t.Witness < 0 || t.Witness >= s.Witnesses {
+				return errInvalidStatement
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Statement) checkWitnesses(witnesses []*ecc.Scalar) error {
+	if len(witnesses) != s.Witnesses {
+		return errInvalidWitness
+	}
+
+	for _, w := range witnesses {
+		if w == nil {
+			return internal.ErrParamNilScalar
+		}
+
+		if w.Group() != s.group {
+			return internal.ErrCastScalar
+		}
+	}
+
+	for i, e := range s.evaluate(witnesses) {
+		if !e.Equal(s.Equations[i].Image) {
+			return errInvalidWitness
+		}
+	}
+
+	return nil
+}
+
+// evaluate returns sum(scalars_i * Base_i) for each equation.
+func (s *Statement) evaluate(scalars []*ecc.Scalar) []*ecc.Element {
+	out := make([]*ecc.Element, len(s.Equations))
+
+	for i, eq := range s.Equations {
+		out[i] = s.group.NewElement()
+		for _, t := range eq.Terms {
+			out[i].Add(t.Base.Copy().Multiply(scalars[t.Witness]))
+		}
+	}
+
+	return out
+}
+
+// commitments returns the verifier's recomputed commitments sum(responses_i * Base_i) - challenge * Image.
+func (s *Statement) commitments(challenge *ecc.Scalar, responses []*ecc.Scalar) []*ecc.Element {
+	out := s.evaluate(responses)
+	for i, eq := range s.Equations {
+		out[i].Subtract(eq.Image.Copy().Multiply(challenge))
+	}
+
+	return out
+}
+
+// appendTo absorbs the structure and the elements of the statement.
+func (s *Statement) appendTo(t *transcript.Transcript) {
+	t.AppendUint64("witnesses", uint64(s.Witnesses))
+	t.AppendUint64("equations", uint64(len(s.Equations)))
+
+	for _, eq := range s.Equations {
+		t.AppendElement("image", eq.Image)
+		t.AppendUint64("terms", uint64(len(eq.Terms)))
+
+		for _, term := range eq.Terms {
+			t.AppendUint64("witness", uint64(term.Witness))
+			t.AppendElement("base", term.Base)
+		}
+	}
+}
+
+// challenge returns the Fiat-Shamir challenge of the context, the statements, and the commitments.
+func challenge(g ecc.Group, context []byte, statements []*Statement, commitments [][]*ecc.Element) *ecc.Scalar {
+	t := transcript.New(g, protocol)
+	t.AppendMessage("context", context)
+
+	for i, s := range statements {
+		s.appendTo(t)
+		t.AppendElements("commitments", commitments[i]...)
+	}
+
+	return t.Challenge("challenge")
+}
+
+func wrap(err error) error {
+	return fmt.Errorf("zkp: %w", err)
+}